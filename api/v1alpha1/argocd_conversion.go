@@ -69,15 +69,18 @@ func (src *ArgoCD) ConvertTo(dstRaw conversion.Hub) error {
 	dst.Spec.Image = src.Spec.Image
 	dst.Spec.Import = (*v1beta1.ArgoCDImportSpec)(src.Spec.Import)
 	dst.Spec.InitialRepositories = src.Spec.InitialRepositories
-	dst.Spec.InitialSSHKnownHosts = v1beta1.SSHHostsSpec(src.Spec.InitialSSHKnownHosts)
+	dst.Spec.InitialSSHKnownHosts = v1beta1.SSHHostsSpec{
+		ExcludeDefaultHosts: src.Spec.InitialSSHKnownHosts.ExcludeDefaultHosts,
+		Keys:                src.Spec.InitialSSHKnownHosts.Keys,
+	}
 	dst.Spec.KustomizeBuildOptions = src.Spec.KustomizeBuildOptions
 	dst.Spec.KustomizeVersions = ConvertAlphaToBetaKustomizeVersions(src.Spec.KustomizeVersions)
 	dst.Spec.OIDCConfig = src.Spec.OIDCConfig
-	dst.Spec.Monitoring = v1beta1.ArgoCDMonitoringSpec(src.Spec.Monitoring)
+	dst.Spec.Monitoring = ConvertAlphaToBetaMonitoring(src.Spec.Monitoring)
 	dst.Spec.NodePlacement = (*v1beta1.ArgoCDNodePlacementSpec)(src.Spec.NodePlacement)
-	dst.Spec.Notifications = v1beta1.ArgoCDNotifications(src.Spec.Notifications)
+	dst.Spec.Notifications = *ConvertAlphaToBetaNotifications(&src.Spec.Notifications)
 	dst.Spec.Prometheus = *ConvertAlphaToBetaPrometheus(&src.Spec.Prometheus)
-	dst.Spec.RBAC = v1beta1.ArgoCDRBACSpec(src.Spec.RBAC)
+	dst.Spec.RBAC = *ConvertAlphaToBetaRBAC(&src.Spec.RBAC)
 	dst.Spec.Redis = *ConvertAlphaToBetaRedis(&src.Spec.Redis)
 	dst.Spec.Repo = *ConvertAlphaToBetaRepo(&src.Spec.Repo)
 	dst.Spec.RepositoryCredentials = src.Spec.RepositoryCredentials
@@ -98,7 +101,7 @@ func (src *ArgoCD) ConvertTo(dstRaw conversion.Hub) error {
 	dst.Spec.AggregatedClusterRoles = src.Spec.AggregatedClusterRoles
 
 	// Status conversion
-	dst.Status = v1beta1.ArgoCDStatus(src.Status)
+	dst.Status = *ConvertAlphaToBetaStatus(&src.Status)
 
 	return nil
 }
@@ -139,15 +142,18 @@ func (dst *ArgoCD) ConvertFrom(srcRaw conversion.Hub) error {
 	dst.Spec.Image = src.Spec.Image
 	dst.Spec.Import = (*ArgoCDImportSpec)(src.Spec.Import)
 	dst.Spec.InitialRepositories = src.Spec.InitialRepositories
-	dst.Spec.InitialSSHKnownHosts = SSHHostsSpec(src.Spec.InitialSSHKnownHosts)
+	dst.Spec.InitialSSHKnownHosts = SSHHostsSpec{
+		ExcludeDefaultHosts: src.Spec.InitialSSHKnownHosts.ExcludeDefaultHosts,
+		Keys:                src.Spec.InitialSSHKnownHosts.Keys,
+	}
 	dst.Spec.KustomizeBuildOptions = src.Spec.KustomizeBuildOptions
 	dst.Spec.KustomizeVersions = ConvertBetaToAlphaKustomizeVersions(src.Spec.KustomizeVersions)
 	dst.Spec.OIDCConfig = src.Spec.OIDCConfig
-	dst.Spec.Monitoring = ArgoCDMonitoringSpec(src.Spec.Monitoring)
+	dst.Spec.Monitoring = ConvertBetaToAlphaMonitoring(src.Spec.Monitoring)
 	dst.Spec.NodePlacement = (*ArgoCDNodePlacementSpec)(src.Spec.NodePlacement)
-	dst.Spec.Notifications = ArgoCDNotifications(src.Spec.Notifications)
+	dst.Spec.Notifications = *ConvertBetaToAlphaNotifications(&src.Spec.Notifications)
 	dst.Spec.Prometheus = *ConvertBetaToAlphaPrometheus(&src.Spec.Prometheus)
-	dst.Spec.RBAC = ArgoCDRBACSpec(src.Spec.RBAC)
+	dst.Spec.RBAC = *ConvertBetaToAlphaRBAC(&src.Spec.RBAC)
 	dst.Spec.Redis = *ConvertBetaToAlphaRedis(&src.Spec.Redis)
 	dst.Spec.Repo = *ConvertBetaToAlphaRepo(&src.Spec.Repo)
 	dst.Spec.RepositoryCredentials = src.Spec.RepositoryCredentials
@@ -168,7 +174,7 @@ func (dst *ArgoCD) ConvertFrom(srcRaw conversion.Hub) error {
 	dst.Spec.AggregatedClusterRoles = src.Spec.AggregatedClusterRoles
 
 	// Status conversion
-	dst.Status = ArgoCDStatus(src.Status)
+	dst.Status = *ConvertBetaToAlphaStatus(&src.Status)
 
 	return nil
 }
@@ -236,7 +242,7 @@ func ConvertAlphaToBetaWebhookServer(src *WebhookServerSpec) *v1beta1.WebhookSer
 	if src != nil {
 		dst = &v1beta1.WebhookServerSpec{
 			Host:    src.Host,
-			Ingress: v1beta1.ArgoCDIngressSpec(src.Ingress),
+			Ingress: ConvertAlphaToBetaIngress(src.Ingress),
 			Route:   v1beta1.ArgoCDRouteSpec(src.Route),
 		}
 	}
@@ -266,7 +272,7 @@ func ConvertAlphaToBetaGrafana(src *ArgoCDGrafanaSpec) *v1beta1.ArgoCDGrafanaSpe
 			Enabled: src.Enabled,
 			Host:    src.Host,
 			Image:   src.Image,
-			Ingress: v1beta1.ArgoCDIngressSpec(src.Ingress),
+			Ingress: ConvertAlphaToBetaIngress(src.Ingress),
 		}
 	}
 	return dst
@@ -278,7 +284,7 @@ func ConvertAlphaToBetaPrometheus(src *ArgoCDPrometheusSpec) *v1beta1.ArgoCDProm
 		dst = &v1beta1.ArgoCDPrometheusSpec{
 			Enabled: src.Enabled,
 			Host:    src.Host,
-			Ingress: v1beta1.ArgoCDIngressSpec(src.Ingress),
+			Ingress: ConvertAlphaToBetaIngress(src.Ingress),
 			Route:   v1beta1.ArgoCDRouteSpec(src.Route),
 			Size:    src.Size,
 		}
@@ -292,7 +298,22 @@ func ConvertAlphaToBetaSSO(src *ArgoCDSSOSpec) *v1beta1.ArgoCDSSOSpec {
 		dst = &v1beta1.ArgoCDSSOSpec{
 			Provider: v1beta1.SSOProviderType(src.Provider),
 			Dex:      ConvertAlphaToBetaDex(src.Dex),
-			Keycloak: (*v1beta1.ArgoCDKeycloakSpec)(src.Keycloak),
+			Keycloak: ConvertAlphaToBetaKeycloak(src.Keycloak),
+		}
+	}
+	return dst
+}
+
+func ConvertAlphaToBetaKeycloak(src *ArgoCDKeycloakSpec) *v1beta1.ArgoCDKeycloakSpec {
+	var dst *v1beta1.ArgoCDKeycloakSpec
+	if src != nil {
+		dst = &v1beta1.ArgoCDKeycloakSpec{
+			Image:     src.Image,
+			Resources: src.Resources,
+			RootCA:    src.RootCA,
+			Version:   src.Version,
+			VerifyTLS: src.VerifyTLS,
+			Host:      src.Host,
 		}
 	}
 	return dst
@@ -345,7 +366,7 @@ func ConvertAlphaToBetaServer(src *ArgoCDServerSpec) *v1beta1.ArgoCDServerSpec {
 			Autoscale:        v1beta1.ArgoCDServerAutoscaleSpec(src.Autoscale),
 			GRPC:             *ConvertAlphaToBetaGRPC(&src.GRPC),
 			Host:             src.Host,
-			Ingress:          v1beta1.ArgoCDIngressSpec(src.Ingress),
+			Ingress:          ConvertAlphaToBetaIngress(src.Ingress),
 			Insecure:         src.Insecure,
 			LogLevel:         src.LogLevel,
 			LogFormat:        src.LogFormat,
@@ -365,12 +386,22 @@ func ConvertAlphaToBetaGRPC(src *ArgoCDServerGRPCSpec) *v1beta1.ArgoCDServerGRPC
 	if src != nil {
 		dst = &v1beta1.ArgoCDServerGRPCSpec{
 			Host:    src.Host,
-			Ingress: v1beta1.ArgoCDIngressSpec(src.Ingress),
+			Ingress: ConvertAlphaToBetaIngress(src.Ingress),
 		}
 	}
 	return dst
 }
 
+func ConvertAlphaToBetaIngress(src ArgoCDIngressSpec) v1beta1.ArgoCDIngressSpec {
+	return v1beta1.ArgoCDIngressSpec{
+		Annotations:      src.Annotations,
+		Enabled:          src.Enabled,
+		IngressClassName: src.IngressClassName,
+		Path:             src.Path,
+		TLS:              src.TLS,
+	}
+}
+
 func ConvertAlphaToBetaKustomizeVersions(src []KustomizeVersionSpec) []v1beta1.KustomizeVersionSpec {
 	var dst []v1beta1.KustomizeVersionSpec
 	for _, s := range src {
@@ -383,6 +414,13 @@ func ConvertAlphaToBetaKustomizeVersions(src []KustomizeVersionSpec) []v1beta1.K
 	return dst
 }
 
+func ConvertAlphaToBetaMonitoring(src ArgoCDMonitoringSpec) v1beta1.ArgoCDMonitoringSpec {
+	return v1beta1.ArgoCDMonitoringSpec{
+		Enabled:        src.Enabled,
+		DisableMetrics: src.DisableMetrics,
+	}
+}
+
 func ConvertAlphaToBetaResourceIgnoreDifferences(src *ResourceIgnoreDifference) *v1beta1.ResourceIgnoreDifference {
 	var dst *v1beta1.ResourceIgnoreDifference
 	if src != nil {
@@ -433,6 +471,55 @@ func ConvertAlphaToBetaResourceHealthChecks(src []ResourceHealthCheck) []v1beta1
 	return dst
 }
 
+func ConvertAlphaToBetaRBAC(src *ArgoCDRBACSpec) *v1beta1.ArgoCDRBACSpec {
+	var dst *v1beta1.ArgoCDRBACSpec
+	if src != nil {
+		dst = &v1beta1.ArgoCDRBACSpec{
+			DefaultPolicy:     src.DefaultPolicy,
+			Policy:            src.Policy,
+			Scopes:            src.Scopes,
+			PolicyMatcherMode: src.PolicyMatcherMode,
+		}
+	}
+	return dst
+}
+
+func ConvertAlphaToBetaNotifications(src *ArgoCDNotifications) *v1beta1.ArgoCDNotifications {
+	var dst *v1beta1.ArgoCDNotifications
+	if src != nil {
+		dst = &v1beta1.ArgoCDNotifications{
+			Replicas:  src.Replicas,
+			Enabled:   src.Enabled,
+			Env:       src.Env,
+			Image:     src.Image,
+			Version:   src.Version,
+			Resources: src.Resources,
+			LogLevel:  src.LogLevel,
+		}
+	}
+	return dst
+}
+
+func ConvertAlphaToBetaStatus(src *ArgoCDStatus) *v1beta1.ArgoCDStatus {
+	var dst *v1beta1.ArgoCDStatus
+	if src != nil {
+		dst = &v1beta1.ArgoCDStatus{
+			ApplicationController:    src.ApplicationController,
+			ApplicationSetController: src.ApplicationSetController,
+			SSO:                      src.SSO,
+			NotificationsController:  src.NotificationsController,
+			Phase:                    src.Phase,
+			Redis:                    src.Redis,
+			Repo:                     src.Repo,
+			Server:                   src.Server,
+			RepoTLSChecksum:          src.RepoTLSChecksum,
+			RedisTLSChecksum:         src.RedisTLSChecksum,
+			Host:                     src.Host,
+		}
+	}
+	return dst
+}
+
 // Conversion funcs for v1beta1 to v1alpha1.
 func ConvertBetaToAlphaController(src *v1beta1.ArgoCDApplicationControllerSpec) *ArgoCDApplicationControllerSpec {
 	var dst *ArgoCDApplicationControllerSpec
@@ -456,7 +543,7 @@ func ConvertBetaToAlphaWebhookServer(src *v1beta1.WebhookServerSpec) *WebhookSer
 	if src != nil {
 		dst = &WebhookServerSpec{
 			Host:    src.Host,
-			Ingress: ArgoCDIngressSpec(src.Ingress),
+			Ingress: ConvertBetaToAlphaIngress(src.Ingress),
 			Route:   ArgoCDRouteSpec(src.Route),
 		}
 	}
@@ -486,7 +573,7 @@ func ConvertBetaToAlphaGrafana(src *v1beta1.ArgoCDGrafanaSpec) *ArgoCDGrafanaSpe
 			Enabled: src.Enabled,
 			Host:    src.Host,
 			Image:   src.Image,
-			Ingress: ArgoCDIngressSpec(src.Ingress),
+			Ingress: ConvertBetaToAlphaIngress(src.Ingress),
 		}
 	}
 	return dst
@@ -498,7 +585,7 @@ func ConvertBetaToAlphaPrometheus(src *v1beta1.ArgoCDPrometheusSpec) *ArgoCDProm
 		dst = &ArgoCDPrometheusSpec{
 			Enabled: src.Enabled,
 			Host:    src.Host,
-			Ingress: ArgoCDIngressSpec(src.Ingress),
+			Ingress: ConvertBetaToAlphaIngress(src.Ingress),
 			Route:   ArgoCDRouteSpec(src.Route),
 			Size:    src.Size,
 		}
@@ -512,7 +599,22 @@ func ConvertBetaToAlphaSSO(src *v1beta1.ArgoCDSSOSpec) *ArgoCDSSOSpec {
 		dst = &ArgoCDSSOSpec{
 			Provider: SSOProviderType(src.Provider),
 			Dex:      ConvertBetaToAlphaDex(src.Dex),
-			Keycloak: (*ArgoCDKeycloakSpec)(src.Keycloak),
+			Keycloak: ConvertBetaToAlphaKeycloak(src.Keycloak),
+		}
+	}
+	return dst
+}
+
+func ConvertBetaToAlphaKeycloak(src *v1beta1.ArgoCDKeycloakSpec) *ArgoCDKeycloakSpec {
+	var dst *ArgoCDKeycloakSpec
+	if src != nil {
+		dst = &ArgoCDKeycloakSpec{
+			Image:     src.Image,
+			Resources: src.Resources,
+			RootCA:    src.RootCA,
+			Version:   src.Version,
+			VerifyTLS: src.VerifyTLS,
+			Host:      src.Host,
 		}
 	}
 	return dst
@@ -564,7 +666,7 @@ func ConvertBetaToAlphaServer(src *v1beta1.ArgoCDServerSpec) *ArgoCDServerSpec {
 			Autoscale:        ArgoCDServerAutoscaleSpec(src.Autoscale),
 			GRPC:             *ConvertBetaToAlphaGRPC(&src.GRPC),
 			Host:             src.Host,
-			Ingress:          ArgoCDIngressSpec(src.Ingress),
+			Ingress:          ConvertBetaToAlphaIngress(src.Ingress),
 			Insecure:         src.Insecure,
 			LogLevel:         src.LogLevel,
 			LogFormat:        src.LogFormat,
@@ -584,12 +686,22 @@ func ConvertBetaToAlphaGRPC(src *v1beta1.ArgoCDServerGRPCSpec) *ArgoCDServerGRPC
 	if src != nil {
 		dst = &ArgoCDServerGRPCSpec{
 			Host:    src.Host,
-			Ingress: ArgoCDIngressSpec(src.Ingress),
+			Ingress: ConvertBetaToAlphaIngress(src.Ingress),
 		}
 	}
 	return dst
 }
 
+func ConvertBetaToAlphaIngress(src v1beta1.ArgoCDIngressSpec) ArgoCDIngressSpec {
+	return ArgoCDIngressSpec{
+		Annotations:      src.Annotations,
+		Enabled:          src.Enabled,
+		IngressClassName: src.IngressClassName,
+		Path:             src.Path,
+		TLS:              src.TLS,
+	}
+}
+
 func ConvertBetaToAlphaKustomizeVersions(src []v1beta1.KustomizeVersionSpec) []KustomizeVersionSpec {
 	var dst []KustomizeVersionSpec
 	for _, s := range src {
@@ -602,6 +714,13 @@ func ConvertBetaToAlphaKustomizeVersions(src []v1beta1.KustomizeVersionSpec) []K
 	return dst
 }
 
+func ConvertBetaToAlphaMonitoring(src v1beta1.ArgoCDMonitoringSpec) ArgoCDMonitoringSpec {
+	return ArgoCDMonitoringSpec{
+		Enabled:        src.Enabled,
+		DisableMetrics: src.DisableMetrics,
+	}
+}
+
 func ConvertBetaToAlphaResourceIgnoreDifferences(src *v1beta1.ResourceIgnoreDifference) *ResourceIgnoreDifference {
 	var dst *ResourceIgnoreDifference
 	if src != nil {
@@ -691,3 +810,52 @@ func ConvertBetaToAlphaRepo(src *v1beta1.ArgoCDRepoSpec) *ArgoCDRepoSpec {
 	}
 	return dst
 }
+
+func ConvertBetaToAlphaRBAC(src *v1beta1.ArgoCDRBACSpec) *ArgoCDRBACSpec {
+	var dst *ArgoCDRBACSpec
+	if src != nil {
+		dst = &ArgoCDRBACSpec{
+			DefaultPolicy:     src.DefaultPolicy,
+			Policy:            src.Policy,
+			Scopes:            src.Scopes,
+			PolicyMatcherMode: src.PolicyMatcherMode,
+		}
+	}
+	return dst
+}
+
+func ConvertBetaToAlphaNotifications(src *v1beta1.ArgoCDNotifications) *ArgoCDNotifications {
+	var dst *ArgoCDNotifications
+	if src != nil {
+		dst = &ArgoCDNotifications{
+			Replicas:  src.Replicas,
+			Enabled:   src.Enabled,
+			Env:       src.Env,
+			Image:     src.Image,
+			Version:   src.Version,
+			Resources: src.Resources,
+			LogLevel:  src.LogLevel,
+		}
+	}
+	return dst
+}
+
+func ConvertBetaToAlphaStatus(src *v1beta1.ArgoCDStatus) *ArgoCDStatus {
+	var dst *ArgoCDStatus
+	if src != nil {
+		dst = &ArgoCDStatus{
+			ApplicationController:    src.ApplicationController,
+			ApplicationSetController: src.ApplicationSetController,
+			SSO:                      src.SSO,
+			NotificationsController:  src.NotificationsController,
+			Phase:                    src.Phase,
+			Redis:                    src.Redis,
+			Repo:                     src.Repo,
+			Server:                   src.Server,
+			RepoTLSChecksum:          src.RepoTLSChecksum,
+			RedisTLSChecksum:         src.RedisTLSChecksum,
+			Host:                     src.Host,
+		}
+	}
+	return dst
+}
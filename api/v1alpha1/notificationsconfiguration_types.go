@@ -16,7 +16,10 @@ limitations under the License.
 
 package v1alpha1
 
-import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
 
 func init() {
 	SchemeBuilder.Register(&NotificationsConfiguration{}, &NotificationsConfigurationList{})
@@ -33,7 +36,8 @@ type NotificationsConfiguration struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
-	Spec NotificationsConfigurationSpec `json:"spec,omitempty"`
+	Spec   NotificationsConfigurationSpec   `json:"spec,omitempty"`
+	Status NotificationsConfigurationStatus `json:"status,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -59,4 +63,134 @@ type NotificationsConfigurationSpec struct {
 	Subscriptions map[string]string `json:"subscriptions,omitempty"`
 	// Context is used to define some shared context between all notification templates
 	Context map[string]string `json:"context,omitempty"`
+	// ServiceDefinitions are typed notification services whose credentials are read from Secrets and copied into
+	// the argocd-notifications-secret Secret by the operator.
+	ServiceDefinitions []NotificationService `json:"serviceDefinitions,omitempty"`
+}
+
+// NotificationService defines a typed notification service. Exactly one of the service types must be set.
+type NotificationService struct {
+	// Name of the service used in subscriptions. Defaults to the type of the service.
+	Name string `json:"name,omitempty"`
+	// Slack configures a Slack service.
+	Slack *NotificationSlackService `json:"slack,omitempty"`
+	// Email configures an email service delivered through an SMTP server.
+	Email *NotificationEmailService `json:"email,omitempty"`
+	// Webhook configures a generic webhook service.
+	Webhook *NotificationWebhookService `json:"webhook,omitempty"`
+	// Teams configures a Microsoft Teams service.
+	Teams *NotificationTeamsService `json:"teams,omitempty"`
+	// PagerDuty configures a PagerDuty Events API v2 service.
+	PagerDuty *NotificationPagerDutyService `json:"pagerDuty,omitempty"`
+	// GitHub configures a GitHub App service.
+	GitHub *NotificationGitHubService `json:"github,omitempty"`
+}
+
+// NotificationSlackService defines a Slack notification service.
+type NotificationSlackService struct {
+	// TokenRef references the key of a Secret holding the Slack bot token.
+	TokenRef corev1.SecretKeySelector `json:"tokenRef"`
+	// SigningSecretRef references the key of a Secret holding the Slack signing secret.
+	SigningSecretRef *corev1.SecretKeySelector `json:"signingSecretRef,omitempty"`
+	// Username is the name the messages are posted as.
+	Username string `json:"username,omitempty"`
+	// Icon is the emoji or image URL of the messages.
+	Icon string `json:"icon,omitempty"`
+	// APIURL is the URL of the Slack API, for use with Slack compatible servers.
+	APIURL string `json:"apiURL,omitempty"`
+}
+
+// NotificationEmailService defines an email notification service.
+type NotificationEmailService struct {
+	// Host of the SMTP server.
+	Host string `json:"host"`
+	// Port of the SMTP server.
+	Port int32 `json:"port"`
+	// From is the sender address of the emails.
+	From string `json:"from"`
+	// Username used to authenticate with the SMTP server.
+	Username string `json:"username,omitempty"`
+	// PasswordRef references the key of a Secret holding the password used to authenticate with the SMTP server.
+	PasswordRef *corev1.SecretKeySelector `json:"passwordRef,omitempty"`
+	// HTML sends the emails as HTML.
+	HTML bool `json:"html,omitempty"`
+	// InsecureSkipVerify disables the verification of the TLS certificate of the SMTP server.
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+}
+
+// NotificationWebhookService defines a generic webhook notification service.
+type NotificationWebhookService struct {
+	// URL of the webhook.
+	URL string `json:"url"`
+	// Headers sent with every request.
+	Headers []NotificationWebhookHeader `json:"headers,omitempty"`
+	// BasicAuth configures basic authentication with the webhook.
+	BasicAuth *NotificationWebhookBasicAuth `json:"basicAuth,omitempty"`
+	// InsecureSkipVerify disables the verification of the TLS certificate of the webhook.
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+}
+
+// NotificationWebhookHeader defines a header sent with webhook requests. Exactly one of Value and ValueFrom must
+// be set.
+type NotificationWebhookHeader struct {
+	// Name of the header.
+	Name string `json:"name"`
+	// Value of the header.
+	Value string `json:"value,omitempty"`
+	// ValueFrom references the key of a Secret holding the value of the header.
+	ValueFrom *corev1.SecretKeySelector `json:"valueFrom,omitempty"`
+}
+
+// NotificationWebhookBasicAuth defines basic authentication credentials for a webhook.
+type NotificationWebhookBasicAuth struct {
+	// Username used to authenticate with the webhook.
+	Username string `json:"username"`
+	// PasswordRef references the key of a Secret holding the password used to authenticate with the webhook.
+	PasswordRef corev1.SecretKeySelector `json:"passwordRef"`
+}
+
+// NotificationTeamsService defines a Microsoft Teams notification service.
+type NotificationTeamsService struct {
+	// Recipients are the incoming webhooks of the Teams channels.
+	Recipients []NotificationTeamsRecipient `json:"recipients"`
+}
+
+// NotificationTeamsRecipient defines the incoming webhook of a Teams channel.
+type NotificationTeamsRecipient struct {
+	// Name of the recipient used in subscriptions.
+	Name string `json:"name"`
+	// URLRef references the key of a Secret holding the incoming webhook URL of the channel.
+	URLRef corev1.SecretKeySelector `json:"urlRef"`
+}
+
+// NotificationPagerDutyService defines a PagerDuty Events API v2 notification service.
+type NotificationPagerDutyService struct {
+	// ServiceKeys are the integration keys of the PagerDuty services.
+	ServiceKeys []NotificationPagerDutyServiceKey `json:"serviceKeys"`
+}
+
+// NotificationPagerDutyServiceKey defines the integration key of a PagerDuty service.
+type NotificationPagerDutyServiceKey struct {
+	// Name of the PagerDuty service used in subscriptions.
+	Name string `json:"name"`
+	// KeyRef references the key of a Secret holding the integration key.
+	KeyRef corev1.SecretKeySelector `json:"keyRef"`
+}
+
+// NotificationGitHubService defines a GitHub App notification service.
+type NotificationGitHubService struct {
+	// AppID is the ID of the GitHub App.
+	AppID string `json:"appID"`
+	// InstallationID is the ID of the installation of the GitHub App.
+	InstallationID string `json:"installationID"`
+	// PrivateKeyRef references the key of a Secret holding the private key of the GitHub App.
+	PrivateKeyRef corev1.SecretKeySelector `json:"privateKeyRef"`
+	// EnterpriseBaseURL is the API URL of a GitHub Enterprise server.
+	EnterpriseBaseURL string `json:"enterpriseBaseURL,omitempty"`
+}
+
+// NotificationsConfigurationStatus defines the observed state of NotificationsConfiguration
+type NotificationsConfigurationStatus struct {
+	// Conditions describe the state of the notifications configuration.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
@@ -1072,12 +1072,254 @@ func (in *KustomizeVersionSpec) DeepCopy() *KustomizeVersionSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotificationEmailService) DeepCopyInto(out *NotificationEmailService) {
+	*out = *in
+	if in.PasswordRef != nil {
+		in, out := &in.PasswordRef, &out.PasswordRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationEmailService.
+func (in *NotificationEmailService) DeepCopy() *NotificationEmailService {
+	if in == nil {
+		return nil
+	}
+	out := new(NotificationEmailService)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotificationGitHubService) DeepCopyInto(out *NotificationGitHubService) {
+	*out = *in
+	in.PrivateKeyRef.DeepCopyInto(&out.PrivateKeyRef)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationGitHubService.
+func (in *NotificationGitHubService) DeepCopy() *NotificationGitHubService {
+	if in == nil {
+		return nil
+	}
+	out := new(NotificationGitHubService)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotificationPagerDutyService) DeepCopyInto(out *NotificationPagerDutyService) {
+	*out = *in
+	if in.ServiceKeys != nil {
+		in, out := &in.ServiceKeys, &out.ServiceKeys
+		*out = make([]NotificationPagerDutyServiceKey, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationPagerDutyService.
+func (in *NotificationPagerDutyService) DeepCopy() *NotificationPagerDutyService {
+	if in == nil {
+		return nil
+	}
+	out := new(NotificationPagerDutyService)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotificationPagerDutyServiceKey) DeepCopyInto(out *NotificationPagerDutyServiceKey) {
+	*out = *in
+	in.KeyRef.DeepCopyInto(&out.KeyRef)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationPagerDutyServiceKey.
+func (in *NotificationPagerDutyServiceKey) DeepCopy() *NotificationPagerDutyServiceKey {
+	if in == nil {
+		return nil
+	}
+	out := new(NotificationPagerDutyServiceKey)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotificationService) DeepCopyInto(out *NotificationService) {
+	*out = *in
+	if in.Slack != nil {
+		in, out := &in.Slack, &out.Slack
+		*out = new(NotificationSlackService)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Email != nil {
+		in, out := &in.Email, &out.Email
+		*out = new(NotificationEmailService)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Webhook != nil {
+		in, out := &in.Webhook, &out.Webhook
+		*out = new(NotificationWebhookService)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Teams != nil {
+		in, out := &in.Teams, &out.Teams
+		*out = new(NotificationTeamsService)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PagerDuty != nil {
+		in, out := &in.PagerDuty, &out.PagerDuty
+		*out = new(NotificationPagerDutyService)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GitHub != nil {
+		in, out := &in.GitHub, &out.GitHub
+		*out = new(NotificationGitHubService)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationService.
+func (in *NotificationService) DeepCopy() *NotificationService {
+	if in == nil {
+		return nil
+	}
+	out := new(NotificationService)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotificationSlackService) DeepCopyInto(out *NotificationSlackService) {
+	*out = *in
+	in.TokenRef.DeepCopyInto(&out.TokenRef)
+	if in.SigningSecretRef != nil {
+		in, out := &in.SigningSecretRef, &out.SigningSecretRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationSlackService.
+func (in *NotificationSlackService) DeepCopy() *NotificationSlackService {
+	if in == nil {
+		return nil
+	}
+	out := new(NotificationSlackService)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotificationTeamsRecipient) DeepCopyInto(out *NotificationTeamsRecipient) {
+	*out = *in
+	in.URLRef.DeepCopyInto(&out.URLRef)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationTeamsRecipient.
+func (in *NotificationTeamsRecipient) DeepCopy() *NotificationTeamsRecipient {
+	if in == nil {
+		return nil
+	}
+	out := new(NotificationTeamsRecipient)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotificationTeamsService) DeepCopyInto(out *NotificationTeamsService) {
+	*out = *in
+	if in.Recipients != nil {
+		in, out := &in.Recipients, &out.Recipients
+		*out = make([]NotificationTeamsRecipient, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationTeamsService.
+func (in *NotificationTeamsService) DeepCopy() *NotificationTeamsService {
+	if in == nil {
+		return nil
+	}
+	out := new(NotificationTeamsService)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotificationWebhookBasicAuth) DeepCopyInto(out *NotificationWebhookBasicAuth) {
+	*out = *in
+	in.PasswordRef.DeepCopyInto(&out.PasswordRef)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationWebhookBasicAuth.
+func (in *NotificationWebhookBasicAuth) DeepCopy() *NotificationWebhookBasicAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(NotificationWebhookBasicAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotificationWebhookHeader) DeepCopyInto(out *NotificationWebhookHeader) {
+	*out = *in
+	if in.ValueFrom != nil {
+		in, out := &in.ValueFrom, &out.ValueFrom
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationWebhookHeader.
+func (in *NotificationWebhookHeader) DeepCopy() *NotificationWebhookHeader {
+	if in == nil {
+		return nil
+	}
+	out := new(NotificationWebhookHeader)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotificationWebhookService) DeepCopyInto(out *NotificationWebhookService) {
+	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]NotificationWebhookHeader, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.BasicAuth != nil {
+		in, out := &in.BasicAuth, &out.BasicAuth
+		*out = new(NotificationWebhookBasicAuth)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationWebhookService.
+func (in *NotificationWebhookService) DeepCopy() *NotificationWebhookService {
+	if in == nil {
+		return nil
+	}
+	out := new(NotificationWebhookService)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NotificationsConfiguration) DeepCopyInto(out *NotificationsConfiguration) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationsConfiguration.
@@ -1168,6 +1410,13 @@ func (in *NotificationsConfigurationSpec) DeepCopyInto(out *NotificationsConfigu
 			(*out)[key] = val
 		}
 	}
+	if in.ServiceDefinitions != nil {
+		in, out := &in.ServiceDefinitions, &out.ServiceDefinitions
+		*out = make([]NotificationService, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationsConfigurationSpec.
@@ -1180,6 +1429,28 @@ func (in *NotificationsConfigurationSpec) DeepCopy() *NotificationsConfiguration
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotificationsConfigurationStatus) DeepCopyInto(out *NotificationsConfigurationStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationsConfigurationStatus.
+func (in *NotificationsConfigurationStatus) DeepCopy() *NotificationsConfigurationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NotificationsConfigurationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceAction) DeepCopyInto(out *ResourceAction) {
 	*out = *in
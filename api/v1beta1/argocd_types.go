@@ -26,6 +26,7 @@ import (
 	autoscaling "k8s.io/api/autoscaling/v1"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -243,6 +244,218 @@ type ArgoCDDexSpec struct {
 
 	// Env lets you specify environment variables for Dex.
 	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// Connectors is a list of typed Dex connectors, rendered into the dex.config property of the argocd-cm ConfigMap
+	// after the connectors given in Config.
+	Connectors []ArgoCDDexConnector `json:"connectors,omitempty"`
+}
+
+// ArgoCDDexConnector defines a typed Dex connector. Exactly one of the connector specific fields must be set.
+type ArgoCDDexConnector struct {
+	// ID is the unique identifier of the connector.
+	ID string `json:"id"`
+
+	// Name is the display name of the connector on the Argo CD login page.
+	Name string `json:"name"`
+
+	// GitHub configures a GitHub connector.
+	GitHub *ArgoCDDexGitHubConnector `json:"github,omitempty"`
+
+	// GitLab configures a GitLab connector.
+	GitLab *ArgoCDDexGitLabConnector `json:"gitlab,omitempty"`
+
+	// LDAP configures an LDAP connector.
+	LDAP *ArgoCDDexLDAPConnector `json:"ldap,omitempty"`
+
+	// OIDC configures a generic OpenID Connect connector.
+	OIDC *ArgoCDDexOIDCConnector `json:"oidc,omitempty"`
+
+	// SAML configures a SAML 2.0 connector.
+	SAML *ArgoCDDexSAMLConnector `json:"saml,omitempty"`
+
+	// Microsoft configures a Microsoft connector.
+	Microsoft *ArgoCDDexMicrosoftConnector `json:"microsoft,omitempty"`
+}
+
+// ArgoCDDexOAuthClient defines the OAuth client credentials of a Dex connector.
+type ArgoCDDexOAuthClient struct {
+	// ClientID is the OAuth client ID.
+	ClientID string `json:"clientID"`
+
+	// ClientSecretRef references the key of a Secret in the namespace of the Argo CD instance that holds the OAuth
+	// client secret. The Secret must be labelled with app.kubernetes.io/part-of: argocd.
+	ClientSecretRef corev1.SecretKeySelector `json:"clientSecretRef"`
+}
+
+// ArgoCDDexGitHubOrg defines a GitHub organization, and optionally its teams, a user must be a member of.
+type ArgoCDDexGitHubOrg struct {
+	// Name of the organization.
+	Name string `json:"name"`
+
+	// Teams within the organization. Membership of any of the teams is required if set.
+	Teams []string `json:"teams,omitempty"`
+}
+
+// ArgoCDDexGitHubConnector defines the configuration of a Dex GitHub connector.
+type ArgoCDDexGitHubConnector struct {
+	ArgoCDDexOAuthClient `json:",inline"`
+
+	// Orgs restricts logins to members of the given organizations.
+	Orgs []ArgoCDDexGitHubOrg `json:"orgs,omitempty"`
+
+	// HostName is the host name of a GitHub Enterprise instance.
+	HostName string `json:"hostName,omitempty"`
+
+	// LoadAllGroups will load all the groups of a user, not only those of the given organizations.
+	LoadAllGroups bool `json:"loadAllGroups,omitempty"`
+
+	// TeamNameField is the team field used as group name.
+	//+kubebuilder:validation:Enum=name;slug;both
+	TeamNameField string `json:"teamNameField,omitempty"`
+}
+
+// ArgoCDDexGitLabConnector defines the configuration of a Dex GitLab connector.
+type ArgoCDDexGitLabConnector struct {
+	ArgoCDDexOAuthClient `json:",inline"`
+
+	// BaseURL is the URL of the GitLab instance. Defaults to https://gitlab.com.
+	BaseURL string `json:"baseURL,omitempty"`
+
+	// Groups restricts logins to members of the given groups.
+	Groups []string `json:"groups,omitempty"`
+}
+
+// ArgoCDDexOIDCConnector defines the configuration of a Dex OpenID Connect connector.
+type ArgoCDDexOIDCConnector struct {
+	ArgoCDDexOAuthClient `json:",inline"`
+
+	// Issuer is the URL of the OpenID Connect provider.
+	Issuer string `json:"issuer"`
+
+	// Scopes requested in addition to openid.
+	Scopes []string `json:"scopes,omitempty"`
+
+	// InsecureEnableGroups will use the groups claim of the provider.
+	InsecureEnableGroups bool `json:"insecureEnableGroups,omitempty"`
+
+	// GetUserInfo will query the UserInfo endpoint for additional claims.
+	GetUserInfo bool `json:"getUserInfo,omitempty"`
+}
+
+// ArgoCDDexMicrosoftConnector defines the configuration of a Dex Microsoft connector.
+type ArgoCDDexMicrosoftConnector struct {
+	ArgoCDDexOAuthClient `json:",inline"`
+
+	// Tenant is the Azure AD tenant to authenticate against.
+	Tenant string `json:"tenant,omitempty"`
+
+	// Groups restricts logins to members of the given groups.
+	Groups []string `json:"groups,omitempty"`
+
+	// OnlySecurityGroups will only load security groups of a user.
+	OnlySecurityGroups bool `json:"onlySecurityGroups,omitempty"`
+}
+
+// ArgoCDDexLDAPUserSearch defines how users are looked up in LDAP.
+type ArgoCDDexLDAPUserSearch struct {
+	// BaseDN to start the search from.
+	BaseDN string `json:"baseDN"`
+
+	// Filter applied to the search.
+	Filter string `json:"filter,omitempty"`
+
+	// Username is the attribute matched against the username entered by the user.
+	Username string `json:"username"`
+
+	// IDAttr is the attribute used as user ID.
+	IDAttr string `json:"idAttr"`
+
+	// EmailAttr is the attribute used as user email.
+	EmailAttr string `json:"emailAttr"`
+
+	// NameAttr is the attribute used as display name.
+	NameAttr string `json:"nameAttr,omitempty"`
+}
+
+// ArgoCDDexLDAPUserMatcher defines how users are matched to groups in LDAP.
+type ArgoCDDexLDAPUserMatcher struct {
+	// UserAttr is the user attribute to match.
+	UserAttr string `json:"userAttr"`
+
+	// GroupAttr is the group attribute to match.
+	GroupAttr string `json:"groupAttr"`
+}
+
+// ArgoCDDexLDAPGroupSearch defines how groups are looked up in LDAP.
+type ArgoCDDexLDAPGroupSearch struct {
+	// BaseDN to start the search from.
+	BaseDN string `json:"baseDN"`
+
+	// Filter applied to the search.
+	Filter string `json:"filter,omitempty"`
+
+	// UserMatchers defines how users are matched to groups.
+	UserMatchers []ArgoCDDexLDAPUserMatcher `json:"userMatchers"`
+
+	// NameAttr is the attribute used as group name.
+	NameAttr string `json:"nameAttr"`
+}
+
+// ArgoCDDexLDAPConnector defines the configuration of a Dex LDAP connector.
+type ArgoCDDexLDAPConnector struct {
+	// Host is the host and optional port of the LDAP server.
+	Host string `json:"host"`
+
+	// InsecureNoSSL will connect to the LDAP server without TLS.
+	InsecureNoSSL bool `json:"insecureNoSSL,omitempty"`
+
+	// InsecureSkipVerify will not verify the certificate of the LDAP server.
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+
+	// StartTLS will connect using ldap:// and upgrade the connection with StartTLS.
+	StartTLS bool `json:"startTLS,omitempty"`
+
+	// BindDN is the DN used to search for users and groups.
+	BindDN string `json:"bindDN,omitempty"`
+
+	// BindPWRef references the key of a Secret in the namespace of the Argo CD instance that holds the password of
+	// BindDN. The Secret must be labelled with app.kubernetes.io/part-of: argocd.
+	BindPWRef *corev1.SecretKeySelector `json:"bindPWRef,omitempty"`
+
+	// UsernamePrompt is the label of the username field on the login page.
+	UsernamePrompt string `json:"usernamePrompt,omitempty"`
+
+	// UserSearch defines how users are looked up.
+	UserSearch ArgoCDDexLDAPUserSearch `json:"userSearch"`
+
+	// GroupSearch defines how groups are looked up.
+	GroupSearch *ArgoCDDexLDAPGroupSearch `json:"groupSearch,omitempty"`
+}
+
+// ArgoCDDexSAMLConnector defines the configuration of a Dex SAML 2.0 connector.
+type ArgoCDDexSAMLConnector struct {
+	// SSOURL is the URL of the identity provider to redirect users to.
+	SSOURL string `json:"ssoURL"`
+
+	// CADataRef references the key of a Secret in the namespace of the Argo CD instance that holds the base64
+	// encoded CA certificate used to validate the SAML responses. The Secret must be labelled with
+	// app.kubernetes.io/part-of: argocd.
+	CADataRef corev1.SecretKeySelector `json:"caDataRef"`
+
+	// EntityIssuer is the issuer value sent in SAML requests.
+	EntityIssuer string `json:"entityIssuer,omitempty"`
+
+	// SSOIssuer is the issuer value expected in SAML responses.
+	SSOIssuer string `json:"ssoIssuer,omitempty"`
+
+	// UsernameAttr is the attribute used as username.
+	UsernameAttr string `json:"usernameAttr"`
+
+	// EmailAttr is the attribute used as user email.
+	EmailAttr string `json:"emailAttr"`
+
+	// GroupsAttr is the attribute used for group membership.
+	GroupsAttr string `json:"groupsAttr,omitempty"`
 }
 
 // ArgoCDGrafanaSpec defines the desired state for the Grafana component.
@@ -251,6 +464,9 @@ type ArgoCDGrafanaSpec struct {
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Enabled",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:fieldGroup:Grafana","urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
 	Enabled bool `json:"enabled"`
 
+	// Gateway defines the desired state for a Gateway API HTTPRoute for the Grafana component.
+	Gateway ArgoCDGatewayRouteSpec `json:"gateway,omitempty"`
+
 	// Host is the hostname to use for Ingress/Route resources.
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Host",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:fieldGroup:Grafana","urn:alm:descriptor:com.tectonic.ui:text"}
 	Host string `json:"host,omitempty"`
@@ -292,6 +508,51 @@ type ArgoCDHASpec struct {
 
 	// Resources defines the Compute Resources required by the container for HA.
 	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// Redis defines the topology of the Redis HA servers, sentinels and HAProxy.
+	Redis *ArgoCDHARedisSpec `json:"redis,omitempty"`
+}
+
+// ArgoCDHARedisSpec defines the topology of Redis when running in HA mode.
+type ArgoCDHARedisSpec struct {
+	// Replicas is the number of Redis servers, each one running along a sentinel. (optional, default `3`)
+	//+kubebuilder:validation:Minimum=3
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// Quorum is the number of sentinels that need to agree about the failure of the master. It must not be greater
+	// than Replicas. (optional, default is a majority of Replicas)
+	//+kubebuilder:validation:Minimum=1
+	Quorum *int32 `json:"quorum,omitempty"`
+
+	// Persistence stores the data of the Redis servers on PersistentVolumeClaims instead of emptyDir volumes.
+	Persistence *ArgoCDRedisPersistenceSpec `json:"persistence,omitempty"`
+
+	// SentinelResources defines the Compute Resources required by the sentinel container. Defaults to the HA
+	// Resources.
+	SentinelResources *corev1.ResourceRequirements `json:"sentinelResources,omitempty"`
+
+	// ProxyReplicas is the number of HAProxy replicas. (optional, default `1`)
+	//+kubebuilder:validation:Minimum=1
+	ProxyReplicas *int32 `json:"proxyReplicas,omitempty"`
+
+	// ProxyResources defines the Compute Resources required by the HAProxy container. Defaults to the HA Resources.
+	ProxyResources *corev1.ResourceRequirements `json:"proxyResources,omitempty"`
+}
+
+// ArgoCDRedisPersistenceSpec defines how the Redis data is persisted.
+type ArgoCDRedisPersistenceSpec struct {
+	// Size is the requested size of the PersistentVolumeClaim holding the Redis data. (optional, default `1Gi`)
+	Size *resource.Quantity `json:"size,omitempty"`
+
+	// StorageClassName is the name of the StorageClass of the PersistentVolumeClaim holding the Redis data.
+	StorageClassName *string `json:"storageClassName,omitempty"`
+
+	// AppendOnly enables the Redis append only file (AOF).
+	AppendOnly bool `json:"appendOnly,omitempty"`
+
+	// Save is the list of RDB snapshot save points, each one formatted as `<seconds> <changes>`. Defaults to
+	// `3600 1`, `300 100` and `60 10000` when AppendOnly is not set.
+	Save []string `json:"save,omitempty"`
 }
 
 // ArgoCDImportSpec defines the desired state for the ArgoCD import/restore process.
@@ -314,12 +575,25 @@ type ArgoCDIngressSpec struct {
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Ingress Enabled'",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:fieldGroup:Grafana","urn:alm:descriptor:com.tectonic.ui:fieldGroup:Prometheus","urn:alm:descriptor:com.tectonic.ui:fieldGroup:Server","urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
 	Enabled bool `json:"enabled"`
 
+	// ExtraHosts is a list of hostnames exposed by the Ingress in addition to the host of the component.
+	ExtraHosts []string `json:"extraHosts,omitempty"`
+
+	// ExtraPaths is a list of paths routed to the component in addition to Path, on every host of the Ingress.
+	ExtraPaths []string `json:"extraPaths,omitempty"`
+
 	// IngressClassName for the Ingress resource.
 	IngressClassName *string `json:"ingressClassName,omitempty"`
 
+	// Labels is the map of labels to add to the Ingress.
+	Labels map[string]string `json:"labels,omitempty"`
+
 	// Path used for the Ingress resource.
 	Path string `json:"path,omitempty"`
 
+	// PathType used for every path of the Ingress resource. Defaults to ImplementationSpecific.
+	// +kubebuilder:validation:Enum=Exact;Prefix;ImplementationSpecific
+	PathType *networkingv1.PathType `json:"pathType,omitempty"`
+
 	// TLS configuration. Currently the Ingress only supports a single TLS
 	// port, 443. If multiple members of this list specify different hosts, they
 	// will be multiplexed on the same port according to the hostname specified
@@ -348,6 +622,107 @@ type ArgoCDKeycloakSpec struct {
 
 	// Host is the hostname to use for Ingress/Route resources.
 	Host string `json:"host,omitempty"`
+
+	// Realm defines additional configuration of the argocd realm, which is reconciled continuously.
+	Realm *ArgoCDKeycloakRealmSpec `json:"realm,omitempty"`
+}
+
+// ArgoCDKeycloakRealmSpec defines additional configuration of the Keycloak realm created for Argo CD. Entries
+// removed from the spec are removed from the realm.
+type ArgoCDKeycloakRealmSpec struct {
+	// IdentityProviders is a list of identity providers to add to the realm.
+	IdentityProviders []ArgoCDKeycloakIdentityProvider `json:"identityProviders,omitempty"`
+
+	// ClientScopes is a list of client scopes to add to the realm, as default client scopes of the argocd client.
+	ClientScopes []ArgoCDKeycloakClientScope `json:"clientScopes,omitempty"`
+
+	// GroupMappers is a list of group membership mappers to add to the argocd client.
+	GroupMappers []ArgoCDKeycloakGroupMapper `json:"groupMappers,omitempty"`
+
+	// Roles is a list of realm roles to add to the realm.
+	Roles []ArgoCDKeycloakRealmRole `json:"roles,omitempty"`
+}
+
+// ArgoCDKeycloakIdentityProvider defines a Keycloak identity provider.
+type ArgoCDKeycloakIdentityProvider struct {
+	// Alias is the unique name of the identity provider.
+	Alias string `json:"alias"`
+
+	// DisplayName is the name of the identity provider on the login page.
+	DisplayName string `json:"displayName,omitempty"`
+
+	// ProviderID is the type of the identity provider, e.g. oidc, saml, github or google.
+	ProviderID string `json:"providerId"`
+
+	// Config is the configuration of the identity provider.
+	Config map[string]string `json:"config,omitempty"`
+
+	// ClientSecretRef references the key of a Secret in the namespace of the Argo CD instance that holds the client
+	// secret of the identity provider.
+	ClientSecretRef *corev1.SecretKeySelector `json:"clientSecretRef,omitempty"`
+
+	// Mappers is a list of mappers of the identity provider.
+	Mappers []ArgoCDKeycloakIdentityProviderMapper `json:"mappers,omitempty"`
+}
+
+// ArgoCDKeycloakIdentityProviderMapper defines a mapper of a Keycloak identity provider.
+type ArgoCDKeycloakIdentityProviderMapper struct {
+	// Name of the mapper.
+	Name string `json:"name"`
+
+	// IdentityProviderMapper is the type of the mapper, e.g. oidc-advanced-group-idp-mapper.
+	IdentityProviderMapper string `json:"identityProviderMapper"`
+
+	// Config is the configuration of the mapper.
+	Config map[string]string `json:"config,omitempty"`
+}
+
+// ArgoCDKeycloakClientScope defines a Keycloak client scope.
+type ArgoCDKeycloakClientScope struct {
+	// Name of the client scope.
+	Name string `json:"name"`
+
+	// Protocol of the client scope. Defaults to openid-connect.
+	Protocol string `json:"protocol,omitempty"`
+
+	// Attributes of the client scope.
+	Attributes map[string]string `json:"attributes,omitempty"`
+
+	// ProtocolMappers is a list of protocol mappers of the client scope.
+	ProtocolMappers []ArgoCDKeycloakProtocolMapper `json:"protocolMappers,omitempty"`
+}
+
+// ArgoCDKeycloakProtocolMapper defines a Keycloak protocol mapper.
+type ArgoCDKeycloakProtocolMapper struct {
+	// Name of the protocol mapper.
+	Name string `json:"name"`
+
+	// ProtocolMapper is the type of the protocol mapper, e.g. oidc-usermodel-attribute-mapper.
+	ProtocolMapper string `json:"protocolMapper"`
+
+	// Config is the configuration of the protocol mapper.
+	Config map[string]string `json:"config,omitempty"`
+}
+
+// ArgoCDKeycloakGroupMapper defines a group membership mapper of the argocd client.
+type ArgoCDKeycloakGroupMapper struct {
+	// Name of the mapper.
+	Name string `json:"name"`
+
+	// ClaimName is the name of the token claim holding the groups of the user.
+	ClaimName string `json:"claimName"`
+
+	// FullPath will add the full path of the groups, e.g. /parent/child, to the claim.
+	FullPath bool `json:"fullPath,omitempty"`
+}
+
+// ArgoCDKeycloakRealmRole defines a Keycloak realm role.
+type ArgoCDKeycloakRealmRole struct {
+	// Name of the role.
+	Name string `json:"name"`
+
+	// Description of the role.
+	Description string `json:"description,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -382,6 +757,12 @@ type ArgoCDNotifications struct {
 
 	// LogLevel describes the log level that should be used by the argocd-notifications. Defaults to ArgoCDDefaultLogLevel if not set.  Valid options are debug,info, error, and warn.
 	LogLevel string `json:"logLevel,omitempty"`
+
+	// SourceNamespaces defines the namespaces in which users may manage their own notifications configuration
+	// (self-service notifications) for the Applications of the namespace, using a NotificationsConfiguration named
+	// default-notifications-configuration. Glob patterns are supported. Only namespaces that are also part of
+	// .spec.sourceNamespaces are considered.
+	SourceNamespaces []string `json:"sourceNamespaces,omitempty"`
 }
 
 // ArgoCDPrometheusSpec defines the desired state for the Prometheus component.
@@ -390,6 +771,9 @@ type ArgoCDPrometheusSpec struct {
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Enabled",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:fieldGroup:Prometheus","urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
 	Enabled bool `json:"enabled"`
 
+	// Gateway defines the desired state for a Gateway API HTTPRoute for the Prometheus component.
+	Gateway ArgoCDGatewayRouteSpec `json:"gateway,omitempty"`
+
 	// Host is the hostname to use for Ingress/Route resources.
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Host",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:fieldGroup:Prometheus","urn:alm:descriptor:com.tectonic.ui:text"}
 	Host string `json:"host,omitempty"`
@@ -430,6 +814,65 @@ type ArgoCDRBACSpec struct {
 	// PolicyMatcherMode configures the matchers function mode for casbin.
 	// There are two options for this, 'glob' for glob matcher or 'regex' for regex matcher.
 	PolicyMatcherMode *string `json:"policyMatcherMode,omitempty"`
+
+	// Roles is a structured list of RBAC roles, their policies and group bindings. The operator validates
+	// each role and renders it into `policy.csv`, after the user-defined Policy CSV. Invalid entries are
+	// skipped and reported in the ArgoCD status.
+	Roles []ArgoCDRBACRole `json:"roles,omitempty"`
+
+	// Overlays are additional named sets of roles rendered into separate `policy.<name>.csv` keys of the
+	// `argocd-rbac-cm` ConfigMap.
+	Overlays []ArgoCDRBACPolicyOverlay `json:"overlays,omitempty"`
+
+	// Fragments configures the aggregation of RBAC policy fragments from ConfigMaps in the namespaces managed by this instance.
+	Fragments *ArgoCDRBACFragmentsSpec `json:"fragments,omitempty"`
+}
+
+// ArgoCDRBACFragmentsSpec defines the options for aggregating RBAC policy fragments from managed namespaces.
+type ArgoCDRBACFragmentsSpec struct {
+	// Enabled toggles the aggregation of RBAC policy fragments. A fragment is a ConfigMap labelled with
+	// `argocd.argoproj.io/rbac-fragment` in a namespace managed by this instance, that holds policy CSV data
+	// under the `policy.csv` key. Fragment rules may only reference roles prefixed with `role:<namespace>:`
+	// and the AppProjects whose sourceNamespaces include the fragment's namespace.
+	Enabled bool `json:"enabled"`
+}
+
+// ArgoCDRBACRole defines an Argo CD RBAC role, the policies granted to it and the subjects bound to it.
+type ArgoCDRBACRole struct {
+	// Name is the name of the role, without the `role:` prefix.
+	Name string `json:"name"`
+
+	// Policies are the policy rules granted to the role.
+	Policies []ArgoCDRBACPolicyRule `json:"policies,omitempty"`
+
+	// Groups is the list of users or SSO groups bound to the role.
+	Groups []string `json:"groups,omitempty"`
+}
+
+// ArgoCDRBACPolicyRule defines a single Argo CD RBAC policy rule.
+type ArgoCDRBACPolicyRule struct {
+	// Resource is the Argo CD resource the rule applies to, e.g. applications, clusters or repositories.
+	Resource string `json:"resource"`
+
+	// Action is the action allowed or denied on the resource, e.g. get, sync or action/apps/Deployment/restart.
+	Action string `json:"action"`
+
+	// Object is the object the rule applies to, e.g. `my-project/*` for applications.
+	Object string `json:"object"`
+
+	// Effect is the effect of the rule. Defaults to allow.
+	// +kubebuilder:validation:Enum=allow;deny
+	Effect string `json:"effect,omitempty"`
+}
+
+// ArgoCDRBACPolicyOverlay defines a named set of RBAC roles rendered into the `policy.<name>.csv` key of the
+// `argocd-rbac-cm` ConfigMap.
+type ArgoCDRBACPolicyOverlay struct {
+	// Name is used to build the `policy.<name>.csv` key.
+	Name string `json:"name"`
+
+	// Roles is the list of RBAC roles rendered into the overlay.
+	Roles []ArgoCDRBACRole `json:"roles,omitempty"`
 }
 
 // ArgoCDRedisSpec defines the desired state for the Redis server component.
@@ -459,6 +902,89 @@ type ArgoCDRedisSpec struct {
 
 	// Remote specifies the remote URL of the Redis container. (optional, by default, a local instance managed by the operator is used.)
 	Remote *string `json:"remote,omitempty"`
+
+	// External configures the connection to a Redis server that is not managed by the operator. When set, it takes
+	// precedence over Remote and the operator does not deploy Redis.
+	External *ArgoCDRedisExternalSpec `json:"external,omitempty"`
+
+	// Persistence stores the Redis cache on a PersistentVolumeClaim, so that it survives restarts of Redis. It is not
+	// used in HA mode, where the persistence is configured in `ha.redis.persistence`.
+	// When persistence is removed, the PersistentVolumeClaim is orphaned rather than deleted, so that the data is kept
+	// until the claim is deleted explicitly. It is adopted again when persistence is re-enabled.
+	Persistence *ArgoCDRedisPersistenceSpec `json:"persistence,omitempty"`
+
+	// MaxMemory is the maximum amount of memory used by Redis for the cache, e.g. `512mb`. (optional, by default the
+	// memory is not limited)
+	MaxMemory string `json:"maxMemory,omitempty"`
+
+	// MaxMemoryPolicy is the eviction policy of Redis when MaxMemory is reached. (optional, default `noeviction`, or
+	// `volatile-lru` in HA mode)
+	//+kubebuilder:validation:Enum=noeviction;allkeys-lru;allkeys-lfu;allkeys-random;volatile-lru;volatile-lfu;volatile-random;volatile-ttl
+	MaxMemoryPolicy string `json:"maxMemoryPolicy,omitempty"`
+
+	// Exporter runs a Redis exporter sidecar next to Redis, exposing the Redis metrics to Prometheus. It is not used in
+	// HA mode, nor when Redis is not managed by the operator.
+	Exporter *ArgoCDRedisExporterSpec `json:"exporter,omitempty"`
+}
+
+// ArgoCDRedisExporterSpec defines the Redis exporter sidecar of the Redis server component.
+type ArgoCDRedisExporterSpec struct {
+	// Enabled will toggle the Redis exporter sidecar.
+	Enabled bool `json:"enabled"`
+
+	// Image is the Redis exporter container image.
+	Image string `json:"image,omitempty"`
+
+	// Version is the Redis exporter container image tag.
+	Version string `json:"version,omitempty"`
+
+	// Resources defines the Compute Resources required by the Redis exporter container.
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+// ArgoCDRedisExternalSpec defines the connection to an external Redis server shared by the Argo CD server, repo server
+// and application controller.
+type ArgoCDRedisExternalSpec struct {
+	// Address is the host and port of the Redis server. It is ignored when Sentinel is set.
+	Address string `json:"address,omitempty"`
+
+	// DB is the index of the Redis database. (optional, default `0`)
+	//+kubebuilder:validation:Minimum=0
+	DB *int32 `json:"db,omitempty"`
+
+	// UsernameRef references the key of a Secret in the namespace of the Argo CD instance that holds the username
+	// used to authenticate to Redis.
+	UsernameRef *corev1.SecretKeySelector `json:"usernameRef,omitempty"`
+
+	// PasswordRef references the key of a Secret in the namespace of the Argo CD instance that holds the password
+	// used to authenticate to Redis.
+	PasswordRef *corev1.SecretKeySelector `json:"passwordRef,omitempty"`
+
+	// TLS enables TLS for the connection to Redis.
+	TLS *ArgoCDRedisExternalTLSSpec `json:"tls,omitempty"`
+
+	// Sentinel connects to Redis through Redis Sentinel instead of Address.
+	Sentinel *ArgoCDRedisSentinelSpec `json:"sentinel,omitempty"`
+}
+
+// ArgoCDRedisExternalTLSSpec defines the TLS configuration of the connection to an external Redis server.
+type ArgoCDRedisExternalTLSSpec struct {
+	// CABundleRef references the key of a Secret in the namespace of the Argo CD instance that holds the PEM encoded
+	// CA bundle used to verify the Redis server certificate. The system CAs are used when it is not set.
+	CABundleRef *corev1.SecretKeySelector `json:"caBundleRef,omitempty"`
+
+	// InsecureSkipVerify will not verify the certificate of the Redis server.
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+}
+
+// ArgoCDRedisSentinelSpec defines the Redis Sentinel used to discover the Redis master.
+type ArgoCDRedisSentinelSpec struct {
+	// MasterName is the name of the Redis master monitored by the sentinels.
+	MasterName string `json:"masterName"`
+
+	// Addresses is the list of host and port of the sentinels.
+	//+kubebuilder:validation:MinItems=1
+	Addresses []string `json:"addresses"`
 }
 
 func (a *ArgoCDRedisSpec) IsEnabled() bool {
@@ -524,17 +1050,67 @@ type ArgoCDRepoSpec struct {
 	// SidecarContainers defines the list of sidecar containers for the repo server deployment
 	SidecarContainers []corev1.Container `json:"sidecarContainers,omitempty"`
 
+	// Plugins defines the Config Management Plugins run in sidecars of the repo server. The operator generates the
+	// sidecar containers, the plugin configuration and the volumes shared with the repo server.
+	Plugins []ArgoCDRepoPluginSpec `json:"plugins,omitempty"`
+
 	// Enabled is the flag to enable Repo Server during ArgoCD installation. (optional, default `true`)
 	Enabled *bool `json:"enabled,omitempty"`
 
 	// Remote specifies the remote URL of the Repo Server container. (optional, by default, a local instance managed by the operator is used.)
 	Remote *string `json:"remote,omitempty"`
+
+	// RemoteTLS configures the TLS connection to the remote repo server pool set in Remote. When set, the Argo CD
+	// components connecting to the repo server use strict TLS validation.
+	RemoteTLS *ArgoCDRepoRemoteTLSSpec `json:"remoteTLS,omitempty"`
 }
 
 func (a *ArgoCDRepoSpec) IsEnabled() bool {
 	return a.Enabled == nil || (a.Enabled != nil && *a.Enabled)
 }
 
+// ArgoCDRepoPluginSpec defines a Config Management Plugin run in a sidecar of the repo server.
+type ArgoCDRepoPluginSpec struct {
+	// Name of the plugin, used to name its sidecar container.
+	//+kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	//+kubebuilder:validation:MaxLength=50
+	Name string `json:"name"`
+
+	// Image of the sidecar container, holding the tools run by the plugin.
+	Image string `json:"image"`
+
+	// Configuration is the content of the plugin.yaml file of the plugin.
+	Configuration string `json:"configuration,omitempty"`
+
+	// ConfigMapRef references a ConfigMap key holding the plugin.yaml file of the plugin, instead of Configuration.
+	ConfigMapRef *corev1.ConfigMapKeySelector `json:"configMapRef,omitempty"`
+
+	// Env lets you specify environment variables for the sidecar container.
+	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// Resources defines the Compute Resources required by the sidecar container.
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+// ArgoCDRepoRemoteTLSSpec defines the TLS configuration of the connection to a remote repo server pool.
+type ArgoCDRepoRemoteTLSSpec struct {
+	// IssueCertificates enables issuing the server and client certificates of the remote repo server pool from the
+	// operator CA. The server bundle is exported in the `<argocd-name>-repo-server-remote-tls` Secret, to be deployed
+	// along the remote repo servers.
+	IssueCertificates bool `json:"issueCertificates,omitempty"`
+
+	// ServerDNSNames are the DNS names of the issued server certificate. (optional, default the host of Remote)
+	ServerDNSNames []string `json:"serverDNSNames,omitempty"`
+
+	// CABundleRef references the CA bundle trusted for the remote repo servers, when the certificates are not issued
+	// by the operator.
+	CABundleRef *corev1.SecretKeySelector `json:"caBundleRef,omitempty"`
+
+	// ClientCertificateSecret is the name of a kubernetes.io/tls Secret holding the client certificate for the remote
+	// repo servers, when the certificates are not issued by the operator.
+	ClientCertificateSecret string `json:"clientCertificateSecret,omitempty"`
+}
+
 // ArgoCDRouteSpec defines the desired state for an OpenShift Route.
 type ArgoCDRouteSpec struct {
 	// Annotations is the map of annotations to use for the Route resource.
@@ -557,6 +1133,41 @@ type ArgoCDRouteSpec struct {
 	WildcardPolicy *routev1.WildcardPolicyType `json:"wildcardPolicy,omitempty"`
 }
 
+// ArgoCDGatewayParentReference identifies a Gateway, and optionally one of its listeners, that a Gateway API route
+// attaches to.
+type ArgoCDGatewayParentReference struct {
+	// Name is the name of the Gateway.
+	Name string `json:"name"`
+
+	// Namespace is the namespace of the Gateway. Defaults to the namespace of the ArgoCD.
+	Namespace string `json:"namespace,omitempty"`
+
+	// SectionName is the name of the Gateway listener to attach to. Defaults to all listeners.
+	SectionName string `json:"sectionName,omitempty"`
+}
+
+// ArgoCDGatewayRouteSpec defines the desired state for the Gateway API route of an Argo CD endpoint.
+type ArgoCDGatewayRouteSpec struct {
+	// Annotations is the map of annotations to use for the route resource.
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// Enabled will toggle the creation of the Gateway API route.
+	Enabled bool `json:"enabled"`
+
+	// Labels is the map of labels to use for the route resource.
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// ParentRefs are the Gateways the route attaches to.
+	ParentRefs []ArgoCDGatewayParentReference `json:"parentRefs,omitempty"`
+
+	// Path is the path prefix routed to the service. Defaults to `/`. Ignored by GRPCRoutes and TLSRoutes.
+	Path string `json:"path,omitempty"`
+
+	// TLSPassthrough creates a TLSRoute instead of an HTTPRoute, so that TLS is terminated by the Argo CD Server
+	// itself. Only supported by the Argo CD Server endpoint.
+	TLSPassthrough bool `json:"tlsPassthrough,omitempty"`
+}
+
 // ArgoCDServerAutoscaleSpec defines the desired state for autoscaling the Argo CD Server component.
 type ArgoCDServerAutoscaleSpec struct {
 	// Enabled will toggle autoscaling support for the Argo CD Server component.
@@ -569,6 +1180,9 @@ type ArgoCDServerAutoscaleSpec struct {
 
 // ArgoCDServerGRPCSpec defines the desired state for the Argo CD Server GRPC options.
 type ArgoCDServerGRPCSpec struct {
+	// Gateway defines the desired state for a Gateway API GRPCRoute for the Argo CD Server GRPC endpoint.
+	Gateway ArgoCDGatewayRouteSpec `json:"gateway,omitempty"`
+
 	// Host is the hostname to use for Ingress/Route resources.
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="GRPC Host",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:fieldGroup:Server","urn:alm:descriptor:com.tectonic.ui:text"}
 	Host string `json:"host,omitempty"`
@@ -583,6 +1197,9 @@ type ArgoCDServerSpec struct {
 	// Autoscale defines the autoscale options for the Argo CD Server component.
 	Autoscale ArgoCDServerAutoscaleSpec `json:"autoscale,omitempty"`
 
+	// Gateway defines the desired state for a Gateway API route for the Argo CD Server component.
+	Gateway ArgoCDGatewayRouteSpec `json:"gateway,omitempty"`
+
 	// GRPC defines the state for the Argo CD Server GRPC options.
 	GRPC ArgoCDServerGRPCSpec `json:"grpc,omitempty"`
 
@@ -694,6 +1311,9 @@ const (
 
 	// SSOProviderTypeDex means dex will be Installed and Integrated with Argo CD.
 	SSOProviderTypeDex SSOProviderType = "dex"
+
+	// SSOProviderTypeOIDC means Argo CD will be Integrated with an existing external OpenID Connect provider.
+	SSOProviderTypeOIDC SSOProviderType = "oidc"
 )
 
 // ArgoCDSSOSpec defines SSO provider.
@@ -706,14 +1326,74 @@ type ArgoCDSSOSpec struct {
 
 	// Keycloak contains the configuration for Argo CD keycloak authentication
 	Keycloak *ArgoCDKeycloakSpec `json:"keycloak,omitempty"`
+
+	// OIDC contains the configuration for Argo CD authentication with an external OpenID Connect provider
+	OIDC *ArgoCDOIDCSpec `json:"oidc,omitempty"`
+}
+
+// ArgoCDOIDCSpec defines the configuration of an external OpenID Connect provider.
+type ArgoCDOIDCSpec struct {
+	// Name is the name of the provider on the Argo CD login page. Defaults to OIDC.
+	Name string `json:"name,omitempty"`
+
+	// Issuer is the issuer URL of the provider.
+	Issuer string `json:"issuer"`
+
+	// ClientID is the OAuth client ID of Argo CD at the provider.
+	ClientID string `json:"clientID"`
+
+	// ClientSecretRef references the key of a Secret in the namespace of the Argo CD instance that holds the OAuth
+	// client secret. The secret is copied into the argocd-secret Secret.
+	ClientSecretRef *corev1.SecretKeySelector `json:"clientSecretRef,omitempty"`
+
+	// RequestedScopes is the list of scopes requested from the provider. Defaults to openid, profile and email.
+	RequestedScopes []string `json:"requestedScopes,omitempty"`
+
+	// RootCA is the PEM encoded CA bundle used to verify the TLS certificate of the provider.
+	RootCA string `json:"rootCA,omitempty"`
+
+	// GroupsClaim is the claim of the ID token that holds the groups of a user. It is requested as an essential claim
+	// and used as the RBAC scope, unless RBAC scopes are set explicitly.
+	GroupsClaim string `json:"groupsClaim,omitempty"`
+
+	// DiscoveryEndpoint is the URL of the OpenID Connect discovery metadata used to validate the provider. Defaults
+	// to the .well-known/openid-configuration path of the issuer.
+	DiscoveryEndpoint string `json:"discoveryEndpoint,omitempty"`
 }
 
 // KustomizeVersionSpec is used to specify information about a kustomize version to be used within ArgoCD.
 type KustomizeVersionSpec struct {
 	// Version is a configured kustomize version in the format of vX.Y.Z
 	Version string `json:"version,omitempty"`
-	// Path is the path to a configured kustomize version on the filesystem of your repo server.
+	// Path is the path to a configured kustomize version on the filesystem of your repo server. Must not be set along
+	// with Image.
 	Path string `json:"path,omitempty"`
+	// Image is a container image holding the kustomize binary. When set, the binary is copied into the repo server by
+	// an init container, so that a custom repo server image is not needed. Version must then be in the format vX.Y.Z.
+	Image string `json:"image,omitempty"`
+	// ImagePath is the path of the kustomize binary in Image. (optional, default `/usr/local/bin/kustomize`)
+	ImagePath string `json:"imagePath,omitempty"`
+}
+
+// ArgoCDGPGKeySpec is used to specify GPG public keys, either inline or from a ConfigMap or Secret key in the namespace
+// of the ArgoCD. Each source may hold several ASCII armored keys.
+type ArgoCDGPGKeySpec struct {
+	// PublicKey holds ASCII armored GPG public keys.
+	PublicKey string `json:"publicKey,omitempty"`
+	// ConfigMapRef references a ConfigMap key holding ASCII armored GPG public keys.
+	ConfigMapRef *corev1.ConfigMapKeySelector `json:"configMapRef,omitempty"`
+	// SecretRef references a Secret key holding ASCII armored GPG public keys.
+	SecretRef *corev1.SecretKeySelector `json:"secretRef,omitempty"`
+}
+
+// HelmVersionSpec is used to specify a helm binary to be used by the repo server instead of the bundled one.
+type HelmVersionSpec struct {
+	// Version is the helm version in the format of vX.Y.Z, for information.
+	Version string `json:"version,omitempty"`
+	// Image is a container image holding the helm binary, copied into the repo server by an init container.
+	Image string `json:"image"`
+	// ImagePath is the path of the helm binary in Image. (optional, default `/usr/local/bin/helm`)
+	ImagePath string `json:"imagePath,omitempty"`
 }
 
 // ArgoCDMonitoringSpec is used to configure workload status monitoring for a given Argo CD instance.
@@ -725,6 +1405,96 @@ type ArgoCDMonitoringSpec struct {
 	Enabled bool `json:"enabled"`
 	// DisableMetrics field can be used to enable or disable the collection of Metrics on Openshift
 	DisableMetrics *bool `json:"disableMetrics,omitempty"`
+	// Alerts overrides the defaults of the alerts shipped in the PrometheusRule for this instance.
+	Alerts []ArgoCDMonitoringAlertSpec `json:"alerts,omitempty"`
+	// Dashboards defines the Grafana dashboards shipped for this instance.
+	Dashboards ArgoCDMonitoringDashboardsSpec `json:"dashboards,omitempty"`
+	// ServiceMonitors defines the scrape settings of the ServiceMonitors created for the Argo CD components.
+	ServiceMonitors ArgoCDMonitoringServiceMonitorsSpec `json:"serviceMonitors,omitempty"`
+}
+
+// ArgoCDMonitoringAlertSpec overrides the defaults of a single alert in the built-in alert catalog.
+type ArgoCDMonitoringAlertSpec struct {
+	// Name of the alert to override, e.g. ApplicationSyncFailed.
+	Name string `json:"name"`
+	// Enabled defines whether the alert is included in the PrometheusRule. Defaults to true.
+	Enabled *bool `json:"enabled,omitempty"`
+	// Threshold overrides the value the alert expression is compared against. Ignored by the component status alerts.
+	//+kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?$`
+	Threshold string `json:"threshold,omitempty"`
+	// For overrides how long the alert expression must be true before the alert fires, e.g. 5m.
+	For string `json:"for,omitempty"`
+	// Severity overrides the severity label of the alert.
+	Severity string `json:"severity,omitempty"`
+	// Labels to add to the alert.
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// ArgoCDMonitoringDashboardsSpec defines the Grafana dashboards shipped for an Argo CD instance.
+type ArgoCDMonitoringDashboardsSpec struct {
+	// Enabled will toggle the creation of the ConfigMap holding the Grafana dashboards.
+	Enabled bool `json:"enabled"`
+	// Labels used by the Grafana dashboards sidecar to discover the ConfigMap. Defaults to the grafana_dashboard label set to "1".
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// ArgoCDMonitoringServiceMonitorsSpec defines the scrape settings of the ServiceMonitor of each Argo CD component.
+type ArgoCDMonitoringServiceMonitorsSpec struct {
+	// ApplicationController defines the scrape settings of the application controller metrics.
+	ApplicationController ArgoCDMonitoringServiceMonitorSpec `json:"applicationController,omitempty"`
+	// ApplicationSetController defines the scrape settings of the ApplicationSet controller metrics.
+	ApplicationSetController ArgoCDMonitoringServiceMonitorSpec `json:"applicationSetController,omitempty"`
+	// Dex defines the scrape settings of the Dex metrics.
+	Dex ArgoCDMonitoringServiceMonitorSpec `json:"dex,omitempty"`
+	// Notifications defines the scrape settings of the notifications controller metrics.
+	Notifications ArgoCDMonitoringServiceMonitorSpec `json:"notifications,omitempty"`
+	// Redis defines the scrape settings of the Redis exporter metrics.
+	Redis ArgoCDMonitoringServiceMonitorSpec `json:"redis,omitempty"`
+	// RepoServer defines the scrape settings of the repo server metrics.
+	RepoServer ArgoCDMonitoringServiceMonitorSpec `json:"repoServer,omitempty"`
+	// Server defines the scrape settings of the Argo CD server metrics.
+	Server ArgoCDMonitoringServiceMonitorSpec `json:"server,omitempty"`
+}
+
+// ArgoCDMonitoringServiceMonitorSpec defines the scrape settings of the ServiceMonitor of an Argo CD component.
+type ArgoCDMonitoringServiceMonitorSpec struct {
+	// Interval at which the metrics are scraped, e.g. 30s. Defaults to the scrape interval of Prometheus.
+	//+kubebuilder:validation:Pattern=`^[0-9]+(ms|s|m|h)$`
+	Interval string `json:"interval,omitempty"`
+	// Relabelings applied to the scraped targets before the metrics are ingested.
+	Relabelings []ArgoCDMonitoringRelabelConfig `json:"relabelings,omitempty"`
+	// TLS scrapes the metrics over HTTPS with the given settings.
+	TLS *ArgoCDMonitoringTLSSpec `json:"tls,omitempty"`
+}
+
+// ArgoCDMonitoringRelabelConfig defines a relabeling of the scraped targets.
+type ArgoCDMonitoringRelabelConfig struct {
+	// SourceLabels are the labels whose values are concatenated and matched against Regex.
+	SourceLabels []string `json:"sourceLabels,omitempty"`
+	// Separator placed between the concatenated source label values. Defaults to ;.
+	Separator string `json:"separator,omitempty"`
+	// TargetLabel is the label the result is written to by the replace and hashmod actions.
+	TargetLabel string `json:"targetLabel,omitempty"`
+	// Regex matched against the concatenated source label values. Defaults to (.*).
+	Regex string `json:"regex,omitempty"`
+	// Modulus used by the hashmod action.
+	Modulus uint64 `json:"modulus,omitempty"`
+	// Replacement written to TargetLabel by the replace action. Defaults to $1.
+	Replacement string `json:"replacement,omitempty"`
+	// Action performed on the match of Regex. Defaults to replace.
+	//+kubebuilder:validation:Enum=replace;keep;drop;hashmod;labelmap;labeldrop;labelkeep
+	Action string `json:"action,omitempty"`
+}
+
+// ArgoCDMonitoringTLSSpec defines the TLS settings used to scrape the metrics of an Argo CD component.
+type ArgoCDMonitoringTLSSpec struct {
+	// CARef references the key of a Secret in the namespace of the Argo CD instance that holds the PEM encoded CA
+	// bundle used to verify the certificate of the component.
+	CARef *corev1.SecretKeySelector `json:"caRef,omitempty"`
+	// ServerName is used to verify the hostname of the certificate of the component.
+	ServerName string `json:"serverName,omitempty"`
+	// InsecureSkipVerify will not verify the certificate of the component.
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
 }
 
 // ArgoCDNodePlacementSpec is used to specify NodeSelector and Tolerations for Argo CD workloads
@@ -735,6 +1505,37 @@ type ArgoCDNodePlacementSpec struct {
 	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
 }
 
+// ArgoCDObservabilitySpec defines the observability options for the Argo CD components.
+type ArgoCDObservabilitySpec struct {
+	// Tracing defines the OpenTelemetry tracing options for the server, repo server and application controller.
+	Tracing ArgoCDTracingSpec `json:"tracing,omitempty"`
+}
+
+// ArgoCDTracingSpec defines the OpenTelemetry tracing options for the Argo CD components.
+type ArgoCDTracingSpec struct {
+	// Enabled will toggle the export of traces by the server, repo server and application controller.
+	Enabled bool `json:"enabled"`
+	// Endpoint is the address of the OTLP gRPC collector the traces are sent to, e.g. otel-collector.monitoring.svc:4317.
+	// It must be set when tracing is enabled.
+	Endpoint string `json:"endpoint,omitempty"`
+	// Insecure disables TLS on the connection to the collector.
+	Insecure bool `json:"insecure,omitempty"`
+	// Attributes to add to the traces sent to the collector.
+	Attributes map[string]string `json:"attributes,omitempty"`
+	// Sampling defines which traces are sent to the collector. All traces are sent when not set.
+	Sampling *ArgoCDTracingSamplingSpec `json:"sampling,omitempty"`
+}
+
+// ArgoCDTracingSamplingSpec defines the sampling of the traces of the Argo CD components.
+type ArgoCDTracingSamplingSpec struct {
+	// Sampler is the OpenTelemetry sampler deciding which traces are sampled, set as OTEL_TRACES_SAMPLER.
+	//+kubebuilder:validation:Enum=always_on;always_off;traceidratio;parentbased_always_on;parentbased_always_off;parentbased_traceidratio
+	Sampler string `json:"sampler"`
+	// Ratio is the ratio of the traces sampled by the traceidratio and parentbased_traceidratio samplers, between 0 and 1, set as OTEL_TRACES_SAMPLER_ARG.
+	//+kubebuilder:validation:Pattern=`^(0(\.[0-9]+)?|1(\.0+)?)$`
+	Ratio string `json:"ratio,omitempty"`
+}
+
 // ArgoCDSpec defines the desired state of ArgoCD
 // +k8s:openapi-gen=true
 type ArgoCDSpec struct {
@@ -775,9 +1576,16 @@ type ArgoCDSpec struct {
 	// Deprecated: Grafana defines the Grafana server options for ArgoCD.
 	Grafana ArgoCDGrafanaSpec `json:"grafana,omitempty"`
 
+	// GPGKeys is a listing of GPG public keys used to verify the signature of commits. The operator indexes the keys
+	// by key ID in the argocd-gpg-keys-cm ConfigMap, and removes the keys that are no longer listed.
+	GPGKeys []ArgoCDGPGKeySpec `json:"gpgKeys,omitempty"`
+
 	// HA options for High Availability support for the Redis component.
 	HA ArgoCDHASpec `json:"ha,omitempty"`
 
+	// HelmVersion replaces the helm binary of the repo server with the one of the given image.
+	HelmVersion *HelmVersionSpec `json:"helmVersion,omitempty"`
+
 	// HelpChatURL is the URL for getting chat help, this will typically be your Slack channel for support.
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Help Chat URL'",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text","urn:alm:descriptor:com.tectonic.ui:advanced"}
 	HelpChatURL string `json:"helpChatURL,omitempty"`
@@ -820,6 +1628,9 @@ type ArgoCDSpec struct {
 	// Notifications defines whether the Argo CD Notifications controller should be installed.
 	Notifications ArgoCDNotifications `json:"notifications,omitempty"`
 
+	// Observability defines the observability options for the Argo CD components.
+	Observability ArgoCDObservabilitySpec `json:"observability,omitempty"`
+
 	// Prometheus defines the Prometheus server options for ArgoCD.
 	Prometheus ArgoCDPrometheusSpec `json:"prometheus,omitempty"`
 
@@ -875,6 +1686,9 @@ type ArgoCDSpec struct {
 	// TLS defines the TLS options for ArgoCD.
 	TLS ArgoCDTLSSpec `json:"tls,omitempty"`
 
+	// TrustedCABundle defines a CA bundle mounted alongside the system trust store of every Argo CD component.
+	TrustedCABundle *ArgoCDTrustedCABundleSpec `json:"trustedCABundle,omitempty"`
+
 	// UsersAnonymousEnabled toggles anonymous user access.
 	// The anonymous users get default role permissions specified argocd-rbac-cm.
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Anonymous Users Enabled'",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch","urn:alm:descriptor:com.tectonic.ui:advanced"}
@@ -977,6 +1791,12 @@ type ArgoCDStatus struct {
 
 	// Host is the hostname of the Ingress.
 	Host string `json:"host,omitempty"`
+
+	// RBACPolicyErrors lists the RBAC policy entries that failed validation. Invalid structured roles and policies are not rendered into the argocd-rbac-cm ConfigMap.
+	RBACPolicyErrors []string `json:"rbacPolicyErrors,omitempty"`
+
+	// URLs lists every URL at which the Argo CD Server is exposed by its Ingress.
+	URLs []string `json:"urls,omitempty"`
 }
 
 // Banner defines an additional banner message to be displayed in Argo CD UI
@@ -995,6 +1815,39 @@ type ArgoCDTLSSpec struct {
 
 	// InitialCerts defines custom TLS certificates upon creation of the cluster for connecting Git repositories via HTTPS.
 	InitialCerts map[string]string `json:"initialCerts,omitempty"`
+
+	// ReconcileCerts keeps the `argocd-tls-certs-cm` ConfigMap in sync with InitialCerts and the certificate
+	// sources, instead of only initializing it. Certificates added by other means are removed.
+	ReconcileCerts bool `json:"reconcileCerts,omitempty"`
+
+	// AggregateCertsFromNamespaces adds the certificates of the ConfigMaps and Secrets labelled with
+	// `argocd.argoproj.io/tls-certs` in the namespace of this instance, and in the managed namespaces listed in
+	// CertsSourceNamespaces, to the `argocd-tls-certs-cm` ConfigMap. Each key of a source is a server name and its
+	// value a PEM certificate. Implies ReconcileCerts.
+	AggregateCertsFromNamespaces bool `json:"aggregateCertsFromNamespaces,omitempty"`
+
+	// CertsSourceNamespaces defines the namespaces managed by this instance whose certificate sources are
+	// aggregated, and the server names they may add certificates for.
+	CertsSourceNamespaces *ArgoCDTrustSourceNamespacesSpec `json:"certsSourceNamespaces,omitempty"`
+
+	// TrustedCABundleHosts are the server names trusted through the trusted CA bundle of TrustedCABundle. Without
+	// TrustedCABundle, the bundle is read from the `<name>-trusted-ca-bundle` ConfigMap, which is injected on
+	// OpenShift. Implies ReconcileCerts.
+	TrustedCABundleHosts []string `json:"trustedCABundleHosts,omitempty"`
+}
+
+// ArgoCDTrustedCABundleSpec defines the CA bundle trusted by all Argo CD components.
+type ArgoCDTrustedCABundleSpec struct {
+	// ConfigMapName is the name of the ConfigMap holding the CA bundle, in the namespace of the ArgoCD.
+	// Defaults to `<name>-trusted-ca-bundle`.
+	ConfigMapName string `json:"configMapName,omitempty"`
+
+	// Key is the key of the CA bundle in the ConfigMap. Defaults to `ca-bundle.crt`.
+	Key string `json:"key,omitempty"`
+
+	// Inject labels the ConfigMap with `config.openshift.io/inject-trusted-cabundle`, so that OpenShift injects
+	// the cluster's trusted CA bundle into it. The ConfigMap is created when missing.
+	Inject bool `json:"inject,omitempty"`
 }
 
 type SSHHostsSpec struct {
@@ -1005,11 +1858,38 @@ type SSHHostsSpec struct {
 	// Keys describes a custom set of SSH Known Hosts that you would like to
 	// have included in your ArgoCD server.
 	Keys string `json:"keys,omitempty"`
+
+	// Reconcile keeps the `argocd-ssh-known-hosts-cm` ConfigMap in sync with this spec and the known hosts
+	// sources, instead of only initializing it. Known hosts added by other means are removed.
+	Reconcile bool `json:"reconcile,omitempty"`
+
+	// AggregateFromNamespaces adds the `ssh_known_hosts` entries of the ConfigMaps and Secrets labelled with
+	// `argocd.argoproj.io/ssh-known-hosts` in the namespace of this instance, and in the managed namespaces listed
+	// in SourceNamespaces, to the `argocd-ssh-known-hosts-cm` ConfigMap. Implies Reconcile.
+	AggregateFromNamespaces bool `json:"aggregateFromNamespaces,omitempty"`
+
+	// SourceNamespaces defines the namespaces managed by this instance whose known hosts sources are aggregated,
+	// and the hosts they may add known hosts for.
+	SourceNamespaces *ArgoCDTrustSourceNamespacesSpec `json:"sourceNamespaces,omitempty"`
+}
+
+// ArgoCDTrustSourceNamespacesSpec defines the managed namespaces trusted to add SSH known hosts or TLS certificates
+// for a set of hosts.
+type ArgoCDTrustSourceNamespacesSpec struct {
+	// Namespaces are the names of the namespaces managed by this instance whose sources are aggregated.
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// AllowedHosts are the host name patterns, such as `*.team-a.example.com`, that the sources in Namespaces may
+	// add entries for. Entries for other hosts are ignored.
+	AllowedHosts []string `json:"allowedHosts,omitempty"`
 }
 
 // WebhookServerSpec defines the options for the ApplicationSet Webhook Server component.
 type WebhookServerSpec struct {
 
+	// Gateway defines the desired state for a Gateway API HTTPRoute for the Application set webhook component.
+	Gateway ArgoCDGatewayRouteSpec `json:"gateway,omitempty"`
+
 	// Host is the hostname to use for Ingress/Route resources.
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Host",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:fieldGroup:Server","urn:alm:descriptor:com.tectonic.ui:text"}
 	Host string `json:"host,omitempty"`
@@ -35,7 +35,7 @@ func (in *ArgoCD) DeepCopyInto(out *ArgoCD) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCD.
@@ -242,106 +242,345 @@ func (in *ArgoCDCertificateSpec) DeepCopy() *ArgoCDCertificateSpec {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArgoCDDexSpec) DeepCopyInto(out *ArgoCDDexSpec) {
+func (in *ArgoCDDexConnector) DeepCopyInto(out *ArgoCDDexConnector) {
 	*out = *in
-	if in.Groups != nil {
-		in, out := &in.Groups, &out.Groups
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.GitHub != nil {
+		in, out := &in.GitHub, &out.GitHub
+		*out = new(ArgoCDDexGitHubConnector)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = new(v1.ResourceRequirements)
+	if in.GitLab != nil {
+		in, out := &in.GitLab, &out.GitLab
+		*out = new(ArgoCDDexGitLabConnector)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.Env != nil {
-		in, out := &in.Env, &out.Env
-		*out = make([]v1.EnvVar, len(*in))
+	if in.LDAP != nil {
+		in, out := &in.LDAP, &out.LDAP
+		*out = new(ArgoCDDexLDAPConnector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OIDC != nil {
+		in, out := &in.OIDC, &out.OIDC
+		*out = new(ArgoCDDexOIDCConnector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SAML != nil {
+		in, out := &in.SAML, &out.SAML
+		*out = new(ArgoCDDexSAMLConnector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Microsoft != nil {
+		in, out := &in.Microsoft, &out.Microsoft
+		*out = new(ArgoCDDexMicrosoftConnector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDDexConnector.
+func (in *ArgoCDDexConnector) DeepCopy() *ArgoCDDexConnector {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDDexConnector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDDexGitHubConnector) DeepCopyInto(out *ArgoCDDexGitHubConnector) {
+	*out = *in
+	in.ArgoCDDexOAuthClient.DeepCopyInto(&out.ArgoCDDexOAuthClient)
+	if in.Orgs != nil {
+		in, out := &in.Orgs, &out.Orgs
+		*out = make([]ArgoCDDexGitHubOrg, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDDexSpec.
-func (in *ArgoCDDexSpec) DeepCopy() *ArgoCDDexSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDDexGitHubConnector.
+func (in *ArgoCDDexGitHubConnector) DeepCopy() *ArgoCDDexGitHubConnector {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDDexSpec)
+	out := new(ArgoCDDexGitHubConnector)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArgoCDGrafanaSpec) DeepCopyInto(out *ArgoCDGrafanaSpec) {
+func (in *ArgoCDDexGitHubOrg) DeepCopyInto(out *ArgoCDDexGitHubOrg) {
 	*out = *in
-	in.Ingress.DeepCopyInto(&out.Ingress)
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = new(v1.ResourceRequirements)
+	if in.Teams != nil {
+		in, out := &in.Teams, &out.Teams
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDDexGitHubOrg.
+func (in *ArgoCDDexGitHubOrg) DeepCopy() *ArgoCDDexGitHubOrg {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDDexGitHubOrg)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDDexGitLabConnector) DeepCopyInto(out *ArgoCDDexGitLabConnector) {
+	*out = *in
+	in.ArgoCDDexOAuthClient.DeepCopyInto(&out.ArgoCDDexOAuthClient)
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDDexGitLabConnector.
+func (in *ArgoCDDexGitLabConnector) DeepCopy() *ArgoCDDexGitLabConnector {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDDexGitLabConnector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDDexLDAPConnector) DeepCopyInto(out *ArgoCDDexLDAPConnector) {
+	*out = *in
+	if in.BindPWRef != nil {
+		in, out := &in.BindPWRef, &out.BindPWRef
+		*out = new(v1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
-	in.Route.DeepCopyInto(&out.Route)
-	if in.Size != nil {
-		in, out := &in.Size, &out.Size
-		*out = new(int32)
-		**out = **in
+	out.UserSearch = in.UserSearch
+	if in.GroupSearch != nil {
+		in, out := &in.GroupSearch, &out.GroupSearch
+		*out = new(ArgoCDDexLDAPGroupSearch)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDGrafanaSpec.
-func (in *ArgoCDGrafanaSpec) DeepCopy() *ArgoCDGrafanaSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDDexLDAPConnector.
+func (in *ArgoCDDexLDAPConnector) DeepCopy() *ArgoCDDexLDAPConnector {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDGrafanaSpec)
+	out := new(ArgoCDDexLDAPConnector)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArgoCDHASpec) DeepCopyInto(out *ArgoCDHASpec) {
+func (in *ArgoCDDexLDAPGroupSearch) DeepCopyInto(out *ArgoCDDexLDAPGroupSearch) {
+	*out = *in
+	if in.UserMatchers != nil {
+		in, out := &in.UserMatchers, &out.UserMatchers
+		*out = make([]ArgoCDDexLDAPUserMatcher, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDDexLDAPGroupSearch.
+func (in *ArgoCDDexLDAPGroupSearch) DeepCopy() *ArgoCDDexLDAPGroupSearch {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDDexLDAPGroupSearch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDDexLDAPUserMatcher) DeepCopyInto(out *ArgoCDDexLDAPUserMatcher) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDDexLDAPUserMatcher.
+func (in *ArgoCDDexLDAPUserMatcher) DeepCopy() *ArgoCDDexLDAPUserMatcher {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDDexLDAPUserMatcher)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDDexLDAPUserSearch) DeepCopyInto(out *ArgoCDDexLDAPUserSearch) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDDexLDAPUserSearch.
+func (in *ArgoCDDexLDAPUserSearch) DeepCopy() *ArgoCDDexLDAPUserSearch {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDDexLDAPUserSearch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDDexMicrosoftConnector) DeepCopyInto(out *ArgoCDDexMicrosoftConnector) {
+	*out = *in
+	in.ArgoCDDexOAuthClient.DeepCopyInto(&out.ArgoCDDexOAuthClient)
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDDexMicrosoftConnector.
+func (in *ArgoCDDexMicrosoftConnector) DeepCopy() *ArgoCDDexMicrosoftConnector {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDDexMicrosoftConnector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDDexOAuthClient) DeepCopyInto(out *ArgoCDDexOAuthClient) {
+	*out = *in
+	in.ClientSecretRef.DeepCopyInto(&out.ClientSecretRef)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDDexOAuthClient.
+func (in *ArgoCDDexOAuthClient) DeepCopy() *ArgoCDDexOAuthClient {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDDexOAuthClient)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDDexOIDCConnector) DeepCopyInto(out *ArgoCDDexOIDCConnector) {
+	*out = *in
+	in.ArgoCDDexOAuthClient.DeepCopyInto(&out.ArgoCDDexOAuthClient)
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDDexOIDCConnector.
+func (in *ArgoCDDexOIDCConnector) DeepCopy() *ArgoCDDexOIDCConnector {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDDexOIDCConnector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDDexSAMLConnector) DeepCopyInto(out *ArgoCDDexSAMLConnector) {
+	*out = *in
+	in.CADataRef.DeepCopyInto(&out.CADataRef)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDDexSAMLConnector.
+func (in *ArgoCDDexSAMLConnector) DeepCopy() *ArgoCDDexSAMLConnector {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDDexSAMLConnector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDDexSpec) DeepCopyInto(out *ArgoCDDexSpec) {
 	*out = *in
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Resources != nil {
 		in, out := &in.Resources, &out.Resources
 		*out = new(v1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]v1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Connectors != nil {
+		in, out := &in.Connectors, &out.Connectors
+		*out = make([]ArgoCDDexConnector, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDHASpec.
-func (in *ArgoCDHASpec) DeepCopy() *ArgoCDHASpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDDexSpec.
+func (in *ArgoCDDexSpec) DeepCopy() *ArgoCDDexSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDHASpec)
+	out := new(ArgoCDDexSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArgoCDImportSpec) DeepCopyInto(out *ArgoCDImportSpec) {
+func (in *ArgoCDGPGKeySpec) DeepCopyInto(out *ArgoCDGPGKeySpec) {
 	*out = *in
-	if in.Namespace != nil {
-		in, out := &in.Namespace, &out.Namespace
-		*out = new(string)
-		**out = **in
+	if in.ConfigMapRef != nil {
+		in, out := &in.ConfigMapRef, &out.ConfigMapRef
+		*out = new(v1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDImportSpec.
-func (in *ArgoCDImportSpec) DeepCopy() *ArgoCDImportSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDGPGKeySpec.
+func (in *ArgoCDGPGKeySpec) DeepCopy() *ArgoCDGPGKeySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDImportSpec)
+	out := new(ArgoCDGPGKeySpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArgoCDIngressSpec) DeepCopyInto(out *ArgoCDIngressSpec) {
+func (in *ArgoCDGatewayParentReference) DeepCopyInto(out *ArgoCDGatewayParentReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDGatewayParentReference.
+func (in *ArgoCDGatewayParentReference) DeepCopy() *ArgoCDGatewayParentReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDGatewayParentReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDGatewayRouteSpec) DeepCopyInto(out *ArgoCDGatewayRouteSpec) {
 	*out = *in
 	if in.Annotations != nil {
 		in, out := &in.Annotations, &out.Annotations
@@ -350,221 +589,996 @@ func (in *ArgoCDIngressSpec) DeepCopyInto(out *ArgoCDIngressSpec) {
 			(*out)[key] = val
 		}
 	}
-	if in.IngressClassName != nil {
-		in, out := &in.IngressClassName, &out.IngressClassName
-		*out = new(string)
-		**out = **in
-	}
-	if in.TLS != nil {
-		in, out := &in.TLS, &out.TLS
-		*out = make([]networkingv1.IngressTLS, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
 		}
 	}
+	if in.ParentRefs != nil {
+		in, out := &in.ParentRefs, &out.ParentRefs
+		*out = make([]ArgoCDGatewayParentReference, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDIngressSpec.
-func (in *ArgoCDIngressSpec) DeepCopy() *ArgoCDIngressSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDGatewayRouteSpec.
+func (in *ArgoCDGatewayRouteSpec) DeepCopy() *ArgoCDGatewayRouteSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDIngressSpec)
+	out := new(ArgoCDGatewayRouteSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArgoCDKeycloakSpec) DeepCopyInto(out *ArgoCDKeycloakSpec) {
+func (in *ArgoCDGrafanaSpec) DeepCopyInto(out *ArgoCDGrafanaSpec) {
 	*out = *in
+	in.Gateway.DeepCopyInto(&out.Gateway)
+	in.Ingress.DeepCopyInto(&out.Ingress)
 	if in.Resources != nil {
 		in, out := &in.Resources, &out.Resources
 		*out = new(v1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.VerifyTLS != nil {
-		in, out := &in.VerifyTLS, &out.VerifyTLS
-		*out = new(bool)
+	in.Route.DeepCopyInto(&out.Route)
+	if in.Size != nil {
+		in, out := &in.Size, &out.Size
+		*out = new(int32)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDKeycloakSpec.
-func (in *ArgoCDKeycloakSpec) DeepCopy() *ArgoCDKeycloakSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDGrafanaSpec.
+func (in *ArgoCDGrafanaSpec) DeepCopy() *ArgoCDGrafanaSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDKeycloakSpec)
+	out := new(ArgoCDGrafanaSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArgoCDList) DeepCopyInto(out *ArgoCDList) {
+func (in *ArgoCDHARedisSpec) DeepCopyInto(out *ArgoCDHARedisSpec) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]ArgoCD, len(*in))
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Quorum != nil {
+		in, out := &in.Quorum, &out.Quorum
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Persistence != nil {
+		in, out := &in.Persistence, &out.Persistence
+		*out = new(ArgoCDRedisPersistenceSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SentinelResources != nil {
+		in, out := &in.SentinelResources, &out.SentinelResources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ProxyReplicas != nil {
+		in, out := &in.ProxyReplicas, &out.ProxyReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ProxyResources != nil {
+		in, out := &in.ProxyResources, &out.ProxyResources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDHARedisSpec.
+func (in *ArgoCDHARedisSpec) DeepCopy() *ArgoCDHARedisSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDHARedisSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDHASpec) DeepCopyInto(out *ArgoCDHASpec) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Redis != nil {
+		in, out := &in.Redis, &out.Redis
+		*out = new(ArgoCDHARedisSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDHASpec.
+func (in *ArgoCDHASpec) DeepCopy() *ArgoCDHASpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDHASpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDImportSpec) DeepCopyInto(out *ArgoCDImportSpec) {
+	*out = *in
+	if in.Namespace != nil {
+		in, out := &in.Namespace, &out.Namespace
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDImportSpec.
+func (in *ArgoCDImportSpec) DeepCopy() *ArgoCDImportSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDImportSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDIngressSpec) DeepCopyInto(out *ArgoCDIngressSpec) {
+	*out = *in
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ExtraHosts != nil {
+		in, out := &in.ExtraHosts, &out.ExtraHosts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExtraPaths != nil {
+		in, out := &in.ExtraPaths, &out.ExtraPaths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IngressClassName != nil {
+		in, out := &in.IngressClassName, &out.IngressClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.PathType != nil {
+		in, out := &in.PathType, &out.PathType
+		*out = new(networkingv1.PathType)
+		**out = **in
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = make([]networkingv1.IngressTLS, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDIngressSpec.
+func (in *ArgoCDIngressSpec) DeepCopy() *ArgoCDIngressSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDIngressSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDKeycloakClientScope) DeepCopyInto(out *ArgoCDKeycloakClientScope) {
+	*out = *in
+	if in.Attributes != nil {
+		in, out := &in.Attributes, &out.Attributes
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ProtocolMappers != nil {
+		in, out := &in.ProtocolMappers, &out.ProtocolMappers
+		*out = make([]ArgoCDKeycloakProtocolMapper, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDKeycloakClientScope.
+func (in *ArgoCDKeycloakClientScope) DeepCopy() *ArgoCDKeycloakClientScope {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDKeycloakClientScope)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDKeycloakGroupMapper) DeepCopyInto(out *ArgoCDKeycloakGroupMapper) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDKeycloakGroupMapper.
+func (in *ArgoCDKeycloakGroupMapper) DeepCopy() *ArgoCDKeycloakGroupMapper {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDKeycloakGroupMapper)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDKeycloakIdentityProvider) DeepCopyInto(out *ArgoCDKeycloakIdentityProvider) {
+	*out = *in
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ClientSecretRef != nil {
+		in, out := &in.ClientSecretRef, &out.ClientSecretRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Mappers != nil {
+		in, out := &in.Mappers, &out.Mappers
+		*out = make([]ArgoCDKeycloakIdentityProviderMapper, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDKeycloakIdentityProvider.
+func (in *ArgoCDKeycloakIdentityProvider) DeepCopy() *ArgoCDKeycloakIdentityProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDKeycloakIdentityProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDKeycloakIdentityProviderMapper) DeepCopyInto(out *ArgoCDKeycloakIdentityProviderMapper) {
+	*out = *in
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDKeycloakIdentityProviderMapper.
+func (in *ArgoCDKeycloakIdentityProviderMapper) DeepCopy() *ArgoCDKeycloakIdentityProviderMapper {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDKeycloakIdentityProviderMapper)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDKeycloakProtocolMapper) DeepCopyInto(out *ArgoCDKeycloakProtocolMapper) {
+	*out = *in
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDKeycloakProtocolMapper.
+func (in *ArgoCDKeycloakProtocolMapper) DeepCopy() *ArgoCDKeycloakProtocolMapper {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDKeycloakProtocolMapper)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDKeycloakRealmRole) DeepCopyInto(out *ArgoCDKeycloakRealmRole) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDKeycloakRealmRole.
+func (in *ArgoCDKeycloakRealmRole) DeepCopy() *ArgoCDKeycloakRealmRole {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDKeycloakRealmRole)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDKeycloakRealmSpec) DeepCopyInto(out *ArgoCDKeycloakRealmSpec) {
+	*out = *in
+	if in.IdentityProviders != nil {
+		in, out := &in.IdentityProviders, &out.IdentityProviders
+		*out = make([]ArgoCDKeycloakIdentityProvider, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ClientScopes != nil {
+		in, out := &in.ClientScopes, &out.ClientScopes
+		*out = make([]ArgoCDKeycloakClientScope, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.GroupMappers != nil {
+		in, out := &in.GroupMappers, &out.GroupMappers
+		*out = make([]ArgoCDKeycloakGroupMapper, len(*in))
+		copy(*out, *in)
+	}
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]ArgoCDKeycloakRealmRole, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDKeycloakRealmSpec.
+func (in *ArgoCDKeycloakRealmSpec) DeepCopy() *ArgoCDKeycloakRealmSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDKeycloakRealmSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDKeycloakSpec) DeepCopyInto(out *ArgoCDKeycloakSpec) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VerifyTLS != nil {
+		in, out := &in.VerifyTLS, &out.VerifyTLS
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Realm != nil {
+		in, out := &in.Realm, &out.Realm
+		*out = new(ArgoCDKeycloakRealmSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDKeycloakSpec.
+func (in *ArgoCDKeycloakSpec) DeepCopy() *ArgoCDKeycloakSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDKeycloakSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDList) DeepCopyInto(out *ArgoCDList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ArgoCD, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDList.
+func (in *ArgoCDList) DeepCopy() *ArgoCDList {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ArgoCDList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDMonitoringAlertSpec) DeepCopyInto(out *ArgoCDMonitoringAlertSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDMonitoringAlertSpec.
+func (in *ArgoCDMonitoringAlertSpec) DeepCopy() *ArgoCDMonitoringAlertSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDMonitoringAlertSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDMonitoringDashboardsSpec) DeepCopyInto(out *ArgoCDMonitoringDashboardsSpec) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDMonitoringDashboardsSpec.
+func (in *ArgoCDMonitoringDashboardsSpec) DeepCopy() *ArgoCDMonitoringDashboardsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDMonitoringDashboardsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDMonitoringRelabelConfig) DeepCopyInto(out *ArgoCDMonitoringRelabelConfig) {
+	*out = *in
+	if in.SourceLabels != nil {
+		in, out := &in.SourceLabels, &out.SourceLabels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDMonitoringRelabelConfig.
+func (in *ArgoCDMonitoringRelabelConfig) DeepCopy() *ArgoCDMonitoringRelabelConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDMonitoringRelabelConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDMonitoringServiceMonitorSpec) DeepCopyInto(out *ArgoCDMonitoringServiceMonitorSpec) {
+	*out = *in
+	if in.Relabelings != nil {
+		in, out := &in.Relabelings, &out.Relabelings
+		*out = make([]ArgoCDMonitoringRelabelConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(ArgoCDMonitoringTLSSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDMonitoringServiceMonitorSpec.
+func (in *ArgoCDMonitoringServiceMonitorSpec) DeepCopy() *ArgoCDMonitoringServiceMonitorSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDMonitoringServiceMonitorSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDMonitoringServiceMonitorsSpec) DeepCopyInto(out *ArgoCDMonitoringServiceMonitorsSpec) {
+	*out = *in
+	in.ApplicationController.DeepCopyInto(&out.ApplicationController)
+	in.ApplicationSetController.DeepCopyInto(&out.ApplicationSetController)
+	in.Dex.DeepCopyInto(&out.Dex)
+	in.Notifications.DeepCopyInto(&out.Notifications)
+	in.Redis.DeepCopyInto(&out.Redis)
+	in.RepoServer.DeepCopyInto(&out.RepoServer)
+	in.Server.DeepCopyInto(&out.Server)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDMonitoringServiceMonitorsSpec.
+func (in *ArgoCDMonitoringServiceMonitorsSpec) DeepCopy() *ArgoCDMonitoringServiceMonitorsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDMonitoringServiceMonitorsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDMonitoringSpec) DeepCopyInto(out *ArgoCDMonitoringSpec) {
+	*out = *in
+	if in.DisableMetrics != nil {
+		in, out := &in.DisableMetrics, &out.DisableMetrics
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Alerts != nil {
+		in, out := &in.Alerts, &out.Alerts
+		*out = make([]ArgoCDMonitoringAlertSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.Dashboards.DeepCopyInto(&out.Dashboards)
+	in.ServiceMonitors.DeepCopyInto(&out.ServiceMonitors)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDMonitoringSpec.
+func (in *ArgoCDMonitoringSpec) DeepCopy() *ArgoCDMonitoringSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDMonitoringSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDMonitoringTLSSpec) DeepCopyInto(out *ArgoCDMonitoringTLSSpec) {
+	*out = *in
+	if in.CARef != nil {
+		in, out := &in.CARef, &out.CARef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDMonitoringTLSSpec.
+func (in *ArgoCDMonitoringTLSSpec) DeepCopy() *ArgoCDMonitoringTLSSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDMonitoringTLSSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDNodePlacementSpec) DeepCopyInto(out *ArgoCDNodePlacementSpec) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDNodePlacementSpec.
+func (in *ArgoCDNodePlacementSpec) DeepCopy() *ArgoCDNodePlacementSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDNodePlacementSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDNotifications) DeepCopyInto(out *ArgoCDNotifications) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]v1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SourceNamespaces != nil {
+		in, out := &in.SourceNamespaces, &out.SourceNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDNotifications.
+func (in *ArgoCDNotifications) DeepCopy() *ArgoCDNotifications {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDNotifications)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDOIDCSpec) DeepCopyInto(out *ArgoCDOIDCSpec) {
+	*out = *in
+	if in.ClientSecretRef != nil {
+		in, out := &in.ClientSecretRef, &out.ClientSecretRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RequestedScopes != nil {
+		in, out := &in.RequestedScopes, &out.RequestedScopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDOIDCSpec.
+func (in *ArgoCDOIDCSpec) DeepCopy() *ArgoCDOIDCSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDOIDCSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDObservabilitySpec) DeepCopyInto(out *ArgoCDObservabilitySpec) {
+	*out = *in
+	in.Tracing.DeepCopyInto(&out.Tracing)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDObservabilitySpec.
+func (in *ArgoCDObservabilitySpec) DeepCopy() *ArgoCDObservabilitySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDObservabilitySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDPrometheusSpec) DeepCopyInto(out *ArgoCDPrometheusSpec) {
+	*out = *in
+	in.Gateway.DeepCopyInto(&out.Gateway)
+	in.Ingress.DeepCopyInto(&out.Ingress)
+	in.Route.DeepCopyInto(&out.Route)
+	if in.Size != nil {
+		in, out := &in.Size, &out.Size
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDPrometheusSpec.
+func (in *ArgoCDPrometheusSpec) DeepCopy() *ArgoCDPrometheusSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDPrometheusSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDRBACFragmentsSpec) DeepCopyInto(out *ArgoCDRBACFragmentsSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDRBACFragmentsSpec.
+func (in *ArgoCDRBACFragmentsSpec) DeepCopy() *ArgoCDRBACFragmentsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDRBACFragmentsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDRBACPolicyOverlay) DeepCopyInto(out *ArgoCDRBACPolicyOverlay) {
+	*out = *in
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]ArgoCDRBACRole, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDRBACPolicyOverlay.
+func (in *ArgoCDRBACPolicyOverlay) DeepCopy() *ArgoCDRBACPolicyOverlay {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDRBACPolicyOverlay)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDRBACPolicyRule) DeepCopyInto(out *ArgoCDRBACPolicyRule) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDRBACPolicyRule.
+func (in *ArgoCDRBACPolicyRule) DeepCopy() *ArgoCDRBACPolicyRule {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDRBACPolicyRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDRBACRole) DeepCopyInto(out *ArgoCDRBACRole) {
+	*out = *in
+	if in.Policies != nil {
+		in, out := &in.Policies, &out.Policies
+		*out = make([]ArgoCDRBACPolicyRule, len(*in))
+		copy(*out, *in)
+	}
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDRBACRole.
+func (in *ArgoCDRBACRole) DeepCopy() *ArgoCDRBACRole {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDRBACRole)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDRBACSpec) DeepCopyInto(out *ArgoCDRBACSpec) {
+	*out = *in
+	if in.DefaultPolicy != nil {
+		in, out := &in.DefaultPolicy, &out.DefaultPolicy
+		*out = new(string)
+		**out = **in
+	}
+	if in.Policy != nil {
+		in, out := &in.Policy, &out.Policy
+		*out = new(string)
+		**out = **in
+	}
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
+		*out = new(string)
+		**out = **in
+	}
+	if in.PolicyMatcherMode != nil {
+		in, out := &in.PolicyMatcherMode, &out.PolicyMatcherMode
+		*out = new(string)
+		**out = **in
+	}
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]ArgoCDRBACRole, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Overlays != nil {
+		in, out := &in.Overlays, &out.Overlays
+		*out = make([]ArgoCDRBACPolicyOverlay, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Fragments != nil {
+		in, out := &in.Fragments, &out.Fragments
+		*out = new(ArgoCDRBACFragmentsSpec)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDList.
-func (in *ArgoCDList) DeepCopy() *ArgoCDList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDRBACSpec.
+func (in *ArgoCDRBACSpec) DeepCopy() *ArgoCDRBACSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDList)
+	out := new(ArgoCDRBACSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ArgoCDList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArgoCDMonitoringSpec) DeepCopyInto(out *ArgoCDMonitoringSpec) {
+func (in *ArgoCDRedisExporterSpec) DeepCopyInto(out *ArgoCDRedisExporterSpec) {
 	*out = *in
-	if in.DisableMetrics != nil {
-		in, out := &in.DisableMetrics, &out.DisableMetrics
-		*out = new(bool)
-		**out = **in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDMonitoringSpec.
-func (in *ArgoCDMonitoringSpec) DeepCopy() *ArgoCDMonitoringSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDRedisExporterSpec.
+func (in *ArgoCDRedisExporterSpec) DeepCopy() *ArgoCDRedisExporterSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDMonitoringSpec)
+	out := new(ArgoCDRedisExporterSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArgoCDNodePlacementSpec) DeepCopyInto(out *ArgoCDNodePlacementSpec) {
+func (in *ArgoCDRedisExternalSpec) DeepCopyInto(out *ArgoCDRedisExternalSpec) {
 	*out = *in
-	if in.NodeSelector != nil {
-		in, out := &in.NodeSelector, &out.NodeSelector
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	if in.DB != nil {
+		in, out := &in.DB, &out.DB
+		*out = new(int32)
+		**out = **in
 	}
-	if in.Tolerations != nil {
-		in, out := &in.Tolerations, &out.Tolerations
-		*out = make([]v1.Toleration, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.UsernameRef != nil {
+		in, out := &in.UsernameRef, &out.UsernameRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PasswordRef != nil {
+		in, out := &in.PasswordRef, &out.PasswordRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(ArgoCDRedisExternalTLSSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Sentinel != nil {
+		in, out := &in.Sentinel, &out.Sentinel
+		*out = new(ArgoCDRedisSentinelSpec)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDNodePlacementSpec.
-func (in *ArgoCDNodePlacementSpec) DeepCopy() *ArgoCDNodePlacementSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDRedisExternalSpec.
+func (in *ArgoCDRedisExternalSpec) DeepCopy() *ArgoCDRedisExternalSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDNodePlacementSpec)
+	out := new(ArgoCDRedisExternalSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArgoCDNotifications) DeepCopyInto(out *ArgoCDNotifications) {
+func (in *ArgoCDRedisExternalTLSSpec) DeepCopyInto(out *ArgoCDRedisExternalTLSSpec) {
 	*out = *in
-	if in.Replicas != nil {
-		in, out := &in.Replicas, &out.Replicas
-		*out = new(int32)
-		**out = **in
-	}
-	if in.Env != nil {
-		in, out := &in.Env, &out.Env
-		*out = make([]v1.EnvVar, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = new(v1.ResourceRequirements)
+	if in.CABundleRef != nil {
+		in, out := &in.CABundleRef, &out.CABundleRef
+		*out = new(v1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDNotifications.
-func (in *ArgoCDNotifications) DeepCopy() *ArgoCDNotifications {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDRedisExternalTLSSpec.
+func (in *ArgoCDRedisExternalTLSSpec) DeepCopy() *ArgoCDRedisExternalTLSSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDNotifications)
+	out := new(ArgoCDRedisExternalTLSSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArgoCDPrometheusSpec) DeepCopyInto(out *ArgoCDPrometheusSpec) {
+func (in *ArgoCDRedisPersistenceSpec) DeepCopyInto(out *ArgoCDRedisPersistenceSpec) {
 	*out = *in
-	in.Ingress.DeepCopyInto(&out.Ingress)
-	in.Route.DeepCopyInto(&out.Route)
 	if in.Size != nil {
 		in, out := &in.Size, &out.Size
-		*out = new(int32)
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.StorageClassName != nil {
+		in, out := &in.StorageClassName, &out.StorageClassName
+		*out = new(string)
 		**out = **in
 	}
+	if in.Save != nil {
+		in, out := &in.Save, &out.Save
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDPrometheusSpec.
-func (in *ArgoCDPrometheusSpec) DeepCopy() *ArgoCDPrometheusSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDRedisPersistenceSpec.
+func (in *ArgoCDRedisPersistenceSpec) DeepCopy() *ArgoCDRedisPersistenceSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDPrometheusSpec)
+	out := new(ArgoCDRedisPersistenceSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArgoCDRBACSpec) DeepCopyInto(out *ArgoCDRBACSpec) {
+func (in *ArgoCDRedisSentinelSpec) DeepCopyInto(out *ArgoCDRedisSentinelSpec) {
 	*out = *in
-	if in.DefaultPolicy != nil {
-		in, out := &in.DefaultPolicy, &out.DefaultPolicy
-		*out = new(string)
-		**out = **in
-	}
-	if in.Policy != nil {
-		in, out := &in.Policy, &out.Policy
-		*out = new(string)
-		**out = **in
-	}
-	if in.Scopes != nil {
-		in, out := &in.Scopes, &out.Scopes
-		*out = new(string)
-		**out = **in
-	}
-	if in.PolicyMatcherMode != nil {
-		in, out := &in.PolicyMatcherMode, &out.PolicyMatcherMode
-		*out = new(string)
-		**out = **in
+	if in.Addresses != nil {
+		in, out := &in.Addresses, &out.Addresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDRBACSpec.
-func (in *ArgoCDRBACSpec) DeepCopy() *ArgoCDRBACSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDRedisSentinelSpec.
+func (in *ArgoCDRedisSentinelSpec) DeepCopy() *ArgoCDRedisSentinelSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDRBACSpec)
+	out := new(ArgoCDRedisSentinelSpec)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -587,6 +1601,21 @@ func (in *ArgoCDRedisSpec) DeepCopyInto(out *ArgoCDRedisSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.External != nil {
+		in, out := &in.External, &out.External
+		*out = new(ArgoCDRedisExternalSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Persistence != nil {
+		in, out := &in.Persistence, &out.Persistence
+		*out = new(ArgoCDRedisPersistenceSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Exporter != nil {
+		in, out := &in.Exporter, &out.Exporter
+		*out = new(ArgoCDRedisExporterSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDRedisSpec.
@@ -599,6 +1628,63 @@ func (in *ArgoCDRedisSpec) DeepCopy() *ArgoCDRedisSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDRepoPluginSpec) DeepCopyInto(out *ArgoCDRepoPluginSpec) {
+	*out = *in
+	if in.ConfigMapRef != nil {
+		in, out := &in.ConfigMapRef, &out.ConfigMapRef
+		*out = new(v1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]v1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDRepoPluginSpec.
+func (in *ArgoCDRepoPluginSpec) DeepCopy() *ArgoCDRepoPluginSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDRepoPluginSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDRepoRemoteTLSSpec) DeepCopyInto(out *ArgoCDRepoRemoteTLSSpec) {
+	*out = *in
+	if in.ServerDNSNames != nil {
+		in, out := &in.ServerDNSNames, &out.ServerDNSNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CABundleRef != nil {
+		in, out := &in.CABundleRef, &out.CABundleRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDRepoRemoteTLSSpec.
+func (in *ArgoCDRepoRemoteTLSSpec) DeepCopy() *ArgoCDRepoRemoteTLSSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDRepoRemoteTLSSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArgoCDRepoSpec) DeepCopyInto(out *ArgoCDRepoSpec) {
 	*out = *in
@@ -657,6 +1743,13 @@ func (in *ArgoCDRepoSpec) DeepCopyInto(out *ArgoCDRepoSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Plugins != nil {
+		in, out := &in.Plugins, &out.Plugins
+		*out = make([]ArgoCDRepoPluginSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Enabled != nil {
 		in, out := &in.Enabled, &out.Enabled
 		*out = new(bool)
@@ -667,6 +1760,11 @@ func (in *ArgoCDRepoSpec) DeepCopyInto(out *ArgoCDRepoSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.RemoteTLS != nil {
+		in, out := &in.RemoteTLS, &out.RemoteTLS
+		*out = new(ArgoCDRepoRemoteTLSSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDRepoSpec.
@@ -731,6 +1829,11 @@ func (in *ArgoCDSSOSpec) DeepCopyInto(out *ArgoCDSSOSpec) {
 		*out = new(ArgoCDKeycloakSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.OIDC != nil {
+		in, out := &in.OIDC, &out.OIDC
+		*out = new(ArgoCDOIDCSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDSSOSpec.
@@ -766,6 +1869,7 @@ func (in *ArgoCDServerAutoscaleSpec) DeepCopy() *ArgoCDServerAutoscaleSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArgoCDServerGRPCSpec) DeepCopyInto(out *ArgoCDServerGRPCSpec) {
 	*out = *in
+	in.Gateway.DeepCopyInto(&out.Gateway)
 	in.Ingress.DeepCopyInto(&out.Ingress)
 }
 
@@ -798,6 +1902,7 @@ func (in *ArgoCDServerServiceSpec) DeepCopy() *ArgoCDServerServiceSpec {
 func (in *ArgoCDServerSpec) DeepCopyInto(out *ArgoCDServerSpec) {
 	*out = *in
 	in.Autoscale.DeepCopyInto(&out.Autoscale)
+	in.Gateway.DeepCopyInto(&out.Gateway)
 	in.GRPC.DeepCopyInto(&out.GRPC)
 	if in.InitContainers != nil {
 		in, out := &in.InitContainers, &out.InitContainers
@@ -886,13 +1991,25 @@ func (in *ArgoCDSpec) DeepCopyInto(out *ArgoCDSpec) {
 		}
 	}
 	in.Grafana.DeepCopyInto(&out.Grafana)
+	if in.GPGKeys != nil {
+		in, out := &in.GPGKeys, &out.GPGKeys
+		*out = make([]ArgoCDGPGKeySpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	in.HA.DeepCopyInto(&out.HA)
+	if in.HelmVersion != nil {
+		in, out := &in.HelmVersion, &out.HelmVersion
+		*out = new(HelmVersionSpec)
+		**out = **in
+	}
 	if in.Import != nil {
 		in, out := &in.Import, &out.Import
 		*out = new(ArgoCDImportSpec)
 		(*in).DeepCopyInto(*out)
 	}
-	out.InitialSSHKnownHosts = in.InitialSSHKnownHosts
+	in.InitialSSHKnownHosts.DeepCopyInto(&out.InitialSSHKnownHosts)
 	if in.KustomizeVersions != nil {
 		in, out := &in.KustomizeVersions, &out.KustomizeVersions
 		*out = make([]KustomizeVersionSpec, len(*in))
@@ -905,6 +2022,7 @@ func (in *ArgoCDSpec) DeepCopyInto(out *ArgoCDSpec) {
 		(*in).DeepCopyInto(*out)
 	}
 	in.Notifications.DeepCopyInto(&out.Notifications)
+	in.Observability.DeepCopyInto(&out.Observability)
 	in.Prometheus.DeepCopyInto(&out.Prometheus)
 	in.RBAC.DeepCopyInto(&out.RBAC)
 	in.Redis.DeepCopyInto(&out.Redis)
@@ -936,6 +2054,11 @@ func (in *ArgoCDSpec) DeepCopyInto(out *ArgoCDSpec) {
 		(*in).DeepCopyInto(*out)
 	}
 	in.TLS.DeepCopyInto(&out.TLS)
+	if in.TrustedCABundle != nil {
+		in, out := &in.TrustedCABundle, &out.TrustedCABundle
+		*out = new(ArgoCDTrustedCABundleSpec)
+		**out = **in
+	}
 	if in.Banner != nil {
 		in, out := &in.Banner, &out.Banner
 		*out = new(Banner)
@@ -956,6 +2079,16 @@ func (in *ArgoCDSpec) DeepCopy() *ArgoCDSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArgoCDStatus) DeepCopyInto(out *ArgoCDStatus) {
 	*out = *in
+	if in.RBACPolicyErrors != nil {
+		in, out := &in.RBACPolicyErrors, &out.RBACPolicyErrors
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.URLs != nil {
+		in, out := &in.URLs, &out.URLs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDStatus.
@@ -979,6 +2112,16 @@ func (in *ArgoCDTLSSpec) DeepCopyInto(out *ArgoCDTLSSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.CertsSourceNamespaces != nil {
+		in, out := &in.CertsSourceNamespaces, &out.CertsSourceNamespaces
+		*out = new(ArgoCDTrustSourceNamespacesSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TrustedCABundleHosts != nil {
+		in, out := &in.TrustedCABundleHosts, &out.TrustedCABundleHosts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDTLSSpec.
@@ -991,6 +2134,88 @@ func (in *ArgoCDTLSSpec) DeepCopy() *ArgoCDTLSSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDTracingSamplingSpec) DeepCopyInto(out *ArgoCDTracingSamplingSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDTracingSamplingSpec.
+func (in *ArgoCDTracingSamplingSpec) DeepCopy() *ArgoCDTracingSamplingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDTracingSamplingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDTracingSpec) DeepCopyInto(out *ArgoCDTracingSpec) {
+	*out = *in
+	if in.Attributes != nil {
+		in, out := &in.Attributes, &out.Attributes
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Sampling != nil {
+		in, out := &in.Sampling, &out.Sampling
+		*out = new(ArgoCDTracingSamplingSpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDTracingSpec.
+func (in *ArgoCDTracingSpec) DeepCopy() *ArgoCDTracingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDTracingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDTrustSourceNamespacesSpec) DeepCopyInto(out *ArgoCDTrustSourceNamespacesSpec) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedHosts != nil {
+		in, out := &in.AllowedHosts, &out.AllowedHosts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDTrustSourceNamespacesSpec.
+func (in *ArgoCDTrustSourceNamespacesSpec) DeepCopy() *ArgoCDTrustSourceNamespacesSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDTrustSourceNamespacesSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDTrustedCABundleSpec) DeepCopyInto(out *ArgoCDTrustedCABundleSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDTrustedCABundleSpec.
+func (in *ArgoCDTrustedCABundleSpec) DeepCopy() *ArgoCDTrustedCABundleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDTrustedCABundleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Banner) DeepCopyInto(out *Banner) {
 	*out = *in
@@ -1006,6 +2231,21 @@ func (in *Banner) DeepCopy() *Banner {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HelmVersionSpec) DeepCopyInto(out *HelmVersionSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HelmVersionSpec.
+func (in *HelmVersionSpec) DeepCopy() *HelmVersionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HelmVersionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IgnoreDifferenceCustomization) DeepCopyInto(out *IgnoreDifferenceCustomization) {
 	*out = *in
@@ -1127,6 +2367,11 @@ func (in *ResourceIgnoreDifference) DeepCopy() *ResourceIgnoreDifference {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SSHHostsSpec) DeepCopyInto(out *SSHHostsSpec) {
 	*out = *in
+	if in.SourceNamespaces != nil {
+		in, out := &in.SourceNamespaces, &out.SourceNamespaces
+		*out = new(ArgoCDTrustSourceNamespacesSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSHHostsSpec.
@@ -1142,6 +2387,7 @@ func (in *SSHHostsSpec) DeepCopy() *SSHHostsSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WebhookServerSpec) DeepCopyInto(out *WebhookServerSpec) {
 	*out = *in
+	in.Gateway.DeepCopyInto(&out.Gateway)
 	in.Ingress.DeepCopyInto(&out.Ingress)
 	in.Route.DeepCopyInto(&out.Route)
 }
@@ -15,37 +15,12 @@ listen health_check_http_url
     mode http
     monitor-uri /healthz
     option      dontlognull
+{{- range $i := .Servers}}
 # Check Sentinel and whether they are nominated master
-backend check_if_redis_is_master_0
+backend check_if_redis_is_master_{{$i}}
     mode tcp
     option tcp-check
-{{- if eq .UseTLS "false"}}
-    tcp-check connect
-{{- else}}
-    tcp-check connect ssl
-{{- end}}
-    tcp-check send "AUTH replace-with-redis-auth"\r\n
-    tcp-check expect string +OK
-    tcp-check send PING\r\n
-    tcp-check expect string +PONG
-    tcp-check send SENTINEL\ get-master-addr-by-name\ argocd\r\n
-    tcp-check expect string REPLACE_ANNOUNCE0
-    tcp-check send QUIT\r\n
-    tcp-check expect string +OK
-{{- if eq .UseTLS "false"}}
-    server R0 {{.ServiceName}}-announce-0:26379 check inter 3s
-    server R1 {{.ServiceName}}-announce-1:26379 check inter 3s
-    server R2 {{.ServiceName}}-announce-2:26379 check inter 3s
-{{- else}}
-    server R0 {{.ServiceName}}-announce-0:26379 verify required ca-file tls.crt check inter 3s
-    server R1 {{.ServiceName}}-announce-1:26379 verify required ca-file tls.crt check inter 3s
-    server R2 {{.ServiceName}}-announce-2:26379 verify required ca-file tls.crt check inter 3s
-{{- end}}
-# Check Sentinel and whether they are nominated master
-backend check_if_redis_is_master_1
-    mode tcp
-    option tcp-check
-{{- if eq .UseTLS "false"}}
+{{- if eq $.UseTLS "false"}}
     tcp-check connect
 {{- else}}
     tcp-check connect ssl
@@ -55,43 +30,16 @@ backend check_if_redis_is_master_1
     tcp-check send PING\r\n
     tcp-check expect string +PONG
     tcp-check send SENTINEL\ get-master-addr-by-name\ argocd\r\n
-    tcp-check expect string REPLACE_ANNOUNCE1
+    tcp-check expect string REPLACE_ANNOUNCE{{$i}}
     tcp-check send QUIT\r\n
     tcp-check expect string +OK
-{{- if eq .UseTLS "false"}}
-    server R0 {{.ServiceName}}-announce-0:26379 check inter 3s
-    server R1 {{.ServiceName}}-announce-1:26379 check inter 3s
-    server R2 {{.ServiceName}}-announce-2:26379 check inter 3s
+{{- range $j := $.Servers}}
+{{- if eq $.UseTLS "false"}}
+    server R{{$j}} {{$.ServiceName}}-announce-{{$j}}:26379 check inter 3s
 {{- else}}
-    server R0 {{.ServiceName}}-announce-0:26379 verify required ca-file tls.crt check inter 3s
-    server R1 {{.ServiceName}}-announce-1:26379 verify required ca-file tls.crt check inter 3s
-    server R2 {{.ServiceName}}-announce-2:26379 verify required ca-file tls.crt check inter 3s
+    server R{{$j}} {{$.ServiceName}}-announce-{{$j}}:26379 verify required ca-file tls.crt check inter 3s
 {{- end}}
-# Check Sentinel and whether they are nominated master
-backend check_if_redis_is_master_2
-    mode tcp
-    option tcp-check
-{{- if eq .UseTLS "false"}}
-    tcp-check connect
-{{- else}}
-    tcp-check connect ssl
 {{- end}}
-    tcp-check send "AUTH replace-with-redis-auth"\r\n
-    tcp-check expect string +OK
-    tcp-check send PING\r\n
-    tcp-check expect string +PONG
-    tcp-check send SENTINEL\ get-master-addr-by-name\ argocd\r\n
-    tcp-check expect string REPLACE_ANNOUNCE2
-    tcp-check send QUIT\r\n
-    tcp-check expect string +OK
-{{- if eq .UseTLS "false"}}
-    server R0 {{.ServiceName}}-announce-0:26379 check inter 3s
-    server R1 {{.ServiceName}}-announce-1:26379 check inter 3s
-    server R2 {{.ServiceName}}-announce-2:26379 check inter 3s
-{{- else}}
-    server R0 {{.ServiceName}}-announce-0:26379 verify required ca-file tls.crt check inter 3s
-    server R1 {{.ServiceName}}-announce-1:26379 verify required ca-file tls.crt check inter 3s
-    server R2 {{.ServiceName}}-announce-2:26379 verify required ca-file tls.crt check inter 3s
 {{- end}}
 
 # decide redis backend to use
@@ -116,18 +64,11 @@ backend bk_redis_master
     tcp-check expect string role:master
     tcp-check send QUIT\r\n
     tcp-check expect string +OK
-{{- if eq .UseTLS "false"}}
-    use-server R0 if { srv_is_up(R0) } { nbsrv(check_if_redis_is_master_0) ge 2 }
-    server R0 {{.ServiceName}}-announce-0:6379 check inter 3s fall 1 rise 1
-    use-server R1 if { srv_is_up(R1) } { nbsrv(check_if_redis_is_master_1) ge 2 }
-    server R1 {{.ServiceName}}-announce-1:6379 check inter 3s fall 1 rise 1
-    use-server R2 if { srv_is_up(R2) } { nbsrv(check_if_redis_is_master_2) ge 2 }
-    server R2 {{.ServiceName}}-announce-2:6379 check inter 3s fall 1 rise 1
+{{- range $i := .Servers}}
+    use-server R{{$i}} if { srv_is_up(R{{$i}}) } { nbsrv(check_if_redis_is_master_{{$i}}) ge {{$.Quorum}} }
+{{- if eq $.UseTLS "false"}}
+    server R{{$i}} {{$.ServiceName}}-announce-{{$i}}:6379 check inter 3s fall 1 rise 1
 {{- else}}
-    use-server R0 if { srv_is_up(R0) } { nbsrv(check_if_redis_is_master_0) ge 2 }
-    server R0 {{.ServiceName}}-announce-0:6379 verify required ca-file tls.crt check inter 3s fall 1 rise 1
-    use-server R1 if { srv_is_up(R1) } { nbsrv(check_if_redis_is_master_1) ge 2 }
-    server R1 {{.ServiceName}}-announce-1:6379 verify required ca-file tls.crt check inter 3s fall 1 rise 1
-    use-server R2 if { srv_is_up(R2) } { nbsrv(check_if_redis_is_master_2) ge 2 }
-    server R2 {{.ServiceName}}-announce-2:6379 verify required ca-file tls.crt check inter 3s fall 1 rise 1
+    server R{{$i}} {{$.ServiceName}}-announce-{{$i}}:6379 verify required ca-file tls.crt check inter 3s fall 1 rise 1
+{{- end}}
 {{- end}}
@@ -4,7 +4,7 @@ INDEX="${HOSTNAME##*-}"
 SENTINEL_PORT={{- if eq .UseTLS "false" -}}26379{{- else -}}0{{- end }}
 MASTER=''
 MASTER_GROUP="argocd"
-QUORUM="2"
+QUORUM="{{.Quorum}}"
 REDIS_CONF=/data/conf/redis.conf
 {{- if eq .UseTLS "false"}}
 REDIS_PORT=6379
@@ -61,7 +61,10 @@ identify_master() {
 sentinel_update() {
     echo "Updating sentinel config.."
     echo "  evaluating sentinel id (\${SENTINEL_ID_${INDEX}})"
-    eval MY_SENTINEL_ID="\$SENTINEL_ID_${INDEX}"
+    eval MY_SENTINEL_ID="\${SENTINEL_ID_${INDEX}:-}"
+    if [ -z "${MY_SENTINEL_ID}" ]; then
+        MY_SENTINEL_ID="$(printf '%s' "${SERVICE}-${INDEX}" | sha1sum | awk '{ print $1 }')"
+    fi
     echo "  sentinel id (${MY_SENTINEL_ID}), sentinel grp (${MASTER_GROUP}), quorum (${QUORUM})"
     sed -i "1s/^/sentinel myid ${MY_SENTINEL_ID}\\n/" "${SENTINEL_CONF}"
     if [ "$SENTINEL_TLS_REPLICATION_ENABLED" = true ]; then
@@ -11,14 +11,23 @@ tls-replication yes
 tls-auth-clients no
 {{- end}}
 bind 0.0.0.0
-maxmemory 0
-maxmemory-policy volatile-lru
+maxmemory {{.MaxMemory}}
+maxmemory-policy {{.MaxMemoryPolicy}}
 min-replicas-max-lag 5
 min-replicas-to-write 1
 rdbchecksum yes
 rdbcompression yes
 repl-diskless-sync yes
+{{- if .Save}}
+{{- range .Save}}
+save {{.}}
+{{- end}}
+{{- else}}
 save ""
+{{- end}}
+{{- if .AppendOnly}}
+appendonly yes
+{{- end}}
 protected-mode no
 requirepass replace-default-auth
 masterauth replace-default-auth
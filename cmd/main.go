@@ -17,6 +17,7 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"crypto/tls"
 	"flag"
 	"fmt"
@@ -34,6 +35,8 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 
 	"github.com/argoproj-labs/argocd-operator/common"
 	"github.com/argoproj-labs/argocd-operator/controllers/argocd"
@@ -86,6 +89,10 @@ func main() {
 	var enableLeaderElection bool
 	var probeAddr string
 	var labelSelectorFlag string
+	var otlpAddress string
+	var otlpInsecure bool
+	var otlpSamplingRatio float64
+	var auditLogDestination string
 
 	var secureMetrics = false
 	var enableHTTP2 = false
@@ -93,6 +100,10 @@ func main() {
 	flag.StringVar(&metricsAddr, "metrics-bind-address", fmt.Sprintf(":%d", common.OperatorMetricsPort), "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	flag.StringVar(&labelSelectorFlag, "label-selector", env.StringFromEnv(common.ArgoCDLabelSelectorKey, common.ArgoCDDefaultLabelSelector), "The label selector is used to map to a subset of ArgoCD instances to reconcile")
+	flag.StringVar(&otlpAddress, "otlp-address", env.StringFromEnv(common.ArgoCDOperatorOTLPAddressKey, ""), "OpenTelemetry collector address to send the operator traces to")
+	flag.BoolVar(&otlpInsecure, "otlp-insecure", env.ParseBoolFromEnv(common.ArgoCDOperatorOTLPInsecureKey, false), "OpenTelemetry collector insecure mode")
+	flag.Float64Var(&otlpSamplingRatio, "otlp-sampling-ratio", env.ParseFloat64FromEnv(common.ArgoCDOperatorOTLPSamplingRatioKey, 1, 0, 1), "Ratio of the operator traces sent to the OpenTelemetry collector, between 0 and 1")
+	flag.StringVar(&auditLogDestination, "audit-log", env.StringFromEnv(common.ArgoCDOperatorAuditLogKey, ""), "Destination of the audit log of the changes made by the operator, either stdout or a file path. The audit log is disabled when empty")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
@@ -215,6 +226,21 @@ func main() {
 		}
 	}
 
+	// Setup Scheme for Gateway API routes if available.
+	if argocd.IsGatewayAPIAvailable() {
+		if err := gatewayv1.Install(mgr.GetScheme()); err != nil {
+			setupLog.Error(err, "")
+			os.Exit(1)
+		}
+	}
+
+	if argocd.IsGatewayExperimentalAPIAvailable() {
+		if err := gatewayv1alpha2.Install(mgr.GetScheme()); err != nil {
+			setupLog.Error(err, "")
+			os.Exit(1)
+		}
+	}
+
 	// Set up the scheme for openshift config if available
 	if argocd.IsVersionAPIAvailable() {
 		if err := configv1.Install(mgr.GetScheme()); err != nil {
@@ -242,10 +268,21 @@ func main() {
 		setupLog.Info("Keycloak instance cannot be managed using OpenShift Template, as DeploymentConfig/Template API is not present")
 	}
 
+	auditLog, err := argocd.OpenAuditLog(auditLogDestination)
+	if err != nil {
+		setupLog.Error(err, "unable to open audit log")
+		os.Exit(1)
+	}
+	if auditLog != nil {
+		defer auditLog.Close()
+		setupLog.Info(fmt.Sprintf("Writing audit log to %s", auditLogDestination))
+	}
+
 	if err = (&argocd.ReconcileArgoCD{
 		Client:        mgr.GetClient(),
 		Scheme:        mgr.GetScheme(),
 		LabelSelector: labelSelectorFlag,
+		AuditLog:      auditLog,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ArgoCD")
 		os.Exit(1)
@@ -283,8 +320,24 @@ func main() {
 		os.Exit(1)
 	}
 
+	ctx := ctrl.SetupSignalHandler()
+
+	if otlpAddress != "" {
+		shutdownTracer, err := argocd.InitTracer(ctx, otlpAddress, otlpInsecure, otlpSamplingRatio)
+		if err != nil {
+			setupLog.Error(err, "unable to set up tracing")
+			os.Exit(1)
+		}
+		defer func() {
+			if err := shutdownTracer(context.Background()); err != nil {
+				setupLog.Error(err, "unable to stop tracing")
+			}
+		}()
+		setupLog.Info(fmt.Sprintf("Sending traces to OpenTelemetry collector %s", otlpAddress))
+	}
+
 	setupLog.Info("starting manager")
-	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
+	if err := mgr.Start(ctx); err != nil {
 		setupLog.Error(err, "problem running manager")
 		os.Exit(1)
 	}
@@ -12,4 +12,8 @@ const (
 	// AnnotationOpenShiftServiceCA is the annotation on services used to
 	// request a TLS certificate from OpenShift's Service CA for AutoTLS
 	AnnotationOpenShiftServiceCA = "service.beta.openshift.io/serving-cert-secret-name"
+
+	// AnnotationRBACPolicyOverlays is the annotation on the RBAC ConfigMap that lists the
+	// policy overlay keys managed by the operator
+	AnnotationRBACPolicyOverlays = "argocds.argoproj.io/rbac-policy-overlays"
 )
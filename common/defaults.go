@@ -139,6 +139,12 @@ const (
 	// ArgoCDDefaultGAAnonymizeUsers is the default value for anonymizing google analytics users.
 	ArgoCDDefaultGAAnonymizeUsers = false
 
+	// ArgoCDDefaultGrafanaDashboardLabel is the default label used by the Grafana sidecar to discover dashboards.
+	ArgoCDDefaultGrafanaDashboardLabel = "grafana_dashboard"
+
+	// ArgoCDDefaultGrafanaDashboardsPath is the default Grafana dashboards directory when not specified.
+	ArgoCDDefaultGrafanaDashboardsPath = "/var/lib/grafana/dashboards"
+
 	// ArgoCDDefaultHelpChatURL is the default help chat URL.
 	ArgoCDDefaultHelpChatURL = ""
 
@@ -195,6 +201,15 @@ const (
 	// ArgoCDDefaultRedisHAProxyVersion is the default Redis HAProxy image tag to use when not specified.
 	ArgoCDDefaultRedisHAProxyVersion = "sha256:7392fbbbb53e9e063ca94891da6656e6062f9d021c0e514888a91535b9f73231" // 2.0.25-alpine
 
+	// ArgoCDDefaultRedisExporterImage is the Redis exporter container image to use when not specified.
+	ArgoCDDefaultRedisExporterImage = "quay.io/oliver006/redis_exporter"
+
+	// ArgoCDDefaultRedisExporterPort is the default listen port for the Redis exporter metrics.
+	ArgoCDDefaultRedisExporterPort = 9121
+
+	// ArgoCDDefaultRedisExporterVersion is the Redis exporter container image tag to use when not specified.
+	ArgoCDDefaultRedisExporterVersion = "v1.62.0"
+
 	// ArgoCDDefaultRedisImage is the Redis container image to use when not specified.
 	ArgoCDDefaultRedisImage = "redis"
 
@@ -109,6 +109,12 @@ const (
 	// ArgoCDKeyRBACScopes is the configuration key for the Argo CD RBAC scopes.
 	ArgoCDKeyRBACScopes = "scopes"
 
+	// ArgoCDKeyRBACPolicyOverlayPrefix is the configuration key prefix for Argo CD RBAC policy CSV overlays.
+	ArgoCDKeyRBACPolicyOverlayPrefix = "policy."
+
+	// ArgoCDKeyRBACPolicyOverlaySuffix is the configuration key suffix for Argo CD RBAC policy CSV overlays.
+	ArgoCDKeyRBACPolicyOverlaySuffix = ".csv"
+
 	// ArgoCDKeyRelease is the prometheus release key for labels.
 	ArgoCDKeyRelease = "release"
 
@@ -148,6 +154,9 @@ const (
 	// ArgoCDKeyTLSCACert is the key for TLS CA certificates.
 	ArgoCDKeyTLSCACert = "ca.crt"
 
+	// ArgoCDKeyTrustedCABundle is the key for the cluster's trusted CA bundle.
+	ArgoCDKeyTrustedCABundle = "ca-bundle.crt"
+
 	// ArgoCDKeyTLSCert is the key for TLS certificates.
 	ArgoCDKeyTLSCert = corev1.TLSCertKey
 
@@ -176,6 +185,10 @@ const (
 	// to used for the Keycloak container.
 	ArgoCDKeycloakImageEnvName = "ARGOCD_KEYCLOAK_IMAGE"
 
+	// ArgoCDRedisExporterImageEnvName is the environment variable used to get the image
+	// to used for the Redis exporter container.
+	ArgoCDRedisExporterImageEnvName = "ARGOCD_REDIS_EXPORTER_IMAGE"
+
 	// ArgoCDRedisHAProxyImageEnvName is the environment variable used to get the image
 	// to used for the Redis HA Proxy container.
 	ArgoCDRedisHAProxyImageEnvName = "ARGOCD_REDIS_HA_PROXY_IMAGE"
@@ -203,9 +216,30 @@ const (
 	// ArgoCDManagedByClusterArgoCDLabel is needed to identify namespace mentioned as sourceNamespace on ArgoCD
 	ArgoCDManagedByClusterArgoCDLabel = "argocd.argoproj.io/managed-by-cluster-argocd"
 
+	// ArgoCDRBACFragmentLabel is needed to identify ConfigMaps holding RBAC policy fragments in managed namespaces
+	ArgoCDRBACFragmentLabel = "argocd.argoproj.io/rbac-fragment"
+
+	// ArgoCDSSHKnownHostsSourceLabel is needed to identify ConfigMaps and Secrets holding SSH known hosts to aggregate
+	ArgoCDSSHKnownHostsSourceLabel = "argocd.argoproj.io/ssh-known-hosts"
+
+	// ArgoCDTLSCertsSourceLabel is needed to identify ConfigMaps and Secrets holding TLS certificates to aggregate
+	ArgoCDTLSCertsSourceLabel = "argocd.argoproj.io/tls-certs"
+
+	// OpenShiftInjectTrustedCABundleLabel asks OpenShift to inject the cluster's trusted CA bundle into a ConfigMap
+	OpenShiftInjectTrustedCABundleLabel = "config.openshift.io/inject-trusted-cabundle"
+
 	// ArgoCDManagedByClusterArgoCDLabel is needed to identify namespace mentioned as sourceNamespace on ArgoCD
 	ArgoCDApplicationSetManagedByClusterArgoCDLabel = "argocd.argoproj.io/applicationset-managed-by-cluster-argocd"
 
+	// ArgoCDNotificationsManagedByClusterArgoCDLabel is needed to identify namespace mentioned as notifications sourceNamespace on ArgoCD
+	ArgoCDNotificationsManagedByClusterArgoCDLabel = "argocd.argoproj.io/notifications-managed-by-cluster-argocd"
+
+	// ArgoCDManagedGPGKeysAnnotation lists the IDs of the GPG keys written by the operator in the gpg-keys ConfigMap
+	ArgoCDManagedGPGKeysAnnotation = "argocd.argoproj.io/managed-gpg-keys"
+
+	// ArgoCDExportOutcomeAnnotation records the outcome of a scheduled export Job once it is counted in the metrics
+	ArgoCDExportOutcomeAnnotation = "argocd.argoproj.io/export-outcome"
+
 	// ArgoCDControllerClusterRoleEnvName is an environment variable to specify a custom cluster role for Argo CD application controller
 	ArgoCDControllerClusterRoleEnvName = "CONTROLLER_CLUSTER_ROLE"
 
@@ -215,6 +249,21 @@ const (
 	// ArgoCDDexSecretKey is used to reference Dex secret from Argo CD secret into Argo CD configmap
 	ArgoCDDexSecretKey = "oidc.dex.clientSecret"
 
+	// ArgoCDOIDCSecretKey is used to reference the external OIDC provider client secret from Argo CD secret into Argo CD configmap
+	ArgoCDOIDCSecretKey = "oidc.clientSecret"
+
 	// Label Selector is an env variable for ArgoCD instance reconcilliation.
 	ArgoCDLabelSelectorKey = "ARGOCD_LABEL_SELECTOR"
+
+	// ArgoCDOperatorAuditLogKey is the env variable for the destination of the audit log of the operator changes.
+	ArgoCDOperatorAuditLogKey = "ARGOCD_OPERATOR_AUDIT_LOG"
+
+	// ArgoCDOperatorOTLPAddressKey is the env variable for the OpenTelemetry collector address of the operator traces.
+	ArgoCDOperatorOTLPAddressKey = "ARGOCD_OPERATOR_OTLP_ADDRESS"
+
+	// ArgoCDOperatorOTLPInsecureKey is the env variable disabling TLS on the connection to the OpenTelemetry collector.
+	ArgoCDOperatorOTLPInsecureKey = "ARGOCD_OPERATOR_OTLP_INSECURE"
+
+	// ArgoCDOperatorOTLPSamplingRatioKey is the env variable for the ratio of the operator traces that are sampled.
+	ArgoCDOperatorOTLPSamplingRatioKey = "ARGOCD_OPERATOR_OTLP_SAMPLING_RATIO"
 )
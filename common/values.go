@@ -47,6 +47,9 @@ const (
 	// ArgoCDExportStorageBackendLocal is the value for the local storage backend.
 	ArgoCDExportStorageBackendLocal = "local"
 
+	// ArgoCDMonitoringDashboardsSuffix is the name suffix for the ConfigMap holding the Grafana dashboards.
+	ArgoCDMonitoringDashboardsSuffix = "monitoring-dashboards"
+
 	// ArgoCDKnownHostsConfigMapName is the upstream hard-coded SSH known hosts data ConfigMap name.
 	ArgoCDKnownHostsConfigMapName = "argocd-ssh-known-hosts-cm"
 
@@ -68,6 +71,9 @@ const (
 	// ArgoCDStatusCompleted is the completed status value.
 	ArgoCDStatusCompleted = "Completed"
 
+	// ArgoCDStatusFailed is the failed status value.
+	ArgoCDStatusFailed = "Failed"
+
 	// ArgoCDTLSCertsConfigMapName is the upstream hard-coded TLS certificate data ConfigMap name.
 	ArgoCDTLSCertsConfigMapName = "argocd-tls-certs-cm"
 
@@ -51,6 +51,9 @@ func (r *ReconcileArgoCD) getArgoApplicationSetCommand(cr *argoproj.ArgoCD) []st
 
 	if cr.Spec.Repo.IsEnabled() {
 		cmd = append(cmd, "--argocd-repo-server", getRepoServerAddress(cr))
+		if isRepoServerTLSVerificationRequested(cr) {
+			cmd = append(cmd, "--repo-server-strict-tls")
+		}
 	} else {
 		log.Info("Repo Server is disabled. This would affect the functioning of ApplicationSet Controller.")
 	}
@@ -221,6 +224,10 @@ func (r *ReconcileArgoCD) reconcileApplicationSetDeployment(cr *argoproj.ArgoCD,
 				EmptyDir: &corev1.EmptyDirVolumeSource{},
 			},
 		},
+		{
+			Name:         "argocd-repo-server-tls",
+			VolumeSource: getRepoServerTLSVolumeSource(cr),
+		},
 		{
 			Name: "tmp",
 			VolumeSource: corev1.VolumeSource{
@@ -246,10 +253,13 @@ func (r *ReconcileArgoCD) reconcileApplicationSetDeployment(cr *argoproj.ArgoCD,
 		}
 	}
 
+	podSpec.Volumes = append(podSpec.Volumes, r.getTrustedCABundleVolumes(cr)...)
+
 	podSpec.Containers = []corev1.Container{
 		r.applicationSetContainer(cr, addSCMGitlabVolumeMount),
 	}
 	AddSeccompProfileForOpenShift(r.Client, podSpec)
+	setTrustedCABundleChecksum(&deploy.Spec.Template, r.getTrustedCABundleChecksum(cr))
 
 	if exists {
 
@@ -263,7 +273,8 @@ func (r *ReconcileArgoCD) reconcileApplicationSetDeployment(cr *argoproj.ArgoCD,
 			!reflect.DeepEqual(existing.Spec.Selector, deploy.Spec.Selector) ||
 			!reflect.DeepEqual(existing.Spec.Template.Spec.NodeSelector, deploy.Spec.Template.Spec.NodeSelector) ||
 			!reflect.DeepEqual(existing.Spec.Template.Spec.Tolerations, deploy.Spec.Template.Spec.Tolerations) ||
-			!reflect.DeepEqual(existing.Spec.Template.Spec.Containers[0].SecurityContext, deploy.Spec.Template.Spec.Containers[0].SecurityContext)
+			!reflect.DeepEqual(existing.Spec.Template.Spec.Containers[0].SecurityContext, deploy.Spec.Template.Spec.Containers[0].SecurityContext) ||
+			existing.Spec.Template.Annotations[trustedCABundleChecksumAnnotation] != deploy.Spec.Template.Annotations[trustedCABundleChecksumAnnotation]
 
 		// If the Deployment already exists, make sure the values we care about are up-to-date
 		if deploymentsDifferent {
@@ -276,6 +287,7 @@ func (r *ReconcileArgoCD) reconcileApplicationSetDeployment(cr *argoproj.ArgoCD,
 			existing.Spec.Template.Spec.NodeSelector = deploy.Spec.Template.Spec.NodeSelector
 			existing.Spec.Template.Spec.Tolerations = deploy.Spec.Template.Spec.Tolerations
 			existing.Spec.Template.Spec.Containers[0].SecurityContext = deploy.Spec.Template.Spec.Containers[0].SecurityContext
+			updateTrustedCABundleChecksum(&existing.Spec.Template, &deploy.Spec.Template)
 			return r.Client.Update(context.TODO(), existing)
 		}
 		return nil // Deployment found with nothing to do, move along...
@@ -333,6 +345,10 @@ func (r *ReconcileArgoCD) applicationSetContainer(cr *argoproj.ArgoCD, addSCMGit
 				Name:      "gpg-keyring",
 				MountPath: "/app/config/gpg/keys",
 			},
+			{
+				Name:      "argocd-repo-server-tls",
+				MountPath: "/app/config/reposerver/tls",
+			},
 			{
 				Name:      "tmp",
 				MountPath: "/tmp",
@@ -368,6 +384,7 @@ func (r *ReconcileArgoCD) applicationSetContainer(cr *argoproj.ArgoCD, addSCMGit
 			MountPath: ApplicationSetGitlabSCMTlsMountPath,
 		})
 	}
+	container.VolumeMounts = append(container.VolumeMounts, r.getTrustedCABundleVolumeMounts(cr)...)
 	return container
 }
 
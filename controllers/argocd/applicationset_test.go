@@ -44,7 +44,6 @@ func applicationSetDefaultVolumeMounts() []corev1.VolumeMount {
 	repoMounts := repoServerDefaultVolumeMounts()
 	ignoredMounts := map[string]bool{
 		"plugins":                             true,
-		"argocd-repo-server-tls":              true,
 		common.ArgoCDRedisServerTLSSecretName: true,
 	}
 	mounts := make([]corev1.VolumeMount, len(repoMounts)-len(ignoredMounts), len(repoMounts)-len(ignoredMounts))
@@ -63,7 +62,6 @@ func applicationSetDefaultVolumes() []corev1.Volume {
 	ignoredVolumes := map[string]bool{
 		"var-files":                           true,
 		"plugins":                             true,
-		"argocd-repo-server-tls":              true,
 		common.ArgoCDRedisServerTLSSecretName: true,
 	}
 	volumes := make([]corev1.Volume, len(repoVolumes)-len(ignoredVolumes), len(repoVolumes)-len(ignoredVolumes))
@@ -153,6 +151,15 @@ func checkExpectedDeploymentValues(t *testing.T, r *ReconcileArgoCD, deployment
 				EmptyDir: &corev1.EmptyDirVolumeSource{},
 			},
 		},
+		{
+			Name: "argocd-repo-server-tls",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: common.ArgoCDRepoServerTLSSecretName,
+					Optional:   boolPtr(true),
+				},
+			},
+		},
 		{
 			Name: "tmp",
 			VolumeSource: corev1.VolumeSource{
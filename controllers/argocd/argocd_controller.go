@@ -19,16 +19,16 @@ package argocd
 import (
 	"context"
 	"fmt"
+	"io"
 	"time"
 
-	"github.com/prometheus/client_golang/prometheus"
-
 	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -49,8 +49,15 @@ type ReconcileArgoCD struct {
 	ManagedSourceNamespaces map[string]string
 	// Stores a list of ApplicationSetSourceNamespaces as keys
 	ManagedApplicationSetSourceNamespaces map[string]string
+	// Stores a list of notifications source namespaces as keys
+	ManagedNotificationsSourceNamespaces map[string]string
 	// Stores label selector used to reconcile a subset of ArgoCD
 	LabelSelector string
+	// Receives the audit log of the changes made to the resources managed by the ArgoCD instances, when set
+	AuditLog io.Writer
+
+	// Emits the Events reporting problems with the ArgoCD instances
+	recorder record.EventRecorder
 }
 
 var log = logr.Log.WithName("controller_argocd")
@@ -74,12 +81,13 @@ var ActiveInstanceMap = make(map[string]string)
 //+kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=create;delete;get;list;patch;update;watch;
 //+kubebuilder:rbac:groups=monitoring.coreos.com,resources=prometheuses;prometheusrules;servicemonitors,verbs=*
 //+kubebuilder:rbac:groups=route.openshift.io,resources=routes;routes/custom-host,verbs=*
+//+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes;grpcroutes;tlsroutes,verbs=*
 //+kubebuilder:rbac:groups=argoproj.io,resources=applications;appprojects,verbs=*
 //+kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=*,verbs=*
 //+kubebuilder:rbac:groups="",resources=pods;pods/log,verbs=get
 //+kubebuilder:rbac:groups=template.openshift.io,resources=templates;templateinstances;templateconfigs,verbs=*
 //+kubebuilder:rbac:groups="oauth.openshift.io",resources=oauthclients,verbs=get;list;watch;create;delete;patch;update
-//+kubebuilder:rbac:groups=argoproj.io,resources=notificationsconfigurations;notificationsconfigurations/finalizers,verbs=*
+//+kubebuilder:rbac:groups=argoproj.io,resources=notificationsconfigurations;notificationsconfigurations/finalizers;notificationsconfigurations/status,verbs=*
 //+kubebuilder:rbac:groups="apiregistration.k8s.io",resources="apiservices",verbs=get;list
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
@@ -97,6 +105,9 @@ func (r *ReconcileArgoCD) Reconcile(ctx context.Context, request ctrl.Request) (
 		ReconcileTime.WithLabelValues(request.Namespace).Observe(time.Since(reconcileStartTS).Seconds())
 	}()
 
+	ctx, span := startReconcileSpan(ctx, request.Namespace, request.Name)
+	defer span.End()
+
 	reqLogger := logr.FromContext(ctx, "namespace", request.Namespace, "name", request.Name)
 	reqLogger.Info("Reconciling ArgoCD")
 
@@ -156,8 +167,7 @@ func (r *ReconcileArgoCD) Reconcile(ctx context.Context, request ctrl.Request) (
 		delete(ActiveInstanceMap, argocd.Namespace)
 		ActiveInstancesByPhase.WithLabelValues(newPhase).Dec()
 		ActiveInstancesTotal.Dec()
-		ActiveInstanceReconciliationCount.DeleteLabelValues(argocd.Namespace)
-		ReconcileTime.DeletePartialMatch(prometheus.Labels{"namespace": argocd.Namespace})
+		deleteInstanceMetrics(argocd.Namespace)
 
 		if argocd.IsDeletionFinalizerPresent() {
 			if err := r.deleteClusterResources(argocd); err != nil {
@@ -178,6 +188,10 @@ func (r *ReconcileArgoCD) Reconcile(ctx context.Context, request ctrl.Request) (
 				return reconcile.Result{}, fmt.Errorf("failed to remove resources from applicationSetSourceNamespaces, error: %w", err)
 			}
 
+			if err := r.removeUnmanagedNotificationsSourceNamespaceResources(argocd); err != nil {
+				return reconcile.Result{}, fmt.Errorf("failed to remove resources from notifications sourceNamespaces, error: %w", err)
+			}
+
 			if err := r.removeDeletionFinalizer(argocd); err != nil {
 				return reconcile.Result{}, err
 			}
@@ -212,7 +226,11 @@ func (r *ReconcileArgoCD) Reconcile(ctx context.Context, request ctrl.Request) (
 		return reconcile.Result{}, err
 	}
 
-	if err := r.reconcileResources(argocd); err != nil {
+	if err = r.setManagedNotificationsSourceNamespaces(argocd); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if err := r.reconcileResources(ctx, argocd); err != nil {
 		// Error reconciling ArgoCD sub-resources - requeue the request.
 		return reconcile.Result{}, err
 	}
@@ -224,6 +242,8 @@ func (r *ReconcileArgoCD) Reconcile(ctx context.Context, request ctrl.Request) (
 // SetupWithManager sets up the controller with the Manager.
 func (r *ReconcileArgoCD) SetupWithManager(mgr ctrl.Manager) error {
 	bldr := ctrl.NewControllerManagedBy(mgr)
-	r.setResourceWatches(bldr, r.clusterResourceMapper, r.tlsSecretMapper, r.namespaceResourceMapper, r.clusterSecretResourceMapper, r.applicationSetSCMTLSConfigMapMapper)
+	r.recorder = mgr.GetEventRecorderFor("argocd-operator")
+	r.Client = newAuditingClient(newDriftTrackingClient(r.Client), r.recorder, r.AuditLog)
+	r.setResourceWatches(bldr, r.clusterResourceMapper, r.tlsSecretMapper, r.namespaceResourceMapper, r.clusterSecretResourceMapper, r.applicationSetSCMTLSConfigMapMapper, r.rbacFragmentConfigMapMapper, r.trustSourceMapper, r.trustedCABundleConfigMapMapper, r.referencedObjectMapper)
 	return bldr.Complete(r)
 }
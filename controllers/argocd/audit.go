@@ -0,0 +1,384 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+const (
+	// auditLogStdout is the audit log destination writing the audit log to the standard output.
+	auditLogStdout = "stdout"
+
+	// maxChangedFieldsDepth is the depth down to which the changed fields of an updated resource are reported.
+	maxChangedFieldsDepth = 4
+
+	// maxChangedFieldsInEvent is the number of changed fields listed in the message of an Event.
+	maxChangedFieldsInEvent = 5
+)
+
+// Fields of the metadata that are maintained by the API server and are not reported as changes.
+var ignoredMetadataFields = []string{"creationTimestamp", "generation", "managedFields", "resourceVersion", "selfLink", "uid"}
+
+// OpenAuditLog opens the given audit log destination, which is either stdout or the path of a file the audit log is
+// appended to. No audit log is returned when the destination is empty.
+func OpenAuditLog(destination string) (io.WriteCloser, error) {
+	switch destination {
+	case "":
+		return nil, nil
+	case auditLogStdout:
+		return nopWriteCloser{os.Stdout}, nil
+	}
+
+	f, err := os.OpenFile(destination, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log %s: %w", destination, err)
+	}
+	return f, nil
+}
+
+// nopWriteCloser is a writer that is not closed with the audit log.
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
+}
+
+// auditRecord is an entry of the audit log, recording a change made by the operator to a resource.
+type auditRecord struct {
+	Time       string   `json:"time"`
+	Action     string   `json:"action"`
+	APIVersion string   `json:"apiVersion"`
+	Kind       string   `json:"kind"`
+	Namespace  string   `json:"namespace,omitempty"`
+	Name       string   `json:"name"`
+	ArgoCD     string   `json:"argocd,omitempty"`
+	Changes    []string `json:"changes,omitempty"`
+}
+
+// auditLogger writes the audit records as JSON lines.
+type auditLogger struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// write appends the given record to the audit log.
+func (l *auditLogger) write(record auditRecord) {
+	data, err := json.Marshal(record)
+	if err != nil {
+		log.Error(err, "unable to encode audit record")
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, err := l.w.Write(append(data, '\n')); err != nil {
+		log.Error(err, "unable to write audit record")
+	}
+}
+
+// auditingClient is a client recording the changes made to the resources managed by an ArgoCD, as Events on the
+// ArgoCD and, when an audit log is set, as audit records.
+type auditingClient struct {
+	client.Client
+	recorder record.EventRecorder
+	auditLog *auditLogger
+}
+
+// newAuditingClient returns a client recording the changes made through the given client, emitting the Events with
+// the given recorder and writing the audit records to the given audit log when set.
+func newAuditingClient(c client.Client, recorder record.EventRecorder, auditLog io.Writer) *auditingClient {
+	ac := &auditingClient{Client: c, recorder: recorder}
+	if auditLog != nil {
+		ac.auditLog = &auditLogger{w: auditLog}
+	}
+	return ac
+}
+
+// Create creates the given object and records its creation.
+func (c *auditingClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	if err := c.Client.Create(ctx, obj, opts...); err != nil {
+		return err
+	}
+	c.record(ctx, obj, "Create", "ResourceCreated", "created", nil)
+	return nil
+}
+
+// Update updates the given object and records the fields that were changed.
+func (c *auditingClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	var changes []string
+	if before := c.getBefore(ctx, obj); before != nil {
+		changes = getChangedFields(before, obj)
+	}
+
+	if err := c.Client.Update(ctx, obj, opts...); err != nil {
+		return err
+	}
+	c.record(ctx, obj, "Update", "ResourceUpdated", "updated", changes)
+	return nil
+}
+
+// Delete deletes the given object and records its deletion.
+func (c *auditingClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	if err := c.Client.Delete(ctx, obj, opts...); err != nil {
+		return err
+	}
+	c.record(ctx, obj, "Delete", "ResourceDeleted", "deleted", nil)
+	return nil
+}
+
+// Patch patches the given object and records the fields that were changed.
+func (c *auditingClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	before := c.getBefore(ctx, obj)
+
+	if err := c.Client.Patch(ctx, obj, patch, opts...); err != nil {
+		return err
+	}
+
+	var changes []string
+	if before != nil {
+		changes = getChangedFields(before, obj)
+	}
+	c.record(ctx, obj, "Patch", "ResourceUpdated", "patched", changes)
+	return nil
+}
+
+// Status returns a writer for the status subresource recording the updates and patches of the status.
+func (c *auditingClient) Status() client.SubResourceWriter {
+	return &auditingStatusWriter{SubResourceWriter: c.Client.Status(), client: c}
+}
+
+// getBefore returns the current state of the given object, or nil when it is not audited or cannot be read.
+func (c *auditingClient) getBefore(ctx context.Context, obj client.Object) client.Object {
+	if !isAudited(obj) {
+		return nil
+	}
+	before, ok := obj.DeepCopyObject().(client.Object)
+	if !ok || c.Client.Get(ctx, client.ObjectKeyFromObject(obj), before) != nil {
+		return nil
+	}
+	return before
+}
+
+// auditingStatusWriter is a writer for the status subresource recording the updates and patches of the status of the
+// resources managed by an ArgoCD. The creations of subresources are not recorded.
+type auditingStatusWriter struct {
+	client.SubResourceWriter
+	client *auditingClient
+}
+
+// Update updates the status of the given object and records the status fields that were changed.
+func (w *auditingStatusWriter) Update(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+	var changes []string
+	if before := w.client.getBefore(ctx, obj); before != nil {
+		changes = getChangedStatusFields(before, obj)
+	}
+
+	if err := w.SubResourceWriter.Update(ctx, obj, opts...); err != nil {
+		return err
+	}
+	w.client.record(ctx, obj, "UpdateStatus", "ResourceUpdated", "updated status of", changes)
+	return nil
+}
+
+// Patch patches the status of the given object and records the status fields that were changed.
+func (w *auditingStatusWriter) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+	before := w.client.getBefore(ctx, obj)
+
+	if err := w.SubResourceWriter.Patch(ctx, obj, patch, opts...); err != nil {
+		return err
+	}
+
+	var changes []string
+	if before != nil {
+		changes = getChangedStatusFields(before, obj)
+	}
+	w.client.record(ctx, obj, "PatchStatus", "ResourceUpdated", "patched status of", changes)
+	return nil
+}
+
+// isAudited returns whether the changes to the given object are recorded. The ArgoCD instances and the Events are
+// left out, since they are not resources managed by an ArgoCD.
+func isAudited(obj client.Object) bool {
+	switch obj.(type) {
+	case *argoproj.ArgoCD, *corev1.Event:
+		return false
+	}
+	return true
+}
+
+// record emits an Event on the ArgoCD managing the given object and writes the change to the audit log.
+func (c *auditingClient) record(ctx context.Context, obj client.Object, action, reason, verb string, changes []string) {
+	if !isAudited(obj) {
+		return
+	}
+
+	gvk, err := apiutil.GVKForObject(obj, c.Scheme())
+	if err != nil {
+		log.Error(err, fmt.Sprintf("unable to determine the kind of %s/%s", obj.GetNamespace(), obj.GetName()))
+		return
+	}
+
+	argocdKey, managed := getManagingArgoCD(obj)
+
+	if c.auditLog != nil {
+		record := auditRecord{
+			Time:       time.Now().UTC().Format(time.RFC3339),
+			Action:     action,
+			APIVersion: gvk.GroupVersion().String(),
+			Kind:       gvk.Kind,
+			Namespace:  obj.GetNamespace(),
+			Name:       obj.GetName(),
+			Changes:    changes,
+		}
+		if managed {
+			record.ArgoCD = argocdKey.String()
+		}
+		c.auditLog.write(record)
+	}
+
+	if !managed || c.recorder == nil {
+		return
+	}
+
+	cr := &argoproj.ArgoCD{}
+	if err := c.Client.Get(ctx, argocdKey, cr); err != nil {
+		return // ArgoCD not found, e.g. already deleted, nothing to emit the Event on.
+	}
+
+	message := fmt.Sprintf("%s %s %s", verb, gvk.Kind, obj.GetName())
+	if len(changes) > maxChangedFieldsInEvent {
+		message = fmt.Sprintf("%s: %s and %d more", message, strings.Join(changes[:maxChangedFieldsInEvent], ", "), len(changes)-maxChangedFieldsInEvent)
+	} else if len(changes) > 0 {
+		message = fmt.Sprintf("%s: %s", message, strings.Join(changes, ", "))
+	}
+	c.recorder.Event(cr, corev1.EventTypeNormal, reason, message)
+}
+
+// getManagingArgoCD returns the namespace and name of the ArgoCD managing the given object, found from its controller
+// reference or else its ArgoCD annotations. The managed-by label is not used, since it holds the name of the managing
+// tool rather than the name of an ArgoCD.
+func getManagingArgoCD(obj client.Object) (types.NamespacedName, bool) {
+	if owner := metav1.GetControllerOf(obj); owner != nil && owner.Kind == "ArgoCD" {
+		return types.NamespacedName{Namespace: obj.GetNamespace(), Name: owner.Name}, true
+	}
+
+	annotations := obj.GetAnnotations()
+	if annotations[common.AnnotationName] != "" && annotations[common.AnnotationNamespace] != "" {
+		return types.NamespacedName{Namespace: annotations[common.AnnotationNamespace], Name: annotations[common.AnnotationName]}, true
+	}
+
+	return types.NamespacedName{}, false
+}
+
+// getChangedFields returns the sorted paths of the fields that differ between the given objects, e.g.
+// spec.template.spec.containers. The status and the metadata maintained by the API server are ignored.
+func getChangedFields(before, after client.Object) []string {
+	beforeFields, err := runtime.DefaultUnstructuredConverter.ToUnstructured(before)
+	if err != nil {
+		return nil
+	}
+	afterFields, err := runtime.DefaultUnstructuredConverter.ToUnstructured(after)
+	if err != nil {
+		return nil
+	}
+
+	for _, fields := range []map[string]interface{}{beforeFields, afterFields} {
+		delete(fields, "status")
+		if metadata, ok := fields["metadata"].(map[string]interface{}); ok {
+			for _, f := range ignoredMetadataFields {
+				delete(metadata, f)
+			}
+		}
+	}
+
+	changes := diffFields(beforeFields, afterFields, "", 1)
+	sort.Strings(changes)
+	return changes
+}
+
+// getChangedStatusFields returns the sorted paths of the status fields that differ between the given objects, e.g.
+// status.conditions.
+func getChangedStatusFields(before, after client.Object) []string {
+	beforeFields, err := runtime.DefaultUnstructuredConverter.ToUnstructured(before)
+	if err != nil {
+		return nil
+	}
+	afterFields, err := runtime.DefaultUnstructuredConverter.ToUnstructured(after)
+	if err != nil {
+		return nil
+	}
+
+	beforeStatus, _ := beforeFields["status"].(map[string]interface{})
+	afterStatus, _ := afterFields["status"].(map[string]interface{})
+	changes := diffFields(beforeStatus, afterStatus, "status", 2)
+	sort.Strings(changes)
+	return changes
+}
+
+// diffFields returns the paths of the fields that differ between the given maps, descending into the nested maps down
+// to maxChangedFieldsDepth.
+func diffFields(before, after map[string]interface{}, prefix string, depth int) []string {
+	keys := map[string]bool{}
+	for k := range before {
+		keys[k] = true
+	}
+	for k := range after {
+		keys[k] = true
+	}
+
+	changes := []string{}
+	for k := range keys {
+		if reflect.DeepEqual(before[k], after[k]) {
+			continue
+		}
+
+		path := k
+		if prefix != "" {
+			path = prefix + "." + k
+		}
+
+		beforeMap, beforeIsMap := before[k].(map[string]interface{})
+		afterMap, afterIsMap := after[k].(map[string]interface{})
+		if depth < maxChangedFieldsDepth && beforeIsMap && afterIsMap {
+			changes = append(changes, diffFields(beforeMap, afterMap, path, depth+1)...)
+			continue
+		}
+		changes = append(changes, path)
+	}
+	return changes
+}
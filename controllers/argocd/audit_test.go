@@ -0,0 +1,189 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+func TestGetChangedFields(t *testing.T) {
+	a := makeTestArgoCD()
+	before := newDeploymentWithSuffix("server", "server", a)
+	before.ResourceVersion = "1"
+	before.Spec.Template.Spec.Containers = []corev1.Container{{Name: "argocd-server", Image: "argocd:v1"}}
+
+	after := before.DeepCopy()
+	after.ResourceVersion = "2"
+	after.Labels["foo"] = "bar"
+	after.Spec.Replicas = int32Ptr(2)
+	after.Spec.Template.Spec.Containers[0].Image = "argocd:v2"
+	after.Status.Replicas = 2
+
+	assert.Equal(t, []string{"metadata.labels.foo", "spec.replicas", "spec.template.spec.containers"}, getChangedFields(before, after))
+	assert.Empty(t, getChangedFields(before, before.DeepCopy()))
+}
+
+func TestAuditingClient(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	auditLog := &bytes.Buffer{}
+	recorder := record.NewFakeRecorder(10)
+	cl := newAuditingClient(makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs), recorder, auditLog)
+
+	cm := newConfigMapWithName("owned", a)
+	cm.Data = map[string]string{"foo": "bar"}
+	assert.NoError(t, controllerutil.SetControllerReference(a, cm, sch))
+	assert.NoError(t, cl.Create(context.TODO(), cm))
+	cm.Data["foo"] = "baz"
+	assert.NoError(t, cl.Update(context.TODO(), cm))
+	assert.NoError(t, cl.Delete(context.TODO(), cm))
+
+	// changes to resources that are not managed by an ArgoCD are only audited
+	unmanaged := &appsv1.Deployment{}
+	unmanaged.Name = "unmanaged"
+	unmanaged.Namespace = a.Namespace
+	assert.NoError(t, cl.Create(context.TODO(), unmanaged))
+
+	// the managed-by label holds the name of the managing tool, not of an ArgoCD
+	labeled := newConfigMapWithName("labeled", a)
+	labeled.Labels = map[string]string{common.ArgoCDKeyManagedBy: a.Name}
+	assert.NoError(t, cl.Create(context.TODO(), labeled))
+
+	close(recorder.Events)
+	events := []string{}
+	for event := range recorder.Events {
+		events = append(events, event)
+	}
+	assert.Equal(t, []string{
+		"Normal ResourceCreated created ConfigMap owned",
+		"Normal ResourceUpdated updated ConfigMap owned: data.foo",
+		"Normal ResourceDeleted deleted ConfigMap owned",
+	}, events)
+
+	lines := strings.Split(strings.TrimSpace(auditLog.String()), "\n")
+	assert.Len(t, lines, 5)
+	records := []auditRecord{}
+	for _, line := range lines {
+		record := auditRecord{}
+		assert.NoError(t, json.Unmarshal([]byte(line), &record))
+		record.Time = ""
+		records = append(records, record)
+	}
+	assert.Equal(t, []auditRecord{
+		{Action: "Create", APIVersion: "v1", Kind: "ConfigMap", Namespace: a.Namespace, Name: "owned", ArgoCD: "argocd/argocd"},
+		{Action: "Update", APIVersion: "v1", Kind: "ConfigMap", Namespace: a.Namespace, Name: "owned", ArgoCD: "argocd/argocd", Changes: []string{"data.foo"}},
+		{Action: "Delete", APIVersion: "v1", Kind: "ConfigMap", Namespace: a.Namespace, Name: "owned", ArgoCD: "argocd/argocd"},
+		{Action: "Create", APIVersion: "apps/v1", Kind: "Deployment", Namespace: a.Namespace, Name: "unmanaged"},
+		{Action: "Create", APIVersion: "v1", Kind: "ConfigMap", Namespace: a.Namespace, Name: "labeled"},
+	}, records)
+}
+
+func TestAuditingClient_patchAndStatus(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+
+	deploy := newDeploymentWithSuffix("server", "server", a)
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	assert.NoError(t, controllerutil.SetControllerReference(a, deploy, sch))
+
+	resObjs := []client.Object{a, deploy}
+	subresObjs := []client.Object{a, deploy}
+	runtimeObjs := []runtime.Object{}
+	auditLog := &bytes.Buffer{}
+	recorder := record.NewFakeRecorder(10)
+	cl := newAuditingClient(makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs), recorder, auditLog)
+
+	patch := client.MergeFrom(deploy.DeepCopy())
+	deploy.Spec.Replicas = int32Ptr(2)
+	assert.NoError(t, cl.Patch(context.TODO(), deploy, patch))
+
+	deploy.Status.Replicas = 2
+	assert.NoError(t, cl.Status().Update(context.TODO(), deploy))
+
+	patch = client.MergeFrom(deploy.DeepCopy())
+	deploy.Status.ReadyReplicas = 2
+	assert.NoError(t, cl.Status().Patch(context.TODO(), deploy, patch))
+
+	// the status of the ArgoCD is not audited
+	a.Status.Phase = "Available"
+	assert.NoError(t, cl.Status().Update(context.TODO(), a))
+
+	close(recorder.Events)
+	events := []string{}
+	for event := range recorder.Events {
+		events = append(events, event)
+	}
+	assert.Equal(t, []string{
+		"Normal ResourceUpdated patched Deployment argocd-server: spec.replicas",
+		"Normal ResourceUpdated updated status of Deployment argocd-server: status.replicas",
+		"Normal ResourceUpdated patched status of Deployment argocd-server: status.readyReplicas",
+	}, events)
+
+	lines := strings.Split(strings.TrimSpace(auditLog.String()), "\n")
+	actions := []string{}
+	for _, line := range lines {
+		record := auditRecord{}
+		assert.NoError(t, json.Unmarshal([]byte(line), &record))
+		actions = append(actions, record.Action)
+	}
+	assert.Equal(t, []string{"Patch", "UpdateStatus", "PatchStatus"}, actions)
+}
+
+func TestOpenAuditLog(t *testing.T) {
+	auditLog, err := OpenAuditLog("")
+	assert.NoError(t, err)
+	assert.Nil(t, auditLog)
+
+	auditLog, err = OpenAuditLog("stdout")
+	assert.NoError(t, err)
+	assert.NotNil(t, auditLog)
+	assert.NoError(t, auditLog.Close())
+
+	path := filepath.Join(t.TempDir(), "audit.log")
+	assert.NoError(t, os.WriteFile(path, []byte("{}\n"), 0600))
+	auditLog, err = OpenAuditLog(path)
+	assert.NoError(t, err)
+	_, err = auditLog.Write([]byte("{}\n"))
+	assert.NoError(t, err)
+	assert.NoError(t, auditLog.Close())
+
+	// the audit log is appended to the existing file
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "{}\n{}\n", string(data))
+}
@@ -30,12 +30,13 @@ import (
 )
 
 // createRBACConfigMap will create the Argo CD RBAC ConfigMap resource.
-func (r *ReconcileArgoCD) createRBACConfigMap(cm *corev1.ConfigMap, cr *argoproj.ArgoCD) error {
+func (r *ReconcileArgoCD) createRBACConfigMap(cm *corev1.ConfigMap, cr *argoproj.ArgoCD, policies map[string]string) error {
 	data := make(map[string]string)
-	data[common.ArgoCDKeyRBACPolicyCSV] = getRBACPolicy(cr)
+	data[common.ArgoCDKeyRBACPolicyCSV] = policies[common.ArgoCDKeyRBACPolicyCSV]
 	data[common.ArgoCDKeyRBACPolicyDefault] = getRBACDefaultPolicy(cr)
 	data[common.ArgoCDKeyRBACScopes] = getRBACScopes(cr)
 	cm.Data = data
+	reconcileRBACPolicyOverlays(cm, policies)
 
 	if err := controllerutil.SetControllerReference(cr, cm, r.Scheme); err != nil {
 		return err
@@ -145,6 +146,8 @@ func getRBACScopes(cr *argoproj.ArgoCD) string {
 	scopes := common.ArgoCDDefaultRBACScopes
 	if cr.Spec.RBAC.Scopes != nil {
 		scopes = *cr.Spec.RBAC.Scopes
+	} else if UseExternalOIDC(cr) && cr.Spec.SSO.OIDC.GroupsClaim != "" {
+		scopes = fmt.Sprintf("[%s]", cr.Spec.SSO.OIDC.GroupsClaim)
 	}
 	return scopes
 }
@@ -320,6 +323,10 @@ func (r *ReconcileArgoCD) reconcileConfigMaps(cr *argoproj.ArgoCD, useTLSForRedi
 		return err
 	}
 
+	if err := r.reconcileTrustedCABundleConfigMap(cr); err != nil {
+		return err
+	}
+
 	if err := r.reconcileSSHKnownHosts(cr); err != nil {
 		return err
 	}
@@ -336,6 +343,14 @@ func (r *ReconcileArgoCD) reconcileConfigMaps(cr *argoproj.ArgoCD, useTLSForRedi
 		return err
 	}
 
+	if err := r.reconcileMonitoringDashboards(cr); err != nil {
+		return err
+	}
+
+	if err := r.reconcileRepoServerPluginsConfigMap(cr); err != nil {
+		return err
+	}
+
 	return r.reconcileGPGKeysConfigMap(cr)
 }
 
@@ -379,12 +394,33 @@ func (r *ReconcileArgoCD) reconcileArgoConfigMap(cr *argoproj.ArgoCD) error {
 	cm.Data[common.ArgoCDKeyKustomizeBuildOptions] = getKustomizeBuildOptions(cr)
 
 	if len(cr.Spec.KustomizeVersions) > 0 {
-		for _, kv := range cr.Spec.KustomizeVersions {
-			cm.Data["kustomize.version."+kv.Version] = kv.Path
+		versions, _ := getKustomizeVersions(cr)
+		for _, kv := range versions {
+			cm.Data["kustomize.version."+kv.Version] = getKustomizeVersionPath(kv)
 		}
 	}
 
 	cm.Data[common.ArgoCDKeyOIDCConfig] = getOIDCConfig(cr)
+	keepOIDCConfig := false
+	if UseExternalOIDC(cr) {
+		if err := r.validateExternalOIDC(cr); err != nil {
+			// do not render a configuration Argo CD cannot log in with, keep the existing one instead so that an
+			// unreachable provider does not block the reconciliation of the other resources
+			log.Error(err, fmt.Sprintf("invalid oidc configuration for ArgoCD %s in namespace %s, keeping the existing oidc.config", cr.Name, cr.Namespace))
+			r.recordEvent(cr, corev1.EventTypeWarning, "InvalidOIDCConfiguration", fmt.Sprintf("keeping the existing oidc.config: %s", err))
+			ssoConfigLegalStatus = ssoLegalFailed
+			if statusErr := r.reconcileStatusSSO(cr); statusErr != nil {
+				log.Error(statusErr, "failed to update the SSO status")
+			}
+			keepOIDCConfig = true
+		} else {
+			oidcConfig, err := getExternalOIDCConfig(cr)
+			if err != nil {
+				return err
+			}
+			cm.Data[common.ArgoCDKeyOIDCConfig] = oidcConfig
+		}
+	}
 
 	if c := getResourceHealthChecks(cr); c != nil {
 		for k, v := range c {
@@ -417,15 +453,9 @@ func (r *ReconcileArgoCD) reconcileArgoConfigMap(cr *argoproj.ArgoCD) error {
 
 	// create dex config if dex is enabled through `.spec.sso`
 	if UseDex(cr) {
-		dexConfig := getDexConfig(cr)
-
-		// Append the default OpenShift dex config if the openShiftOAuth is requested through `.spec.sso.dex`.
-		if cr.Spec.SSO != nil && cr.Spec.SSO.Dex != nil && cr.Spec.SSO.Dex.OpenShiftOAuth {
-			cfg, err := r.getOpenShiftDexConfig(cr)
-			if err != nil {
-				return err
-			}
-			dexConfig = cfg
+		dexConfig, err := r.getDesiredDexConfig(cr, "")
+		if err != nil {
+			return err
 		}
 		cm.Data[common.ArgoCDKeyDexConfig] = dexConfig
 	}
@@ -462,6 +492,9 @@ func (r *ReconcileArgoCD) reconcileArgoConfigMap(cr *argoproj.ArgoCD) error {
 		} else if cr.Spec.SSO != nil && cr.Spec.SSO.Provider.ToLower() == argoproj.SSOProviderTypeKeycloak {
 			// retain oidc.config during reconcilliation when keycloak is configured
 			cm.Data[common.ArgoCDKeyOIDCConfig] = existingCM.Data[common.ArgoCDKeyOIDCConfig]
+		} else if keepOIDCConfig {
+			// retain oidc.config while the external OIDC provider cannot be validated
+			cm.Data[common.ArgoCDKeyOIDCConfig] = existingCM.Data[common.ArgoCDKeyOIDCConfig]
 		}
 
 		if !reflect.DeepEqual(cm.Data, existingCM.Data) {
@@ -500,19 +533,47 @@ func (r *ReconcileArgoCD) reconcileGrafanaDashboards(cr *argoproj.ArgoCD) error
 
 // reconcileRBAC will ensure that the ArgoCD RBAC ConfigMap is present.
 func (r *ReconcileArgoCD) reconcileRBAC(cr *argoproj.ArgoCD) error {
+	policies, errs := getRBACPolicyData(cr)
+
+	fragments, fragmentErrs, err := r.getRBACPolicyFragments(cr)
+	if err != nil {
+		return err
+	}
+	for k, v := range fragments {
+		policies[k] = v
+	}
+	errs = append(errs, fragmentErrs...)
+
+	for _, e := range errs {
+		log.Info(fmt.Sprintf("invalid RBAC policy entry for ArgoCD %s in namespace %s: %s", cr.Name, cr.Namespace, e))
+	}
+
 	cm := newConfigMapWithName(common.ArgoCDRBACConfigMapName, cr)
 	if argoutil.IsObjectFound(r.Client, cr.Namespace, cm.Name, cm) {
-		return r.reconcileRBACConfigMap(cm, cr)
+		if err := r.reconcileRBACConfigMap(cm, cr, policies); err != nil {
+			return err
+		}
+	} else if err := r.createRBACConfigMap(cm, cr, policies); err != nil {
+		return err
 	}
-	return r.createRBACConfigMap(cm, cr)
+	return r.reconcileStatusRBACPolicy(cr, errs)
 }
 
 // reconcileRBACConfigMap will ensure that the RBAC ConfigMap is syncronized with the given ArgoCD.
-func (r *ReconcileArgoCD) reconcileRBACConfigMap(cm *corev1.ConfigMap, cr *argoproj.ArgoCD) error {
+func (r *ReconcileArgoCD) reconcileRBACConfigMap(cm *corev1.ConfigMap, cr *argoproj.ArgoCD, policies map[string]string) error {
 	changed := false
-	// Policy CSV
-	if cr.Spec.RBAC.Policy != nil && cm.Data[common.ArgoCDKeyRBACPolicyCSV] != *cr.Spec.RBAC.Policy {
-		cm.Data[common.ArgoCDKeyRBACPolicyCSV] = *cr.Spec.RBAC.Policy
+	if cm.Data == nil {
+		cm.Data = make(map[string]string)
+	}
+
+	// Policy CSV, only managed when the policy or the roles of the ArgoCD are set
+	if (cr.Spec.RBAC.Policy != nil || len(cr.Spec.RBAC.Roles) > 0) && cm.Data[common.ArgoCDKeyRBACPolicyCSV] != policies[common.ArgoCDKeyRBACPolicyCSV] {
+		cm.Data[common.ArgoCDKeyRBACPolicyCSV] = policies[common.ArgoCDKeyRBACPolicyCSV]
+		changed = true
+	}
+
+	// Policy CSV overlays
+	if reconcileRBACPolicyOverlays(cm, policies) {
 		changed = true
 	}
 
@@ -587,6 +648,11 @@ func (r *ReconcileArgoCD) reconcileRedisHAConfigMap(cr *argoproj.ArgoCD, useTLSF
 			// ConfigMap exists but HA enabled flag has been set to false, delete the ConfigMap
 			return r.Client.Delete(context.TODO(), cm)
 		}
+		// The topology of Redis HA is rendered into the ConfigMap, keep it up to date
+		if data := getRedisHAConfigMapData(cr, useTLSForRedis, r.getRedisHAServedReplicas(cr)); !reflect.DeepEqual(cm.Data, data) {
+			cm.Data = data
+			return r.Client.Update(context.TODO(), cm)
+		}
 		return nil // ConfigMap found with nothing changed, move along...
 	}
 
@@ -594,13 +660,7 @@ func (r *ReconcileArgoCD) reconcileRedisHAConfigMap(cr *argoproj.ArgoCD, useTLSF
 		return nil // HA not enabled, do nothing.
 	}
 
-	cm.Data = map[string]string{
-		"haproxy.cfg":     getRedisHAProxyConfig(cr, useTLSForRedis),
-		"haproxy_init.sh": getRedisHAProxyScript(cr),
-		"init.sh":         getRedisInitScript(cr, useTLSForRedis),
-		"redis.conf":      getRedisConf(useTLSForRedis),
-		"sentinel.conf":   getRedisSentinelConf(useTLSForRedis),
-	}
+	cm.Data = getRedisHAConfigMapData(cr, useTLSForRedis, r.getRedisHAServedReplicas(cr))
 
 	if err := controllerutil.SetControllerReference(cr, cm, r.Scheme); err != nil {
 		return err
@@ -608,6 +668,18 @@ func (r *ReconcileArgoCD) reconcileRedisHAConfigMap(cr *argoproj.ArgoCD, useTLSF
 	return r.Client.Create(context.TODO(), cm)
 }
 
+// getRedisHAConfigMapData returns the content of the Redis HA ConfigMap for the given ArgoCD, with HAProxy serving the
+// given number of Redis HA servers.
+func getRedisHAConfigMapData(cr *argoproj.ArgoCD, useTLSForRedis bool, servers int32) map[string]string {
+	return map[string]string{
+		"haproxy.cfg":     getRedisHAProxyConfig(cr, useTLSForRedis, servers),
+		"haproxy_init.sh": getRedisHAProxyScript(cr, servers),
+		"init.sh":         getRedisInitScript(cr, useTLSForRedis),
+		"redis.conf":      getRedisConf(cr, useTLSForRedis),
+		"sentinel.conf":   getRedisSentinelConf(useTLSForRedis),
+	}
+}
+
 func (r *ReconcileArgoCD) recreateRedisHAConfigMap(cr *argoproj.ArgoCD, useTLSForRedis bool) error {
 	cm := newConfigMapWithName(common.ArgoCDRedisHAConfigMapName, cr)
 	if argoutil.IsObjectFound(r.Client, cr.Namespace, cm.Name, cm) {
@@ -628,27 +700,65 @@ func (r *ReconcileArgoCD) recreateRedisHAHealthConfigMap(cr *argoproj.ArgoCD, us
 	return r.reconcileRedisHAHealthConfigMap(cr, useTLSForRedis)
 }
 
-// reconcileSSHKnownHosts will ensure that the ArgoCD SSH Known Hosts ConfigMap is present.
+// reconcileSSHKnownHosts will ensure that the ArgoCD SSH Known Hosts ConfigMap is present, and kept in sync with
+// the given ArgoCD when requested.
 func (r *ReconcileArgoCD) reconcileSSHKnownHosts(cr *argoproj.ArgoCD) error {
 	cm := newConfigMapWithName(common.ArgoCDKnownHostsConfigMapName, cr)
-	if argoutil.IsObjectFound(r.Client, cr.Namespace, cm.Name, cm) {
+	exists := argoutil.IsObjectFound(r.Client, cr.Namespace, cm.Name, cm)
+	if exists && !isSSHKnownHostsReconciled(cr) {
 		return nil // ConfigMap found, move along...
 	}
 
-	cm.Data = map[string]string{
-		common.ArgoCDKeySSHKnownHosts: getInitialSSHKnownHosts(cr),
+	skh, err := r.getSSHKnownHosts(cr)
+	if err != nil {
+		return err
+	}
+	data := map[string]string{
+		common.ArgoCDKeySSHKnownHosts: skh,
 	}
 
+	if exists {
+		if reflect.DeepEqual(cm.Data, data) {
+			return nil
+		}
+		cm.Data = data
+		return r.Client.Update(context.TODO(), cm)
+	}
+
+	cm.Data = data
+
 	if err := controllerutil.SetControllerReference(cr, cm, r.Scheme); err != nil {
 		return err
 	}
 	return r.Client.Create(context.TODO(), cm)
 }
 
-// reconcileTLSCerts will ensure that the ArgoCD TLS Certs ConfigMap is present.
+// reconcileTLSCerts will ensure that the ArgoCD TLS Certs ConfigMap is present, and kept in sync with the given
+// ArgoCD when requested.
 func (r *ReconcileArgoCD) reconcileTLSCerts(cr *argoproj.ArgoCD) error {
 	cm := newConfigMapWithName(common.ArgoCDTLSCertsConfigMapName, cr)
-	if argoutil.IsObjectFound(r.Client, cr.Namespace, cm.Name, cm) {
+	exists := argoutil.IsObjectFound(r.Client, cr.Namespace, cm.Name, cm)
+
+	if isTLSCertsReconciled(cr) {
+		certs, err := r.getTLSCerts(cr)
+		if err != nil {
+			return err
+		}
+		if exists {
+			if reflect.DeepEqual(cm.Data, certs) || (len(cm.Data) == 0 && len(certs) == 0) {
+				return nil
+			}
+			cm.Data = certs
+			return r.Client.Update(context.TODO(), cm)
+		}
+		cm.Data = certs
+		if err := controllerutil.SetControllerReference(cr, cm, r.Scheme); err != nil {
+			return err
+		}
+		return r.Client.Create(context.TODO(), cm)
+	}
+
+	if exists {
 		return nil // ConfigMap found, move along...
 	}
 
@@ -659,15 +769,3 @@ func (r *ReconcileArgoCD) reconcileTLSCerts(cr *argoproj.ArgoCD) error {
 	}
 	return r.Client.Create(context.TODO(), cm)
 }
-
-// reconcileGPGKeysConfigMap creates a gpg-keys config map
-func (r *ReconcileArgoCD) reconcileGPGKeysConfigMap(cr *argoproj.ArgoCD) error {
-	cm := newConfigMapWithName(common.ArgoCDGPGKeysConfigMapName, cr)
-	if argoutil.IsObjectFound(r.Client, cr.Namespace, cm.Name, cm) {
-		return nil
-	}
-	if err := controllerutil.SetControllerReference(cr, cm, r.Scheme); err != nil {
-		return err
-	}
-	return r.Client.Create(context.TODO(), cm)
-}
@@ -12,6 +12,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
@@ -228,3 +229,218 @@ func (r *ReconcileArgoCD) applicationSetSCMTLSConfigMapMapper(ctx context.Contex
 
 	return result
 }
+
+// rbacFragmentConfigMapMapper maps a watch event on a configmap labelled as an RBAC policy fragment in a managed
+// namespace, back to the ArgoCD object that we want to reconcile. The label is checked by the predicate of the watch,
+// since the configmap of an update that removed the label is not labelled anymore.
+func (r *ReconcileArgoCD) rbacFragmentConfigMapMapper(ctx context.Context, o client.Object) []reconcile.Request {
+	var result = []reconcile.Request{}
+
+	ns := &corev1.Namespace{}
+	if err := r.Client.Get(ctx, types.NamespacedName{Name: o.GetNamespace()}, ns); err != nil {
+		return result
+	}
+
+	owner, ok := ns.Labels[common.ArgoCDManagedByLabel]
+	if !ok || owner == ns.Name {
+		return result
+	}
+
+	argocds := &argoproj.ArgoCDList{}
+	if err := r.Client.List(ctx, argocds, &client.ListOptions{Namespace: owner}); err != nil {
+		return result
+	}
+
+	if len(argocds.Items) != 1 {
+		return result
+	}
+
+	argocd := argocds.Items[0]
+	if argocd.Spec.RBAC.Fragments == nil || !argocd.Spec.RBAC.Fragments.Enabled {
+		return result
+	}
+
+	namespacedName := client.ObjectKey{
+		Name:      argocd.Name,
+		Namespace: argocd.Namespace,
+	}
+	result = []reconcile.Request{
+		{NamespacedName: namespacedName},
+	}
+
+	return result
+}
+
+// trustSourceMapper maps a watch event on a configmap or secret labelled as a source of SSH known hosts or TLS
+// certificates, back to the ArgoCD objects that aggregate it.
+func (r *ReconcileArgoCD) trustSourceMapper(ctx context.Context, o client.Object) []reconcile.Request {
+	var result = []reconcile.Request{}
+
+	_, sshKnownHosts := o.GetLabels()[common.ArgoCDSSHKnownHostsSourceLabel]
+	_, tlsCerts := o.GetLabels()[common.ArgoCDTLSCertsSourceLabel]
+	if !sshKnownHosts && !tlsCerts {
+		return result
+	}
+
+	// the source may belong to an instance in its own namespace, or to the instance managing its namespace
+	namespaces := []string{o.GetNamespace()}
+	ns := &corev1.Namespace{}
+	if err := r.Client.Get(ctx, types.NamespacedName{Name: o.GetNamespace()}, ns); err == nil {
+		if owner, ok := ns.Labels[common.ArgoCDManagedByLabel]; ok && owner != "" && owner != ns.Name {
+			namespaces = append(namespaces, owner)
+		}
+	}
+
+	for _, namespace := range namespaces {
+		argocds := &argoproj.ArgoCDList{}
+		if err := r.Client.List(ctx, argocds, &client.ListOptions{Namespace: namespace}); err != nil {
+			continue
+		}
+		for _, argocd := range argocds.Items {
+			if (sshKnownHosts && argocd.Spec.InitialSSHKnownHosts.AggregateFromNamespaces &&
+				isTrustSourceNamespace(&argocd, argocd.Spec.InitialSSHKnownHosts.SourceNamespaces, o.GetNamespace())) ||
+				(tlsCerts && argocd.Spec.TLS.AggregateCertsFromNamespaces &&
+					isTrustSourceNamespace(&argocd, argocd.Spec.TLS.CertsSourceNamespaces, o.GetNamespace())) {
+				result = append(result, reconcile.Request{
+					NamespacedName: client.ObjectKey{Name: argocd.Name, Namespace: argocd.Namespace},
+				})
+			}
+		}
+	}
+
+	return result
+}
+
+// trustedCABundleConfigMapMapper maps a watch event on the configmap holding the trusted CA bundle of an ArgoCD,
+// back to that ArgoCD, so that its components are rolled out and its TLS certs are rendered with the new bundle.
+func (r *ReconcileArgoCD) trustedCABundleConfigMapMapper(ctx context.Context, o client.Object) []reconcile.Request {
+	var result = []reconcile.Request{}
+
+	argocds := &argoproj.ArgoCDList{}
+	if err := r.Client.List(ctx, argocds, &client.ListOptions{Namespace: o.GetNamespace()}); err != nil {
+		return result
+	}
+
+	for i := range argocds.Items {
+		argocd := &argocds.Items[i]
+		if isTrustedCABundleConfigMapOf(argocd, o) {
+			result = append(result, reconcile.Request{
+				NamespacedName: client.ObjectKey{Name: argocd.Name, Namespace: argocd.Namespace},
+			})
+		}
+	}
+
+	return result
+}
+
+// isTrustedCABundleConfigMapOf returns whether the given object is the configmap holding the trusted CA bundle of the
+// given ArgoCD.
+func isTrustedCABundleConfigMapOf(cr *argoproj.ArgoCD, o client.Object) bool {
+	return isTrustedCABundleUsed(cr) && getTrustedCABundleConfigMapName(cr) == o.GetName()
+}
+
+// isTrustedCABundleConfigMap returns whether the given configmap holds the trusted CA bundle of an ArgoCD of its
+// namespace. It is used as the predicate of the watch, so that other configmaps are not mapped.
+func (r *ReconcileArgoCD) isTrustedCABundleConfigMap(o client.Object) bool {
+	return r.hasArgoCD(o.GetNamespace(), func(cr *argoproj.ArgoCD) bool {
+		return isTrustedCABundleConfigMapOf(cr, o)
+	})
+}
+
+// hasArgoCD returns whether the given namespace holds an ArgoCD matching the given function.
+func (r *ReconcileArgoCD) hasArgoCD(namespace string, match func(cr *argoproj.ArgoCD) bool) bool {
+	argocds := &argoproj.ArgoCDList{}
+	if err := r.Client.List(context.TODO(), argocds, &client.ListOptions{Namespace: namespace}); err != nil {
+		return false
+	}
+	for i := range argocds.Items {
+		if match(&argocds.Items[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+// referencedObjectMapper maps a watch event on a configmap or secret back to the ArgoCD objects of its namespace that
+// reference it in their spec, so that rotated credentials and keys are picked up.
+func (r *ReconcileArgoCD) referencedObjectMapper(ctx context.Context, o client.Object) []reconcile.Request {
+	var result = []reconcile.Request{}
+
+	argocds := &argoproj.ArgoCDList{}
+	if err := r.Client.List(ctx, argocds, &client.ListOptions{Namespace: o.GetNamespace()}); err != nil {
+		return result
+	}
+
+	for i := range argocds.Items {
+		argocd := &argocds.Items[i]
+		if isReferencedObjectOf(argocd, o) {
+			result = append(result, reconcile.Request{
+				NamespacedName: client.ObjectKey{Name: argocd.Name, Namespace: argocd.Namespace},
+			})
+		}
+	}
+
+	return result
+}
+
+// isReferencedObjectOf returns whether the given configmap or secret is referenced by the spec of the given ArgoCD.
+func isReferencedObjectOf(cr *argoproj.ArgoCD, o client.Object) bool {
+	switch o.(type) {
+	case *corev1.ConfigMap:
+		return containsString(getReferencedConfigMapNames(cr), o.GetName())
+	case *corev1.Secret:
+		return containsString(getReferencedSecretNames(cr), o.GetName())
+	default:
+		return false
+	}
+}
+
+// isReferencedObject returns whether the given configmap or secret is referenced by the spec of an ArgoCD of its
+// namespace. It is used as the predicate of the watch, so that other configmaps and secrets are not mapped.
+func (r *ReconcileArgoCD) isReferencedObject(o client.Object) bool {
+	return r.hasArgoCD(o.GetNamespace(), func(cr *argoproj.ArgoCD) bool {
+		return isReferencedObjectOf(cr, o)
+	})
+}
+
+// getReferencedConfigMapNames will return the names of the configmaps in the namespace of the given ArgoCD that are
+// referenced by its spec, and are read by the operator when reconciling it.
+func getReferencedConfigMapNames(cr *argoproj.ArgoCD) []string {
+	names := []string{}
+	for _, source := range cr.Spec.GPGKeys {
+		if source.ConfigMapRef != nil {
+			names = append(names, source.ConfigMapRef.Name)
+		}
+	}
+	return names
+}
+
+// getReferencedSecretNames will return the names of the secrets in the namespace of the given ArgoCD that are
+// referenced by its spec, and are read by the operator when reconciling it.
+func getReferencedSecretNames(cr *argoproj.ArgoCD) []string {
+	names := []string{}
+	if UseExternalOIDC(cr) && cr.Spec.SSO.OIDC.ClientSecretRef != nil {
+		names = append(names, cr.Spec.SSO.OIDC.ClientSecretRef.Name)
+	}
+	if UseDex(cr) {
+		for _, connector := range getDexConnectors(cr) {
+			for _, ref := range getDexConnectorSecretRefs(connector) {
+				names = append(names, ref.Name)
+			}
+		}
+	}
+	if cr.Spec.SSO != nil && cr.Spec.SSO.Provider.ToLower() == argoproj.SSOProviderTypeKeycloak &&
+		cr.Spec.SSO.Keycloak != nil && cr.Spec.SSO.Keycloak.Realm != nil {
+		for _, idp := range cr.Spec.SSO.Keycloak.Realm.IdentityProviders {
+			if idp.ClientSecretRef != nil {
+				names = append(names, idp.ClientSecretRef.Name)
+			}
+		}
+	}
+	for _, source := range cr.Spec.GPGKeys {
+		if source.SecretRef != nil {
+			names = append(names, source.SecretRef.Name)
+		}
+	}
+	return names
+}
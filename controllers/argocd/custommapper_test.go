@@ -942,3 +942,219 @@ func TestReconcileArgoCD_namespaceResourceMapperForWildCardNamespaceWithoutManag
 		})
 	}
 }
+
+func TestReconcileArgoCD_rbacFragmentConfigMapMapper(t *testing.T) {
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.RBAC.Fragments = &argoproj.ArgoCDRBACFragmentsSpec{Enabled: true}
+	})
+	managed := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "team-a",
+			Labels: map[string]string{common.ArgoCDManagedByLabel: a.Namespace},
+		},
+	}
+	unmanaged := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: "team-b"},
+	}
+
+	resObjs := []client.Object{a, managed, unmanaged}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	fragment := func(namespace string, labels map[string]string) client.Object {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "rbac", Namespace: namespace, Labels: labels},
+		}
+	}
+	labels := map[string]string{common.ArgoCDRBACFragmentLabel: "true"}
+
+	type test struct {
+		name string
+		o    client.Object
+		want []reconcile.Request
+	}
+
+	tests := []test{
+		{
+			name: "test when fragment is in a managed namespace",
+			o:    fragment(managed.Name, labels),
+			want: []reconcile.Request{
+				{
+					NamespacedName: types.NamespacedName{
+						Name:      a.Name,
+						Namespace: a.Namespace,
+					},
+				},
+			},
+		},
+		{
+			name: "test when fragment is in an unmanaged namespace",
+			o:    fragment(unmanaged.Name, labels),
+			want: []reconcile.Request{},
+		},
+		{
+			name: "test when the fragment label was removed",
+			o:    fragment(managed.Name, nil),
+			want: []reconcile.Request{
+				{
+					NamespacedName: types.NamespacedName{
+						Name:      a.Name,
+						Namespace: a.Namespace,
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := r.rbacFragmentConfigMapMapper(context.TODO(), tt.o); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReconcileArgoCD.rbacFragmentConfigMapMapper(), got = %v, want = %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReconcileArgoCD_referencedObjectMapper(t *testing.T) {
+	a := makeTestOIDCArgoCD("https://idp.example.com", "")
+	a.Spec.GPGKeys = []argoproj.ArgoCDGPGKeySpec{
+		{ConfigMapRef: &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "team-keys"}, Key: "keys.asc"}},
+		{SecretRef: secretKeySelector("release-keys", "keys.asc")},
+	}
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	secret := func(name, namespace string) client.Object {
+		return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}
+	}
+	configMap := func(name, namespace string) client.Object {
+		return &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}
+	}
+	want := []reconcile.Request{
+		{
+			NamespacedName: types.NamespacedName{
+				Name:      a.Name,
+				Namespace: a.Namespace,
+			},
+		},
+	}
+
+	tests := []struct {
+		name string
+		o    client.Object
+		want []reconcile.Request
+	}{
+		{
+			name: "test when the secret is the oidc client secret",
+			o:    secret("oidc", a.Namespace),
+			want: want,
+		},
+		{
+			name: "test when the secret is a gpg key source",
+			o:    secret("release-keys", a.Namespace),
+			want: want,
+		},
+		{
+			name: "test when the configmap is a gpg key source",
+			o:    configMap("team-keys", a.Namespace),
+			want: want,
+		},
+		{
+			name: "test when a configmap has the name of a referenced secret",
+			o:    configMap("release-keys", a.Namespace),
+			want: []reconcile.Request{},
+		},
+		{
+			name: "test when the secret is not referenced",
+			o:    secret("other", a.Namespace),
+			want: []reconcile.Request{},
+		},
+		{
+			name: "test when the secret is in another namespace",
+			o:    secret("oidc", "other"),
+			want: []reconcile.Request{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := r.referencedObjectMapper(context.TODO(), tt.o); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReconcileArgoCD.referencedObjectMapper(), got = %v, want = %v", got, tt.want)
+			}
+			if got := r.isReferencedObject(tt.o); got != (len(tt.want) > 0) {
+				t.Errorf("ReconcileArgoCD.isReferencedObject(), got = %v, want = %v", got, len(tt.want) > 0)
+			}
+		})
+	}
+}
+
+func TestReconcileArgoCD_referencedObjectMapper_dexConnectors(t *testing.T) {
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.SSO = &argoproj.ArgoCDSSOSpec{
+			Provider: argoproj.SSOProviderTypeDex,
+			Dex: &argoproj.ArgoCDDexSpec{
+				Connectors: []argoproj.ArgoCDDexConnector{
+					{ID: "github", Name: "GitHub", GitHub: &argoproj.ArgoCDDexGitHubConnector{
+						ArgoCDDexOAuthClient: argoproj.ArgoCDDexOAuthClient{ClientID: "id", ClientSecretRef: *secretKeySelector("github-secret", "clientSecret")},
+					}},
+					{ID: "ldap", Name: "LDAP", LDAP: &argoproj.ArgoCDDexLDAPConnector{
+						Host:      "ldap.example.com:636",
+						BindPWRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "ldap-secret"}, Key: "bindPW"},
+					}},
+				},
+			},
+		}
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	want := []reconcile.Request{{NamespacedName: types.NamespacedName{Name: a.Name, Namespace: a.Namespace}}}
+	for _, name := range []string{"github-secret", "ldap-secret"} {
+		secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: a.Namespace}}
+		assert.Equal(t, want, r.referencedObjectMapper(context.TODO(), secret))
+	}
+	other := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: a.Namespace}}
+	assert.Empty(t, r.referencedObjectMapper(context.TODO(), other))
+}
+
+func TestReconcileArgoCD_referencedObjectMapper_keycloakIdentityProviders(t *testing.T) {
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.SSO = &argoproj.ArgoCDSSOSpec{
+			Provider: argoproj.SSOProviderTypeKeycloak,
+			Keycloak: &argoproj.ArgoCDKeycloakSpec{
+				Realm: &argoproj.ArgoCDKeycloakRealmSpec{
+					IdentityProviders: []argoproj.ArgoCDKeycloakIdentityProvider{
+						{Alias: "github", ProviderID: "github", ClientSecretRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "github-idp"}, Key: "clientSecret"}},
+						{Alias: "google", ProviderID: "google"},
+					},
+				},
+			},
+		}
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "github-idp", Namespace: a.Namespace}}
+	assert.Equal(t, []reconcile.Request{{NamespacedName: types.NamespacedName{Name: a.Name, Namespace: a.Namespace}}},
+		r.referencedObjectMapper(context.TODO(), secret))
+	other := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: a.Namespace}}
+	assert.Empty(t, r.referencedObjectMapper(context.TODO(), other))
+}
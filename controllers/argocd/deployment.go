@@ -207,25 +207,6 @@ func getArgoImportVolumes(cr *argoprojv1alpha1.ArgoCDExport) []corev1.Volume {
 	return volumes
 }
 
-func getArgoRedisArgs(useTLS bool) []string {
-	args := make([]string, 0)
-
-	args = append(args, "--save", "")
-	args = append(args, "--appendonly", "no")
-	args = append(args, "--requirepass $(REDIS_PASSWORD)")
-
-	if useTLS {
-		args = append(args, "--tls-port", "6379")
-		args = append(args, "--port", "0")
-
-		args = append(args, "--tls-cert-file", "/app/config/redis/tls/tls.crt")
-		args = append(args, "--tls-key-file", "/app/config/redis/tls/tls.key")
-		args = append(args, "--tls-auth-clients", "no")
-	}
-
-	return args
-}
-
 // getArgoRepoCommand will return the command for the ArgoCD Repo component.
 func getArgoRepoCommand(cr *argoproj.ArgoCD, useTLSForRedis bool) []string {
 	cmd := make([]string, 0)
@@ -234,18 +215,12 @@ func getArgoRepoCommand(cr *argoproj.ArgoCD, useTLSForRedis bool) []string {
 	cmd = append(cmd, "argocd-repo-server")
 
 	if cr.Spec.Redis.IsEnabled() {
-		cmd = append(cmd, "--redis", getRedisServerAddress(cr))
+		cmd = append(cmd, getRedisCommandArgs(cr, useTLSForRedis, "/app/config/reposerver/tls")...)
 	} else {
 		log.Info("Redis is Disabled. Skipping adding Redis configuration to Repo Server.")
 	}
-	if useTLSForRedis {
-		cmd = append(cmd, "--redis-use-tls")
-		if isRedisTLSVerificationDisabled(cr) {
-			cmd = append(cmd, "--redis-insecure-skip-tls-verify")
-		} else {
-			cmd = append(cmd, "--redis-ca-certificate", "/app/config/reposerver/tls/redis/tls.crt")
-		}
-	}
+
+	cmd = append(cmd, getTracingCommandArgs(cr)...)
 
 	cmd = append(cmd, "--loglevel")
 	cmd = append(cmd, getLogLevel(cr.Spec.Repo.LogLevel))
@@ -301,19 +276,12 @@ func getArgoServerCommand(cr *argoproj.ArgoCD, useTLSForRedis bool) []string {
 	}
 
 	if cr.Spec.Redis.IsEnabled() {
-		cmd = append(cmd, "--redis", getRedisServerAddress(cr))
+		cmd = append(cmd, getRedisCommandArgs(cr, useTLSForRedis, "/app/config/server/tls")...)
 	} else {
 		log.Info("Redis is Disabled. Skipping adding Redis configuration to ArgoCD Server.")
 	}
 
-	if useTLSForRedis {
-		cmd = append(cmd, "--redis-use-tls")
-		if isRedisTLSVerificationDisabled(cr) {
-			cmd = append(cmd, "--redis-insecure-skip-tls-verify")
-		} else {
-			cmd = append(cmd, "--redis-ca-certificate", "/app/config/server/tls/redis/tls.crt")
-		}
-	}
+	cmd = append(cmd, getTracingCommandArgs(cr)...)
 
 	cmd = append(cmd, "--loglevel")
 	cmd = append(cmd, getLogLevel(cr.Spec.Server.LogLevel))
@@ -421,6 +389,10 @@ func (r *ReconcileArgoCD) reconcileDeployments(cr *argoproj.ArgoCD, useTLSForRed
 		log.Error(err, "error reconciling dex deployment")
 	}
 
+	if err := r.reconcileRedisPersistentVolumeClaim(cr); err != nil {
+		return err
+	}
+
 	err := r.reconcileRedisDeployment(cr, useTLSForRedis)
 	if err != nil {
 		return err
@@ -478,7 +450,7 @@ func (r *ReconcileArgoCD) reconcileRedisDeployment(cr *argoproj.ArgoCD, useTLS b
 	AddSeccompProfileForOpenShift(r.Client, &deploy.Spec.Template.Spec)
 
 	deploy.Spec.Template.Spec.Containers = []corev1.Container{{
-		Args:            getArgoRedisArgs(useTLS),
+		Args:            getArgoRedisArgs(cr, useTLS),
 		Image:           getRedisContainerImage(cr),
 		ImagePullPolicy: corev1.PullAlways,
 		Name:            "redis",
@@ -523,6 +495,31 @@ func (r *ReconcileArgoCD) reconcileRedisDeployment(cr *argoproj.ArgoCD, useTLS b
 		},
 	}
 
+	if isRedisExporterEnabled(cr) {
+		deploy.Spec.Template.Spec.Containers = append(deploy.Spec.Template.Spec.Containers, getRedisExporterContainer(cr, useTLS))
+	}
+
+	if getRedisPersistence(cr) != nil {
+		// The data volume can only be attached to a single pod, so the old pod must be gone before the new one starts.
+		deploy.Spec.Strategy = appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType}
+		if deploy.Spec.Template.Spec.SecurityContext == nil {
+			deploy.Spec.Template.Spec.SecurityContext = &corev1.PodSecurityContext{}
+		}
+		deploy.Spec.Template.Spec.SecurityContext.FSGroup = int64Ptr(999)
+		deploy.Spec.Template.Spec.Containers[0].VolumeMounts = append(deploy.Spec.Template.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{
+			Name:      "data",
+			MountPath: redisDataDir,
+		})
+		deploy.Spec.Template.Spec.Volumes = append(deploy.Spec.Template.Spec.Volumes, corev1.Volume{
+			Name: "data",
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: getRedisPersistentVolumeClaimName(cr),
+				},
+			},
+		})
+	}
+
 	if err := applyReconcilerHook(cr, deploy, ""); err != nil {
 		return err
 	}
@@ -569,13 +566,45 @@ func (r *ReconcileArgoCD) reconcileRedisDeployment(cr *argoproj.ArgoCD, useTLS b
 			changed = true
 		}
 
+		if isRedisExporterContainerChanged(deploy.Spec.Template.Spec.Containers[1:], existing.Spec.Template.Spec.Containers[1:]) {
+			existing.Spec.Template.Spec.Containers = append(existing.Spec.Template.Spec.Containers[0:1],
+				deploy.Spec.Template.Spec.Containers[1:]...)
+			changed = true
+		}
+
+		// Only the data volume is compared, since the API server defaults the fields of the other volumes.
+		desiredVolume, desiredMount := getRedisDataVolume(deploy)
+		existingVolume, existingMount := getRedisDataVolume(existing)
+		if !reflect.DeepEqual(desiredVolume, existingVolume) || !reflect.DeepEqual(desiredMount, existingMount) {
+			setRedisDataVolume(existing, desiredVolume, desiredMount)
+			changed = true
+		}
+
+		if !reflect.DeepEqual(getPodFSGroup(&deploy.Spec.Template.Spec), getPodFSGroup(&existing.Spec.Template.Spec)) {
+			if existing.Spec.Template.Spec.SecurityContext == nil {
+				existing.Spec.Template.Spec.SecurityContext = &corev1.PodSecurityContext{}
+			}
+			existing.Spec.Template.Spec.SecurityContext.FSGroup = getPodFSGroup(&deploy.Spec.Template.Spec)
+			changed = true
+		}
+
+		// Only the strategy type is compared, since the API server defaults the rolling update parameters.
+		desiredStrategy := deploy.Spec.Strategy.Type
+		if desiredStrategy == "" {
+			desiredStrategy = appsv1.RollingUpdateDeploymentStrategyType
+		}
+		if existing.Spec.Strategy.Type != "" && existing.Spec.Strategy.Type != desiredStrategy {
+			existing.Spec.Strategy = deploy.Spec.Strategy
+			changed = true
+		}
+
 		if changed {
 			return r.Client.Update(context.TODO(), existing)
 		}
 		return nil // Deployment found with nothing to do, move along...
 	}
 
-	if cr.Spec.Redis.IsEnabled() && cr.Spec.Redis.Remote != nil && *cr.Spec.Redis.Remote != "" {
+	if isRedisRemote(cr) {
 		log.Info("Custom Redis Endpoint. Skipping starting redis.")
 		return nil
 	}
@@ -659,7 +688,7 @@ func (r *ReconcileArgoCD) reconcileRedisHAProxyDeployment(cr *argoproj.ArgoCD) e
 				Name:          "redis",
 			},
 		},
-		Resources: getRedisHAResources(cr),
+		Resources: getRedisHAProxyResources(cr),
 		SecurityContext: &corev1.SecurityContext{
 			AllowPrivilegeEscalation: boolPtr(false),
 			Capabilities: &corev1.Capabilities{
@@ -699,7 +728,7 @@ func (r *ReconcileArgoCD) reconcileRedisHAProxyDeployment(cr *argoproj.ArgoCD) e
 		ImagePullPolicy: corev1.PullIfNotPresent,
 		Name:            "config-init",
 		Env:             proxyEnvVars(),
-		Resources:       getRedisHAResources(cr),
+		Resources:       getRedisHAProxyResources(cr),
 		SecurityContext: &corev1.SecurityContext{
 			AllowPrivilegeEscalation: boolPtr(false),
 			Capabilities: &corev1.Capabilities{
@@ -784,6 +813,14 @@ func (r *ReconcileArgoCD) reconcileRedisHAProxyDeployment(cr *argoproj.ArgoCD) e
 
 	deploy.Spec.Template.Spec.ServiceAccountName = fmt.Sprintf("%s-%s", cr.Name, "argocd-redis-ha")
 
+	deploy.Spec.Replicas = getRedisHAProxyReplicas(cr)
+	// HAProxy resolves the Redis HA servers on startup, restart it when the topology changes
+	if checksum := getRedisHAProxyTopologyChecksum(cr, r.getRedisHAServedReplicas(cr)); checksum != "" {
+		deploy.Spec.Template.ObjectMeta.Annotations = map[string]string{
+			redisHATopologyChecksumAnnotation: checksum,
+		}
+	}
+
 	version, err := getClusterVersion(r.Client)
 	if err != nil {
 		log.Error(err, "error getting cluster version")
@@ -829,6 +866,21 @@ func (r *ReconcileArgoCD) reconcileRedisHAProxyDeployment(cr *argoproj.ArgoCD) e
 			changed = true
 		}
 
+		if !reflect.DeepEqual(deploy.Spec.Replicas, existing.Spec.Replicas) {
+			existing.Spec.Replicas = deploy.Spec.Replicas
+			changed = true
+		}
+
+		if existing.Spec.Template.Annotations[redisHATopologyChecksumAnnotation] != deploy.Spec.Template.Annotations[redisHATopologyChecksumAnnotation] {
+			existing.Spec.Template.Annotations = argoutil.AppendStringMap(existing.Spec.Template.Annotations, nil)
+			if checksum := deploy.Spec.Template.Annotations[redisHATopologyChecksumAnnotation]; checksum != "" {
+				existing.Spec.Template.Annotations[redisHATopologyChecksumAnnotation] = checksum
+			} else {
+				delete(existing.Spec.Template.Annotations, redisHATopologyChecksumAnnotation)
+			}
+			changed = true
+		}
+
 		if changed {
 			return r.Client.Update(context.TODO(), existing)
 		}
@@ -861,19 +913,10 @@ func (r *ReconcileArgoCD) reconcileRepoDeployment(cr *argoproj.ArgoCD, useTLSFor
 
 	// Global proxy env vars go first
 	repoEnv := cr.Spec.Repo.Env
-	repoEnv = append(repoEnv, corev1.EnvVar{
-		Name: "REDIS_PASSWORD",
-		ValueFrom: &corev1.EnvVarSource{
-			SecretKeyRef: &corev1.SecretKeySelector{
-				LocalObjectReference: corev1.LocalObjectReference{
-					Name: fmt.Sprintf("%s-%s", cr.Name, "redis-initial-password"),
-				},
-				Key: "admin.password",
-			},
-		},
-	})
+	repoEnv = append(repoEnv, getRedisEnv(cr)...)
 	// Environment specified in the CR take precedence over everything else
 	repoEnv = argoutil.EnvMerge(repoEnv, proxyEnvVars(), false)
+	repoEnv = argoutil.EnvMerge(repoEnv, getTracingEnv(cr), false)
 	if cr.Spec.Repo.ExecTimeout != nil {
 		repoEnv = argoutil.EnvMerge(repoEnv, []corev1.EnvVar{{Name: "ARGOCD_EXEC_TIMEOUT", Value: fmt.Sprintf("%ds", *cr.Spec.Repo.ExecTimeout)}}, true)
 	}
@@ -907,6 +950,8 @@ func (r *ReconcileArgoCD) reconcileRepoDeployment(cr *argoproj.ArgoCD, useTLSFor
 		},
 	}}
 
+	deploy.Spec.Template.Spec.InitContainers = append(deploy.Spec.Template.Spec.InitContainers, getRepoServerToolInitContainers(cr)...)
+
 	if cr.Spec.Repo.InitContainers != nil {
 		deploy.Spec.Template.Spec.InitContainers = append(deploy.Spec.Template.Spec.InitContainers, cr.Spec.Repo.InitContainers...)
 	}
@@ -960,6 +1005,10 @@ func (r *ReconcileArgoCD) reconcileRepoDeployment(cr *argoproj.ArgoCD, useTLSFor
 
 	}
 
+	repoServerVolumeMounts = append(repoServerVolumeMounts, getRedisExternalCAVolumeMounts(cr, "/app/config/reposerver/tls")...)
+	repoServerVolumeMounts = append(repoServerVolumeMounts, r.getTrustedCABundleVolumeMounts(cr)...)
+	repoServerVolumeMounts = append(repoServerVolumeMounts, getRepoServerToolVolumeMounts(cr)...)
+
 	if cr.Spec.Repo.VolumeMounts != nil {
 		repoServerVolumeMounts = append(repoServerVolumeMounts, cr.Spec.Repo.VolumeMounts...)
 	}
@@ -1013,6 +1062,8 @@ func (r *ReconcileArgoCD) reconcileRepoDeployment(cr *argoproj.ArgoCD, useTLSFor
 		VolumeMounts: repoServerVolumeMounts,
 	}}
 
+	deploy.Spec.Template.Spec.Containers = append(deploy.Spec.Template.Spec.Containers, getRepoServerPluginContainers(cr)...)
+
 	if cr.Spec.Repo.SidecarContainers != nil {
 		deploy.Spec.Template.Spec.Containers = append(deploy.Spec.Template.Spec.Containers, cr.Spec.Repo.SidecarContainers...)
 	}
@@ -1096,16 +1147,29 @@ func (r *ReconcileArgoCD) reconcileRepoDeployment(cr *argoproj.ArgoCD, useTLSFor
 		})
 	}
 
+	repoServerVolumes = append(repoServerVolumes, getRedisExternalCAVolumes(cr)...)
+	repoServerVolumes = append(repoServerVolumes, r.getTrustedCABundleVolumes(cr)...)
+	repoServerVolumes = append(repoServerVolumes, getRepoServerPluginVolumes(cr)...)
+	repoServerVolumes = append(repoServerVolumes, getRepoServerToolVolumes(cr)...)
+
 	if cr.Spec.Repo.Volumes != nil {
 		repoServerVolumes = append(repoServerVolumes, cr.Spec.Repo.Volumes...)
 	}
 
 	deploy.Spec.Template.Spec.Volumes = repoServerVolumes
+	setTrustedCABundleChecksum(&deploy.Spec.Template, r.getTrustedCABundleChecksum(cr))
 
 	if replicas := getArgoCDRepoServerReplicas(cr); replicas != nil {
 		deploy.Spec.Replicas = replicas
 	}
 
+	if checksum := getRepoServerPluginsChecksum(cr); checksum != "" {
+		if deploy.Spec.Template.Annotations == nil {
+			deploy.Spec.Template.Annotations = map[string]string{}
+		}
+		deploy.Spec.Template.Annotations[repoServerPluginsChecksumAnnotation] = checksum
+	}
+
 	existing := newDeploymentWithSuffix("repo-server", "repo-server", cr)
 	if argoutil.IsObjectFound(r.Client, cr.Namespace, existing.Name, existing) {
 
@@ -1169,6 +1233,20 @@ func (r *ReconcileArgoCD) reconcileRepoDeployment(cr *argoproj.ArgoCD, useTLSFor
 			existing.Spec.Replicas = deploy.Spec.Replicas
 			changed = true
 		}
+		if checksum := deploy.Spec.Template.Annotations[repoServerPluginsChecksumAnnotation]; checksum != existing.Spec.Template.Annotations[repoServerPluginsChecksumAnnotation] {
+			if checksum == "" {
+				delete(existing.Spec.Template.Annotations, repoServerPluginsChecksumAnnotation)
+			} else {
+				if existing.Spec.Template.Annotations == nil {
+					existing.Spec.Template.Annotations = map[string]string{}
+				}
+				existing.Spec.Template.Annotations[repoServerPluginsChecksumAnnotation] = checksum
+			}
+			changed = true
+		}
+		if updateTrustedCABundleChecksum(&existing.Spec.Template, &deploy.Spec.Template) {
+			changed = true
+		}
 
 		if deploy.Spec.Template.Spec.AutomountServiceAccountToken != existing.Spec.Template.Spec.AutomountServiceAccountToken {
 			existing.Spec.Template.Spec.AutomountServiceAccountToken = deploy.Spec.Template.Spec.AutomountServiceAccountToken
@@ -1202,18 +1280,9 @@ func (r *ReconcileArgoCD) reconcileRepoDeployment(cr *argoproj.ArgoCD, useTLSFor
 func (r *ReconcileArgoCD) reconcileServerDeployment(cr *argoproj.ArgoCD, useTLSForRedis bool) error {
 	deploy := newDeploymentWithSuffix("server", "server", cr)
 	serverEnv := cr.Spec.Server.Env
-	serverEnv = append(serverEnv, corev1.EnvVar{
-		Name: "REDIS_PASSWORD",
-		ValueFrom: &corev1.EnvVarSource{
-			SecretKeyRef: &corev1.SecretKeySelector{
-				LocalObjectReference: corev1.LocalObjectReference{
-					Name: fmt.Sprintf("%s-%s", cr.Name, "redis-initial-password"),
-				},
-				Key: "admin.password",
-			},
-		},
-	})
+	serverEnv = append(serverEnv, getRedisEnv(cr)...)
 	serverEnv = argoutil.EnvMerge(serverEnv, proxyEnvVars(), false)
+	serverEnv = argoutil.EnvMerge(serverEnv, getTracingEnv(cr), false)
 	AddSeccompProfileForOpenShift(r.Client, &deploy.Spec.Template.Spec)
 
 	if cr.Spec.Server.InitContainers != nil {
@@ -1237,6 +1306,8 @@ func (r *ReconcileArgoCD) reconcileServerDeployment(cr *argoproj.ArgoCD, useTLSF
 			MountPath: "/app/config/server/tls/redis",
 		},
 	}
+	serverVolumeMounts = append(serverVolumeMounts, getRedisExternalCAVolumeMounts(cr, "/app/config/server/tls")...)
+	serverVolumeMounts = append(serverVolumeMounts, r.getTrustedCABundleVolumeMounts(cr)...)
 
 	if cr.Spec.Server.VolumeMounts != nil {
 		serverVolumeMounts = append(serverVolumeMounts, cr.Spec.Server.VolumeMounts...)
@@ -1314,13 +1385,8 @@ func (r *ReconcileArgoCD) reconcileServerDeployment(cr *argoproj.ArgoCD, useTLSF
 			},
 		},
 		{
-			Name: "argocd-repo-server-tls",
-			VolumeSource: corev1.VolumeSource{
-				Secret: &corev1.SecretVolumeSource{
-					SecretName: common.ArgoCDRepoServerTLSSecretName,
-					Optional:   boolPtr(true),
-				},
-			},
+			Name:         "argocd-repo-server-tls",
+			VolumeSource: getRepoServerTLSVolumeSource(cr),
 		},
 		{
 			Name: common.ArgoCDRedisServerTLSSecretName,
@@ -1333,11 +1399,15 @@ func (r *ReconcileArgoCD) reconcileServerDeployment(cr *argoproj.ArgoCD, useTLSF
 		},
 	}
 
+	serverVolumes = append(serverVolumes, getRedisExternalCAVolumes(cr)...)
+	serverVolumes = append(serverVolumes, r.getTrustedCABundleVolumes(cr)...)
+
 	if cr.Spec.Server.Volumes != nil {
 		serverVolumes = append(serverVolumes, cr.Spec.Server.Volumes...)
 	}
 
 	deploy.Spec.Template.Spec.Volumes = serverVolumes
+	setTrustedCABundleChecksum(&deploy.Spec.Template, r.getTrustedCABundleChecksum(cr))
 
 	if replicas := getArgoCDServerReplicas(cr); replicas != nil {
 		deploy.Spec.Replicas = replicas
@@ -1402,6 +1472,9 @@ func (r *ReconcileArgoCD) reconcileServerDeployment(cr *argoproj.ArgoCD, useTLSF
 				deploy.Spec.Template.Spec.Containers[1:]...)
 			changed = true
 		}
+		if updateTrustedCABundleChecksum(&existing.Spec.Template, &deploy.Spec.Template) {
+			changed = true
+		}
 		if !reflect.DeepEqual(deploy.Spec.Replicas, existing.Spec.Replicas) {
 			if !cr.Spec.Server.Autoscale.Enabled {
 				existing.Spec.Replicas = deploy.Spec.Replicas
@@ -99,15 +99,9 @@ func (r *ReconcileArgoCD) getDexOAuthClientSecret(cr *argoproj.ArgoCD) (*string,
 // reconcileDexConfiguration will ensure that Dex is configured properly.
 func (r *ReconcileArgoCD) reconcileDexConfiguration(cm *corev1.ConfigMap, cr *argoproj.ArgoCD) error {
 	actual := cm.Data[common.ArgoCDKeyDexConfig]
-	desired := getDexConfig(cr)
-
-	// Append the default OpenShift dex config if the openShiftOAuth is requested through `.spec.sso.dex`.
-	if cr.Spec.SSO != nil && cr.Spec.SSO.Dex != nil && cr.Spec.SSO.Dex.OpenShiftOAuth {
-		cfg, err := r.getOpenShiftDexConfig(cr)
-		if err != nil {
-			return err
-		}
-		desired = cfg
+	desired, err := r.getDesiredDexConfig(cr, actual)
+	if err != nil {
+		return err
 	}
 
 	if actual != desired {
@@ -318,6 +312,9 @@ func (r *ReconcileArgoCD) reconcileDexDeployment(cr *argoproj.ArgoCD) error {
 			EmptyDir: &corev1.EmptyDirVolumeSource{},
 		},
 	}}
+	deploy.Spec.Template.Spec.Volumes = append(deploy.Spec.Template.Spec.Volumes, r.getTrustedCABundleVolumes(cr)...)
+	deploy.Spec.Template.Spec.Containers[0].VolumeMounts = append(deploy.Spec.Template.Spec.Containers[0].VolumeMounts, r.getTrustedCABundleVolumeMounts(cr)...)
+	setTrustedCABundleChecksum(&deploy.Spec.Template, r.getTrustedCABundleChecksum(cr))
 
 	existing := newDeploymentWithSuffix("dex-server", "dex-server", cr)
 	if argoutil.IsObjectFound(r.Client, cr.Namespace, existing.Name, existing) {
@@ -372,6 +369,20 @@ func (r *ReconcileArgoCD) reconcileDexDeployment(cr *argoproj.ArgoCD) error {
 			changed = true
 		}
 
+		if !reflect.DeepEqual(deploy.Spec.Template.Spec.Volumes, existing.Spec.Template.Spec.Volumes) {
+			existing.Spec.Template.Spec.Volumes = deploy.Spec.Template.Spec.Volumes
+			changed = true
+		}
+
+		if !reflect.DeepEqual(deploy.Spec.Template.Spec.Containers[0].VolumeMounts, existing.Spec.Template.Spec.Containers[0].VolumeMounts) {
+			existing.Spec.Template.Spec.Containers[0].VolumeMounts = deploy.Spec.Template.Spec.Containers[0].VolumeMounts
+			changed = true
+		}
+
+		if updateTrustedCABundleChecksum(&existing.Spec.Template, &deploy.Spec.Template) {
+			changed = true
+		}
+
 		if changed {
 			return r.Client.Update(context.TODO(), existing)
 		}
@@ -435,6 +446,45 @@ func (r *ReconcileArgoCD) reconcileDexService(cr *argoproj.ArgoCD) error {
 	return r.Client.Create(context.TODO(), svc)
 }
 
+// reconcileDexMetricsService will ensure that the Service for the Dex metrics is present.
+func (r *ReconcileArgoCD) reconcileDexMetricsService(cr *argoproj.ArgoCD) error {
+	svc := newServiceWithSuffix("dex-server-metrics", "dex-server", cr)
+	svc.Spec.Selector = map[string]string{
+		common.ArgoCDKeyName: nameWithSuffix("dex-server", cr),
+	}
+	svc.Spec.Ports = []corev1.ServicePort{
+		{
+			Name:       common.ArgoCDKeyMetrics,
+			Port:       common.ArgoCDDefaultDexMetricsPort,
+			Protocol:   corev1.ProtocolTCP,
+			TargetPort: intstr.FromInt(common.ArgoCDDefaultDexMetricsPort),
+		},
+	}
+
+	existing := newServiceWithSuffix("dex-server-metrics", "dex-server", cr)
+	if argoutil.IsObjectFound(r.Client, cr.Namespace, existing.Name, existing) {
+		if !UseDex(cr) {
+			log.Info("deleting the existing Dex metrics service because dex uninstallation has been requested")
+			return r.Client.Delete(context.TODO(), existing)
+		}
+		if updateServiceSelectorAndPorts(existing, svc) {
+			log.Info(fmt.Sprintf("updating service %s for Argo CD instance %s in namespace %s", existing.Name, cr.Name, cr.Namespace))
+			return r.Client.Update(context.TODO(), existing)
+		}
+		return nil // Service found with nothing to do, move along...
+	}
+
+	if !UseDex(cr) {
+		return nil // Dex is disabled, do nothing
+	}
+
+	if err := controllerutil.SetControllerReference(cr, svc, r.Scheme); err != nil {
+		return err
+	}
+	log.Info(fmt.Sprintf("creating service %s for Argo CD instance %s in namespace %s", svc.Name, cr.Name, cr.Namespace))
+	return r.Client.Create(context.TODO(), svc)
+}
+
 // reconcileDexResources consolidates all dex resources reconciliation calls. It serves as the single place to trigger both creation
 // and deletion of dex resources based on the specified configuration of dex
 func (r *ReconcileArgoCD) reconcileDexResources(cr *argoproj.ArgoCD) error {
@@ -464,6 +514,10 @@ func (r *ReconcileArgoCD) reconcileDexResources(cr *argoproj.ArgoCD) error {
 		log.Error(err, "error reconciling dex service")
 	}
 
+	if err := r.reconcileDexMetricsService(cr); err != nil {
+		log.Error(err, "error reconciling dex metrics service")
+	}
+
 	if err := r.reconcileDexDeployment(cr); err != nil {
 		log.Error(err, "error reconciling dex deployment")
 	}
@@ -503,6 +557,10 @@ func (r *ReconcileArgoCD) deleteDexResources(cr *argoproj.ArgoCD) error {
 		log.Error(err, "error reconciling dex service")
 	}
 
+	if err := r.reconcileDexMetricsService(cr); err != nil {
+		log.Error(err, "error reconciling dex metrics service")
+	}
+
 	// Reconcile dex config in argocd-cm (right after dex is disabled)
 	// this is required for a one time trigger of reconcileDexConfiguration directly in case of a dex deletion event,
 	// since reconcileArgoConfigMap won't call reconcileDexConfiguration once dex has been disabled (to avoid reconciling on
@@ -0,0 +1,396 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+// getDexConnectors will return the typed Dex connectors of the given ArgoCD.
+func getDexConnectors(cr *argoproj.ArgoCD) []argoproj.ArgoCDDexConnector {
+	if cr.Spec.SSO == nil || cr.Spec.SSO.Dex == nil {
+		return nil
+	}
+	return cr.Spec.SSO.Dex.Connectors
+}
+
+// getDexSecretReference will return the Argo CD reference to the given Secret key, which Argo CD resolves when
+// loading the Dex configuration.
+func getDexSecretReference(ref corev1.SecretKeySelector) string {
+	return fmt.Sprintf("$%s:%s", ref.Name, ref.Key)
+}
+
+// getDesiredDexConfig will return the desired dex.config property of the argocd-cm ConfigMap for the given ArgoCD.
+// The given current dex.config is returned when reconcileSSO found the typed Dex connectors invalid, so that a
+// configuration that would crash Dex is never rendered.
+func (r *ReconcileArgoCD) getDesiredDexConfig(cr *argoproj.ArgoCD, current string) (string, error) {
+	if dexConnectorsErr != nil {
+		return current, nil
+	}
+
+	config := getDexConfig(cr)
+
+	// Append the default OpenShift dex config if the openShiftOAuth is requested through `.spec.sso.dex`.
+	if cr.Spec.SSO != nil && cr.Spec.SSO.Dex != nil && cr.Spec.SSO.Dex.OpenShiftOAuth {
+		cfg, err := r.getOpenShiftDexConfig(cr)
+		if err != nil {
+			return "", err
+		}
+		config = cfg
+	}
+
+	connectors := getDexConnectors(cr)
+	if len(connectors) == 0 {
+		return config, nil
+	}
+
+	dex := make(map[string]interface{})
+	if err := yaml.Unmarshal([]byte(config), dex); err != nil {
+		return "", err
+	}
+	if err := addDexConnectorsFromCR(connectors, dex); err != nil {
+		return "", err
+	}
+
+	bytes, err := yaml.Marshal(dex)
+	return string(bytes), err
+}
+
+// addDexConnectorsFromCR will append the given typed connectors to the connectors of the given Dex configuration.
+func addDexConnectorsFromCR(connectors []argoproj.ArgoCDDexConnector, dex map[string]interface{}) error {
+	var existing []interface{}
+	if c, ok := dex["connectors"]; ok && c != nil {
+		switch v := c.(type) {
+		case []interface{}:
+			existing = v
+		case []DexConnector:
+			for _, connector := range v {
+				existing = append(existing, connector)
+			}
+		default:
+			return fmt.Errorf("dex connectors must be a list")
+		}
+	}
+
+	ids := make(map[string]bool)
+	for _, c := range existing {
+		switch v := c.(type) {
+		case map[interface{}]interface{}:
+			ids[fmt.Sprint(v["id"])] = true
+		case DexConnector:
+			ids[v.ID] = true
+		}
+	}
+
+	for _, connector := range connectors {
+		if ids[connector.ID] {
+			return fmt.Errorf("dex connector id %q is already defined in the dex configuration", connector.ID)
+		}
+		existing = append(existing, renderDexConnector(connector))
+	}
+	dex["connectors"] = existing
+	return nil
+}
+
+// renderDexConnector will return the Dex configuration of the given typed connector.
+func renderDexConnector(c argoproj.ArgoCDDexConnector) DexConnector {
+	connector := DexConnector{
+		ID:     c.ID,
+		Name:   c.Name,
+		Config: make(map[string]interface{}),
+	}
+	cfg := connector.Config
+
+	setString := func(key, value string) {
+		if value != "" {
+			cfg[key] = value
+		}
+	}
+	setBool := func(key string, value bool) {
+		if value {
+			cfg[key] = true
+		}
+	}
+	setList := func(key string, value []string) {
+		if len(value) > 0 {
+			cfg[key] = value
+		}
+	}
+	setClient := func(client argoproj.ArgoCDDexOAuthClient) {
+		cfg["clientID"] = client.ClientID
+		cfg["clientSecret"] = getDexSecretReference(client.ClientSecretRef)
+	}
+
+	switch {
+	case c.GitHub != nil:
+		connector.Type = "github"
+		setClient(c.GitHub.ArgoCDDexOAuthClient)
+		if len(c.GitHub.Orgs) > 0 {
+			orgs := make([]map[string]interface{}, 0, len(c.GitHub.Orgs))
+			for _, org := range c.GitHub.Orgs {
+				o := map[string]interface{}{"name": org.Name}
+				if len(org.Teams) > 0 {
+					o["teams"] = org.Teams
+				}
+				orgs = append(orgs, o)
+			}
+			cfg["orgs"] = orgs
+		}
+		setString("hostName", c.GitHub.HostName)
+		setBool("loadAllGroups", c.GitHub.LoadAllGroups)
+		setString("teamNameField", c.GitHub.TeamNameField)
+	case c.GitLab != nil:
+		connector.Type = "gitlab"
+		setClient(c.GitLab.ArgoCDDexOAuthClient)
+		setString("baseURL", c.GitLab.BaseURL)
+		setList("groups", c.GitLab.Groups)
+	case c.OIDC != nil:
+		connector.Type = "oidc"
+		setClient(c.OIDC.ArgoCDDexOAuthClient)
+		setString("issuer", c.OIDC.Issuer)
+		setList("scopes", c.OIDC.Scopes)
+		setBool("insecureEnableGroups", c.OIDC.InsecureEnableGroups)
+		setBool("getUserInfo", c.OIDC.GetUserInfo)
+	case c.Microsoft != nil:
+		connector.Type = "microsoft"
+		setClient(c.Microsoft.ArgoCDDexOAuthClient)
+		setString("tenant", c.Microsoft.Tenant)
+		setList("groups", c.Microsoft.Groups)
+		setBool("onlySecurityGroups", c.Microsoft.OnlySecurityGroups)
+	case c.LDAP != nil:
+		connector.Type = "ldap"
+		setString("host", c.LDAP.Host)
+		setBool("insecureNoSSL", c.LDAP.InsecureNoSSL)
+		setBool("insecureSkipVerify", c.LDAP.InsecureSkipVerify)
+		setBool("startTLS", c.LDAP.StartTLS)
+		setString("bindDN", c.LDAP.BindDN)
+		if c.LDAP.BindPWRef != nil {
+			cfg["bindPW"] = getDexSecretReference(*c.LDAP.BindPWRef)
+		}
+		setString("usernamePrompt", c.LDAP.UsernamePrompt)
+
+		userSearch := map[string]interface{}{
+			"baseDN":    c.LDAP.UserSearch.BaseDN,
+			"username":  c.LDAP.UserSearch.Username,
+			"idAttr":    c.LDAP.UserSearch.IDAttr,
+			"emailAttr": c.LDAP.UserSearch.EmailAttr,
+		}
+		if c.LDAP.UserSearch.Filter != "" {
+			userSearch["filter"] = c.LDAP.UserSearch.Filter
+		}
+		if c.LDAP.UserSearch.NameAttr != "" {
+			userSearch["nameAttr"] = c.LDAP.UserSearch.NameAttr
+		}
+		cfg["userSearch"] = userSearch
+
+		if gs := c.LDAP.GroupSearch; gs != nil {
+			matchers := make([]map[string]interface{}, 0, len(gs.UserMatchers))
+			for _, m := range gs.UserMatchers {
+				matchers = append(matchers, map[string]interface{}{"userAttr": m.UserAttr, "groupAttr": m.GroupAttr})
+			}
+			groupSearch := map[string]interface{}{
+				"baseDN":       gs.BaseDN,
+				"userMatchers": matchers,
+				"nameAttr":     gs.NameAttr,
+			}
+			if gs.Filter != "" {
+				groupSearch["filter"] = gs.Filter
+			}
+			cfg["groupSearch"] = groupSearch
+		}
+	case c.SAML != nil:
+		connector.Type = "saml"
+		setString("ssoURL", c.SAML.SSOURL)
+		cfg["caData"] = getDexSecretReference(c.SAML.CADataRef)
+		setString("entityIssuer", c.SAML.EntityIssuer)
+		setString("ssoIssuer", c.SAML.SSOIssuer)
+		setString("usernameAttr", c.SAML.UsernameAttr)
+		setString("emailAttr", c.SAML.EmailAttr)
+		setString("groupsAttr", c.SAML.GroupsAttr)
+	}
+
+	return connector
+}
+
+// validateDexConnectors will return an error if any of the typed Dex connectors of the given ArgoCD is missing
+// required fields, or references a Secret key that Argo CD cannot resolve.
+func (r *ReconcileArgoCD) validateDexConnectors(cr *argoproj.ArgoCD) error {
+	var errs []string
+	ids := make(map[string]bool)
+
+	for i, c := range getDexConnectors(cr) {
+		path := fmt.Sprintf("connectors[%d]", i)
+
+		if c.ID == "" {
+			errs = append(errs, path+".id: must not be empty")
+		} else if ids[c.ID] {
+			errs = append(errs, fmt.Sprintf("%s.id: duplicate connector id %q", path, c.ID))
+		}
+		ids[c.ID] = true
+
+		if c.Name == "" {
+			errs = append(errs, path+".name: must not be empty")
+		}
+
+		errs = append(errs, validateDexConnector(path, c)...)
+
+		for field, ref := range getDexConnectorSecretRefs(c) {
+			if err := r.validateDexSecretRef(cr, ref); err != nil {
+				errs = append(errs, fmt.Sprintf("%s.%s: %v", path, field, err))
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid dex connectors: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// validateDexConnector will return the validation errors of the connector specific fields of the given connector
+// at the given path.
+func validateDexConnector(path string, c argoproj.ArgoCDDexConnector) []string {
+	var errs []string
+
+	set := 0
+	for _, ok := range []bool{c.GitHub != nil, c.GitLab != nil, c.LDAP != nil, c.OIDC != nil, c.SAML != nil, c.Microsoft != nil} {
+		if ok {
+			set++
+		}
+	}
+	if set != 1 {
+		return []string{path + ": exactly one of github, gitlab, ldap, oidc, saml or microsoft must be set"}
+	}
+
+	required := func(field, value string) {
+		if value == "" {
+			errs = append(errs, fmt.Sprintf("%s.%s: must not be empty", path, field))
+		}
+	}
+	validURL := func(field, value string) {
+		if value == "" {
+			return
+		}
+		if u, err := url.Parse(value); err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			errs = append(errs, fmt.Sprintf("%s.%s: %q is not a valid URL", path, field, value))
+		}
+	}
+	client := func(prefix string, client argoproj.ArgoCDDexOAuthClient) {
+		required(prefix+".clientID", client.ClientID)
+	}
+
+	switch {
+	case c.GitHub != nil:
+		client("github", c.GitHub.ArgoCDDexOAuthClient)
+		for i, org := range c.GitHub.Orgs {
+			required(fmt.Sprintf("github.orgs[%d].name", i), org.Name)
+		}
+	case c.GitLab != nil:
+		client("gitlab", c.GitLab.ArgoCDDexOAuthClient)
+		validURL("gitlab.baseURL", c.GitLab.BaseURL)
+	case c.OIDC != nil:
+		client("oidc", c.OIDC.ArgoCDDexOAuthClient)
+		required("oidc.issuer", c.OIDC.Issuer)
+		validURL("oidc.issuer", c.OIDC.Issuer)
+	case c.Microsoft != nil:
+		client("microsoft", c.Microsoft.ArgoCDDexOAuthClient)
+	case c.LDAP != nil:
+		required("ldap.host", c.LDAP.Host)
+		if c.LDAP.InsecureNoSSL && c.LDAP.StartTLS {
+			errs = append(errs, path+".ldap.startTLS: cannot be combined with insecureNoSSL")
+		}
+		if c.LDAP.BindPWRef != nil && c.LDAP.BindDN == "" {
+			errs = append(errs, path+".ldap.bindDN: must be set when bindPWRef is set")
+		}
+		required("ldap.userSearch.baseDN", c.LDAP.UserSearch.BaseDN)
+		required("ldap.userSearch.username", c.LDAP.UserSearch.Username)
+		required("ldap.userSearch.idAttr", c.LDAP.UserSearch.IDAttr)
+		required("ldap.userSearch.emailAttr", c.LDAP.UserSearch.EmailAttr)
+		if gs := c.LDAP.GroupSearch; gs != nil {
+			required("ldap.groupSearch.baseDN", gs.BaseDN)
+			required("ldap.groupSearch.nameAttr", gs.NameAttr)
+			if len(gs.UserMatchers) == 0 {
+				errs = append(errs, path+".ldap.groupSearch.userMatchers: must not be empty")
+			}
+			for i, m := range gs.UserMatchers {
+				required(fmt.Sprintf("ldap.groupSearch.userMatchers[%d].userAttr", i), m.UserAttr)
+				required(fmt.Sprintf("ldap.groupSearch.userMatchers[%d].groupAttr", i), m.GroupAttr)
+			}
+		}
+	case c.SAML != nil:
+		required("saml.ssoURL", c.SAML.SSOURL)
+		validURL("saml.ssoURL", c.SAML.SSOURL)
+		required("saml.usernameAttr", c.SAML.UsernameAttr)
+		required("saml.emailAttr", c.SAML.EmailAttr)
+	}
+
+	return errs
+}
+
+// getDexConnectorSecretRefs will return the Secret references of the given connector, keyed by field path.
+func getDexConnectorSecretRefs(c argoproj.ArgoCDDexConnector) map[string]corev1.SecretKeySelector {
+	refs := make(map[string]corev1.SecretKeySelector)
+	switch {
+	case c.GitHub != nil:
+		refs["github.clientSecretRef"] = c.GitHub.ClientSecretRef
+	case c.GitLab != nil:
+		refs["gitlab.clientSecretRef"] = c.GitLab.ClientSecretRef
+	case c.OIDC != nil:
+		refs["oidc.clientSecretRef"] = c.OIDC.ClientSecretRef
+	case c.Microsoft != nil:
+		refs["microsoft.clientSecretRef"] = c.Microsoft.ClientSecretRef
+	case c.LDAP != nil:
+		if c.LDAP.BindPWRef != nil {
+			refs["ldap.bindPWRef"] = *c.LDAP.BindPWRef
+		}
+	case c.SAML != nil:
+		refs["saml.caDataRef"] = c.SAML.CADataRef
+	}
+	return refs
+}
+
+// validateDexSecretRef will return an error if the given Secret key does not exist in the namespace of the given
+// ArgoCD, or if the Secret is not labelled for use by Argo CD.
+func (r *ReconcileArgoCD) validateDexSecretRef(cr *argoproj.ArgoCD, ref corev1.SecretKeySelector) error {
+	if ref.Name == "" || ref.Key == "" {
+		return fmt.Errorf("secret name and key must not be empty")
+	}
+
+	secret := &corev1.Secret{}
+	if err := r.Client.Get(context.TODO(), types.NamespacedName{Name: ref.Name, Namespace: cr.Namespace}, secret); err != nil {
+		if errors.IsNotFound(err) {
+			return fmt.Errorf("secret %q not found", ref.Name)
+		}
+		return err
+	}
+	if _, ok := secret.Data[ref.Key]; !ok {
+		return fmt.Errorf("key %q not found in secret %q", ref.Key, ref.Name)
+	}
+	if secret.Labels[common.ArgoCDKeyPartOf] != common.ArgoCDAppName {
+		return fmt.Errorf("secret %q must be labelled with %s=%s", ref.Name, common.ArgoCDKeyPartOf, common.ArgoCDAppName)
+	}
+	return nil
+}
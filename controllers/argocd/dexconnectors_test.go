@@ -0,0 +1,269 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+func makeTestDexConnectorSecret(labelled bool) *corev1.Secret {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "dex-secrets",
+			Namespace: testNamespace,
+		},
+		Data: map[string][]byte{
+			"github": []byte("s3cr3t"),
+			"ldap":   []byte("bindpw"),
+		},
+	}
+	if labelled {
+		secret.Labels = map[string]string{common.ArgoCDKeyPartOf: common.ArgoCDAppName}
+	}
+	return secret
+}
+
+func TestGetDesiredDexConfig_withConnectors(t *testing.T) {
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.SSO = &argoproj.ArgoCDSSOSpec{
+			Provider: argoproj.SSOProviderTypeDex,
+			Dex: &argoproj.ArgoCDDexSpec{
+				Config: "connectors:\n- type: oidc\n  id: existing\n  name: Existing\n",
+				Connectors: []argoproj.ArgoCDDexConnector{
+					{
+						ID:   "github",
+						Name: "GitHub",
+						GitHub: &argoproj.ArgoCDDexGitHubConnector{
+							ArgoCDDexOAuthClient: argoproj.ArgoCDDexOAuthClient{
+								ClientID:        "client-id",
+								ClientSecretRef: corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "dex-secrets"}, Key: "github"},
+							},
+							Orgs: []argoproj.ArgoCDDexGitHubOrg{{Name: "my-org", Teams: []string{"devs"}}},
+						},
+					},
+					{
+						ID:   "ldap",
+						Name: "LDAP",
+						LDAP: &argoproj.ArgoCDDexLDAPConnector{
+							Host:      "ldap.example.com:636",
+							BindDN:    "cn=admin,dc=example,dc=com",
+							BindPWRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "dex-secrets"}, Key: "ldap"},
+							UserSearch: argoproj.ArgoCDDexLDAPUserSearch{
+								BaseDN:    "ou=people,dc=example,dc=com",
+								Username:  "uid",
+								IDAttr:    "uid",
+								EmailAttr: "mail",
+							},
+						},
+					},
+				},
+			},
+		}
+	})
+
+	resObjs := []client.Object{a, makeTestDexConnectorSecret(true)}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.validateDexConnectors(a))
+
+	config, err := r.getDesiredDexConfig(a, "")
+	assert.NoError(t, err)
+	assert.Equal(t, `connectors:
+- id: existing
+  name: Existing
+  type: oidc
+- config:
+    clientID: client-id
+    clientSecret: $dex-secrets:github
+    orgs:
+    - name: my-org
+      teams:
+      - devs
+  id: github
+  name: GitHub
+  type: github
+- config:
+    bindDN: cn=admin,dc=example,dc=com
+    bindPW: $dex-secrets:ldap
+    host: ldap.example.com:636
+    userSearch:
+      baseDN: ou=people,dc=example,dc=com
+      emailAttr: mail
+      idAttr: uid
+      username: uid
+  id: ldap
+  name: LDAP
+  type: ldap
+`, config)
+
+	// connector ids must not clash with the connectors of the dex config
+	a.Spec.SSO.Dex.Connectors[0].ID = "existing"
+	_, err = r.getDesiredDexConfig(a, "")
+	assert.EqualError(t, err, `dex connector id "existing" is already defined in the dex configuration`)
+
+	// the current dex config is kept while the connectors are invalid
+	dexConnectorsErr = fmt.Errorf("invalid dex connectors")
+	defer func() { dexConnectorsErr = nil }()
+	config, err = r.getDesiredDexConfig(a, "current")
+	assert.NoError(t, err)
+	assert.Equal(t, "current", config)
+}
+
+func TestValidateDexConnectors(t *testing.T) {
+	secretRef := func(name, key string) corev1.SecretKeySelector {
+		return corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: name}, Key: key}
+	}
+
+	tests := []struct {
+		name       string
+		connectors []argoproj.ArgoCDDexConnector
+		labelled   bool
+		wantErr    string
+	}{
+		{
+			name: "valid oidc connector",
+			connectors: []argoproj.ArgoCDDexConnector{
+				{ID: "oidc", Name: "OIDC", OIDC: &argoproj.ArgoCDDexOIDCConnector{
+					ArgoCDDexOAuthClient: argoproj.ArgoCDDexOAuthClient{ClientID: "id", ClientSecretRef: secretRef("dex-secrets", "github")},
+					Issuer:               "https://idp.example.com",
+				}},
+			},
+			labelled: true,
+		},
+		{
+			name: "missing and duplicate fields",
+			connectors: []argoproj.ArgoCDDexConnector{
+				{ID: "a", Name: "A"},
+				{ID: "a", OIDC: &argoproj.ArgoCDDexOIDCConnector{
+					ArgoCDDexOAuthClient: argoproj.ArgoCDDexOAuthClient{ClientSecretRef: secretRef("dex-secrets", "github")},
+					Issuer:               "idp.example.com",
+				}},
+				{ID: "saml", Name: "SAML", SAML: &argoproj.ArgoCDDexSAMLConnector{
+					SSOURL:    "https://idp.example.com/sso",
+					CADataRef: secretRef("dex-secrets", "ca"),
+				}},
+			},
+			labelled: true,
+			wantErr: `invalid dex connectors: connectors[0]: exactly one of github, gitlab, ldap, oidc, saml or microsoft must be set; ` +
+				`connectors[1].id: duplicate connector id "a"; connectors[1].name: must not be empty; ` +
+				`connectors[1].oidc.clientID: must not be empty; connectors[1].oidc.issuer: "idp.example.com" is not a valid URL; ` +
+				`connectors[2].saml.usernameAttr: must not be empty; connectors[2].saml.emailAttr: must not be empty; ` +
+				`connectors[2].saml.caDataRef: key "ca" not found in secret "dex-secrets"`,
+		},
+		{
+			name: "secret not labelled for argo cd",
+			connectors: []argoproj.ArgoCDDexConnector{
+				{ID: "gitlab", Name: "GitLab", GitLab: &argoproj.ArgoCDDexGitLabConnector{
+					ArgoCDDexOAuthClient: argoproj.ArgoCDDexOAuthClient{ClientID: "id", ClientSecretRef: secretRef("dex-secrets", "github")},
+				}},
+			},
+			wantErr: `invalid dex connectors: connectors[0].gitlab.clientSecretRef: secret "dex-secrets" must be labelled with app.kubernetes.io/part-of=argocd`,
+		},
+		{
+			name: "secret not found",
+			connectors: []argoproj.ArgoCDDexConnector{
+				{ID: "ms", Name: "Microsoft", Microsoft: &argoproj.ArgoCDDexMicrosoftConnector{
+					ArgoCDDexOAuthClient: argoproj.ArgoCDDexOAuthClient{ClientID: "id", ClientSecretRef: secretRef("missing", "key")},
+				}},
+			},
+			wantErr: `invalid dex connectors: connectors[0].microsoft.clientSecretRef: secret "missing" not found`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+				a.Spec.SSO = &argoproj.ArgoCDSSOSpec{
+					Provider: argoproj.SSOProviderTypeDex,
+					Dex:      &argoproj.ArgoCDDexSpec{Connectors: test.connectors},
+				}
+			})
+
+			resObjs := []client.Object{a, makeTestDexConnectorSecret(test.labelled)}
+			subresObjs := []client.Object{a}
+			runtimeObjs := []runtime.Object{}
+			sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+			cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+			r := makeTestReconciler(cl, sch)
+
+			err := r.validateDexConnectors(a)
+			if test.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestReconcileArgoConfigMap_invalidDexConnectors(t *testing.T) {
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.SSO = &argoproj.ArgoCDSSOSpec{
+			Provider: argoproj.SSOProviderTypeDex,
+			Dex: &argoproj.ArgoCDDexSpec{
+				Connectors: []argoproj.ArgoCDDexConnector{
+					{ID: "github", Name: "GitHub", GitHub: &argoproj.ArgoCDDexGitHubConnector{
+						ArgoCDDexOAuthClient: argoproj.ArgoCDDexOAuthClient{
+							ClientSecretRef: corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "dex-secrets"}, Key: "github"},
+						},
+					}},
+				},
+			},
+		}
+	})
+
+	existing := newConfigMapWithName(common.ArgoCDConfigMapName, a)
+	existing.Data = map[string]string{common.ArgoCDKeyDexConfig: "connectors: []\n"}
+
+	resObjs := []client.Object{a, makeTestDexConnectorSecret(true), existing}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+	recorder := record.NewFakeRecorder(10)
+	r.recorder = recorder
+	defer func() { dexConnectorsErr = nil }()
+
+	// the connectors are validated once, by reconcileSSO
+	err := r.reconcileSSO(a)
+	assert.EqualError(t, err, illegalSSOConfiguration+`invalid dex connectors: connectors[0].github.clientID: must not be empty`)
+	assert.Equal(t, ssoLegalFailed, a.Status.SSO)
+	assert.Equal(t, "Warning InvalidDexConfiguration keeping the existing dex.config: invalid dex connectors: connectors[0].github.clientID: must not be empty", <-recorder.Events)
+
+	// the other settings of argocd-cm are still reconciled, while the existing dex config is kept
+	assert.NoError(t, r.reconcileArgoConfigMap(a))
+	cm := &corev1.ConfigMap{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDConfigMapName, Namespace: testNamespace}, cm))
+	assert.Equal(t, "connectors: []\n", cm.Data[common.ArgoCDKeyDexConfig])
+	assert.Equal(t, "false", cm.Data[common.ArgoCDKeyStatusBadgeEnabled])
+}
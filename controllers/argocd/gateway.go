@@ -0,0 +1,445 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+var (
+	gatewayAPIFound             = false
+	gatewayExperimentalAPIFound = false
+)
+
+// IsGatewayAPIAvailable returns true if the Gateway API (HTTPRoute) is present.
+func IsGatewayAPIAvailable() bool {
+	return gatewayAPIFound
+}
+
+// IsGatewayExperimentalAPIAvailable returns true if the experimental Gateway API (GRPCRoute, TLSRoute) is present.
+func IsGatewayExperimentalAPIAvailable() bool {
+	return gatewayExperimentalAPIFound
+}
+
+// verifyGatewayAPI will verify that the Gateway API is present, and whether the experimental GRPCRoute and TLSRoute
+// resources are installed as well.
+func verifyGatewayAPI() error {
+	found, err := argoutil.VerifyAPIResources(gatewayv1.GroupName, gatewayv1.GroupVersion.Version, "httproutes")
+	if err != nil {
+		return err
+	}
+	gatewayAPIFound = found
+
+	// the v1alpha2 group version is served by the standard channel too, for ReferenceGrant
+	found, err = argoutil.VerifyAPIResources(gatewayv1alpha2.GroupName, gatewayv1alpha2.GroupVersion.Version, "grpcroutes", "tlsroutes")
+	if err != nil {
+		return err
+	}
+	gatewayExperimentalAPIFound = found
+	return nil
+}
+
+// newGatewayRouteMeta returns the ObjectMeta for a Gateway API route with the given name suffix for the ArgoCD.
+func newGatewayRouteMeta(suffix string, cr *argoproj.ArgoCD, spec argoproj.ArgoCDGatewayRouteSpec) metav1.ObjectMeta {
+	name := nameWithSuffix(suffix, cr)
+
+	lbls := argoutil.LabelsForCluster(cr)
+	lbls[common.ArgoCDKeyName] = name
+	for key, val := range spec.Labels {
+		lbls[key] = val
+	}
+
+	return metav1.ObjectMeta{
+		Name:        name,
+		Namespace:   cr.Namespace,
+		Labels:      lbls,
+		Annotations: spec.Annotations,
+	}
+}
+
+// getGatewayParentRefs will return the Gateway API parent references for the given route spec.
+func getGatewayParentRefs(spec argoproj.ArgoCDGatewayRouteSpec) []gatewayv1.ParentReference {
+	refs := make([]gatewayv1.ParentReference, 0, len(spec.ParentRefs))
+	for _, p := range spec.ParentRefs {
+		// Group and Kind are set to the values the API server defaults them to, so that comparing with the live
+		// route does not report a difference.
+		group := gatewayv1.Group(gatewayv1.GroupName)
+		kind := gatewayv1.Kind("Gateway")
+		ref := gatewayv1.ParentReference{
+			Group: &group,
+			Kind:  &kind,
+			Name:  gatewayv1.ObjectName(p.Name),
+		}
+		if p.Namespace != "" {
+			ns := gatewayv1.Namespace(p.Namespace)
+			ref.Namespace = &ns
+		}
+		if p.SectionName != "" {
+			section := gatewayv1.SectionName(p.SectionName)
+			ref.SectionName = &section
+		}
+		refs = append(refs, ref)
+	}
+	return refs
+}
+
+// getGatewayHostnames will return the route hostnames for the given host. An empty host matches every hostname
+// accepted by the Gateway listener.
+func getGatewayHostnames(host string) ([]gatewayv1.Hostname, error) {
+	if host == "" {
+		return nil, nil
+	}
+	hostname, err := shortenHostname(host)
+	if err != nil {
+		return nil, err
+	}
+	return []gatewayv1.Hostname{gatewayv1.Hostname(hostname)}, nil
+}
+
+// newGatewayBackendRef returns a reference to the given Service and port.
+func newGatewayBackendRef(service string, port int32) gatewayv1.BackendRef {
+	group := gatewayv1.Group("")
+	kind := gatewayv1.Kind("Service")
+	weight := int32(1)
+	return gatewayv1.BackendRef{
+		BackendObjectReference: gatewayv1.BackendObjectReference{
+			Group: &group,
+			Kind:  &kind,
+			Name:  gatewayv1.ObjectName(service),
+			Port:  (*gatewayv1.PortNumber)(&port),
+		},
+		Weight: &weight,
+	}
+}
+
+// newHTTPRoute returns a new HTTPRoute with the given name suffix for the ArgoCD, forwarding requests matching the
+// route path to the given Service and port.
+func newHTTPRoute(suffix string, cr *argoproj.ArgoCD, spec argoproj.ArgoCDGatewayRouteSpec, host, defaultPath, service string, port int32) (*gatewayv1.HTTPRoute, error) {
+	hostnames, err := getGatewayHostnames(host)
+	if err != nil {
+		return nil, err
+	}
+
+	path := defaultPath
+	if spec.Path != "" {
+		path = spec.Path
+	}
+	pathType := gatewayv1.PathMatchPathPrefix
+
+	return &gatewayv1.HTTPRoute{
+		ObjectMeta: newGatewayRouteMeta(suffix, cr, spec),
+		Spec: gatewayv1.HTTPRouteSpec{
+			CommonRouteSpec: gatewayv1.CommonRouteSpec{
+				ParentRefs: getGatewayParentRefs(spec),
+			},
+			Hostnames: hostnames,
+			Rules: []gatewayv1.HTTPRouteRule{
+				{
+					Matches: []gatewayv1.HTTPRouteMatch{
+						{
+							Path: &gatewayv1.HTTPPathMatch{
+								Type:  &pathType,
+								Value: &path,
+							},
+						},
+					},
+					BackendRefs: []gatewayv1.HTTPBackendRef{
+						{
+							BackendRef: newGatewayBackendRef(service, port),
+						},
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+// reconcileGatewayRoutes will ensure that all ArgoCD Gateway API routes are present.
+func (r *ReconcileArgoCD) reconcileGatewayRoutes(cr *argoproj.ArgoCD) error {
+	if err := r.reconcileServerGatewayRoute(cr); err != nil {
+		return err
+	}
+
+	if err := r.reconcileServerGRPCGatewayRoute(cr); err != nil {
+		return err
+	}
+
+	if err := r.reconcileGrafanaGatewayRoute(cr); err != nil {
+		return err
+	}
+
+	if err := r.reconcilePrometheusGatewayRoute(cr); err != nil {
+		return err
+	}
+
+	if err := r.reconcileApplicationSetControllerWebhookGatewayRoute(cr); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// reconcileServerGatewayRoute will ensure that the ArgoCD Server HTTPRoute, or TLSRoute when TLS passthrough is
+// requested, is present.
+func (r *ReconcileArgoCD) reconcileServerGatewayRoute(cr *argoproj.ArgoCD) error {
+	spec := cr.Spec.Server.Gateway
+	passthrough := spec.Enabled && spec.TLSPassthrough
+
+	if !passthrough {
+		if err := r.deleteGatewayRouteIfExists(cr, &gatewayv1alpha2.TLSRoute{}, nameWithSuffix("server", cr)); err != nil {
+			return err
+		}
+	}
+
+	if !spec.Enabled || passthrough {
+		if err := r.deleteGatewayRouteIfExists(cr, &gatewayv1.HTTPRoute{}, nameWithSuffix("server", cr)); err != nil {
+			return err
+		}
+	}
+
+	if !spec.Enabled {
+		return nil // Gateway route not enabled, move along...
+	}
+
+	if passthrough {
+		if !IsGatewayExperimentalAPIAvailable() {
+			log.Info("TLSRoute requested for the Argo CD Server but the experimental Gateway API is not available")
+			return nil
+		}
+
+		hostnames, err := getGatewayHostnames(cr.Spec.Server.Host)
+		if err != nil {
+			return err
+		}
+
+		route := &gatewayv1alpha2.TLSRoute{
+			ObjectMeta: newGatewayRouteMeta("server", cr, spec),
+			Spec: gatewayv1alpha2.TLSRouteSpec{
+				CommonRouteSpec: gatewayv1alpha2.CommonRouteSpec{
+					ParentRefs: getGatewayParentRefs(spec),
+				},
+				Hostnames: hostnames,
+				Rules: []gatewayv1alpha2.TLSRouteRule{
+					{
+						BackendRefs: []gatewayv1alpha2.BackendRef{
+							newGatewayBackendRef(nameWithSuffix("server", cr), 443),
+						},
+					},
+				},
+			},
+		}
+		return r.applyGatewayRoute(cr, route, &gatewayv1alpha2.TLSRoute{}, func(existing client.Object) bool {
+			return updateGatewayRouteSpec(&existing.(*gatewayv1alpha2.TLSRoute).Spec, route.Spec)
+		})
+	}
+
+	if !cr.Spec.Server.Insecure {
+		r.reportInsecureServerRequired(cr, "HTTPRoute")
+		return r.deleteGatewayRouteIfExists(cr, &gatewayv1.HTTPRoute{}, nameWithSuffix("server", cr))
+	}
+
+	route, err := newHTTPRoute("server", cr, spec, cr.Spec.Server.Host, common.ArgoCDDefaultIngressPath, nameWithSuffix("server", cr), 80)
+	if err != nil {
+		return err
+	}
+	return r.applyGatewayRoute(cr, route, &gatewayv1.HTTPRoute{}, func(existing client.Object) bool {
+		return updateGatewayRouteSpec(&existing.(*gatewayv1.HTTPRoute).Spec, route.Spec)
+	})
+}
+
+// reconcileServerGRPCGatewayRoute will ensure that the ArgoCD Server GRPCRoute is present.
+func (r *ReconcileArgoCD) reconcileServerGRPCGatewayRoute(cr *argoproj.ArgoCD) error {
+	spec := cr.Spec.Server.GRPC.Gateway
+	if !spec.Enabled {
+		return r.deleteGatewayRouteIfExists(cr, &gatewayv1alpha2.GRPCRoute{}, nameWithSuffix("grpc", cr))
+	}
+
+	if !IsGatewayExperimentalAPIAvailable() {
+		log.Info("GRPCRoute requested for the Argo CD Server but the experimental Gateway API is not available")
+		return nil
+	}
+
+	if !cr.Spec.Server.Insecure {
+		r.reportInsecureServerRequired(cr, "GRPCRoute")
+		return r.deleteGatewayRouteIfExists(cr, &gatewayv1alpha2.GRPCRoute{}, nameWithSuffix("grpc", cr))
+	}
+
+	hostnames, err := getGatewayHostnames(cr.Spec.Server.GRPC.Host)
+	if err != nil {
+		return err
+	}
+
+	route := &gatewayv1alpha2.GRPCRoute{
+		ObjectMeta: newGatewayRouteMeta("grpc", cr, spec),
+		Spec: gatewayv1alpha2.GRPCRouteSpec{
+			CommonRouteSpec: gatewayv1alpha2.CommonRouteSpec{
+				ParentRefs: getGatewayParentRefs(spec),
+			},
+			Hostnames: hostnames,
+			Rules: []gatewayv1alpha2.GRPCRouteRule{
+				{
+					BackendRefs: []gatewayv1alpha2.GRPCBackendRef{
+						{
+							BackendRef: newGatewayBackendRef(nameWithSuffix("server", cr), 80),
+						},
+					},
+				},
+			},
+		},
+	}
+	return r.applyGatewayRoute(cr, route, &gatewayv1alpha2.GRPCRoute{}, func(existing client.Object) bool {
+		return updateGatewayRouteSpec(&existing.(*gatewayv1alpha2.GRPCRoute).Spec, route.Spec)
+	})
+}
+
+// reportInsecureServerRequired reports that the given route of the Argo CD Server is not created. The Gateway
+// terminates TLS and forwards plain HTTP to the server, which would redirect it back to HTTPS, since the operator does
+// not manage a BackendTLSPolicy for the server Service.
+func (r *ReconcileArgoCD) reportInsecureServerRequired(cr *argoproj.ArgoCD, kind string) {
+	msg := fmt.Sprintf("%s requested for the Argo CD Server but .spec.server.insecure is not set, use TLS passthrough or set .spec.server.insecure", kind)
+	log.Info(msg, "name", cr.Name, "namespace", cr.Namespace)
+	r.recordEvent(cr, corev1.EventTypeWarning, "InvalidGatewayRoute", msg)
+}
+
+// reconcileGrafanaGatewayRoute will ensure that the ArgoCD Grafana HTTPRoute is present.
+func (r *ReconcileArgoCD) reconcileGrafanaGatewayRoute(cr *argoproj.ArgoCD) error {
+	//nolint:staticcheck
+	if !cr.Spec.Grafana.Enabled || !cr.Spec.Grafana.Gateway.Enabled {
+		// Grafana itself or the route not enabled, remove any route left behind.
+		return r.deleteGatewayRouteIfExists(cr, &gatewayv1.HTTPRoute{}, nameWithSuffix("grafana", cr))
+	}
+
+	log.Info(grafanaDeprecatedWarning)
+
+	return nil
+}
+
+// reconcilePrometheusGatewayRoute will ensure that the ArgoCD Prometheus HTTPRoute is present.
+func (r *ReconcileArgoCD) reconcilePrometheusGatewayRoute(cr *argoproj.ArgoCD) error {
+	spec := cr.Spec.Prometheus.Gateway
+	if !cr.Spec.Prometheus.Enabled || !spec.Enabled {
+		return r.deleteGatewayRouteIfExists(cr, &gatewayv1.HTTPRoute{}, nameWithSuffix("prometheus", cr))
+	}
+
+	route, err := newHTTPRoute("prometheus", cr, spec, cr.Spec.Prometheus.Host, common.ArgoCDDefaultIngressPath, "prometheus-operated", 9090)
+	if err != nil {
+		return err
+	}
+	return r.applyGatewayRoute(cr, route, &gatewayv1.HTTPRoute{}, func(existing client.Object) bool {
+		return updateGatewayRouteSpec(&existing.(*gatewayv1.HTTPRoute).Spec, route.Spec)
+	})
+}
+
+// reconcileApplicationSetControllerWebhookGatewayRoute will ensure that the ApplicationSet webhook HTTPRoute is present.
+func (r *ReconcileArgoCD) reconcileApplicationSetControllerWebhookGatewayRoute(cr *argoproj.ArgoCD) error {
+	name := nameWithSuffix(common.ApplicationSetServiceNameSuffix, cr)
+	if cr.Spec.ApplicationSet == nil || !cr.Spec.ApplicationSet.WebhookServer.Gateway.Enabled {
+		return r.deleteGatewayRouteIfExists(cr, &gatewayv1.HTTPRoute{}, name)
+	}
+
+	spec := cr.Spec.ApplicationSet.WebhookServer.Gateway
+	route, err := newHTTPRoute(common.ApplicationSetServiceNameSuffix, cr, spec, cr.Spec.ApplicationSet.WebhookServer.Host, "/api/webhook", name, 7000)
+	if err != nil {
+		return err
+	}
+	return r.applyGatewayRoute(cr, route, &gatewayv1.HTTPRoute{}, func(existing client.Object) bool {
+		return updateGatewayRouteSpec(&existing.(*gatewayv1.HTTPRoute).Spec, route.Spec)
+	})
+}
+
+// applyGatewayRoute will create the desired route, or bring an existing one back in line with it. The updateSpec
+// function copies the desired spec onto the existing route and reports whether anything changed.
+func (r *ReconcileArgoCD) applyGatewayRoute(cr *argoproj.ArgoCD, desired, existing client.Object, updateSpec func(client.Object) bool) error {
+	if !argoutil.IsObjectFound(r.Client, desired.GetNamespace(), desired.GetName(), existing) {
+		if err := controllerutil.SetControllerReference(cr, desired, r.Scheme); err != nil {
+			return err
+		}
+		log.Info(fmt.Sprintf("creating gateway route %s", desired.GetName()))
+		return r.Client.Create(context.TODO(), desired)
+	}
+
+	changed := updateSpec(existing)
+	if !reflect.DeepEqual(existing.GetLabels(), desired.GetLabels()) {
+		existing.SetLabels(desired.GetLabels())
+		changed = true
+	}
+	if !reflect.DeepEqual(existing.GetAnnotations(), desired.GetAnnotations()) {
+		existing.SetAnnotations(desired.GetAnnotations())
+		changed = true
+	}
+
+	if !changed {
+		return nil
+	}
+	log.Info(fmt.Sprintf("updating gateway route %s to match the ArgoCD spec", existing.GetName()))
+	return r.Client.Update(context.TODO(), existing)
+}
+
+// updateGatewayRouteSpec copies the desired route spec onto the existing one, returning true when they differed.
+func updateGatewayRouteSpec[T any](existing *T, desired T) bool {
+	if reflect.DeepEqual(*existing, desired) {
+		return false
+	}
+	*existing = desired
+	return true
+}
+
+// isGatewayRouteAccepted returns true if at least one parent Gateway has accepted the route.
+func isGatewayRouteAccepted(status gatewayv1.RouteStatus) bool {
+	for _, parent := range status.Parents {
+		if meta.IsStatusConditionTrue(parent.Conditions, string(gatewayv1.RouteConditionAccepted)) {
+			return true
+		}
+	}
+	return false
+}
+
+// deleteGatewayRouteIfExists will delete the Gateway API route with the given name when it is present. Nothing is
+// done when the corresponding API is not available on the cluster.
+func (r *ReconcileArgoCD) deleteGatewayRouteIfExists(cr *argoproj.ArgoCD, route client.Object, name string) error {
+	switch route.(type) {
+	case *gatewayv1.HTTPRoute:
+		if !IsGatewayAPIAvailable() {
+			return nil
+		}
+	default:
+		if !IsGatewayExperimentalAPIAvailable() {
+			return nil
+		}
+	}
+
+	if !argoutil.IsObjectFound(r.Client, cr.Namespace, name, route) {
+		return nil
+	}
+	log.Info(fmt.Sprintf("deleting gateway route %s, it is no longer enabled", name))
+	if err := r.Client.Delete(context.TODO(), route); err != nil {
+		return fmt.Errorf("failed to delete gateway route %s: %w", name, err)
+	}
+	return nil
+}
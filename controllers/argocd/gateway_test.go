@@ -0,0 +1,229 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+func setGatewayAPIFound(t *testing.T, found, experimental bool) {
+	gatewayTemp, experimentalTemp := gatewayAPIFound, gatewayExperimentalAPIFound
+	t.Cleanup(func() {
+		gatewayAPIFound, gatewayExperimentalAPIFound = gatewayTemp, experimentalTemp
+	})
+	gatewayAPIFound, gatewayExperimentalAPIFound = found, experimental
+}
+
+func TestReconcileArgoCD_reconcileServerGatewayRoute(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	setGatewayAPIFound(t, true, true)
+
+	a := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Spec.Server.Host = "argocd.example.com"
+		cr.Spec.Server.Gateway = argoproj.ArgoCDGatewayRouteSpec{
+			Enabled: true,
+			Labels:  map[string]string{"team": "platform"},
+			ParentRefs: []argoproj.ArgoCDGatewayParentReference{
+				{Name: "public", Namespace: "gateways", SectionName: "https"},
+			},
+		}
+	})
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme, gatewayv1.Install, gatewayv1alpha2.Install)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+	recorder := record.NewFakeRecorder(10)
+	r.recorder = recorder
+
+	// the server must be insecure, since the Gateway forwards plain HTTP to it
+	assert.NoError(t, r.reconcileServerGatewayRoute(a))
+	assert.False(t, argoutil.IsObjectFound(r.Client, testNamespace, "argocd-server", &gatewayv1.HTTPRoute{}))
+	assert.Contains(t, <-recorder.Events, "Warning InvalidGatewayRoute HTTPRoute requested for the Argo CD Server but .spec.server.insecure is not set")
+
+	a.Spec.Server.Insecure = true
+	assert.NoError(t, r.reconcileServerGatewayRoute(a))
+
+	route := &gatewayv1.HTTPRoute{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-server", Namespace: testNamespace}, route))
+	assert.Equal(t, "platform", route.Labels["team"])
+	assert.Equal(t, []gatewayv1.Hostname{"argocd.example.com"}, route.Spec.Hostnames)
+	assert.Len(t, route.Spec.ParentRefs, 1)
+	assert.Equal(t, gatewayv1.ObjectName("public"), route.Spec.ParentRefs[0].Name)
+	assert.Equal(t, gatewayv1.Namespace("gateways"), *route.Spec.ParentRefs[0].Namespace)
+	assert.Equal(t, gatewayv1.SectionName("https"), *route.Spec.ParentRefs[0].SectionName)
+	backend := route.Spec.Rules[0].BackendRefs[0]
+	assert.Equal(t, gatewayv1.ObjectName("argocd-server"), backend.Name)
+	assert.Equal(t, gatewayv1.PortNumber(80), *backend.Port)
+	assert.Equal(t, "/", *route.Spec.Rules[0].Matches[0].Path.Value)
+
+	// changes to the spec are applied to the existing route
+	a.Spec.Server.Gateway.Path = "/argocd"
+	assert.NoError(t, r.reconcileServerGatewayRoute(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-server", Namespace: testNamespace}, route))
+	assert.Equal(t, "/argocd", *route.Spec.Rules[0].Matches[0].Path.Value)
+
+	// a route left behind by an insecure server is removed once the server serves TLS again
+	a.Spec.Server.Insecure = false
+	assert.NoError(t, r.reconcileServerGatewayRoute(a))
+	assert.False(t, argoutil.IsObjectFound(r.Client, testNamespace, "argocd-server", &gatewayv1.HTTPRoute{}))
+
+	// TLS passthrough replaces the HTTPRoute with a TLSRoute
+	a.Spec.Server.Gateway.TLSPassthrough = true
+	assert.NoError(t, r.reconcileServerGatewayRoute(a))
+	assert.False(t, argoutil.IsObjectFound(r.Client, testNamespace, "argocd-server", &gatewayv1.HTTPRoute{}))
+	tlsRoute := &gatewayv1alpha2.TLSRoute{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-server", Namespace: testNamespace}, tlsRoute))
+	assert.Equal(t, gatewayv1.PortNumber(443), *tlsRoute.Spec.Rules[0].BackendRefs[0].Port)
+
+	// disabling the gateway removes the route
+	a.Spec.Server.Gateway.Enabled = false
+	assert.NoError(t, r.reconcileServerGatewayRoute(a))
+	assert.False(t, argoutil.IsObjectFound(r.Client, testNamespace, "argocd-server", &gatewayv1alpha2.TLSRoute{}))
+}
+
+func TestReconcileArgoCD_reconcileGatewayRoutes(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	setGatewayAPIFound(t, true, true)
+
+	parentRefs := []argoproj.ArgoCDGatewayParentReference{{Name: "public"}}
+	a := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Spec.Server.Insecure = true
+		cr.Spec.Server.GRPC.Gateway = argoproj.ArgoCDGatewayRouteSpec{Enabled: true, ParentRefs: parentRefs}
+		cr.Spec.Prometheus.Enabled = true
+		cr.Spec.Prometheus.Gateway = argoproj.ArgoCDGatewayRouteSpec{Enabled: true, ParentRefs: parentRefs}
+		cr.Spec.ApplicationSet = &argoproj.ArgoCDApplicationSet{
+			WebhookServer: argoproj.WebhookServerSpec{
+				Host:    "webhook.example.com",
+				Gateway: argoproj.ArgoCDGatewayRouteSpec{Enabled: true, ParentRefs: parentRefs},
+			},
+		}
+	})
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme, gatewayv1.Install, gatewayv1alpha2.Install)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileGatewayRoutes(a))
+
+	grpcRoute := &gatewayv1alpha2.GRPCRoute{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-grpc", Namespace: testNamespace}, grpcRoute))
+	assert.Equal(t, gatewayv1.ObjectName("argocd-server"), grpcRoute.Spec.Rules[0].BackendRefs[0].Name)
+	assert.Equal(t, gatewayv1.PortNumber(80), *grpcRoute.Spec.Rules[0].BackendRefs[0].Port)
+
+	prometheusRoute := &gatewayv1.HTTPRoute{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-prometheus", Namespace: testNamespace}, prometheusRoute))
+	assert.Equal(t, gatewayv1.ObjectName("prometheus-operated"), prometheusRoute.Spec.Rules[0].BackendRefs[0].Name)
+	assert.Empty(t, prometheusRoute.Spec.Hostnames)
+
+	webhookRoute := &gatewayv1.HTTPRoute{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-applicationset-controller", Namespace: testNamespace}, webhookRoute))
+	assert.Equal(t, []gatewayv1.Hostname{"webhook.example.com"}, webhookRoute.Spec.Hostnames)
+	assert.Equal(t, "/api/webhook", *webhookRoute.Spec.Rules[0].Matches[0].Path.Value)
+	assert.Equal(t, gatewayv1.PortNumber(7000), *webhookRoute.Spec.Rules[0].BackendRefs[0].Port)
+
+	a.Spec.Server.GRPC.Gateway.Enabled = false
+	a.Spec.Prometheus.Enabled = false
+	a.Spec.ApplicationSet = nil
+	assert.NoError(t, r.reconcileGatewayRoutes(a))
+	assert.False(t, argoutil.IsObjectFound(r.Client, testNamespace, "argocd-grpc", &gatewayv1alpha2.GRPCRoute{}))
+	assert.False(t, argoutil.IsObjectFound(r.Client, testNamespace, "argocd-prometheus", &gatewayv1.HTTPRoute{}))
+	assert.False(t, argoutil.IsObjectFound(r.Client, testNamespace, "argocd-applicationset-controller", &gatewayv1.HTTPRoute{}))
+}
+
+func TestReconcileArgoCD_reconcileServerGatewayRoute_withoutExperimentalAPI(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	setGatewayAPIFound(t, true, false)
+
+	a := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Spec.Server.Gateway = argoproj.ArgoCDGatewayRouteSpec{Enabled: true, TLSPassthrough: true}
+		cr.Spec.Server.GRPC.Gateway = argoproj.ArgoCDGatewayRouteSpec{Enabled: true}
+	})
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme, gatewayv1.Install)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	// routes from the experimental channel are skipped rather than failing the reconciliation
+	assert.NoError(t, r.reconcileServerGatewayRoute(a))
+	assert.NoError(t, r.reconcileServerGRPCGatewayRoute(a))
+}
+
+func TestReconcileArgoCD_reconcileStatusHost_gateway(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	setGatewayAPIFound(t, true, true)
+
+	a := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Spec.Server.Host = "argocd.example.com"
+		cr.Spec.Server.Insecure = true
+		cr.Spec.Server.Gateway = argoproj.ArgoCDGatewayRouteSpec{
+			Enabled:    true,
+			ParentRefs: []argoproj.ArgoCDGatewayParentReference{{Name: "public"}},
+		}
+	})
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme, gatewayv1.Install, gatewayv1alpha2.Install)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileServerGatewayRoute(a))
+
+	// the route has not been accepted by the Gateway yet
+	assert.NoError(t, r.reconcileStatusHost(a))
+	assert.Equal(t, "", a.Status.Host)
+	assert.Equal(t, "Pending", a.Status.Phase)
+
+	route := &gatewayv1.HTTPRoute{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-server", Namespace: testNamespace}, route))
+	route.Status.Parents = []gatewayv1.RouteParentStatus{
+		{
+			ParentRef:      route.Spec.ParentRefs[0],
+			ControllerName: "example.com/gateway-controller",
+			Conditions: []metav1.Condition{
+				{
+					Type:               string(gatewayv1.RouteConditionAccepted),
+					Status:             metav1.ConditionTrue,
+					Reason:             string(gatewayv1.RouteReasonAccepted),
+					LastTransitionTime: metav1.Now(),
+				},
+			},
+		},
+	}
+	assert.NoError(t, r.Client.Update(context.TODO(), route))
+
+	assert.NoError(t, r.reconcileStatusHost(a))
+	assert.Equal(t, "argocd.example.com", a.Status.Host)
+}
@@ -0,0 +1,200 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+// parseGPGPublicKeys parses the given ASCII armored GPG public keys and returns them armored one by one, indexed by
+// key ID as expected by Argo CD.
+func parseGPGPublicKeys(data string) (map[string]string, error) {
+	entities := openpgp.EntityList{}
+	// armor.Decode reuses a bufio.Reader, so that the blocks following the first one are not lost in its buffer.
+	reader := bufio.NewReader(strings.NewReader(data))
+	for {
+		block, err := armor.Decode(reader)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if block.Type != openpgp.PublicKeyType {
+			return nil, fmt.Errorf("unexpected %s block, only public keys are allowed", block.Type)
+		}
+		blockEntities, err := openpgp.ReadKeyRing(block.Body)
+		if err != nil {
+			return nil, err
+		}
+		entities = append(entities, blockEntities...)
+	}
+	if len(entities) == 0 {
+		return nil, errors.New("no public key found")
+	}
+
+	keys := map[string]string{}
+	for _, entity := range entities {
+		if entity.PrivateKey != nil {
+			return nil, errors.New("private keys are not allowed")
+		}
+		var buf bytes.Buffer
+		w, err := armor.Encode(&buf, openpgp.PublicKeyType, nil)
+		if err != nil {
+			return nil, err
+		}
+		if err := entity.Serialize(w); err != nil {
+			return nil, err
+		}
+		if err := w.Close(); err != nil {
+			return nil, err
+		}
+		keys[strings.ToUpper(entity.PrimaryKey.KeyIdString())] = buf.String() + "\n"
+	}
+	return keys, nil
+}
+
+// getGPGKeySource returns the ASCII armored GPG public keys of the given source.
+func (r *ReconcileArgoCD) getGPGKeySource(cr *argoproj.ArgoCD, source argoproj.ArgoCDGPGKeySpec) (string, error) {
+	switch {
+	case source.ConfigMapRef != nil:
+		cm := newConfigMapWithName(source.ConfigMapRef.Name, cr)
+		if !argoutil.IsObjectFound(r.Client, cr.Namespace, cm.Name, cm) {
+			return "", fmt.Errorf("configmap %s not found", source.ConfigMapRef.Name)
+		}
+		data, ok := cm.Data[source.ConfigMapRef.Key]
+		if !ok {
+			return "", fmt.Errorf("key %s not found in configmap %s", source.ConfigMapRef.Key, source.ConfigMapRef.Name)
+		}
+		return data, nil
+	case source.SecretRef != nil:
+		secret, err := argoutil.FetchSecret(r.Client, cr.ObjectMeta, source.SecretRef.Name)
+		if err != nil {
+			return "", err
+		}
+		data, ok := secret.Data[source.SecretRef.Key]
+		if !ok {
+			return "", fmt.Errorf("key %s not found in secret %s", source.SecretRef.Key, source.SecretRef.Name)
+		}
+		return string(data), nil
+	default:
+		return source.PublicKey, nil
+	}
+}
+
+// getGPGKeys returns the GPG public keys declared in the given ArgoCD, indexed by key ID, together with the problems
+// of the sources that cannot be read or hold an invalid key. Those sources are skipped.
+func (r *ReconcileArgoCD) getGPGKeys(cr *argoproj.ArgoCD) (map[string]string, []string) {
+	keys := map[string]string{}
+	problems := []string{}
+	for i, source := range cr.Spec.GPGKeys {
+		data, err := r.getGPGKeySource(cr, source)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("gpg key %d: %v", i, err))
+			continue
+		}
+		parsed, err := parseGPGPublicKeys(data)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("gpg key %d: %v", i, err))
+			continue
+		}
+		for id, key := range parsed {
+			keys[id] = key
+		}
+	}
+	return keys, problems
+}
+
+// getManagedGPGKeyIDs returns the IDs of the GPG keys previously written by the operator in the given ConfigMap.
+func getManagedGPGKeyIDs(cm *corev1.ConfigMap) []string {
+	ids := cm.Annotations[common.ArgoCDManagedGPGKeysAnnotation]
+	if ids == "" {
+		return nil
+	}
+	return strings.Split(ids, ",")
+}
+
+// reconcileGPGKeysConfigMap creates a gpg-keys config map, and keeps the GPG keys declared in the ArgoCD up to date.
+// The keys added by other means are left untouched. Sources that cannot be read or hold an invalid key are skipped
+// and reported with a warning Event on the ArgoCD.
+func (r *ReconcileArgoCD) reconcileGPGKeysConfigMap(cr *argoproj.ArgoCD) error {
+	keys, problems := r.getGPGKeys(cr)
+	for _, problem := range problems {
+		log.Info(fmt.Sprintf("skipping invalid %s of ArgoCD %s in namespace %s", problem, cr.Name, cr.Namespace))
+		r.recordEvent(cr, corev1.EventTypeWarning, "InvalidGPGKey", "skipped invalid "+problem)
+	}
+
+	cm := newConfigMapWithName(common.ArgoCDGPGKeysConfigMapName, cr)
+	found := argoutil.IsObjectFound(r.Client, cr.Namespace, cm.Name, cm)
+
+	data := map[string]string{}
+	for id, key := range cm.Data {
+		data[id] = key
+	}
+	for _, id := range getManagedGPGKeyIDs(cm) {
+		delete(data, id)
+	}
+	ids := make([]string, 0, len(keys))
+	for id, key := range keys {
+		data[id] = key
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	if !found {
+		if len(keys) > 0 {
+			cm.Data = data
+			cm.Annotations = map[string]string{common.ArgoCDManagedGPGKeysAnnotation: strings.Join(ids, ",")}
+		}
+		if err := controllerutil.SetControllerReference(cr, cm, r.Scheme); err != nil {
+			return err
+		}
+		return r.Client.Create(context.TODO(), cm)
+	}
+
+	managedIDs := strings.Join(ids, ",")
+	dataChanged := (len(cm.Data) > 0 || len(data) > 0) && !reflect.DeepEqual(cm.Data, data)
+	if !dataChanged && cm.Annotations[common.ArgoCDManagedGPGKeysAnnotation] == managedIDs {
+		return nil // ConfigMap found with nothing to do, move along...
+	}
+
+	cm.Data = data
+	if len(ids) > 0 {
+		if cm.Annotations == nil {
+			cm.Annotations = map[string]string{}
+		}
+		cm.Annotations[common.ArgoCDManagedGPGKeysAnnotation] = managedIDs
+	} else {
+		delete(cm.Annotations, common.ArgoCDManagedGPGKeysAnnotation)
+	}
+	return r.Client.Update(context.TODO(), cm)
+}
@@ -0,0 +1,140 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+// makeTestGPGKey returns the ID and the ASCII armored public key of a new GPG key.
+func makeTestGPGKey(t *testing.T, name string) (string, string) {
+	entity, err := openpgp.NewEntity(name, "", name+"@example.com", nil)
+	assert.NoError(t, err)
+	var buf bytes.Buffer
+	w, err := armor.Encode(&buf, openpgp.PublicKeyType, nil)
+	assert.NoError(t, err)
+	assert.NoError(t, entity.Serialize(w))
+	assert.NoError(t, w.Close())
+	return strings.ToUpper(entity.PrimaryKey.KeyIdString()), buf.String()
+}
+
+func TestParseGPGPublicKeys(t *testing.T) {
+	aliceID, alice := makeTestGPGKey(t, "alice")
+	bobID, bob := makeTestGPGKey(t, "bob")
+
+	keys, err := parseGPGPublicKeys(alice + "\n" + bob)
+	assert.NoError(t, err)
+	assert.Len(t, keys, 2)
+	assert.Contains(t, keys, aliceID)
+	assert.Contains(t, keys, bobID)
+	assert.Len(t, aliceID, 16)
+
+	// each key is armored on its own
+	keys, err = parseGPGPublicKeys(keys[aliceID])
+	assert.NoError(t, err)
+	assert.Len(t, keys, 1)
+
+	_, err = parseGPGPublicKeys("not a key")
+	assert.Error(t, err)
+}
+
+func TestReconcileArgoCD_GPGKeys(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	aliceID, alice := makeTestGPGKey(t, "alice")
+	bobID, bob := makeTestGPGKey(t, "bob")
+	carolID, carol := makeTestGPGKey(t, "carol")
+
+	a := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Spec.GPGKeys = []argoproj.ArgoCDGPGKeySpec{
+			{PublicKey: alice},
+			{ConfigMapRef: &corev1.ConfigMapKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "team-keys"},
+				Key:                  "bob.asc",
+			}},
+		}
+	})
+	teamKeys := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "team-keys", Namespace: testNamespace},
+		Data:       map[string]string{"bob.asc": bob},
+	}
+	manualKeys := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: common.ArgoCDGPGKeysConfigMapName, Namespace: testNamespace},
+		Data:       map[string]string{carolID: carol},
+	}
+
+	resObjs := []client.Object{a, teamKeys, manualKeys}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileGPGKeysConfigMap(a))
+	cm := &corev1.ConfigMap{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDGPGKeysConfigMapName, Namespace: testNamespace}, cm))
+	assert.Len(t, cm.Data, 3)
+	assert.Contains(t, cm.Data, aliceID)
+	assert.Contains(t, cm.Data, bobID)
+	assert.Equal(t, carol, cm.Data[carolID])
+	assert.ElementsMatch(t, []string{aliceID, bobID}, getManagedGPGKeyIDs(cm))
+
+	// keys that are no longer declared are removed, the others are left untouched
+	a.Spec.GPGKeys = a.Spec.GPGKeys[:1]
+	assert.NoError(t, r.reconcileGPGKeysConfigMap(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDGPGKeysConfigMapName, Namespace: testNamespace}, cm))
+	assert.Len(t, cm.Data, 2)
+	assert.NotContains(t, cm.Data, bobID)
+	assert.Equal(t, []string{aliceID}, getManagedGPGKeyIDs(cm))
+
+	a.Spec.GPGKeys = nil
+	assert.NoError(t, r.reconcileGPGKeysConfigMap(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDGPGKeysConfigMapName, Namespace: testNamespace}, cm))
+	assert.Equal(t, map[string]string{carolID: carol}, cm.Data)
+	assert.NotContains(t, cm.Annotations, common.ArgoCDManagedGPGKeysAnnotation)
+
+	// invalid sources are skipped and reported, the valid ones are still applied
+	recorder := record.NewFakeRecorder(10)
+	r.recorder = recorder
+	a.Spec.GPGKeys = []argoproj.ArgoCDGPGKeySpec{
+		{PublicKey: "not a key"},
+		{SecretRef: secretKeySelector("missing", "key.asc")},
+		{PublicKey: alice},
+	}
+	assert.NoError(t, r.reconcileGPGKeysConfigMap(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDGPGKeysConfigMapName, Namespace: testNamespace}, cm))
+	assert.Len(t, cm.Data, 2)
+	assert.Contains(t, cm.Data, aliceID)
+	assert.Equal(t, []string{aliceID}, getManagedGPGKeyIDs(cm))
+	assert.Len(t, recorder.Events, 2)
+	assert.Contains(t, <-recorder.Events, "Warning InvalidGPGKey skipped invalid gpg key 0: ")
+	assert.Contains(t, <-recorder.Events, "Warning InvalidGPGKey skipped invalid gpg key 1: ")
+}
@@ -17,6 +17,8 @@ package argocd
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"slices"
 
 	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -36,6 +38,91 @@ func getPathOrDefault(path string) string {
 	return result
 }
 
+// getIngressHosts will return the hosts exposed by an Ingress: the host of the component followed by any extra hosts.
+func getIngressHosts(host string, spec argoproj.ArgoCDIngressSpec) []string {
+	hosts := []string{host}
+	for _, h := range spec.ExtraHosts {
+		if h != "" && h != host {
+			hosts = append(hosts, h)
+		}
+	}
+	return hosts
+}
+
+// getIngressPaths will return the paths routed by an Ingress: the path of the component followed by any extra paths.
+func getIngressPaths(path string, spec argoproj.ArgoCDIngressSpec) []string {
+	paths := []string{path}
+	for _, p := range spec.ExtraPaths {
+		if p != "" && p != path {
+			paths = append(paths, p)
+		}
+	}
+	return paths
+}
+
+// getIngressPathType will return the path type to use for the paths of an Ingress.
+func getIngressPathType(spec argoproj.ArgoCDIngressSpec) networkingv1.PathType {
+	if spec.PathType != nil && len(*spec.PathType) > 0 {
+		return *spec.PathType
+	}
+	return networkingv1.PathTypeImplementationSpecific
+}
+
+// newIngressRules returns a rule for every given host, each routing all of the given paths to the named port of
+// the given Service.
+func newIngressRules(hosts, paths []string, spec argoproj.ArgoCDIngressSpec, service, port string) []networkingv1.IngressRule {
+	rules := make([]networkingv1.IngressRule, 0, len(hosts))
+	for _, host := range hosts {
+		httpPaths := make([]networkingv1.HTTPIngressPath, 0, len(paths))
+		for _, path := range paths {
+			pathType := getIngressPathType(spec)
+			httpPaths = append(httpPaths, networkingv1.HTTPIngressPath{
+				Path: path,
+				Backend: networkingv1.IngressBackend{
+					Service: &networkingv1.IngressServiceBackend{
+						Name: service,
+						Port: networkingv1.ServiceBackendPort{
+							Name: port,
+						},
+					},
+				},
+				PathType: &pathType,
+			})
+		}
+		rules = append(rules, networkingv1.IngressRule{
+			Host: host,
+			IngressRuleValue: networkingv1.IngressRuleValue{
+				HTTP: &networkingv1.HTTPIngressRuleValue{
+					Paths: httpPaths,
+				},
+			},
+		})
+	}
+	return rules
+}
+
+// getIngressURLs will return every URL exposed by the rules of the given Ingress. Hosts covered by one of the TLS
+// options of the Ingress are reported with the https scheme.
+func getIngressURLs(ingress *networkingv1.Ingress) []string {
+	var urls []string
+	for _, rule := range ingress.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		scheme := "http"
+		for _, tls := range ingress.Spec.TLS {
+			if len(tls.Hosts) == 0 || slices.Contains(tls.Hosts, rule.Host) {
+				scheme = "https"
+				break
+			}
+		}
+		for _, path := range rule.HTTP.Paths {
+			urls = append(urls, fmt.Sprintf("%s://%s%s", scheme, rule.Host, path.Path))
+		}
+	}
+	return urls
+}
+
 // newIngress returns a new Ingress instance for the given ArgoCD.
 func newIngress(cr *argoproj.ArgoCD) *networkingv1.Ingress {
 	return &networkingv1.Ingress{
@@ -92,24 +179,18 @@ func (r *ReconcileArgoCD) reconcileIngresses(cr *argoproj.ArgoCD) error {
 // reconcileArgoServerIngress will ensure that the ArgoCD Server Ingress is present.
 func (r *ReconcileArgoCD) reconcileArgoServerIngress(cr *argoproj.ArgoCD) error {
 	ingress := newIngressWithSuffix("server", cr)
-	if argoutil.IsObjectFound(r.Client, cr.Namespace, ingress.Name, ingress) {
-		if !cr.Spec.Server.Ingress.Enabled {
-			// Ingress exists but enabled flag has been set to false, delete the Ingress
-			return r.Client.Delete(context.TODO(), ingress)
-		}
-
-		// If Ingress found and enabled, make sure the ingressClassName is up-to-date
-		if ingress.Spec.IngressClassName != cr.Spec.Server.Ingress.IngressClassName {
-			ingress.Spec.IngressClassName = cr.Spec.Server.Ingress.IngressClassName
-			return r.Client.Update(context.TODO(), ingress)
-		}
-		return nil // Ingress found and enabled, do nothing
+	found := argoutil.IsObjectFound(r.Client, cr.Namespace, ingress.Name, ingress)
+	if found && !cr.Spec.Server.Ingress.Enabled {
+		// Ingress exists but enabled flag has been set to false, delete the Ingress
+		return r.Client.Delete(context.TODO(), ingress)
 	}
 
 	if !cr.Spec.Server.Ingress.Enabled {
 		return nil // Ingress not enabled, move along...
 	}
 
+	desired := newIngressWithSuffix("server", cr)
+
 	// Add default annotations
 	atns := make(map[string]string)
 	atns[common.ArgoCDKeyIngressSSLRedirect] = "true"
@@ -120,72 +201,46 @@ func (r *ReconcileArgoCD) reconcileArgoServerIngress(cr *argoproj.ArgoCD) error
 		atns = cr.Spec.Server.Ingress.Annotations
 	}
 
-	ingress.ObjectMeta.Annotations = atns
+	desired.ObjectMeta.Annotations = atns
 
-	ingress.Spec.IngressClassName = cr.Spec.Server.Ingress.IngressClassName
+	desired.Spec.IngressClassName = cr.Spec.Server.Ingress.IngressClassName
 
-	pathType := networkingv1.PathTypeImplementationSpecific
 	// Add rules
-	ingress.Spec.Rules = []networkingv1.IngressRule{
-		{
-			Host: getArgoServerHost(cr),
-			IngressRuleValue: networkingv1.IngressRuleValue{
-				HTTP: &networkingv1.HTTPIngressRuleValue{
-					Paths: []networkingv1.HTTPIngressPath{
-						{
-							Path: getPathOrDefault(cr.Spec.Server.Ingress.Path),
-							Backend: networkingv1.IngressBackend{
-								Service: &networkingv1.IngressServiceBackend{
-									Name: nameWithSuffix("server", cr),
-									Port: networkingv1.ServiceBackendPort{
-										Name: "http",
-									},
-								},
-							},
-							PathType: &pathType,
-						},
-					},
-				},
-			},
-		},
-	}
+	hosts := getIngressHosts(getArgoServerHost(cr), cr.Spec.Server.Ingress)
+	paths := getIngressPaths(getPathOrDefault(cr.Spec.Server.Ingress.Path), cr.Spec.Server.Ingress)
+	desired.Spec.Rules = newIngressRules(hosts, paths, cr.Spec.Server.Ingress, nameWithSuffix("server", cr), "http")
 
 	// Add default TLS options
-	ingress.Spec.TLS = []networkingv1.IngressTLS{
+	desired.Spec.TLS = []networkingv1.IngressTLS{
 		{
-			Hosts: []string{
-				getArgoServerHost(cr),
-			},
+			Hosts:      hosts,
 			SecretName: common.ArgoCDSecretName,
 		},
 	}
 
 	// Allow override of TLS options if specified
 	if len(cr.Spec.Server.Ingress.TLS) > 0 {
-		ingress.Spec.TLS = cr.Spec.Server.Ingress.TLS
+		desired.Spec.TLS = cr.Spec.Server.Ingress.TLS
 	}
 
-	if err := controllerutil.SetControllerReference(cr, ingress, r.Scheme); err != nil {
-		return err
-	}
-	return r.Client.Create(context.TODO(), ingress)
+	return r.applyIngress(cr, desired, ingress, found, cr.Spec.Server.Ingress)
 }
 
 // reconcileArgoServerGRPCIngress will ensure that the ArgoCD Server GRPC Ingress is present.
 func (r *ReconcileArgoCD) reconcileArgoServerGRPCIngress(cr *argoproj.ArgoCD) error {
 	ingress := newIngressWithSuffix("grpc", cr)
-	if argoutil.IsObjectFound(r.Client, cr.Namespace, ingress.Name, ingress) {
-		if !cr.Spec.Server.GRPC.Ingress.Enabled {
-			// Ingress exists but enabled flag has been set to false, delete the Ingress
-			return r.Client.Delete(context.TODO(), ingress)
-		}
-		return nil // Ingress found and enabled, do nothing
+	found := argoutil.IsObjectFound(r.Client, cr.Namespace, ingress.Name, ingress)
+	if found && !cr.Spec.Server.GRPC.Ingress.Enabled {
+		// Ingress exists but enabled flag has been set to false, delete the Ingress
+		return r.Client.Delete(context.TODO(), ingress)
 	}
 
 	if !cr.Spec.Server.GRPC.Ingress.Enabled {
 		return nil // Ingress not enabled, move along...
 	}
 
+	desired := newIngressWithSuffix("grpc", cr)
+
 	// Add default annotations
 	atns := make(map[string]string)
 	atns[common.ArgoCDKeyIngressBackendProtocol] = "GRPC"
@@ -195,55 +250,29 @@ func (r *ReconcileArgoCD) reconcileArgoServerGRPCIngress(cr *argoproj.ArgoCD) er
 		atns = cr.Spec.Server.GRPC.Ingress.Annotations
 	}
 
-	ingress.ObjectMeta.Annotations = atns
+	desired.ObjectMeta.Annotations = atns
 
-	ingress.Spec.IngressClassName = cr.Spec.Server.GRPC.Ingress.IngressClassName
+	desired.Spec.IngressClassName = cr.Spec.Server.GRPC.Ingress.IngressClassName
 
-	pathType := networkingv1.PathTypeImplementationSpecific
 	// Add rules
-	ingress.Spec.Rules = []networkingv1.IngressRule{
-		{
-			Host: getArgoServerGRPCHost(cr),
-			IngressRuleValue: networkingv1.IngressRuleValue{
-				HTTP: &networkingv1.HTTPIngressRuleValue{
-					Paths: []networkingv1.HTTPIngressPath{
-						{
-							Path: getPathOrDefault(cr.Spec.Server.GRPC.Ingress.Path),
-							Backend: networkingv1.IngressBackend{
-								Service: &networkingv1.IngressServiceBackend{
-									Name: nameWithSuffix("server", cr),
-									Port: networkingv1.ServiceBackendPort{
-										Name: "https",
-									},
-								},
-							},
-							PathType: &pathType,
-						},
-					},
-				},
-			},
-		},
-	}
+	hosts := getIngressHosts(getArgoServerGRPCHost(cr), cr.Spec.Server.GRPC.Ingress)
+	paths := getIngressPaths(getPathOrDefault(cr.Spec.Server.GRPC.Ingress.Path), cr.Spec.Server.GRPC.Ingress)
+	desired.Spec.Rules = newIngressRules(hosts, paths, cr.Spec.Server.GRPC.Ingress, nameWithSuffix("server", cr), "https")
 
 	// Add TLS options
-	ingress.Spec.TLS = []networkingv1.IngressTLS{
+	desired.Spec.TLS = []networkingv1.IngressTLS{
 		{
-			Hosts: []string{
-				getArgoServerGRPCHost(cr),
-			},
+			Hosts:      hosts,
 			SecretName: common.ArgoCDSecretName,
 		},
 	}
 
 	// Allow override of TLS options if specified
 	if len(cr.Spec.Server.GRPC.Ingress.TLS) > 0 {
-		ingress.Spec.TLS = cr.Spec.Server.GRPC.Ingress.TLS
+		desired.Spec.TLS = cr.Spec.Server.GRPC.Ingress.TLS
 	}
 
-	if err := controllerutil.SetControllerReference(cr, ingress, r.Scheme); err != nil {
-		return err
-	}
-	return r.Client.Create(context.TODO(), ingress)
+	return r.applyIngress(cr, desired, ingress, found, cr.Spec.Server.GRPC.Ingress)
 }
 
 // reconcileGrafanaIngress will ensure that the ArgoCD Server GRPC Ingress is present.
@@ -272,18 +301,18 @@ func (r *ReconcileArgoCD) reconcileGrafanaIngress(cr *argoproj.ArgoCD) error {
 // reconcilePrometheusIngress will ensure that the Prometheus Ingress is present.
 func (r *ReconcileArgoCD) reconcilePrometheusIngress(cr *argoproj.ArgoCD) error {
 	ingress := newIngressWithSuffix("prometheus", cr)
-	if argoutil.IsObjectFound(r.Client, cr.Namespace, ingress.Name, ingress) {
-		if !cr.Spec.Prometheus.Enabled || !cr.Spec.Prometheus.Ingress.Enabled {
-			// Ingress exists but enabled flag has been set to false, delete the Ingress
-			return r.Client.Delete(context.TODO(), ingress)
-		}
-		return nil // Ingress found and enabled, do nothing
+	found := argoutil.IsObjectFound(r.Client, cr.Namespace, ingress.Name, ingress)
+	if found && (!cr.Spec.Prometheus.Enabled || !cr.Spec.Prometheus.Ingress.Enabled) {
+		// Ingress exists but enabled flag has been set to false, delete the Ingress
+		return r.Client.Delete(context.TODO(), ingress)
 	}
 
 	if !cr.Spec.Prometheus.Enabled || !cr.Spec.Prometheus.Ingress.Enabled {
 		return nil // Prometheus itself or Ingress not enabled, move along...
 	}
 
+	desired := newIngressWithSuffix("prometheus", cr)
+
 	// Add default annotations
 	atns := make(map[string]string)
 	atns[common.ArgoCDKeyIngressSSLRedirect] = "true"
@@ -294,38 +323,17 @@ func (r *ReconcileArgoCD) reconcilePrometheusIngress(cr *argoproj.ArgoCD) error
 		atns = cr.Spec.Prometheus.Ingress.Annotations
 	}
 
-	ingress.ObjectMeta.Annotations = atns
+	desired.ObjectMeta.Annotations = atns
 
-	ingress.Spec.IngressClassName = cr.Spec.Prometheus.Ingress.IngressClassName
+	desired.Spec.IngressClassName = cr.Spec.Prometheus.Ingress.IngressClassName
 
-	pathType := networkingv1.PathTypeImplementationSpecific
 	// Add rules
-	ingress.Spec.Rules = []networkingv1.IngressRule{
-		{
-			Host: getPrometheusHost(cr),
-			IngressRuleValue: networkingv1.IngressRuleValue{
-				HTTP: &networkingv1.HTTPIngressRuleValue{
-					Paths: []networkingv1.HTTPIngressPath{
-						{
-							Path: getPathOrDefault(cr.Spec.Prometheus.Ingress.Path),
-							Backend: networkingv1.IngressBackend{
-								Service: &networkingv1.IngressServiceBackend{
-									Name: "prometheus-operated",
-									Port: networkingv1.ServiceBackendPort{
-										Name: "web",
-									},
-								},
-							},
-							PathType: &pathType,
-						},
-					},
-				},
-			},
-		},
-	}
+	hosts := getIngressHosts(getPrometheusHost(cr), cr.Spec.Prometheus.Ingress)
+	paths := getIngressPaths(getPathOrDefault(cr.Spec.Prometheus.Ingress.Path), cr.Spec.Prometheus.Ingress)
+	desired.Spec.Rules = newIngressRules(hosts, paths, cr.Spec.Prometheus.Ingress, "prometheus-operated", "web")
 
 	// Add TLS options
-	ingress.Spec.TLS = []networkingv1.IngressTLS{
+	desired.Spec.TLS = []networkingv1.IngressTLS{
 		{
 			Hosts:      []string{cr.Name},
 			SecretName: common.ArgoCDSecretName,
@@ -334,23 +342,18 @@ func (r *ReconcileArgoCD) reconcilePrometheusIngress(cr *argoproj.ArgoCD) error
 
 	// Allow override of TLS options if specified
 	if len(cr.Spec.Prometheus.Ingress.TLS) > 0 {
-		ingress.Spec.TLS = cr.Spec.Prometheus.Ingress.TLS
+		desired.Spec.TLS = cr.Spec.Prometheus.Ingress.TLS
 	}
 
-	if err := controllerutil.SetControllerReference(cr, ingress, r.Scheme); err != nil {
-		return err
-	}
-	return r.Client.Create(context.TODO(), ingress)
+	return r.applyIngress(cr, desired, ingress, found, cr.Spec.Prometheus.Ingress)
 }
 
 // reconcileApplicationSetControllerIngress will ensure that the ApplicationSetController Ingress is present.
 func (r *ReconcileArgoCD) reconcileApplicationSetControllerIngress(cr *argoproj.ArgoCD) error {
 	ingress := newIngressWithSuffix(common.ApplicationSetServiceNameSuffix, cr)
-	if argoutil.IsObjectFound(r.Client, cr.Namespace, ingress.Name, ingress) {
-		if cr.Spec.ApplicationSet == nil || !cr.Spec.ApplicationSet.WebhookServer.Ingress.Enabled {
-			return r.Client.Delete(context.TODO(), ingress)
-		}
-		return nil // Ingress found and enabled, do nothing
+	found := argoutil.IsObjectFound(r.Client, cr.Namespace, ingress.Name, ingress)
+	if found && (cr.Spec.ApplicationSet == nil || !cr.Spec.ApplicationSet.WebhookServer.Ingress.Enabled) {
+		return r.Client.Delete(context.TODO(), ingress)
 	}
 
 	if cr.Spec.ApplicationSet == nil || !cr.Spec.ApplicationSet.WebhookServer.Ingress.Enabled {
@@ -358,6 +361,8 @@ func (r *ReconcileArgoCD) reconcileApplicationSetControllerIngress(cr *argoproj.
 		return nil // Ingress not enabled, move along...
 	}
 
+	desired := newIngressWithSuffix(common.ApplicationSetServiceNameSuffix, cr)
+
 	// Add annotations
 	atns := make(map[string]string)
 	atns[common.ArgoCDKeyIngressSSLRedirect] = "true"
@@ -368,46 +373,65 @@ func (r *ReconcileArgoCD) reconcileApplicationSetControllerIngress(cr *argoproj.
 		atns = cr.Spec.ApplicationSet.WebhookServer.Ingress.Annotations
 	}
 
-	ingress.ObjectMeta.Annotations = atns
+	desired.ObjectMeta.Annotations = atns
 
-	pathType := networkingv1.PathTypeImplementationSpecific
 	httpServerHost, err := getApplicationSetHTTPServerHost(cr)
 	if err != nil {
 		return err
 	}
 
 	// Add rules
-	ingress.Spec.Rules = []networkingv1.IngressRule{
-		{
-			Host: httpServerHost,
-			IngressRuleValue: networkingv1.IngressRuleValue{
-				HTTP: &networkingv1.HTTPIngressRuleValue{
-					Paths: []networkingv1.HTTPIngressPath{
-						{
-							Path: "/api/webhook",
-							Backend: networkingv1.IngressBackend{
-								Service: &networkingv1.IngressServiceBackend{
-									Name: nameWithSuffix(common.ApplicationSetServiceNameSuffix, cr),
-									Port: networkingv1.ServiceBackendPort{
-										Name: "webhook",
-									},
-								},
-							},
-							PathType: &pathType,
-						},
-					},
-				},
-			},
-		},
-	}
+	hosts := getIngressHosts(httpServerHost, cr.Spec.ApplicationSet.WebhookServer.Ingress)
+	paths := getIngressPaths("/api/webhook", cr.Spec.ApplicationSet.WebhookServer.Ingress)
+	desired.Spec.Rules = newIngressRules(hosts, paths, cr.Spec.ApplicationSet.WebhookServer.Ingress, nameWithSuffix(common.ApplicationSetServiceNameSuffix, cr), "webhook")
 
 	// Allow override of TLS options if specified
 	if len(cr.Spec.ApplicationSet.WebhookServer.Ingress.TLS) > 0 {
-		ingress.Spec.TLS = cr.Spec.ApplicationSet.WebhookServer.Ingress.TLS
+		desired.Spec.TLS = cr.Spec.ApplicationSet.WebhookServer.Ingress.TLS
 	}
 
-	if err := controllerutil.SetControllerReference(cr, ingress, r.Scheme); err != nil {
-		return err
+	return r.applyIngress(cr, desired, ingress, found, cr.Spec.ApplicationSet.WebhookServer.Ingress)
+}
+
+// applyIngress will create the desired Ingress when it was not found, or bring the ingress class, rules, TLS options
+// and labels of the existing Ingress in line with it. Annotations are only set when the Ingress is created.
+func (r *ReconcileArgoCD) applyIngress(cr *argoproj.ArgoCD, desired, existing *networkingv1.Ingress, found bool, spec argoproj.ArgoCDIngressSpec) error {
+	for key, val := range spec.Labels {
+		desired.ObjectMeta.Labels[key] = val
+	}
+
+	if !found {
+		if err := controllerutil.SetControllerReference(cr, desired, r.Scheme); err != nil {
+			return err
+		}
+		return r.Client.Create(context.TODO(), desired)
+	}
+
+	changed := false
+	if !reflect.DeepEqual(existing.Spec.IngressClassName, desired.Spec.IngressClassName) {
+		existing.Spec.IngressClassName = desired.Spec.IngressClassName
+		changed = true
+	}
+	if !reflect.DeepEqual(existing.Spec.Rules, desired.Spec.Rules) {
+		existing.Spec.Rules = desired.Spec.Rules
+		changed = true
+	}
+	if !reflect.DeepEqual(existing.Spec.TLS, desired.Spec.TLS) {
+		existing.Spec.TLS = desired.Spec.TLS
+		changed = true
+	}
+	for key, val := range desired.ObjectMeta.Labels {
+		if existing.ObjectMeta.Labels[key] != val {
+			if existing.ObjectMeta.Labels == nil {
+				existing.ObjectMeta.Labels = map[string]string{}
+			}
+			existing.ObjectMeta.Labels[key] = val
+			changed = true
+		}
+	}
+
+	if !changed {
+		return nil // Ingress found and up to date, do nothing
 	}
-	return r.Client.Create(context.TODO(), ingress)
+	return r.Client.Update(context.TODO(), existing)
 }
@@ -226,3 +226,88 @@ func TestReconcileApplicationSetService_Ingress(t *testing.T) {
 	assert.NoError(t, r.reconcileApplicationSetControllerIngress(a))
 	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Namespace: ingress.Namespace, Name: ingress.Name}, ingress))
 }
+
+func TestReconcileArgoCD_reconcile_ServerIngress_extraHostsAndPaths(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+
+	prefix := networkingv1.PathTypePrefix
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Server.Host = "argocd.example.com"
+		a.Spec.Server.Ingress = argoproj.ArgoCDIngressSpec{
+			Enabled:    true,
+			ExtraHosts: []string{"argocd.internal.example.com"},
+			ExtraPaths: []string{"/api/webhook"},
+			Labels:     map[string]string{"exposure": "public"},
+			PathType:   &prefix,
+		}
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileArgoServerIngress(a))
+
+	ingress := &networkingv1.Ingress{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-server", Namespace: testNamespace}, ingress))
+	assert.Equal(t, "public", ingress.Labels["exposure"])
+	assert.Len(t, ingress.Spec.Rules, 2)
+	assert.Equal(t, "argocd.example.com", ingress.Spec.Rules[0].Host)
+	assert.Equal(t, "argocd.internal.example.com", ingress.Spec.Rules[1].Host)
+	for _, rule := range ingress.Spec.Rules {
+		assert.Len(t, rule.HTTP.Paths, 2)
+		assert.Equal(t, "/", rule.HTTP.Paths[0].Path)
+		assert.Equal(t, "/api/webhook", rule.HTTP.Paths[1].Path)
+		assert.Equal(t, networkingv1.PathTypePrefix, *rule.HTTP.Paths[0].PathType)
+	}
+	assert.Equal(t, []string{"argocd.example.com", "argocd.internal.example.com"}, ingress.Spec.TLS[0].Hosts)
+	assert.Equal(t, []string{
+		"https://argocd.example.com/",
+		"https://argocd.example.com/api/webhook",
+		"https://argocd.internal.example.com/",
+		"https://argocd.internal.example.com/api/webhook",
+	}, getIngressURLs(ingress))
+
+	// hosts removed from the spec are removed from the existing Ingress
+	a.Spec.Server.Ingress.ExtraHosts = nil
+	a.Spec.Server.Ingress.ExtraPaths = nil
+	assert.NoError(t, r.reconcileArgoServerIngress(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-server", Namespace: testNamespace}, ingress))
+	assert.Len(t, ingress.Spec.Rules, 1)
+	assert.Len(t, ingress.Spec.Rules[0].HTTP.Paths, 1)
+	assert.Equal(t, []string{"argocd.example.com"}, ingress.Spec.TLS[0].Hosts)
+}
+
+func TestReconcileApplicationSetService_Ingress_extraHosts(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.ApplicationSet = &argoproj.ArgoCDApplicationSet{
+			WebhookServer: argoproj.WebhookServerSpec{
+				Host: "webhook.example.com",
+				Ingress: argoproj.ArgoCDIngressSpec{
+					Enabled:    true,
+					ExtraHosts: []string{"webhook.internal.example.com"},
+				},
+			},
+		}
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileApplicationSetControllerIngress(a))
+
+	ingress := newIngressWithSuffix(common.ApplicationSetServiceNameSuffix, a)
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Namespace: ingress.Namespace, Name: ingress.Name}, ingress))
+	assert.Equal(t, []string{
+		"http://webhook.example.com/api/webhook",
+		"http://webhook.internal.example.com/api/webhook",
+	}, getIngressURLs(ingress))
+}
@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"os"
 	"reflect"
+	"strings"
 
 	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
 	"github.com/argoproj-labs/argocd-operator/common"
@@ -47,6 +48,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
@@ -78,6 +80,8 @@ const (
 	defaultKeycloakAdminPassword = "admin"
 	// Default Hostname for Keycloak Ingress.
 	keycloakIngressHost = "keycloak-ingress"
+	// Annotation on the Keycloak deployment that records the additional realm configuration applied to Keycloak.
+	keycloakRealmConfigAnnotation = "argocd.argoproj.io/realm-config"
 )
 
 var (
@@ -956,6 +960,182 @@ func createRealmConfig(cfg *keycloakConfig) ([]byte, error) {
 	return json, nil
 }
 
+// getKeycloakRealmConfig will return the additional realm configuration declared in `.spec.sso.keycloak.realm` of
+// the given ArgoCD, resolving the referenced client secrets.
+func (r *ReconcileArgoCD) getKeycloakRealmConfig(cr *argoproj.ArgoCD) (*keycloakRealmConfig, error) {
+	cfg := &keycloakRealmConfig{}
+	if cr.Spec.SSO == nil || cr.Spec.SSO.Keycloak == nil || cr.Spec.SSO.Keycloak.Realm == nil {
+		return cfg, nil
+	}
+	realm := cr.Spec.SSO.Keycloak.Realm
+
+	var errs []string
+	unique := func(path, name string, seen map[string]bool) {
+		if name == "" {
+			errs = append(errs, path+": must not be empty")
+		} else if seen[name] {
+			errs = append(errs, fmt.Sprintf("%s: duplicate name %q", path, name))
+		}
+		seen[name] = true
+	}
+
+	aliases := make(map[string]bool)
+	for i, idp := range realm.IdentityProviders {
+		path := fmt.Sprintf("identityProviders[%d]", i)
+		unique(path+".alias", idp.Alias, aliases)
+		if idp.ProviderID == "" {
+			errs = append(errs, path+".providerId: must not be empty")
+		}
+
+		config := make(map[string]string)
+		for k, v := range idp.Config {
+			config[k] = v
+		}
+		if ref := idp.ClientSecretRef; ref != nil {
+			secret := &corev1.Secret{}
+			if err := r.Client.Get(context.TODO(), types.NamespacedName{Name: ref.Name, Namespace: cr.Namespace}, secret); err != nil {
+				return nil, fmt.Errorf("%s.clientSecretRef: %w", path, err)
+			}
+			value, ok := secret.Data[ref.Key]
+			if !ok {
+				return nil, fmt.Errorf("%s.clientSecretRef: key %q not found in secret %q", path, ref.Key, ref.Name)
+			}
+			config["clientSecret"] = string(value)
+		}
+		cfg.IdentityProviders = append(cfg.IdentityProviders, &KeycloakIdentityProvider{
+			Alias:       idp.Alias,
+			DisplayName: idp.DisplayName,
+			ProviderID:  idp.ProviderID,
+			Config:      config,
+		})
+
+		mappers := make(map[string]bool)
+		for j, mapper := range idp.Mappers {
+			unique(fmt.Sprintf("%s.mappers[%d].name", path, j), mapper.Name, mappers)
+			cfg.IdentityProviderMappers = append(cfg.IdentityProviderMappers, &KeycloakIdentityProviderMapper{
+				Name:                   mapper.Name,
+				IdentityProviderAlias:  idp.Alias,
+				IdentityProviderMapper: mapper.IdentityProviderMapper,
+				Config:                 mapper.Config,
+			})
+		}
+	}
+
+	scopes := make(map[string]bool)
+	for i, scope := range realm.ClientScopes {
+		path := fmt.Sprintf("clientScopes[%d]", i)
+		unique(path+".name", scope.Name, scopes)
+
+		protocol := scope.Protocol
+		if protocol == "" {
+			protocol = "openid-connect"
+		}
+		s := KeycloakClientScope{
+			Name:       scope.Name,
+			Protocol:   protocol,
+			Attributes: scope.Attributes,
+		}
+		mappers := make(map[string]bool)
+		for j, mapper := range scope.ProtocolMappers {
+			unique(fmt.Sprintf("%s.protocolMappers[%d].name", path, j), mapper.Name, mappers)
+			s.ProtocolMappers = append(s.ProtocolMappers, KeycloakProtocolMapper{
+				Name:           mapper.Name,
+				Protocol:       protocol,
+				ProtocolMapper: mapper.ProtocolMapper,
+				Config:         mapper.Config,
+			})
+		}
+		cfg.ClientScopes = append(cfg.ClientScopes, s)
+	}
+
+	groupMappers := make(map[string]bool)
+	for i, mapper := range realm.GroupMappers {
+		path := fmt.Sprintf("groupMappers[%d]", i)
+		unique(path+".name", mapper.Name, groupMappers)
+		if mapper.ClaimName == "" {
+			errs = append(errs, path+".claimName: must not be empty")
+		}
+		cfg.ClientProtocolMappers = append(cfg.ClientProtocolMappers, KeycloakProtocolMapper{
+			Name:           mapper.Name,
+			Protocol:       "openid-connect",
+			ProtocolMapper: "oidc-group-membership-mapper",
+			Config: map[string]string{
+				"claim.name":           mapper.ClaimName,
+				"full.path":            fmt.Sprint(mapper.FullPath),
+				"id.token.claim":       "true",
+				"access.token.claim":   "true",
+				"userinfo.token.claim": "true",
+			},
+		})
+	}
+
+	roles := make(map[string]bool)
+	for i, role := range realm.Roles {
+		unique(fmt.Sprintf("roles[%d].name", i), role.Name, roles)
+		cfg.Roles = append(cfg.Roles, KeycloakRole{Name: role.Name, Description: role.Description})
+	}
+
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("invalid keycloak realm configuration: %s", strings.Join(errs, "; "))
+	}
+	return cfg, nil
+}
+
+// reconcileKeycloakRealmConfig will apply the additional realm configuration of the given ArgoCD to Keycloak once
+// the realm has been created, comparing it against the live realm on each reconciliation. The entries created by
+// the operator are recorded on the given Keycloak deployment, so that only those are removed from the realm.
+func (r *ReconcileArgoCD) reconcileKeycloakRealmConfig(cr *argoproj.ArgoCD, cfg *keycloakConfig, deployment client.Object) error {
+	if deployment.GetAnnotations()["argocd.argoproj.io/realm-created"] != "true" {
+		return nil
+	}
+
+	desired, err := r.getKeycloakRealmConfig(cr)
+	if err != nil {
+		return err
+	}
+
+	previous := deployment.GetAnnotations()[keycloakRealmConfigAnnotation]
+	state := &keycloakRealmState{}
+	if previous != "" {
+		if err := json.Unmarshal([]byte(previous), state); err != nil {
+			log.Info(fmt.Sprintf("ignoring invalid keycloak realm configuration annotation: %v", err))
+		}
+	}
+	if reflect.DeepEqual(desired, &keycloakRealmConfig{}) && reflect.DeepEqual(state, &keycloakRealmState{}) {
+		// nothing is desired, and nothing created by the operator is left to remove.
+		return nil
+	}
+
+	h, err := newKeycloakHTTPClient(cfg)
+	if err != nil {
+		return err
+	}
+	syncErr := h.syncRealmConfig(keycloakRealm, desired, state)
+
+	// record the entries created by the operator even if the sync failed part way, so they are not orphaned.
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	if string(data) != previous {
+		if err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+			if err := r.Client.Get(context.TODO(), client.ObjectKeyFromObject(deployment), deployment); err != nil {
+				return err
+			}
+			annotations := deployment.GetAnnotations()
+			if annotations == nil {
+				annotations = make(map[string]string)
+			}
+			annotations[keycloakRealmConfigAnnotation] = string(data)
+			deployment.SetAnnotations(annotations)
+			return r.Client.Update(context.TODO(), deployment)
+		}); err != nil {
+			return err
+		}
+	}
+	return syncErr
+}
+
 // Gets Keycloak Server cert. This cert is used to authenticate the api calls to the Keycloak service.
 func (r *ReconcileArgoCD) getKCServerCert(cr *argoproj.ArgoCD) ([]byte, error) {
 
@@ -1409,6 +1589,13 @@ func (r *ReconcileArgoCD) reconcileKeycloakForOpenShift(cr *argoproj.ArgoCD) err
 			}
 		}
 
+		// Apply the additional realm configuration declared in `.spec.sso.keycloak.realm`.
+		if err := r.reconcileKeycloakRealmConfig(cr, cfg, existingDC); err != nil {
+			log.Error(err, fmt.Sprintf("Failed to reconcile keycloak realm configuration for ArgoCD %s in namespace %s",
+				cr.Name, cr.Namespace))
+			return err
+		}
+
 		// Updates OIDC Configuration in the argocd-cm when Keycloak is initially configured
 		// or when user requests to update the OIDC configuration through `.spec.sso.keycloak.rootCA`.
 		err = r.updateArgoCDConfiguration(cr, keycloakRouteURL)
@@ -1502,6 +1689,13 @@ func (r *ReconcileArgoCD) reconcileKeycloak(cr *argoproj.ArgoCD) error {
 			}
 		}
 
+		// Apply the additional realm configuration declared in `.spec.sso.keycloak.realm`.
+		if err := r.reconcileKeycloakRealmConfig(cr, cfg, existingDeployment); err != nil {
+			log.Error(err, fmt.Sprintf("Failed to reconcile keycloak realm configuration for ArgoCD %s in namespace %s",
+				cr.Name, cr.Namespace))
+			return err
+		}
+
 		// Updates OIDC Configuration in the argocd-cm when Keycloak is initially configured
 		// or when user requests to update the OIDC configuration through `.spec.sso.keycloak.rootCA`.
 		err = r.updateArgoCDConfiguration(cr, kIngURL)
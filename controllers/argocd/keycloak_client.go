@@ -2,6 +2,7 @@ package argocd
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"crypto/tls"
 	"crypto/x509"
 	json "encoding/json"
@@ -9,6 +10,7 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"reflect"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -24,12 +26,12 @@ type httpclient struct {
 	token     string
 }
 
-// Creates a new realm for Keycloak.
-func createRealm(cfg *keycloakConfig) (string, error) {
+// Creates a new http client for Keycloak, authenticated with the admin credentials of the given config.
+func newKeycloakHTTPClient(cfg *keycloakConfig) (*httpclient, error) {
 
 	req, err := defaultRequester(cfg.KeycloakServerCert, cfg.VerifyTLS)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	// create a new http client.
@@ -47,11 +49,22 @@ func createRealm(cfg *keycloakConfig) (string, error) {
 	// login request updates the auth token for httpclient.
 	err = h.login(cfg.Username, cfg.Password)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	log.Info(fmt.Sprintf("Access Token for keycloak of ArgoCD %s in namespace %s generated successfully",
 		cfg.ArgoName, cfg.ArgoNamespace))
 
+	return h, nil
+}
+
+// Creates a new realm for Keycloak.
+func createRealm(cfg *keycloakConfig) (string, error) {
+
+	h, err := newKeycloakHTTPClient(cfg)
+	if err != nil {
+		return "", err
+	}
+
 	realmConfig, err := createRealmConfig(cfg)
 	if err != nil {
 		return "", err
@@ -190,3 +203,519 @@ func (h *httpclient) validateKeycloakURL(URL string) error {
 	_ = res.Body.Close()
 	return nil
 }
+
+// keycloakAPIError is returned when the Keycloak admin API responds with an unexpected status.
+type keycloakAPIError struct {
+	Method     string
+	Path       string
+	StatusCode int
+}
+
+func (e *keycloakAPIError) Error() string {
+	return fmt.Sprintf("keycloak %s %s returned status %d", e.Method, e.Path, e.StatusCode)
+}
+
+// isKeycloakNotFound returns true if the given error is a not found response of the Keycloak admin API.
+func isKeycloakNotFound(err error) bool {
+	var apiErr *keycloakAPIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// do sends a request with the given JSON body to the given path of the admin API of the given realm, and decodes
+// the JSON response into out. It returns the response headers.
+func (h *httpclient) do(method, realm, path string, body, out interface{}) (http.Header, error) {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader = bytes.NewBuffer(data)
+	}
+
+	path = fmt.Sprintf("%s/%s%s", realmURL, url.PathEscape(realm), path)
+	request, err := http.NewRequest(method, fmt.Sprintf("%s%s", h.URL, path), reader)
+	if err != nil {
+		return nil, err
+	}
+
+	// set headers.
+	if body != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+	request.Header.Add("Authorization", fmt.Sprintf("Bearer %s", h.token))
+
+	response, err := h.requester.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return nil, &keycloakAPIError{Method: method, Path: path, StatusCode: response.StatusCode}
+	}
+
+	if out != nil {
+		data, err := io.ReadAll(response.Body)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(data, out); err != nil {
+			return nil, err
+		}
+	}
+	return response.Header, nil
+}
+
+// delete removes the resource at the given path of the admin API of the given realm, ignoring resources that
+// do not exist.
+func (h *httpclient) delete(realm, path string) error {
+	if _, err := h.do(http.MethodDelete, realm, path, nil, nil); err != nil && !isKeycloakNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// syncRealmConfig applies the given additional realm configuration to the given realm, updating only the entries
+// that differ from the live realm. Entries that were created by the operator, as recorded in the given state, are
+// removed once they are no longer desired. The state is updated as entries are created and removed, so that it is
+// accurate even if an error is returned.
+func (h *httpclient) syncRealmConfig(realm string, desired *keycloakRealmConfig, state *keycloakRealmState) error {
+	if err := h.syncRealmRoles(realm, desired.Roles, state); err != nil {
+		return err
+	}
+
+	if err := h.syncIdentityProviders(realm, desired, state); err != nil {
+		return err
+	}
+
+	clients := []KeycloakAPIClientRef{}
+	if _, err := h.do(http.MethodGet, realm, "/clients?clientId="+url.QueryEscape(keycloakClient), nil, &clients); err != nil {
+		return err
+	}
+	if len(clients) == 0 {
+		return fmt.Errorf("keycloak client %s not found in realm %s", keycloakClient, realm)
+	}
+	clientPath := "/clients/" + url.PathEscape(clients[0].ID)
+
+	if err := h.syncClientScopes(realm, clientPath, desired.ClientScopes, state); err != nil {
+		return err
+	}
+
+	var err error
+	state.ClientProtocolMappers, err = h.syncProtocolMappers(realm, clientPath+"/protocol-mappers/models",
+		desired.ClientProtocolMappers, state.ClientProtocolMappers)
+	return err
+}
+
+// syncRealmRoles creates or updates the given realm roles, and removes the roles created by the operator that are
+// no longer desired.
+func (h *httpclient) syncRealmRoles(realm string, roles []KeycloakRole, state *keycloakRealmState) error {
+	desired := make(map[string]bool)
+	for _, role := range roles {
+		desired[role.Name] = true
+		path := "/roles/" + url.PathEscape(role.Name)
+		live := KeycloakRole{}
+		_, err := h.do(http.MethodGet, realm, path, nil, &live)
+		switch {
+		case isKeycloakNotFound(err):
+			if _, err = h.do(http.MethodPost, realm, "/roles", role, nil); err == nil {
+				state.Roles = addKeycloakName(state.Roles, role.Name)
+			}
+		case err == nil:
+			role.ID = live.ID
+			if keycloakNeedsUpdate(role, live) {
+				_, err = h.do(http.MethodPut, realm, path, role, nil)
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, name := range append([]string(nil), state.Roles...) {
+		if desired[name] {
+			continue
+		}
+		if err := h.delete(realm, "/roles/"+url.PathEscape(name)); err != nil {
+			return err
+		}
+		state.Roles = removeKeycloakName(state.Roles, name)
+	}
+	return nil
+}
+
+// syncIdentityProviders creates or updates the identity providers of the given config together with their
+// mappers, and removes the identity providers and mappers created by the operator that are no longer desired.
+func (h *httpclient) syncIdentityProviders(realm string, cfg *keycloakRealmConfig, state *keycloakRealmState) error {
+	desired := make(map[string]bool)
+	for _, idp := range cfg.IdentityProviders {
+		desired[idp.Alias] = true
+		path := "/identity-provider/instances/" + url.PathEscape(idp.Alias)
+		live := KeycloakIdentityProvider{}
+		secretHash := getKeycloakClientSecretHash(idp)
+		_, err := h.do(http.MethodGet, realm, path, nil, &live)
+		switch {
+		case isKeycloakNotFound(err):
+			if _, err = h.do(http.MethodPost, realm, "/identity-provider/instances", idp, nil); err == nil {
+				state.IdentityProviders = addKeycloakName(state.IdentityProviders, idp.Alias)
+			}
+		case err == nil:
+			// the client secret is masked by Keycloak, so a change is detected from its hash instead.
+			compared := *idp
+			compared.Config = make(map[string]string)
+			for k, v := range idp.Config {
+				if k != "clientSecret" {
+					compared.Config[k] = v
+				}
+			}
+			if keycloakNeedsUpdate(compared, live) || secretHash != state.ClientSecretHashes[idp.Alias] {
+				_, err = h.do(http.MethodPut, realm, path, idp, nil)
+			}
+		}
+		if err != nil {
+			return err
+		}
+		setKeycloakClientSecretHash(state, idp.Alias, secretHash)
+
+		existing := []KeycloakIdentityProviderMapper{}
+		if _, err := h.do(http.MethodGet, realm, path+"/mappers", nil, &existing); err != nil {
+			return err
+		}
+		liveMappers := make(map[string]KeycloakIdentityProviderMapper)
+		for _, mapper := range existing {
+			liveMappers[mapper.Name] = mapper
+		}
+
+		owned := state.IdentityProviderMappers[idp.Alias]
+		desiredMappers := make(map[string]bool)
+		for _, mapper := range cfg.IdentityProviderMappers {
+			if mapper.IdentityProviderAlias != idp.Alias {
+				continue
+			}
+			desiredMappers[mapper.Name] = true
+			m := *mapper
+			if l, ok := liveMappers[m.Name]; ok {
+				m.ID = l.ID
+				if keycloakNeedsUpdate(m, l) {
+					_, err = h.do(http.MethodPut, realm, path+"/mappers/"+url.PathEscape(l.ID), m, nil)
+				}
+			} else if _, err = h.do(http.MethodPost, realm, path+"/mappers", m, nil); err == nil {
+				owned = addKeycloakName(owned, m.Name)
+			}
+			if err != nil {
+				setKeycloakNames(&state.IdentityProviderMappers, idp.Alias, owned)
+				return err
+			}
+		}
+
+		for _, name := range append([]string(nil), owned...) {
+			if desiredMappers[name] {
+				continue
+			}
+			if l, ok := liveMappers[name]; ok {
+				if err := h.delete(realm, path+"/mappers/"+url.PathEscape(l.ID)); err != nil {
+					setKeycloakNames(&state.IdentityProviderMappers, idp.Alias, owned)
+					return err
+				}
+			}
+			owned = removeKeycloakName(owned, name)
+		}
+		setKeycloakNames(&state.IdentityProviderMappers, idp.Alias, owned)
+	}
+
+	for _, alias := range append([]string(nil), state.IdentityProviders...) {
+		if desired[alias] {
+			continue
+		}
+		if err := h.delete(realm, "/identity-provider/instances/"+url.PathEscape(alias)); err != nil {
+			return err
+		}
+		state.IdentityProviders = removeKeycloakName(state.IdentityProviders, alias)
+		setKeycloakNames(&state.IdentityProviderMappers, alias, nil)
+		setKeycloakClientSecretHash(state, alias, "")
+	}
+
+	// the hashes of the identity providers that are no longer declared, but were not created by the operator, are
+	// forgotten.
+	for alias := range state.ClientSecretHashes {
+		if !desired[alias] {
+			setKeycloakClientSecretHash(state, alias, "")
+		}
+	}
+
+	// mappers created by the operator on identity providers that it did not create, and that are no longer
+	// declared, are removed from those identity providers.
+	for alias, owned := range state.IdentityProviderMappers {
+		if desired[alias] {
+			continue
+		}
+		path := "/identity-provider/instances/" + url.PathEscape(alias)
+		existing := []KeycloakIdentityProviderMapper{}
+		if _, err := h.do(http.MethodGet, realm, path+"/mappers", nil, &existing); err != nil && !isKeycloakNotFound(err) {
+			return err
+		}
+		for _, mapper := range existing {
+			if containsString(owned, mapper.Name) {
+				if err := h.delete(realm, path+"/mappers/"+url.PathEscape(mapper.ID)); err != nil {
+					return err
+				}
+			}
+		}
+		setKeycloakNames(&state.IdentityProviderMappers, alias, nil)
+	}
+	return nil
+}
+
+// syncClientScopes creates or updates the given client scopes together with their protocol mappers, and adds them
+// to the default client scopes of the client at the given path. Client scopes, protocol mappers and default client
+// scopes that were created or added by the operator are removed once they are no longer desired, while client
+// scopes that already existed in the realm, such as profile or email, are kept.
+func (h *httpclient) syncClientScopes(realm, clientPath string, scopes []KeycloakClientScope, state *keycloakRealmState) error {
+	existing := []KeycloakClientScope{}
+	if _, err := h.do(http.MethodGet, realm, "/client-scopes", nil, &existing); err != nil {
+		return err
+	}
+	live := make(map[string]KeycloakClientScope)
+	for _, scope := range existing {
+		live[scope.Name] = scope
+	}
+
+	defaults := []KeycloakClientScope{}
+	if _, err := h.do(http.MethodGet, realm, clientPath+"/default-client-scopes", nil, &defaults); err != nil {
+		return err
+	}
+	linked := make(map[string]bool)
+	for _, scope := range defaults {
+		linked[scope.ID] = true
+	}
+
+	desired := make(map[string]bool)
+	for _, scope := range scopes {
+		desired[scope.Name] = true
+		s := scope
+		s.ProtocolMappers = nil
+
+		var id string
+		if l, ok := live[scope.Name]; ok {
+			id = l.ID
+			s.ID = id
+			if keycloakNeedsUpdate(s, l) {
+				if _, err := h.do(http.MethodPut, realm, "/client-scopes/"+url.PathEscape(id), s, nil); err != nil {
+					return err
+				}
+			}
+		} else {
+			header, err := h.do(http.MethodPost, realm, "/client-scopes", s, nil)
+			if err != nil {
+				return err
+			}
+			// the ID of the created client scope is the last segment of the location header.
+			location := header.Get("Location")
+			id = location[strings.LastIndex(location, "/")+1:]
+			if id == "" {
+				return fmt.Errorf("keycloak did not return the location of client scope %s", scope.Name)
+			}
+			state.ClientScopes = addKeycloakName(state.ClientScopes, scope.Name)
+		}
+
+		owned, err := h.syncProtocolMappers(realm, "/client-scopes/"+url.PathEscape(id)+"/protocol-mappers/models",
+			scope.ProtocolMappers, state.ClientScopeProtocolMappers[scope.Name])
+		setKeycloakNames(&state.ClientScopeProtocolMappers, scope.Name, owned)
+		if err != nil {
+			return err
+		}
+
+		if !linked[id] {
+			if _, err := h.do(http.MethodPut, realm, clientPath+"/default-client-scopes/"+url.PathEscape(id), nil, nil); err != nil {
+				return err
+			}
+			state.DefaultClientScopes = addKeycloakName(state.DefaultClientScopes, scope.Name)
+		}
+	}
+
+	for _, name := range append([]string(nil), state.DefaultClientScopes...) {
+		if desired[name] {
+			continue
+		}
+		if l, ok := live[name]; ok {
+			if err := h.delete(realm, clientPath+"/default-client-scopes/"+url.PathEscape(l.ID)); err != nil {
+				return err
+			}
+		}
+		state.DefaultClientScopes = removeKeycloakName(state.DefaultClientScopes, name)
+	}
+
+	for name, owned := range state.ClientScopeProtocolMappers {
+		if desired[name] {
+			continue
+		}
+		if l, ok := live[name]; ok && !containsString(state.ClientScopes, name) {
+			if _, err := h.syncProtocolMappers(realm, "/client-scopes/"+url.PathEscape(l.ID)+"/protocol-mappers/models", nil, owned); err != nil {
+				return err
+			}
+		}
+		setKeycloakNames(&state.ClientScopeProtocolMappers, name, nil)
+	}
+
+	for _, name := range append([]string(nil), state.ClientScopes...) {
+		if desired[name] {
+			continue
+		}
+		if l, ok := live[name]; ok {
+			if err := h.delete(realm, "/client-scopes/"+url.PathEscape(l.ID)); err != nil {
+				return err
+			}
+		}
+		state.ClientScopes = removeKeycloakName(state.ClientScopes, name)
+	}
+	return nil
+}
+
+// syncProtocolMappers creates or updates the given protocol mappers at the given path, and removes the given owned
+// mappers that are no longer desired. It returns the names of the mappers created by the operator at the path.
+func (h *httpclient) syncProtocolMappers(realm, path string, mappers []KeycloakProtocolMapper, owned []string) ([]string, error) {
+	existing := []KeycloakProtocolMapper{}
+	if _, err := h.do(http.MethodGet, realm, path, nil, &existing); err != nil {
+		return owned, err
+	}
+	live := make(map[string]KeycloakProtocolMapper)
+	for _, mapper := range existing {
+		live[mapper.Name] = mapper
+	}
+
+	desired := make(map[string]bool)
+	for _, mapper := range mappers {
+		desired[mapper.Name] = true
+		var err error
+		if l, ok := live[mapper.Name]; ok {
+			mapper.ID = l.ID
+			if keycloakNeedsUpdate(mapper, l) {
+				_, err = h.do(http.MethodPut, realm, path+"/"+url.PathEscape(l.ID), mapper, nil)
+			}
+		} else if _, err = h.do(http.MethodPost, realm, path, mapper, nil); err == nil {
+			owned = addKeycloakName(owned, mapper.Name)
+		}
+		if err != nil {
+			return owned, err
+		}
+	}
+
+	for _, name := range append([]string(nil), owned...) {
+		if desired[name] {
+			continue
+		}
+		if l, ok := live[name]; ok {
+			if err := h.delete(realm, path+"/"+url.PathEscape(l.ID)); err != nil {
+				return owned, err
+			}
+		}
+		owned = removeKeycloakName(owned, name)
+	}
+	return owned, nil
+}
+
+// keycloakNeedsUpdate returns true if any field set in the given desired representation differs from the given
+// live representation. Fields that are not set in the desired representation are left to Keycloak.
+func keycloakNeedsUpdate(desired, live interface{}) bool {
+	d, err := keycloakToMap(desired)
+	if err != nil {
+		return true
+	}
+	l, err := keycloakToMap(live)
+	if err != nil {
+		return true
+	}
+	return !keycloakContains(l, d)
+}
+
+// keycloakToMap returns the generic JSON representation of the given object.
+func keycloakToMap(obj interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	m := make(map[string]interface{})
+	return m, json.Unmarshal(data, &m)
+}
+
+// keycloakContains returns true if every field of the given desired representation is equal in the given live
+// representation, comparing nested objects field by field.
+func keycloakContains(live, desired map[string]interface{}) bool {
+	for k, v := range desired {
+		if dm, ok := v.(map[string]interface{}); ok {
+			lm, ok := live[k].(map[string]interface{})
+			if !ok || !keycloakContains(lm, dm) {
+				return false
+			}
+			continue
+		}
+		if !reflect.DeepEqual(v, live[k]) {
+			return false
+		}
+	}
+	return true
+}
+
+// addKeycloakName returns the given names with the given name added, if it is not present yet.
+func addKeycloakName(names []string, name string) []string {
+	if containsString(names, name) {
+		return names
+	}
+	return append(names, name)
+}
+
+// removeKeycloakName returns the given names without the given name.
+func removeKeycloakName(names []string, name string) []string {
+	result := make([]string, 0, len(names))
+	for _, n := range names {
+		if n != name {
+			result = append(result, n)
+		}
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
+
+// setKeycloakNames records the given names under the given key, removing the key when there are no names.
+func setKeycloakNames(m *map[string][]string, key string, names []string) {
+	if len(names) == 0 {
+		delete(*m, key)
+		if len(*m) == 0 {
+			*m = nil
+		}
+		return
+	}
+	if *m == nil {
+		*m = make(map[string][]string)
+	}
+	(*m)[key] = names
+}
+
+// getKeycloakClientSecretHash returns the hash of the client secret of the given identity provider, or an empty
+// string if it has none.
+func getKeycloakClientSecretHash(idp *KeycloakIdentityProvider) string {
+	secret, ok := idp.Config["clientSecret"]
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(secret)))
+}
+
+// setKeycloakClientSecretHash records the given client secret hash of the given identity provider in the given
+// state, removing it when empty.
+func setKeycloakClientSecretHash(state *keycloakRealmState, alias, hash string) {
+	if hash == "" {
+		delete(state.ClientSecretHashes, alias)
+		if len(state.ClientSecretHashes) == 0 {
+			state.ClientSecretHashes = nil
+		}
+		return
+	}
+	if state.ClientSecretHashes == nil {
+		state.ClientSecretHashes = make(map[string]string)
+	}
+	state.ClientSecretHashes[alias] = hash
+}
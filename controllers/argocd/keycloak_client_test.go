@@ -1,8 +1,11 @@
 package argocd
 
 import (
+	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"encoding/pem"
@@ -106,3 +109,307 @@ func TestClient_useKeycloakServerCertificate(t *testing.T) {
 	assert.Equal(t, resp.StatusCode, 200)
 
 }
+
+// fakeKeycloak is an in-memory implementation of the parts of the Keycloak admin API used to reconcile the
+// additional realm configuration.
+type fakeKeycloak struct {
+	t      *testing.T
+	nextID int
+
+	roles          map[string]KeycloakRole
+	idps           map[string]KeycloakIdentityProvider
+	idpMappers     map[string]map[string]KeycloakIdentityProviderMapper
+	scopes         map[string]KeycloakClientScope
+	scopeMappers   map[string]map[string]KeycloakProtocolMapper
+	defaultScopes  map[string]bool
+	clientMappers  map[string]KeycloakProtocolMapper
+	requestsByVerb map[string]int
+}
+
+func newFakeKeycloak(t *testing.T) *fakeKeycloak {
+	return &fakeKeycloak{
+		t:              t,
+		roles:          map[string]KeycloakRole{},
+		idps:           map[string]KeycloakIdentityProvider{},
+		idpMappers:     map[string]map[string]KeycloakIdentityProviderMapper{},
+		scopes:         map[string]KeycloakClientScope{"builtin": {ID: "builtin", Name: "profile"}},
+		scopeMappers:   map[string]map[string]KeycloakProtocolMapper{"builtin": {"builtin": {ID: "builtin", Name: "full name"}}},
+		defaultScopes:  map[string]bool{"builtin": true},
+		clientMappers:  map[string]KeycloakProtocolMapper{"builtin": {ID: "builtin", Name: "audience"}},
+		requestsByVerb: map[string]int{},
+	}
+}
+
+func (f *fakeKeycloak) id() string {
+	f.nextID++
+	return fmt.Sprintf("id-%d", f.nextID)
+}
+
+func (f *fakeKeycloak) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.URL.Path == authURL {
+		_ = json.NewEncoder(w).Encode(TokenResponse{AccessToken: "dummy"})
+		return
+	}
+	f.requestsByVerb[req.Method]++
+	assert.Equal(f.t, "Bearer dummy", req.Header.Get("Authorization"))
+
+	prefix := realmURL + "/" + keycloakRealm
+	if !strings.HasPrefix(req.URL.Path, prefix) {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	parts := strings.Split(strings.TrimPrefix(req.URL.Path, prefix+"/"), "/")
+
+	decode := func(v interface{}) {
+		assert.NoError(f.t, json.NewDecoder(req.Body).Decode(v))
+	}
+	reply := func(v interface{}) {
+		_ = json.NewEncoder(w).Encode(v)
+	}
+	created := func(id string) {
+		w.Header().Set("Location", fmt.Sprintf("http://%s%s/%s", req.Host, req.URL.Path, id))
+		w.WriteHeader(http.StatusCreated)
+	}
+	notFound := func() {
+		w.WriteHeader(http.StatusNotFound)
+	}
+
+	switch {
+	// realm roles
+	case len(parts) == 1 && parts[0] == "roles" && req.Method == http.MethodPost:
+		role := KeycloakRole{}
+		decode(&role)
+		role.ID = f.id()
+		f.roles[role.Name] = role
+		created(role.ID)
+	case len(parts) == 2 && parts[0] == "roles":
+		role, ok := f.roles[parts[1]]
+		if !ok {
+			notFound()
+			return
+		}
+		switch req.Method {
+		case http.MethodGet:
+			reply(role)
+		case http.MethodPut:
+			decode(&role)
+			f.roles[parts[1]] = role
+		case http.MethodDelete:
+			delete(f.roles, parts[1])
+		}
+
+	// identity providers and their mappers
+	case len(parts) == 2 && parts[0] == "identity-provider" && req.Method == http.MethodPost:
+		idp := KeycloakIdentityProvider{}
+		decode(&idp)
+		f.idps[idp.Alias] = idp
+		f.idpMappers[idp.Alias] = map[string]KeycloakIdentityProviderMapper{}
+		created(idp.Alias)
+	case len(parts) >= 3 && parts[0] == "identity-provider":
+		alias := parts[2]
+		idp, ok := f.idps[alias]
+		if !ok {
+			notFound()
+			return
+		}
+		switch {
+		case len(parts) == 3 && req.Method == http.MethodGet:
+			// Keycloak masks the client secret of the identity providers it returns.
+			masked := idp
+			masked.Config = map[string]string{}
+			for k, v := range idp.Config {
+				masked.Config[k] = v
+			}
+			if _, ok := masked.Config["clientSecret"]; ok {
+				masked.Config["clientSecret"] = "**********"
+			}
+			reply(masked)
+		case len(parts) == 3 && req.Method == http.MethodPut:
+			decode(&idp)
+			f.idps[alias] = idp
+		case len(parts) == 3 && req.Method == http.MethodDelete:
+			delete(f.idps, alias)
+			delete(f.idpMappers, alias)
+		case len(parts) == 4 && req.Method == http.MethodGet:
+			mappers := []KeycloakIdentityProviderMapper{}
+			for _, m := range f.idpMappers[alias] {
+				mappers = append(mappers, m)
+			}
+			reply(mappers)
+		case len(parts) == 4 && req.Method == http.MethodPost:
+			m := KeycloakIdentityProviderMapper{}
+			decode(&m)
+			m.ID = f.id()
+			f.idpMappers[alias][m.ID] = m
+			created(m.ID)
+		case len(parts) == 5 && req.Method == http.MethodPut:
+			m := KeycloakIdentityProviderMapper{}
+			decode(&m)
+			f.idpMappers[alias][parts[4]] = m
+		case len(parts) == 5 && req.Method == http.MethodDelete:
+			delete(f.idpMappers[alias], parts[4])
+		}
+
+	// the argocd client, its default client scopes and protocol mappers
+	case len(parts) == 1 && parts[0] == "clients":
+		assert.Equal(f.t, keycloakClient, req.URL.Query().Get("clientId"))
+		reply([]KeycloakAPIClientRef{{ID: "client-uuid", ClientID: keycloakClient}})
+	case len(parts) == 3 && parts[0] == "clients" && parts[2] == "default-client-scopes":
+		scopes := []KeycloakClientScope{}
+		for id := range f.defaultScopes {
+			scopes = append(scopes, KeycloakClientScope{ID: id, Name: f.scopes[id].Name})
+		}
+		reply(scopes)
+	case len(parts) == 4 && parts[0] == "clients" && parts[2] == "default-client-scopes":
+		if req.Method == http.MethodPut {
+			f.defaultScopes[parts[3]] = true
+		} else {
+			delete(f.defaultScopes, parts[3])
+		}
+	case len(parts) >= 4 && parts[0] == "clients" && parts[2] == "protocol-mappers":
+		f.serveProtocolMappers(w, req, parts[4:], f.clientMappers, decode, reply, created)
+
+	// client scopes and their protocol mappers
+	case len(parts) == 1 && parts[0] == "client-scopes" && req.Method == http.MethodGet:
+		scopes := []KeycloakClientScope{}
+		for _, s := range f.scopes {
+			scopes = append(scopes, s)
+		}
+		reply(scopes)
+	case len(parts) == 1 && parts[0] == "client-scopes" && req.Method == http.MethodPost:
+		s := KeycloakClientScope{}
+		decode(&s)
+		s.ID = f.id()
+		f.scopeMappers[s.ID] = map[string]KeycloakProtocolMapper{}
+		for _, m := range s.ProtocolMappers {
+			m.ID = f.id()
+			f.scopeMappers[s.ID][m.ID] = m
+		}
+		s.ProtocolMappers = nil
+		f.scopes[s.ID] = s
+		created(s.ID)
+	case len(parts) == 2 && parts[0] == "client-scopes":
+		if _, ok := f.scopes[parts[1]]; !ok {
+			notFound()
+			return
+		}
+		if req.Method == http.MethodPut {
+			s := KeycloakClientScope{}
+			decode(&s)
+			f.scopes[parts[1]] = s
+		} else {
+			delete(f.scopes, parts[1])
+		}
+	case len(parts) >= 4 && parts[0] == "client-scopes" && parts[2] == "protocol-mappers":
+		f.serveProtocolMappers(w, req, parts[4:], f.scopeMappers[parts[1]], decode, reply, created)
+
+	default:
+		f.t.Errorf("unexpected keycloak request %s %s", req.Method, req.URL.Path)
+		w.WriteHeader(http.StatusBadRequest)
+	}
+}
+
+func (f *fakeKeycloak) serveProtocolMappers(w http.ResponseWriter, req *http.Request, parts []string, mappers map[string]KeycloakProtocolMapper,
+	decode, reply func(interface{}), created func(string)) {
+	switch {
+	case len(parts) == 0 && req.Method == http.MethodGet:
+		list := []KeycloakProtocolMapper{}
+		for _, m := range mappers {
+			list = append(list, m)
+		}
+		reply(list)
+	case len(parts) == 0 && req.Method == http.MethodPost:
+		m := KeycloakProtocolMapper{}
+		decode(&m)
+		m.ID = f.id()
+		mappers[m.ID] = m
+		created(m.ID)
+	case len(parts) == 1 && req.Method == http.MethodPut:
+		m := KeycloakProtocolMapper{}
+		decode(&m)
+		mappers[parts[0]] = m
+	case len(parts) == 1 && req.Method == http.MethodDelete:
+		delete(mappers, parts[0])
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+	}
+}
+
+func TestKeycloak_syncRealmConfig(t *testing.T) {
+	fake := newFakeKeycloak(t)
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	h := &httpclient{
+		requester: server.Client(),
+		URL:       server.URL,
+		token:     "dummy",
+	}
+
+	desired := &keycloakRealmConfig{
+		IdentityProviders: []*KeycloakIdentityProvider{
+			{Alias: "github", ProviderID: "github", Config: map[string]string{"clientId": "id", "clientSecret": "secret"}},
+		},
+		IdentityProviderMappers: []*KeycloakIdentityProviderMapper{
+			{Name: "team", IdentityProviderAlias: "github", IdentityProviderMapper: "hardcoded-group-idp-mapper"},
+		},
+		ClientScopes: []KeycloakClientScope{
+			{Name: "teams", Protocol: "openid-connect", ProtocolMappers: []KeycloakProtocolMapper{{Name: "teams", ProtocolMapper: "oidc-usermodel-attribute-mapper"}}},
+			{Name: "profile", ProtocolMappers: []KeycloakProtocolMapper{{Name: "nickname", ProtocolMapper: "oidc-usermodel-attribute-mapper"}}},
+		},
+		ClientProtocolMappers: []KeycloakProtocolMapper{{Name: "groups", ProtocolMapper: "oidc-group-membership-mapper"}},
+		Roles:                 []KeycloakRole{{Name: "argocd-admins", Description: "admins"}},
+	}
+	state := &keycloakRealmState{}
+	assert.NoError(t, h.syncRealmConfig(keycloakRealm, desired, state))
+
+	assert.Equal(t, "admins", fake.roles["argocd-admins"].Description)
+	assert.Contains(t, fake.idps, "github")
+	assert.Len(t, fake.idpMappers["github"], 1)
+	assert.Len(t, fake.scopes, 2)
+	assert.Len(t, fake.scopeMappers["builtin"], 2)
+	assert.Len(t, fake.defaultScopes, 2)
+	assert.Len(t, fake.clientMappers, 2)
+
+	// Only the entries created by the operator are recorded, the built-in profile scope is adopted.
+	assert.Equal(t, &keycloakRealmState{
+		IdentityProviders:          []string{"github"},
+		IdentityProviderMappers:    map[string][]string{"github": {"team"}},
+		ClientScopes:               []string{"teams"},
+		ClientScopeProtocolMappers: map[string][]string{"teams": {"teams"}, "profile": {"nickname"}},
+		DefaultClientScopes:        []string{"teams"},
+		ClientProtocolMappers:      []string{"groups"},
+		Roles:                      []string{"argocd-admins"},
+		ClientSecretHashes:         map[string]string{"github": getKeycloakClientSecretHash(desired.IdentityProviders[0])},
+	}, state)
+
+	// A second sync of an unchanged configuration does not write to Keycloak.
+	writes := fake.requestsByVerb[http.MethodPost] + fake.requestsByVerb[http.MethodPut] + fake.requestsByVerb[http.MethodDelete]
+	assert.NoError(t, h.syncRealmConfig(keycloakRealm, desired, state))
+	assert.Equal(t, writes, fake.requestsByVerb[http.MethodPost]+fake.requestsByVerb[http.MethodPut]+fake.requestsByVerb[http.MethodDelete])
+
+	// A rotated client secret is applied to the identity provider.
+	puts := fake.requestsByVerb[http.MethodPut]
+	desired.IdentityProviders[0].Config["clientSecret"] = "rotated"
+	assert.NoError(t, h.syncRealmConfig(keycloakRealm, desired, state))
+	assert.Equal(t, puts+1, fake.requestsByVerb[http.MethodPut])
+	assert.Equal(t, "rotated", fake.idps["github"].Config["clientSecret"])
+	assert.Equal(t, getKeycloakClientSecretHash(desired.IdentityProviders[0]), state.ClientSecretHashes["github"])
+
+	// Changes made directly in Keycloak are reverted.
+	fake.roles["argocd-admins"] = KeycloakRole{ID: fake.roles["argocd-admins"].ID, Name: "argocd-admins", Description: "changed"}
+	assert.NoError(t, h.syncRealmConfig(keycloakRealm, desired, state))
+	assert.Equal(t, "admins", fake.roles["argocd-admins"].Description)
+	assert.Len(t, fake.scopes, 2)
+	assert.Len(t, fake.clientMappers, 2)
+
+	// Entries created by the operator are removed once no longer desired, adopted and unmanaged entries are kept.
+	assert.NoError(t, h.syncRealmConfig(keycloakRealm, &keycloakRealmConfig{}, state))
+	assert.Empty(t, fake.roles)
+	assert.Empty(t, fake.idps)
+	assert.Equal(t, map[string]KeycloakClientScope{"builtin": {ID: "builtin", Name: "profile"}}, fake.scopes)
+	assert.Equal(t, map[string]KeycloakProtocolMapper{"builtin": {ID: "builtin", Name: "full name"}}, fake.scopeMappers["builtin"])
+	assert.Equal(t, map[string]bool{"builtin": true}, fake.defaultScopes)
+	assert.Equal(t, map[string]KeycloakProtocolMapper{"builtin": {ID: "builtin", Name: "audience"}}, fake.clientMappers)
+	assert.Equal(t, &keycloakRealmState{}, state)
+}
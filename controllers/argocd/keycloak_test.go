@@ -16,7 +16,10 @@ package argocd
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	oappsv1 "github.com/openshift/api/apps/v1"
@@ -532,3 +535,67 @@ func removeTemplateAPI() {
 	templateAPIFound = false
 	deploymentConfigAPIFound = false
 }
+
+func TestKeycloak_reconcileKeycloakRealmConfig(t *testing.T) {
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.SSO = &argoproj.ArgoCDSSOSpec{
+			Provider: argoproj.SSOProviderTypeKeycloak,
+			Keycloak: &argoproj.ArgoCDKeycloakSpec{
+				Realm: &argoproj.ArgoCDKeycloakRealmSpec{
+					IdentityProviders: []argoproj.ArgoCDKeycloakIdentityProvider{
+						{
+							Alias:           "corp",
+							ProviderID:      "oidc",
+							Config:          map[string]string{"clientId": "argocd"},
+							ClientSecretRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "corp-idp"}, Key: "secret"},
+						},
+					},
+					GroupMappers: []argoproj.ArgoCDKeycloakGroupMapper{{Name: "groups", ClaimName: "groups"}},
+					Roles:        []argoproj.ArgoCDKeycloakRealmRole{{Name: "argocd-admins"}},
+				},
+			},
+		}
+	})
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "corp-idp", Namespace: a.Namespace},
+		Data:       map[string][]byte{"secret": []byte("s3cr3t")},
+	}
+	deployment := newKeycloakDeployment(a)
+	deployment.Annotations["argocd.argoproj.io/realm-created"] = "true"
+
+	resObjs := []client.Object{a, secret, deployment}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	fake := newFakeKeycloak(t)
+	server := httptest.NewServer(fake)
+	defer server.Close()
+	cfg := &keycloakConfig{ArgoName: a.Name, ArgoNamespace: a.Namespace, KeycloakURL: server.URL}
+
+	assert.NoError(t, r.reconcileKeycloakRealmConfig(a, cfg, deployment))
+	assert.Equal(t, "s3cr3t", fake.idps["corp"].Config["clientSecret"])
+	assert.Equal(t, "groups", fake.clientMappers["id-2"].Config["claim.name"])
+	assert.Contains(t, fake.roles, "argocd-admins")
+
+	state := &keycloakRealmState{}
+	assert.NoError(t, json.Unmarshal([]byte(deployment.Annotations[keycloakRealmConfigAnnotation]), state))
+	assert.Equal(t, []string{"corp"}, state.IdentityProviders)
+	assert.Equal(t, []string{"groups"}, state.ClientProtocolMappers)
+	assert.Equal(t, []string{"argocd-admins"}, state.Roles)
+	assert.NotContains(t, deployment.Annotations[keycloakRealmConfigAnnotation], "s3cr3t")
+
+	// An unchanged configuration is compared against the realm, but not written again.
+	reads := fake.requestsByVerb[http.MethodGet]
+	writes := fake.requestsByVerb[http.MethodPost] + fake.requestsByVerb[http.MethodPut]
+	assert.NoError(t, r.reconcileKeycloakRealmConfig(a, cfg, deployment))
+	assert.Greater(t, fake.requestsByVerb[http.MethodGet], reads)
+	assert.Equal(t, writes, fake.requestsByVerb[http.MethodPost]+fake.requestsByVerb[http.MethodPut])
+
+	// Invalid configuration is rejected.
+	a.Spec.SSO.Keycloak.Realm.Roles = append(a.Spec.SSO.Keycloak.Realm.Roles, argoproj.ArgoCDKeycloakRealmRole{Name: "argocd-admins"})
+	assert.EqualError(t, r.reconcileKeycloakRealmConfig(a, cfg, deployment),
+		`invalid keycloak realm configuration: roles[1].name: duplicate name "argocd-admins"`)
+}
@@ -118,6 +118,9 @@ type oidcConfig struct {
 // KeycloakIdentityProviderMapper defines IdentityProvider Mappers
 // issue: https://github.com/keycloak/keycloak-operator/issues/471
 type KeycloakIdentityProviderMapper struct {
+	// Identity Provider Mapper ID.
+	// +optional
+	ID string `json:"id,omitempty"`
 	// Name
 	// +optional
 	Name string `json:"name,omitempty"`
@@ -157,3 +160,48 @@ type CustomKeycloakAPIRealm struct {
 	// issue: https://github.com/keycloak/keycloak-operator/issues/471
 	IdentityProviderMappers []*KeycloakIdentityProviderMapper `json:"identityProviderMappers,omitempty"`
 }
+
+// KeycloakRole defines a Keycloak realm role.
+type KeycloakRole struct {
+	// Role ID.
+	// +optional
+	ID string `json:"id,omitempty"`
+	// Role name.
+	Name string `json:"name"`
+	// Role description.
+	// +optional
+	Description string `json:"description,omitempty"`
+}
+
+// KeycloakAPIClientRef identifies a Keycloak client by its internal ID.
+type KeycloakAPIClientRef struct {
+	// Client internal ID.
+	ID string `json:"id"`
+	// Client ID.
+	ClientID string `json:"clientId"`
+}
+
+// keycloakRealmConfig defines the additional configuration of the Argo CD realm that is reconciled continuously.
+type keycloakRealmConfig struct {
+	IdentityProviders       []*KeycloakIdentityProvider       `json:"identityProviders,omitempty"`
+	IdentityProviderMappers []*KeycloakIdentityProviderMapper `json:"identityProviderMappers,omitempty"`
+	ClientScopes            []KeycloakClientScope             `json:"clientScopes,omitempty"`
+	ClientProtocolMappers   []KeycloakProtocolMapper          `json:"clientProtocolMappers,omitempty"`
+	Roles                   []KeycloakRole                    `json:"roles,omitempty"`
+}
+
+// keycloakRealmState records the entries of the additional realm configuration that were created by the operator,
+// so that only those entries are removed from the realm once they are removed from the ArgoCD spec. Entries that
+// already existed in the realm, such as the built-in client scopes, are updated but never removed.
+type keycloakRealmState struct {
+	IdentityProviders          []string            `json:"identityProviders,omitempty"`
+	IdentityProviderMappers    map[string][]string `json:"identityProviderMappers,omitempty"`
+	ClientScopes               []string            `json:"clientScopes,omitempty"`
+	ClientScopeProtocolMappers map[string][]string `json:"clientScopeProtocolMappers,omitempty"`
+	DefaultClientScopes        []string            `json:"defaultClientScopes,omitempty"`
+	ClientProtocolMappers      []string            `json:"clientProtocolMappers,omitempty"`
+	Roles                      []string            `json:"roles,omitempty"`
+	// ClientSecretHashes holds the hash of the client secret last applied to each identity provider, since Keycloak
+	// masks the client secrets it returns.
+	ClientSecretHashes map[string]string `json:"clientSecretHashes,omitempty"`
+}
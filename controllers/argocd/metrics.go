@@ -1,8 +1,25 @@
 package argocd
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
 	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
 )
 
 var (
@@ -36,8 +53,235 @@ var (
 		Help:    "Length of time per reconciliation per instance",
 		Buckets: []float64{0.05, 0.075, 0.1, 0.15, 0.2, 0.22, 0.24, 0.26, 0.28, 0.3, 0.32, 0.34, 0.37, 0.4, 0.42, 0.44, 0.48, 0.5, 0.55, 0.6, 0.75, 0.9, 1.00},
 	}, []string{"namespace"})
+
+	// ReconcileStepTime is a prometheus metric which keeps track of the duration
+	// of each reconcile step for a given instance
+	ReconcileStepTime = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "argocd_instance_reconcile_step_duration_seconds",
+		Help:    "Length of time per reconcile step per instance",
+		Buckets: []float64{0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5},
+	}, []string{"namespace", "step"})
+
+	ReconcileStepErrors = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "argocd_instance_reconcile_step_errors_total",
+			Help: "Number of failed reconcile steps per instance",
+		},
+		[]string{"namespace", "step"},
+	)
+
+	DriftCorrections = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "argocd_instance_drift_corrections_total",
+			Help: "Number of updates made to the resources owned by an instance to bring them back to their desired state",
+		},
+		[]string{"namespace", "kind"},
+	)
+
+	CertificateExpiry = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "argocd_instance_certificate_expiry_timestamp_seconds",
+			Help: "Expiry time of the TLS certificates used by an instance, in seconds since the epoch",
+		},
+		[]string{"namespace", "secret"},
+	)
 )
 
 func init() {
-	metrics.Registry.MustRegister(ActiveInstancesTotal, ActiveInstancesByPhase, ActiveInstanceReconciliationCount, ReconcileTime)
+	metrics.Registry.MustRegister(ActiveInstancesTotal, ActiveInstancesByPhase, ActiveInstanceReconciliationCount, ReconcileTime,
+		ReconcileStepTime, ReconcileStepErrors, DriftCorrections, CertificateExpiry)
+}
+
+// deleteInstanceMetrics removes the metrics of the instance in the given namespace.
+func deleteInstanceMetrics(namespace string) {
+	ActiveInstanceReconciliationCount.DeleteLabelValues(namespace)
+	ReconcileTime.DeletePartialMatch(prometheus.Labels{"namespace": namespace})
+	ReconcileStepTime.DeletePartialMatch(prometheus.Labels{"namespace": namespace})
+	ReconcileStepErrors.DeletePartialMatch(prometheus.Labels{"namespace": namespace})
+	DriftCorrections.DeletePartialMatch(prometheus.Labels{"namespace": namespace})
+	CertificateExpiry.DeletePartialMatch(prometheus.Labels{"namespace": namespace})
+}
+
+// driftTrackingClient is a client counting the updates made to the resources owned by an ArgoCD that bring them
+// back to the state last applied by the operator. The applied states are only kept in memory, so updates are not
+// counted for a resource until the operator applied it once since it started.
+type driftTrackingClient struct {
+	client.Client
+
+	mu sync.Mutex
+	// State last applied to the owned resources, by resource
+	applied map[string]appliedState
+}
+
+// appliedState is the state last applied by the operator to a resource.
+type appliedState struct {
+	// Resource version returned by the last update
+	resourceVersion string
+	// Hash of the applied state, see getAppliedStateHash
+	hash string
+}
+
+// newDriftTrackingClient returns a client counting the drift corrections made through the given client.
+func newDriftTrackingClient(c client.Client) *driftTrackingClient {
+	return &driftTrackingClient{Client: c, applied: make(map[string]appliedState)}
+}
+
+// Get retrieves the given object, and forgets the state last applied to it when it no longer exists.
+func (c *driftTrackingClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	err := c.Client.Get(ctx, key, obj, opts...)
+	if apierrors.IsNotFound(err) {
+		c.forget(obj, key)
+	}
+	return err
+}
+
+// Update updates the given object, and counts it as a drift correction when the object is owned by an ArgoCD and
+// it was changed by someone else since the operator last applied it. An object is changed by someone else when its
+// resource version differs from the one returned by the last update and its applied state differs from the last
+// applied one.
+func (c *driftTrackingClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	owner := metav1.GetControllerOf(obj)
+	if owner == nil || owner.Kind != "ArgoCD" {
+		return c.Client.Update(ctx, obj, opts...)
+	}
+
+	gvk, err := apiutil.GVKForObject(obj, c.Scheme())
+	if err != nil {
+		log.Error(err, fmt.Sprintf("unable to determine the kind of %s/%s", obj.GetNamespace(), obj.GetName()))
+		return c.Client.Update(ctx, obj, opts...)
+	}
+	key := fmt.Sprintf("%s/%s/%s", gvk.Kind, obj.GetNamespace(), obj.GetName())
+
+	// The update only succeeds when the resource version of the object is the live one, so the live state can only
+	// be compared when the cache returns the same version. Otherwise the cache is stale or the update is
+	// unconditional.
+	resourceVersion := obj.GetResourceVersion()
+	var liveHash string
+	live, ok := obj.DeepCopyObject().(client.Object)
+	if ok && resourceVersion != "" {
+		if err := c.Get(ctx, client.ObjectKeyFromObject(obj), live); err == nil && live.GetResourceVersion() == resourceVersion {
+			liveHash = getAppliedStateHash(live)
+		}
+	}
+
+	if err := c.Client.Update(ctx, obj, opts...); err != nil {
+		if apierrors.IsNotFound(err) {
+			c.forget(obj, client.ObjectKeyFromObject(obj))
+		}
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if last, ok := c.applied[key]; ok && liveHash != "" && resourceVersion != last.resourceVersion && liveHash != last.hash {
+		DriftCorrections.WithLabelValues(obj.GetNamespace(), gvk.Kind).Inc()
+	}
+	c.applied[key] = appliedState{resourceVersion: obj.GetResourceVersion(), hash: getAppliedStateHash(obj)}
+	return nil
+}
+
+// Delete deletes the given object, and forgets the state last applied to it.
+func (c *driftTrackingClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	if err := c.Client.Delete(ctx, obj, opts...); err != nil {
+		return err
+	}
+	c.forget(obj, client.ObjectKeyFromObject(obj))
+	return nil
+}
+
+// forget forgets the state last applied to the object with the given key.
+func (c *driftTrackingClient) forget(obj client.Object, key client.ObjectKey) {
+	gvk, err := apiutil.GVKForObject(obj, c.Scheme())
+	if err != nil {
+		return
+	}
+	c.mu.Lock()
+	delete(c.applied, fmt.Sprintf("%s/%s/%s", gvk.Kind, key.Namespace, key.Name))
+	c.mu.Unlock()
+}
+
+// unmanagedAnnotationPrefixes are the prefixes of the annotations that Kubernetes components and other controllers
+// set on the resources owned by an ArgoCD, such as the revision of a Deployment.
+var unmanagedAnnotationPrefixes = []string{
+	"deployment.kubernetes.io/",
+	"kubectl.kubernetes.io/",
+	"pv.kubernetes.io/",
+	"volume.kubernetes.io/",
+	"volume.beta.kubernetes.io/",
+	"kubernetes.io/service-account.",
+	"service.alpha.openshift.io/",
+	"service.beta.openshift.io/serving-cert-signed-by",
+	"service.beta.openshift.io/expiry",
+	"service.beta.openshift.io/originating-service-",
+	"auth.openshift.io/",
+	"openshift.io/",
+}
+
+// isUnmanagedAnnotation returns whether the given annotation is set by Kubernetes components or other controllers.
+func isUnmanagedAnnotation(key string) bool {
+	for _, prefix := range unmanagedAnnotationPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// getAppliedStateHash returns a hash of the state of the given object that is applied by the operator: its labels,
+// the annotations not set by other controllers and its content, without the rest of its metadata and its status.
+func getAppliedStateHash(obj client.Object) string {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return ""
+	}
+	delete(content, "apiVersion")
+	delete(content, "kind")
+	delete(content, "status")
+
+	annotations := map[string]string{}
+	for k, v := range obj.GetAnnotations() {
+		if !isUnmanagedAnnotation(k) {
+			annotations[k] = v
+		}
+	}
+	content["metadata"] = map[string]interface{}{
+		"labels":      obj.GetLabels(),
+		"annotations": annotations,
+	}
+
+	data, err := json.Marshal(content)
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(data))
+}
+
+// getCertificateSecretNames returns the names of the secrets holding the TLS certificates used by the given ArgoCD.
+func getCertificateSecretNames(cr *argoproj.ArgoCD) []string {
+	return []string{
+		nameWithSuffix(common.ArgoCDCASuffix, cr),
+		nameWithSuffix("tls", cr),
+		common.ArgoCDServerTLSSecretName,
+		common.ArgoCDRepoServerTLSSecretName,
+		common.ArgoCDRedisServerTLSSecretName,
+	}
+}
+
+// updateCertificateExpiryMetrics will set the expiry time of the TLS certificates used by the given ArgoCD.
+func (r *ReconcileArgoCD) updateCertificateExpiryMetrics(cr *argoproj.ArgoCD) {
+	for _, name := range getCertificateSecretNames(cr) {
+		secret := &corev1.Secret{}
+		if !argoutil.IsObjectFound(r.Client, cr.Namespace, name, secret) || len(secret.Data[corev1.TLSCertKey]) == 0 {
+			CertificateExpiry.DeleteLabelValues(cr.Namespace, name)
+			continue
+		}
+
+		cert, err := argoutil.ParsePEMEncodedCert(secret.Data[corev1.TLSCertKey])
+		if err != nil {
+			log.Info(fmt.Sprintf("unable to parse the certificate of secret %s: %v", name, err))
+			CertificateExpiry.DeleteLabelValues(cr.Namespace, name)
+			continue
+		}
+		CertificateExpiry.WithLabelValues(cr.Namespace, name).Set(float64(cert.NotAfter.Unix()))
+	}
 }
@@ -0,0 +1,205 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+func TestReconcileArgoCD_runReconcileStep_metrics(t *testing.T) {
+	cr := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Namespace = "step-metrics"
+	})
+	t.Cleanup(func() {
+		deleteInstanceMetrics(cr.Namespace)
+	})
+	r := &ReconcileArgoCD{}
+
+	assert.NoError(t, r.runReconcileStep(context.TODO(), cr, "reconcileRoles", func() error { return nil }))
+	assert.Error(t, r.runReconcileStep(context.TODO(), cr, "reconcileSecrets", func() error { return errors.New("boom") }))
+
+	assert.Equal(t, 2, testutil.CollectAndCount(ReconcileStepTime, "argocd_instance_reconcile_step_duration_seconds"))
+	assert.Equal(t, float64(0), testutil.ToFloat64(ReconcileStepErrors.WithLabelValues(cr.Namespace, "reconcileRoles")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(ReconcileStepErrors.WithLabelValues(cr.Namespace, "reconcileSecrets")))
+
+	deleteInstanceMetrics(cr.Namespace)
+	assert.Equal(t, 0, testutil.CollectAndCount(ReconcileStepTime, "argocd_instance_reconcile_step_duration_seconds"))
+}
+
+func TestDriftTrackingClient_Update(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Namespace = "drift-metrics"
+	})
+	t.Cleanup(func() {
+		deleteInstanceMetrics(a.Namespace)
+	})
+
+	owned := newConfigMapWithName("owned", a)
+	notOwned := newConfigMapWithName("not-owned", a)
+
+	resObjs := []client.Object{a, owned, notOwned}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := newDriftTrackingClient(makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs))
+	drift := func() float64 {
+		return testutil.ToFloat64(DriftCorrections.WithLabelValues(a.Namespace, "ConfigMap"))
+	}
+
+	// the first update and the updates of an unchanged object are not drift corrections
+	assert.NoError(t, controllerutil.SetControllerReference(a, owned, sch))
+	owned.Data = map[string]string{"foo": "bar"}
+	assert.NoError(t, cl.Update(context.TODO(), owned))
+	assert.NoError(t, cl.Update(context.TODO(), owned))
+	assert.Equal(t, float64(0), drift())
+
+	// neither are changes of the desired state
+	owned.Data = map[string]string{"foo": "baz"}
+	assert.NoError(t, cl.Update(context.TODO(), owned))
+	assert.Equal(t, float64(0), drift())
+
+	// restoring the state last applied after an external change is
+	external := owned.DeepCopy()
+	external.Data = map[string]string{"foo": "changed"}
+	assert.NoError(t, cl.Client.Update(context.TODO(), external))
+	owned.ResourceVersion = external.ResourceVersion
+	assert.NoError(t, cl.Update(context.TODO(), owned))
+	assert.Equal(t, float64(1), drift())
+
+	// changes made by other controllers to their own metadata are not
+	external = owned.DeepCopy()
+	external.Annotations = map[string]string{"deployment.kubernetes.io/revision": "2"}
+	assert.NoError(t, cl.Client.Update(context.TODO(), external))
+	owned.ResourceVersion = external.ResourceVersion
+	owned.Annotations = external.Annotations
+	assert.NoError(t, cl.Update(context.TODO(), owned))
+	assert.Equal(t, float64(1), drift())
+
+	// nor are unconditional updates, whose live state cannot be read from the cache reliably
+	external = owned.DeepCopy()
+	external.Data = map[string]string{"foo": "changed"}
+	assert.NoError(t, cl.Client.Update(context.TODO(), external))
+	owned.ResourceVersion = ""
+	assert.NoError(t, cl.Update(context.TODO(), owned))
+	assert.Equal(t, float64(1), drift())
+
+	notOwned.Data = map[string]string{"foo": "bar"}
+	assert.NoError(t, cl.Update(context.TODO(), notOwned))
+	notOwned.Data = map[string]string{"foo": "baz"}
+	assert.NoError(t, cl.Update(context.TODO(), notOwned))
+	assert.Equal(t, float64(1), drift())
+}
+
+func TestDriftTrackingClient_Update_staleCache(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Namespace = "drift-metrics-stale"
+	})
+	t.Cleanup(func() {
+		deleteInstanceMetrics(a.Namespace)
+	})
+
+	owned := newConfigMapWithName("owned", a)
+	resObjs := []client.Object{a, owned}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := newDriftTrackingClient(makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs))
+
+	assert.NoError(t, controllerutil.SetControllerReference(a, owned, sch))
+	owned.Data = map[string]string{"foo": "bar"}
+	assert.NoError(t, cl.Update(context.TODO(), owned))
+
+	// an update of the state read before the last update is rejected, and is not a drift correction
+	stale := owned.DeepCopy()
+	stale.ResourceVersion = "1"
+	stale.Data = map[string]string{"foo": "baz"}
+	assert.Error(t, cl.Update(context.TODO(), stale))
+	assert.NoError(t, cl.Update(context.TODO(), owned))
+	assert.Equal(t, float64(0), testutil.ToFloat64(DriftCorrections.WithLabelValues(a.Namespace, "ConfigMap")))
+}
+
+func TestDriftTrackingClient_forgetsRemovedObjects(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	owned := newConfigMapWithName("owned", a)
+	resObjs := []client.Object{a, owned}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := newDriftTrackingClient(makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs))
+
+	assert.NoError(t, controllerutil.SetControllerReference(a, owned, sch))
+	assert.NoError(t, cl.Update(context.TODO(), owned))
+	assert.Len(t, cl.applied, 1)
+
+	// objects removed without this client, such as by the garbage collector, are forgotten once they are not found
+	assert.NoError(t, cl.Client.Delete(context.TODO(), owned))
+	assert.Len(t, cl.applied, 1)
+	err := cl.Get(context.TODO(), client.ObjectKeyFromObject(owned), &corev1.ConfigMap{})
+	assert.True(t, apierrors.IsNotFound(err))
+	assert.Empty(t, cl.applied)
+}
+
+func TestReconcileArgoCD_updateCertificateExpiryMetrics(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Namespace = "certificate-metrics"
+	})
+	t.Cleanup(func() {
+		deleteInstanceMetrics(a.Namespace)
+	})
+
+	key, err := argoutil.NewPrivateKey()
+	assert.NoError(t, err)
+	cert, err := argoutil.NewSelfSignedCACertificate(a.Name, key)
+	assert.NoError(t, err)
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: common.ArgoCDRepoServerTLSSecretName, Namespace: a.Namespace},
+		Type:       corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			corev1.TLSCertKey: argoutil.EncodeCertificatePEM(cert),
+		},
+	}
+
+	resObjs := []client.Object{a, secret}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	r.updateCertificateExpiryMetrics(a)
+
+	assert.Equal(t, float64(cert.NotAfter.Unix()), testutil.ToFloat64(CertificateExpiry.WithLabelValues(a.Namespace, common.ArgoCDRepoServerTLSSecretName)))
+	assert.Equal(t, 1, testutil.CollectAndCount(CertificateExpiry, "argocd_instance_certificate_expiry_timestamp_seconds"))
+}
@@ -0,0 +1,282 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+
+	monitoringv1 "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+const (
+	// componentStatusRuleGroup is the rule group of the alerts tracking the workload statuses.
+	componentStatusRuleGroup = "ArgoCDComponentStatus"
+
+	// operationalStatusRuleGroup is the rule group of the alerts tracking the health of the Argo CD operations.
+	operationalStatusRuleGroup = "ArgoCDOperationalStatus"
+)
+
+// monitoringAlert is an alert of the built-in alert catalog.
+type monitoringAlert struct {
+	name      string
+	group     string
+	message   string
+	severity  string
+	duration  string
+	threshold string
+	// expr returns the alert expression for the given threshold.
+	expr func(threshold string) string
+}
+
+// newWorkloadNotReadyAlert returns an alert firing when the given workload does not have all its replicas ready.
+func newWorkloadNotReadyAlert(cr *argoproj.ArgoCD, name, kind, workload, component, severity, duration string) monitoringAlert {
+	return monitoringAlert{
+		name:     name,
+		group:    componentStatusRuleGroup,
+		message:  fmt.Sprintf("%s deployment for Argo CD instance in namespace %s is not running", component, cr.Namespace),
+		severity: severity,
+		duration: duration,
+		expr: func(string) string {
+			return fmt.Sprintf("kube_%s_status_replicas{%s=\"%s\", namespace=\"%s\"} != kube_%s_status_replicas_ready{%s=\"%s\", namespace=\"%s\"} ",
+				kind, kind, workload, cr.Namespace, kind, kind, workload, cr.Namespace)
+		},
+	}
+}
+
+// getMonitoringAlertCatalog returns the built-in alerts for the given ArgoCD, with their default settings.
+func getMonitoringAlertCatalog(cr *argoproj.ArgoCD) []monitoringAlert {
+	controllerSelector := fmt.Sprintf("job=\"%s\", namespace=\"%s\"", nameWithSuffix("metrics", cr), cr.Namespace)
+	repoServerSelector := fmt.Sprintf("job=\"%s\", namespace=\"%s\"", nameWithSuffix("repo-server", cr), cr.Namespace)
+
+	return []monitoringAlert{
+		newWorkloadNotReadyAlert(cr, "ApplicationControllerNotReady", "statefulset", nameWithSuffix("application-controller", cr), "application controller", "critical", "1m"),
+		newWorkloadNotReadyAlert(cr, "ServerNotReady", "deployment", nameWithSuffix("server", cr), "server", "critical", "1m"),
+		newWorkloadNotReadyAlert(cr, "RepoServerNotReady", "deployment", nameWithSuffix("repo-server", cr), "repo server", "critical", "1m"),
+		newWorkloadNotReadyAlert(cr, "ApplicationSetControllerNotReady", "deployment", nameWithSuffix("applicationset-controller", cr), "applicationSet controller", "warning", "5m"),
+		newWorkloadNotReadyAlert(cr, "DexNotReady", "deployment", nameWithSuffix("dex-server", cr), "dex", "warning", "5m"),
+		newWorkloadNotReadyAlert(cr, "NotificationsControllerNotReady", "deployment", nameWithSuffix("notifications-controller", cr), "notifications controller", "warning", "5m"),
+		newWorkloadNotReadyAlert(cr, "RedisNotReady", "deployment", nameWithSuffix("redis", cr), "redis", "warning", "5m"),
+		{
+			name:      "ApplicationSyncFailed",
+			group:     operationalStatusRuleGroup,
+			message:   fmt.Sprintf("applications managed by Argo CD instance in namespace %s failed to sync", cr.Namespace),
+			severity:  "warning",
+			duration:  "1m",
+			threshold: "0",
+			expr: func(threshold string) string {
+				return fmt.Sprintf("sum by (name, project) (increase(argocd_app_sync_total{%s, phase=~\"Error|Failed\"}[10m])) > %s", controllerSelector, threshold)
+			},
+		},
+		{
+			name:      "ApplicationHealthDegraded",
+			group:     operationalStatusRuleGroup,
+			message:   fmt.Sprintf("applications managed by Argo CD instance in namespace %s are degraded", cr.Namespace),
+			severity:  "warning",
+			duration:  "15m",
+			threshold: "0",
+			expr: func(threshold string) string {
+				return fmt.Sprintf("sum by (name, project) (argocd_app_info{%s, health_status=\"Degraded\"}) > %s", controllerSelector, threshold)
+			},
+		},
+		{
+			name:      "RepoServerErrors",
+			group:     operationalStatusRuleGroup,
+			message:   fmt.Sprintf("repo server for Argo CD instance in namespace %s is failing requests", cr.Namespace),
+			severity:  "warning",
+			duration:  "10m",
+			threshold: "0.05",
+			expr: func(threshold string) string {
+				return fmt.Sprintf("sum(rate(grpc_server_handled_total{%s, grpc_code!=\"OK\"}[5m])) / sum(rate(grpc_server_handled_total{%s}[5m])) > %s", repoServerSelector, repoServerSelector, threshold)
+			},
+		},
+		{
+			name:      "ControllerQueueDepthHigh",
+			group:     operationalStatusRuleGroup,
+			message:   fmt.Sprintf("application controller work queues for Argo CD instance in namespace %s are backing up", cr.Namespace),
+			severity:  "warning",
+			duration:  "10m",
+			threshold: "100",
+			expr: func(threshold string) string {
+				return fmt.Sprintf("max by (name) (workqueue_depth{%s}) > %s", controllerSelector, threshold)
+			},
+		},
+		{
+			name:      "CertificateExpiringSoon",
+			group:     operationalStatusRuleGroup,
+			message:   fmt.Sprintf("TLS certificates used by Argo CD instance in namespace %s expire soon", cr.Namespace),
+			severity:  "warning",
+			duration:  "1h",
+			threshold: "14",
+			expr: func(threshold string) string {
+				// The namespace label of the operator metrics is exposed as exported_namespace, since the scrape target
+				// has a namespace label of its own.
+				return fmt.Sprintf("(min by (secret) (argocd_instance_certificate_expiry_timestamp_seconds{exported_namespace=\"%s\"}) - time()) / 86400 < %s", cr.Namespace, threshold)
+			},
+		},
+	}
+}
+
+// getPrometheusRuleGroups returns the rule groups of the PrometheusRule for the given ArgoCD, built from the
+// alert catalog with the overrides of the monitoring spec applied.
+func getPrometheusRuleGroups(cr *argoproj.ArgoCD) []monitoringv1.RuleGroup {
+	overrides := map[string]argoproj.ArgoCDMonitoringAlertSpec{}
+	for _, alert := range cr.Spec.Monitoring.Alerts {
+		overrides[alert.Name] = alert
+	}
+
+	groups := []monitoringv1.RuleGroup{}
+	for _, alert := range getMonitoringAlertCatalog(cr) {
+		override, ok := overrides[alert.name]
+		delete(overrides, alert.name)
+		if ok && override.Enabled != nil && !*override.Enabled {
+			continue
+		}
+
+		labels := map[string]string{}
+		for k, v := range override.Labels {
+			labels[k] = v
+		}
+		labels["severity"] = alert.severity
+		if override.Severity != "" {
+			labels["severity"] = override.Severity
+		}
+
+		threshold := alert.threshold
+		if override.Threshold != "" {
+			threshold = override.Threshold
+		}
+
+		duration := alert.duration
+		if override.For != "" {
+			duration = override.For
+		}
+
+		rule := monitoringv1.Rule{
+			Alert: alert.name,
+			Annotations: map[string]string{
+				"message": alert.message,
+			},
+			Expr: intstr.IntOrString{
+				Type:   intstr.String,
+				StrVal: alert.expr(threshold),
+			},
+			For:    duration,
+			Labels: labels,
+		}
+
+		if len(groups) == 0 || groups[len(groups)-1].Name != alert.group {
+			groups = append(groups, monitoringv1.RuleGroup{Name: alert.group})
+		}
+		groups[len(groups)-1].Rules = append(groups[len(groups)-1].Rules, rule)
+	}
+
+	for name := range overrides {
+		log.Info(fmt.Sprintf("ignoring override of unknown alert %s for ArgoCD %s/%s", name, cr.Namespace, cr.Name))
+	}
+
+	return groups
+}
+
+// getGrafanaDashboardsPath will return the path for the Grafana dashboards.
+func getGrafanaDashboardsPath() string {
+	path := os.Getenv("GRAFANA_DASHBOARDS_PATH")
+	if len(path) > 0 {
+		return path
+	}
+	return common.ArgoCDDefaultGrafanaDashboardsPath
+}
+
+// getGrafanaDashboards will load the Grafana dashboards from disk, indexed by file name.
+func getGrafanaDashboards() (map[string]string, error) {
+	files, err := filepath.Glob(filepath.Join(getGrafanaDashboardsPath(), "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	dashboards := make(map[string]string, len(files))
+	for _, f := range files {
+		data, err := os.ReadFile(f)
+		if err != nil {
+			return nil, err
+		}
+		dashboards[filepath.Base(f)] = string(data)
+	}
+	return dashboards, nil
+}
+
+// getMonitoringDashboardsLabels returns the labels the Grafana sidecar uses to discover the dashboards ConfigMap.
+func getMonitoringDashboardsLabels(cr *argoproj.ArgoCD) map[string]string {
+	if len(cr.Spec.Monitoring.Dashboards.Labels) > 0 {
+		return cr.Spec.Monitoring.Dashboards.Labels
+	}
+	return map[string]string{
+		common.ArgoCDDefaultGrafanaDashboardLabel: "1",
+	}
+}
+
+// reconcileMonitoringDashboards will ensure that the ConfigMap holding the Grafana dashboards is present and up to
+// date when dashboards are enabled, and removed otherwise.
+func (r *ReconcileArgoCD) reconcileMonitoringDashboards(cr *argoproj.ArgoCD) error {
+	cm := newConfigMapWithName(nameWithSuffix(common.ArgoCDMonitoringDashboardsSuffix, cr), cr)
+	found := argoutil.IsObjectFound(r.Client, cr.Namespace, cm.Name, cm)
+
+	if !cr.Spec.Monitoring.Dashboards.Enabled {
+		if found {
+			log.Info(fmt.Sprintf("monitoring dashboards disabled, deleting ConfigMap %s", cm.Name))
+			return r.Client.Delete(context.TODO(), cm)
+		}
+		return nil // Dashboards not enabled, do nothing.
+	}
+
+	dashboards, err := getGrafanaDashboards()
+	if err != nil {
+		return err
+	}
+
+	labels := argoutil.LabelsForCluster(cr)
+	labels[common.ArgoCDKeyName] = cm.Name
+	for k, v := range getMonitoringDashboardsLabels(cr) {
+		labels[k] = v
+	}
+
+	if !found {
+		cm.Labels = labels
+		cm.Data = dashboards
+		if err := controllerutil.SetControllerReference(cr, cm, r.Scheme); err != nil {
+			return err
+		}
+		log.Info(fmt.Sprintf("creating monitoring dashboards ConfigMap %s", cm.Name))
+		return r.Client.Create(context.TODO(), cm)
+	}
+
+	if reflect.DeepEqual(cm.Labels, labels) && reflect.DeepEqual(cm.Data, dashboards) {
+		return nil // ConfigMap found with nothing to do, move along...
+	}
+
+	cm.Labels = labels
+	cm.Data = dashboards
+	log.Info(fmt.Sprintf("updating monitoring dashboards ConfigMap %s", cm.Name))
+	return r.Client.Update(context.TODO(), cm)
+}
@@ -0,0 +1,117 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	monitoringv1 "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+)
+
+func findPrometheusRule(groups []monitoringv1.RuleGroup, alert string) *monitoringv1.Rule {
+	for _, group := range groups {
+		for i := range group.Rules {
+			if group.Rules[i].Alert == alert {
+				return &group.Rules[i]
+			}
+		}
+	}
+	return nil
+}
+
+func TestReconcileArgoCD_reconcilePrometheusRule_alertOverrides(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Spec.Monitoring.Enabled = true
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme, monitoringv1.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcilePrometheusRule(a))
+
+	rule := &monitoringv1.PrometheusRule{}
+	key := types.NamespacedName{Name: "argocd-component-status-alert", Namespace: a.Namespace}
+	assert.NoError(t, r.Client.Get(context.TODO(), key, rule))
+	queueDepth := findPrometheusRule(rule.Spec.Groups, "ControllerQueueDepthHigh")
+	assert.NotNil(t, queueDepth)
+	assert.Equal(t, "max by (name) (workqueue_depth{job=\"argocd-metrics\", namespace=\"argocd\"}) > 100", queueDepth.Expr.StrVal)
+	assert.NotNil(t, findPrometheusRule(rule.Spec.Groups, "ApplicationSyncFailed"))
+
+	// the rule is kept in sync with the overrides of the alert catalog
+	a.Spec.Monitoring.Alerts = []argoproj.ArgoCDMonitoringAlertSpec{
+		{Name: "ApplicationSyncFailed", Enabled: boolPtr(false)},
+		{Name: "ControllerQueueDepthHigh", Threshold: "250", For: "30m", Severity: "critical", Labels: map[string]string{"team": "platform"}},
+		{Name: "UnknownAlert", Enabled: boolPtr(false)},
+	}
+	assert.NoError(t, r.reconcilePrometheusRule(a))
+
+	assert.NoError(t, r.Client.Get(context.TODO(), key, rule))
+	assert.Nil(t, findPrometheusRule(rule.Spec.Groups, "ApplicationSyncFailed"))
+	queueDepth = findPrometheusRule(rule.Spec.Groups, "ControllerQueueDepthHigh")
+	assert.NotNil(t, queueDepth)
+	assert.Equal(t, "max by (name) (workqueue_depth{job=\"argocd-metrics\", namespace=\"argocd\"}) > 250", queueDepth.Expr.StrVal)
+	assert.Equal(t, "30m", string(queueDepth.For))
+	assert.Equal(t, map[string]string{"severity": "critical", "team": "platform"}, queueDepth.Labels)
+}
+
+func TestReconcileArgoCD_reconcileMonitoringDashboards(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	t.Setenv("GRAFANA_DASHBOARDS_PATH", "../../build/grafana/dashboards")
+	a := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Spec.Monitoring.Dashboards.Enabled = true
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileMonitoringDashboards(a))
+
+	cm := &corev1.ConfigMap{}
+	key := types.NamespacedName{Name: "argocd-monitoring-dashboards", Namespace: a.Namespace}
+	assert.NoError(t, r.Client.Get(context.TODO(), key, cm))
+	assert.Equal(t, "1", cm.Labels["grafana_dashboard"])
+	assert.Contains(t, cm.Data, "argocd-overview.json")
+	assert.True(t, json.Valid([]byte(cm.Data["argocd-overview.json"])))
+
+	// custom sidecar labels replace the default one
+	a.Spec.Monitoring.Dashboards.Labels = map[string]string{"dashboards": "argocd"}
+	assert.NoError(t, r.reconcileMonitoringDashboards(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, cm))
+	assert.Equal(t, "argocd", cm.Labels["dashboards"])
+	assert.NotContains(t, cm.Labels, "grafana_dashboard")
+
+	a.Spec.Monitoring.Dashboards.Enabled = false
+	assert.NoError(t, r.reconcileMonitoringDashboards(a))
+	assert.Error(t, r.Client.Get(context.TODO(), key, cm))
+}
@@ -12,6 +12,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
 	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
 )
 
@@ -97,6 +98,18 @@ func (r *ReconcileArgoCD) ReconcileRedisNetworkPolicy(cr *argoproj.ArgoCD) error
 		},
 	}
 
+	// The Redis exporter metrics are scraped by Prometheus, which may run in any namespace.
+	if isRedisExporterEnabled(cr) {
+		networkPolicy.Spec.Ingress = append(networkPolicy.Spec.Ingress, networkingv1.NetworkPolicyIngressRule{
+			Ports: []networkingv1.NetworkPolicyPort{
+				{
+					Protocol: TCPProtocol,
+					Port:     &intstr.IntOrString{Type: intstr.Int, IntVal: common.ArgoCDDefaultRedisExporterPort},
+				},
+			},
+		})
+	}
+
 	// Check if the network policy already exists
 	existing := &networkingv1.NetworkPolicy{
 		ObjectMeta: metav1.ObjectMeta{
@@ -3,10 +3,12 @@ package argocd
 import (
 	"context"
 	"fmt"
+	"os"
 	"reflect"
+	"strings"
 	"time"
 
-	monitoringv1 "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/argoproj/argo-cd/v2/util/glob"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
@@ -14,6 +16,7 @@ import (
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	"github.com/argoproj-labs/argocd-operator/api/v1alpha1"
@@ -45,6 +48,28 @@ func (r *ReconcileArgoCD) reconcileNotificationsController(cr *argoproj.ArgoCD)
 		return err
 	}
 
+	log.Info("reconciling notifications cluster role")
+	clusterRole, err := r.reconcileNotificationsClusterRole(cr)
+	if err != nil {
+		return err
+	}
+
+	log.Info("reconciling notifications cluster role binding")
+	if err := r.reconcileNotificationsClusterRoleBinding(cr, clusterRole, sa); err != nil {
+		return err
+	}
+
+	log.Info("reconciling notifications source namespaces")
+	if err := r.reconcileNotificationsSourceNamespaces(cr); err != nil {
+		return err
+	}
+
+	// Source namespaces are cleaned up here while notifications are enabled, and by deleteNotificationsResources once
+	// they are disabled.
+	if err := r.removeUnmanagedNotificationsSourceNamespaceResources(cr); err != nil {
+		return err
+	}
+
 	log.Info("reconciling NotificationsConfiguration")
 	if err := r.reconcileNotificationsConfigurationCR(cr); err != nil {
 		return err
@@ -157,6 +182,22 @@ func (r *ReconcileArgoCD) deleteNotificationsResources(cr *argoproj.ArgoCD) erro
 		return err
 	}
 
+	log.Info("reconciling notifications source namespaces")
+	if err := r.removeUnmanagedNotificationsSourceNamespaceResources(cr); err != nil {
+		return err
+	}
+
+	log.Info("reconciling notifications cluster role binding")
+	clusterRole := newClusterRole(common.ArgoCDNotificationsControllerComponent, nil, cr)
+	if err := r.reconcileNotificationsClusterRoleBinding(cr, clusterRole, sa); err != nil {
+		return err
+	}
+
+	log.Info("reconciling notifications cluster role")
+	if _, err := r.reconcileNotificationsClusterRole(cr); err != nil {
+		return err
+	}
+
 	log.Info("reconciling notifications role binding")
 	if err := r.reconcileNotificationsRoleBinding(cr, role, sa); err != nil {
 		return err
@@ -360,18 +401,14 @@ func (r *ReconcileArgoCD) reconcileNotificationsDeployment(cr *argoproj.ArgoCD,
 			},
 		},
 		{
-			Name: "argocd-repo-server-tls",
-			VolumeSource: corev1.VolumeSource{
-				Secret: &corev1.SecretVolumeSource{
-					SecretName: common.ArgoCDRepoServerTLSSecretName,
-					Optional:   boolPtr(true),
-				},
-			},
+			Name:         "argocd-repo-server-tls",
+			VolumeSource: getRepoServerTLSVolumeSource(cr),
 		},
 	}
+	podSpec.Volumes = append(podSpec.Volumes, r.getTrustedCABundleVolumes(cr)...)
 
 	podSpec.Containers = []corev1.Container{{
-		Command:         getNotificationsCommand(cr),
+		Command:         r.getNotificationsCommand(cr),
 		Image:           getArgoContainerImage(cr),
 		ImagePullPolicy: corev1.PullAlways,
 		Name:            common.ArgoCDNotificationsControllerComponent,
@@ -409,6 +446,8 @@ func (r *ReconcileArgoCD) reconcileNotificationsDeployment(cr *argoproj.ArgoCD,
 		},
 		WorkingDir: "/app",
 	}}
+	podSpec.Containers[0].VolumeMounts = append(podSpec.Containers[0].VolumeMounts, r.getTrustedCABundleVolumeMounts(cr)...)
+	setTrustedCABundleChecksum(&desiredDeployment.Spec.Template, r.getTrustedCABundleChecksum(cr))
 
 	// fetch existing deployment by name
 	deploymentChanged := false
@@ -473,6 +512,10 @@ func (r *ReconcileArgoCD) reconcileNotificationsDeployment(cr *argoproj.ArgoCD,
 		deploymentChanged = true
 	}
 
+	if updateTrustedCABundleChecksum(&existingDeployment.Spec.Template, &desiredDeployment.Spec.Template) {
+		deploymentChanged = true
+	}
+
 	if !reflect.DeepEqual(existingDeployment.Spec.Template.Spec.Containers[0].Resources, desiredDeployment.Spec.Template.Spec.Containers[0].Resources) {
 		existingDeployment.Spec.Template.Spec.Containers[0].Resources = desiredDeployment.Spec.Template.Spec.Containers[0].Resources
 		deploymentChanged = true
@@ -546,27 +589,16 @@ func (r *ReconcileArgoCD) reconcileNotificationsMetricsService(cr *argoproj.Argo
 func (r *ReconcileArgoCD) reconcileNotificationsServiceMonitor(cr *argoproj.ArgoCD) error {
 
 	name := fmt.Sprintf("%s-%s", cr.Name, "notifications-controller-metrics")
-	serviceMonitor := newServiceMonitorWithName(name, cr)
-	if argoutil.IsObjectFound(r.Client, cr.Namespace, serviceMonitor.Name, serviceMonitor) {
-		// Service found, do nothing
-		return nil
-	}
 
-	serviceMonitor.Spec.Selector = v1.LabelSelector{
-		MatchLabels: map[string]string{
-			common.ArgoCDKeyName: name,
-		},
+	endpoint := newServiceMonitorEndpoint(cr.Spec.Monitoring.ServiceMonitors.Notifications)
+	if endpoint.Scheme == "" {
+		endpoint.Scheme = "http"
 	}
-
-	serviceMonitor.Spec.Endpoints = []monitoringv1.Endpoint{
-		{
-			Port:     "metrics",
-			Scheme:   "http",
-			Interval: "30s",
-		},
+	if endpoint.Interval == "" {
+		endpoint.Interval = "30s"
 	}
 
-	return r.Client.Create(context.TODO(), serviceMonitor)
+	return r.reconcileComponentServiceMonitor(cr, name, name, endpoint, true)
 }
 
 // reconcileNotificationsSecret only creates/deletes the argocd-notifications-secret based on whether notifications is enabled/disabled in the CR
@@ -614,7 +646,7 @@ func (r *ReconcileArgoCD) reconcileNotificationsSecret(cr *argoproj.ArgoCD) erro
 	return nil
 }
 
-func getNotificationsCommand(cr *argoproj.ArgoCD) []string {
+func (r *ReconcileArgoCD) getNotificationsCommand(cr *argoproj.ArgoCD) []string {
 
 	cmd := make([]string, 0)
 	cmd = append(cmd, "argocd-notifications")
@@ -624,10 +656,22 @@ func getNotificationsCommand(cr *argoproj.ArgoCD) []string {
 
 	if cr.Spec.Repo.IsEnabled() {
 		cmd = append(cmd, "--argocd-repo-server", getRepoServerAddress(cr))
+		if isRepoServerTLSVerificationRequested(cr) {
+			cmd = append(cmd, "--argocd-repo-server-strict-tls")
+		}
 	} else {
 		log.Info("Repo Server is disabled. This would affect the functioning of Notification Controller.")
 	}
 
+	sourceNamespaces, err := r.getNotificationsSourceNamespaces(cr)
+	if err != nil {
+		log.Error(err, "failed to retrieve notifications source namespaces")
+	}
+	if len(sourceNamespaces) > 0 {
+		cmd = append(cmd, "--application-namespaces", strings.Join(sourceNamespaces, ","))
+		cmd = append(cmd, "--self-service-notification-enabled")
+	}
+
 	return cmd
 }
 
@@ -642,3 +686,345 @@ func getNotificationsResources(cr *argoproj.ArgoCD) corev1.ResourceRequirements
 
 	return resources
 }
+
+// getNotificationsSourceNamespaces returns the namespaces in which the notifications controller processes Applications and
+// self-service notifications configuration. Only namespaces that are also Application source namespaces are returned,
+// and only for cluster-scoped instances since the notifications controller then watches resources cluster-wide.
+func (r *ReconcileArgoCD) getNotificationsSourceNamespaces(cr *argoproj.ArgoCD) ([]string, error) {
+	if !cr.Spec.Notifications.Enabled || len(cr.Spec.Notifications.SourceNamespaces) == 0 {
+		return nil, nil
+	}
+	if !allowedNamespace(cr.Namespace, os.Getenv("ARGOCD_CLUSTER_CONFIG_NAMESPACES")) {
+		return nil, nil
+	}
+
+	appsNamespaces, err := r.getSourceNamespaces(cr)
+	if err != nil {
+		return nil, err
+	}
+
+	sourceNamespaces := []string{}
+	for _, namespace := range appsNamespaces {
+		if namespace == cr.Namespace {
+			continue
+		}
+		if glob.MatchStringInList(cr.Spec.Notifications.SourceNamespaces, namespace, glob.GLOB) {
+			sourceNamespaces = append(sourceNamespaces, namespace)
+		}
+	}
+
+	return sourceNamespaces, nil
+}
+
+// reconcileNotificationsClusterRole reconciles the clusterrole required by the notifications controller to watch
+// Applications and the self-service notifications configuration objects across namespaces when ArgoCD is cluster-scoped.
+// Access to the configuration of each source namespace is granted by the role reconciled in the namespace.
+func (r *ReconcileArgoCD) reconcileNotificationsClusterRole(cr *argoproj.ArgoCD) (*rbacv1.ClusterRole, error) {
+
+	sourceNamespaces, err := r.getNotificationsSourceNamespaces(cr)
+	if err != nil {
+		return nil, err
+	}
+
+	// source namespaces are only returned for cluster-scoped instances
+	allowed := len(sourceNamespaces) > 0
+
+	policyRules := []rbacv1.PolicyRule{
+		{
+			APIGroups: []string{"argoproj.io"},
+			Resources: []string{
+				"applications",
+			},
+			Verbs: []string{
+				"get",
+				"list",
+				"patch",
+				"update",
+				"watch",
+			},
+		},
+		// the notifications controller lists its configuration of every namespace with a metadata.name field
+		// selector, which only namespaced roles cannot authorize, so only the configuration objects are listed here
+		{
+			APIGroups:     []string{""},
+			ResourceNames: []string{"argocd-notifications-cm"},
+			Resources:     []string{"configmaps"},
+			Verbs:         []string{"list", "watch"},
+		},
+		{
+			APIGroups:     []string{""},
+			ResourceNames: []string{"argocd-notifications-secret"},
+			Resources:     []string{"secrets"},
+			Verbs:         []string{"list", "watch"},
+		},
+	}
+
+	clusterRole := newClusterRole(common.ArgoCDNotificationsControllerComponent, policyRules, cr)
+	if err := applyReconcilerHook(cr, clusterRole, ""); err != nil {
+		return nil, err
+	}
+
+	existingClusterRole := &rbacv1.ClusterRole{}
+	if err := r.Client.Get(context.TODO(), types.NamespacedName{Name: clusterRole.Name}, existingClusterRole); err != nil {
+		if !errors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to reconcile the cluster role for the service account associated with %s : %s", clusterRole.Name, err)
+		}
+		if !allowed {
+			return clusterRole, nil
+		}
+		log.Info(fmt.Sprintf("Creating clusterrole %s", clusterRole.Name))
+		return clusterRole, r.Client.Create(context.TODO(), clusterRole)
+	}
+
+	// no source namespaces or ArgoCD not cluster scoped, cleanup any existing resource and exit
+	if !allowed {
+		log.Info(fmt.Sprintf("Deleting clusterrole %s", existingClusterRole.Name))
+		if err := r.Client.Delete(context.TODO(), existingClusterRole); err != nil && !errors.IsNotFound(err) {
+			return existingClusterRole, err
+		}
+		return existingClusterRole, nil
+	}
+
+	if !reflect.DeepEqual(existingClusterRole.Rules, clusterRole.Rules) {
+		existingClusterRole.Rules = clusterRole.Rules
+		if err := r.Client.Update(context.TODO(), existingClusterRole); err != nil {
+			return nil, err
+		}
+	}
+	return existingClusterRole, nil
+}
+
+// reconcileNotificationsClusterRoleBinding reconciles the clusterrolebinding for the notifications controller when ArgoCD
+// is cluster-scoped and notifications source namespaces are configured
+func (r *ReconcileArgoCD) reconcileNotificationsClusterRoleBinding(cr *argoproj.ArgoCD, role *rbacv1.ClusterRole, sa *corev1.ServiceAccount) error {
+
+	sourceNamespaces, err := r.getNotificationsSourceNamespaces(cr)
+	if err != nil {
+		return err
+	}
+
+	// source namespaces are only returned for cluster-scoped instances
+	allowed := len(sourceNamespaces) > 0
+
+	clusterRB := newClusterRoleBindingWithname(common.ArgoCDNotificationsControllerComponent, cr)
+	clusterRB.Subjects = []rbacv1.Subject{
+		{
+			Kind:      rbacv1.ServiceAccountKind,
+			Name:      sa.Name,
+			Namespace: cr.Namespace,
+		},
+	}
+	clusterRB.RoleRef = rbacv1.RoleRef{
+		APIGroup: rbacv1.GroupName,
+		Kind:     "ClusterRole",
+		Name:     role.Name,
+	}
+	if err := applyReconcilerHook(cr, clusterRB, ""); err != nil {
+		return err
+	}
+
+	existingClusterRB := &rbacv1.ClusterRoleBinding{}
+	if err := r.Client.Get(context.TODO(), types.NamespacedName{Name: clusterRB.Name}, existingClusterRB); err != nil {
+		if !errors.IsNotFound(err) {
+			return fmt.Errorf("failed to reconcile the cluster rolebinding for the service account associated with %s : %s", clusterRB.Name, err)
+		}
+		if !allowed {
+			return nil
+		}
+		log.Info(fmt.Sprintf("Creating clusterrolebinding %s", clusterRB.Name))
+		return r.Client.Create(context.TODO(), clusterRB)
+	}
+
+	// no source namespaces or ArgoCD not cluster scoped, cleanup any existing resource and exit
+	if !allowed {
+		log.Info(fmt.Sprintf("Deleting clusterrolebinding %s", existingClusterRB.Name))
+		if err := r.Client.Delete(context.TODO(), existingClusterRB); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+		return nil
+	}
+
+	if !reflect.DeepEqual(existingClusterRB.RoleRef, clusterRB.RoleRef) {
+		// RoleRef can't be updated, delete the rolebinding so that it gets recreated
+		_ = r.Client.Delete(context.TODO(), existingClusterRB)
+		return fmt.Errorf("change detected in roleRef for clusterrolebinding %s of Argo CD instance %s in namespace %s", existingClusterRB.Name, cr.Name, cr.Namespace)
+	} else if !reflect.DeepEqual(existingClusterRB.Subjects, clusterRB.Subjects) {
+		existingClusterRB.Subjects = clusterRB.Subjects
+		return r.Client.Update(context.TODO(), existingClusterRB)
+	}
+	return nil
+}
+
+// reconcileNotificationsSourceNamespaces labels the notifications source namespaces of the ArgoCD instance, so that
+// the NotificationsConfiguration controller accepts self-service notifications configuration in these namespaces.
+func (r *ReconcileArgoCD) reconcileNotificationsSourceNamespaces(cr *argoproj.ArgoCD) error {
+
+	sourceNamespaces, err := r.getNotificationsSourceNamespaces(cr)
+	if err != nil {
+		return err
+	}
+
+	for _, sourceNamespace := range sourceNamespaces {
+		namespace := &corev1.Namespace{}
+		if err := r.Client.Get(context.TODO(), types.NamespacedName{Name: sourceNamespace}, namespace); err != nil {
+			return fmt.Errorf("failed to retrieve namespace %s : %s", sourceNamespace, err)
+		}
+
+		// a namespace can only be a notifications source namespace of a single Argo CD instance
+		if value, ok := namespace.Labels[common.ArgoCDNotificationsManagedByClusterArgoCDLabel]; ok && value != cr.Namespace {
+			log.Info(fmt.Sprintf("Skipping notifications source namespace %s as it is already managed by Argo CD instance in namespace %s", namespace.Name, value))
+			continue
+		}
+
+		if _, ok := namespace.Labels[common.ArgoCDNotificationsManagedByClusterArgoCDLabel]; !ok {
+			if namespace.Labels == nil {
+				namespace.Labels = make(map[string]string)
+			}
+			namespace.Labels[common.ArgoCDNotificationsManagedByClusterArgoCDLabel] = cr.Namespace
+			log.Info(fmt.Sprintf("Adding notifications label to namespace %s", namespace.Name))
+			if err := r.Client.Update(context.TODO(), namespace); err != nil {
+				return fmt.Errorf("failed to add notifications label to namespace %s : %s", namespace.Name, err)
+			}
+		}
+
+		// role & rolebinding for the notifications controller to read the notifications configuration of the namespace
+		role := rbacv1.Role{
+			ObjectMeta: v1.ObjectMeta{
+				Name:      getResourceNameForNotificationsSourceNamespaces(cr),
+				Namespace: sourceNamespace,
+				Labels:    argoutil.LabelsForCluster(cr),
+			},
+			Rules: policyRuleForNotificationsSourceNamespaces(),
+		}
+		if err := r.reconcileSourceNamespaceRole(role, cr); err != nil {
+			return err
+		}
+
+		roleBinding := rbacv1.RoleBinding{
+			ObjectMeta: v1.ObjectMeta{
+				Name:        getResourceNameForNotificationsSourceNamespaces(cr),
+				Labels:      argoutil.LabelsForCluster(cr),
+				Annotations: argoutil.AnnotationsForCluster(cr),
+				Namespace:   sourceNamespace,
+			},
+			RoleRef: rbacv1.RoleRef{
+				APIGroup: rbacv1.GroupName,
+				Kind:     "Role",
+				Name:     getResourceNameForNotificationsSourceNamespaces(cr),
+			},
+			Subjects: []rbacv1.Subject{
+				{
+					Kind:      rbacv1.ServiceAccountKind,
+					Name:      fmt.Sprintf("%s-%s", cr.Name, common.ArgoCDNotificationsControllerComponent),
+					Namespace: cr.Namespace,
+				},
+			},
+		}
+		if err := r.reconcileSourceNamespaceRoleBinding(roleBinding, cr); err != nil {
+			return err
+		}
+
+		if r.ManagedNotificationsSourceNamespaces == nil {
+			r.ManagedNotificationsSourceNamespaces = make(map[string]string)
+		}
+		r.ManagedNotificationsSourceNamespaces[sourceNamespace] = ""
+	}
+
+	return nil
+}
+
+// getResourceNameForNotificationsSourceNamespaces returns the name of the role and rolebinding of the notifications
+// controller in the notifications source namespaces.
+func getResourceNameForNotificationsSourceNamespaces(cr *argoproj.ArgoCD) string {
+	return fmt.Sprintf("%s-%s-notifications", cr.Name, cr.Namespace)
+}
+
+// removeUnmanagedNotificationsSourceNamespaceResources removes the notifications label from namespaces that are no longer
+// notifications source namespaces of the ArgoCD instance. ManagedNotificationsSourceNamespaces keeps track of labeled namespaces.
+func (r *ReconcileArgoCD) removeUnmanagedNotificationsSourceNamespaceResources(cr *argoproj.ArgoCD) error {
+
+	sourceNamespaces := []string{}
+	if cr.GetDeletionTimestamp() == nil {
+		namespaces, err := r.getNotificationsSourceNamespaces(cr)
+		if err != nil {
+			return err
+		}
+		sourceNamespaces = namespaces
+	}
+
+	for ns := range r.ManagedNotificationsSourceNamespaces {
+		if contains(sourceNamespaces, ns) {
+			continue
+		}
+		if err := r.cleanupUnmanagedNotificationsSourceNamespaceResources(cr, ns); err != nil {
+			log.Error(err, fmt.Sprintf("error cleaning up notifications resources for namespace %s", ns))
+			continue
+		}
+		delete(r.ManagedNotificationsSourceNamespaces, ns)
+	}
+	return nil
+}
+
+// cleanupUnmanagedNotificationsSourceNamespaceResources removes the notifications role, rolebinding and label from the
+// target namespace
+func (r *ReconcileArgoCD) cleanupUnmanagedNotificationsSourceNamespaceResources(cr *argoproj.ArgoCD, ns string) error {
+	namespace := corev1.Namespace{}
+	if err := r.Client.Get(context.TODO(), types.NamespacedName{Name: ns}, &namespace); err != nil {
+		if !errors.IsNotFound(err) {
+			return err
+		}
+		return nil
+	}
+
+	if value, ok := namespace.Labels[common.ArgoCDNotificationsManagedByClusterArgoCDLabel]; !ok || value != cr.Namespace {
+		return nil
+	}
+
+	// delete the notifications role & rolebinding
+	name := getResourceNameForNotificationsSourceNamespaces(cr)
+	roleBinding := &rbacv1.RoleBinding{}
+	if err := r.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: ns}, roleBinding); err == nil {
+		if err := r.Client.Delete(context.TODO(), roleBinding); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	} else if !errors.IsNotFound(err) {
+		return fmt.Errorf("failed to get the rolebinding %s in namespace %s : %s", name, ns, err)
+	}
+	role := &rbacv1.Role{}
+	if err := r.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: ns}, role); err == nil {
+		if err := r.Client.Delete(context.TODO(), role); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	} else if !errors.IsNotFound(err) {
+		return fmt.Errorf("failed to get the role %s in namespace %s : %s", name, ns, err)
+	}
+
+	delete(namespace.Labels, common.ArgoCDNotificationsManagedByClusterArgoCDLabel)
+	log.Info(fmt.Sprintf("Removing notifications label from namespace %s", namespace.Name))
+	if err := r.Client.Update(context.TODO(), &namespace); err != nil {
+		return fmt.Errorf("failed to remove notifications label from namespace %s : %s", namespace.Name, err)
+	}
+	return nil
+}
+
+// setManagedNotificationsSourceNamespaces populates ManagedNotificationsSourceNamespaces var with namespaces
+// with "argocd.argoproj.io/notifications-managed-by-cluster-argocd" label.
+func (r *ReconcileArgoCD) setManagedNotificationsSourceNamespaces(cr *argoproj.ArgoCD) error {
+	if r.ManagedNotificationsSourceNamespaces == nil {
+		r.ManagedNotificationsSourceNamespaces = make(map[string]string)
+	}
+	namespaces := &corev1.NamespaceList{}
+	listOption := client.MatchingLabels{
+		common.ArgoCDNotificationsManagedByClusterArgoCDLabel: cr.Namespace,
+	}
+
+	if err := r.Client.List(context.TODO(), namespaces, listOption); err != nil {
+		return err
+	}
+
+	for _, namespace := range namespaces.Items {
+		r.ManagedNotificationsSourceNamespaces[namespace.Name] = ""
+	}
+
+	return nil
+}
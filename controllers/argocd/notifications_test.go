@@ -507,3 +507,131 @@ func TestReconcileNotifications_testLogLevel(t *testing.T) {
 		t.Fatalf("operator failed to override the manual changes to notification controller:\n%s", diff)
 	}
 }
+
+func TestReconcileNotifications_SourceNamespaces(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	t.Setenv("ARGOCD_CLUSTER_CONFIG_NAMESPACES", testNamespace)
+
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.SourceNamespaces = []string{"team-*", "other"}
+		a.Spec.Notifications.Enabled = true
+		a.Spec.Notifications.SourceNamespaces = []string{"team-*", "unknown"}
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme, v1alpha1.AddToScheme, monitoringv1.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	for _, ns := range []string{"team-a", "team-b", "other", "unknown"} {
+		assert.NoError(t, createNamespace(r, ns, ""))
+	}
+
+	sourceNamespaces, err := r.getNotificationsSourceNamespaces(a)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"team-a", "team-b"}, sourceNamespaces)
+
+	assert.NoError(t, r.reconcileNotificationsController(a))
+
+	// the notifications controller processes the Applications and configuration of the source namespaces
+	deployment := &appsv1.Deployment{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: a.Name + "-notifications-controller", Namespace: a.Namespace}, deployment))
+	assert.Equal(t, []string{
+		"argocd-notifications",
+		"--loglevel",
+		"info",
+		"--argocd-repo-server",
+		"argocd-repo-server.argocd.svc.cluster.local:8081",
+		"--application-namespaces",
+		"team-a,team-b",
+		"--self-service-notification-enabled",
+	}, deployment.Spec.Template.Spec.Containers[0].Command)
+
+	clusterRole := &rbacv1.ClusterRole{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: GenerateUniqueResourceName(common.ArgoCDNotificationsControllerComponent, a)}, clusterRole))
+	clusterRoleBinding := &rbacv1.ClusterRoleBinding{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: GenerateUniqueResourceName(common.ArgoCDNotificationsControllerComponent, a)}, clusterRoleBinding))
+	assert.Equal(t, clusterRole.Name, clusterRoleBinding.RoleRef.Name)
+	// the configuration can only be listed cluster-wide by name, and is read from the source namespaces through roles
+	for _, rule := range clusterRole.Rules {
+		if contains(rule.APIGroups, "") {
+			assert.NotEmpty(t, rule.ResourceNames, rule.Resources)
+		}
+	}
+	for _, ns := range []string{"team-a", "team-b"} {
+		role := &rbacv1.Role{}
+		assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-argocd-notifications", Namespace: ns}, role))
+		assert.Equal(t, policyRuleForNotificationsSourceNamespaces(), role.Rules)
+		roleBinding := &rbacv1.RoleBinding{}
+		assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-argocd-notifications", Namespace: ns}, roleBinding))
+		assert.Equal(t, []rbacv1.Subject{{Kind: rbacv1.ServiceAccountKind, Name: "argocd-argocd-notifications-controller", Namespace: a.Namespace}}, roleBinding.Subjects)
+	}
+	err = r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-argocd-notifications", Namespace: "other"}, &rbacv1.Role{})
+	assert.True(t, errors.IsNotFound(err))
+
+	for ns, labeled := range map[string]bool{"team-a": true, "team-b": true, "other": false, "unknown": false} {
+		namespace := &corev1.Namespace{}
+		assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: ns}, namespace))
+		assert.Equal(t, labeled, namespace.Labels[common.ArgoCDNotificationsManagedByClusterArgoCDLabel] == a.Namespace, ns)
+	}
+
+	// removing a source namespace removes the label from the namespace
+	a.Spec.Notifications.SourceNamespaces = []string{"team-a"}
+	assert.NoError(t, r.reconcileNotificationsController(a))
+	namespace := &corev1.Namespace{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "team-b"}, namespace))
+	assert.NotContains(t, namespace.Labels, common.ArgoCDNotificationsManagedByClusterArgoCDLabel)
+	assert.NotContains(t, r.ManagedNotificationsSourceNamespaces, "team-b")
+	err = r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-argocd-notifications", Namespace: "team-b"}, &rbacv1.Role{})
+	assert.True(t, errors.IsNotFound(err))
+	err = r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-argocd-notifications", Namespace: "team-b"}, &rbacv1.RoleBinding{})
+	assert.True(t, errors.IsNotFound(err))
+
+	// disabling notifications removes the cluster role and the labels
+	a.Spec.Notifications.Enabled = false
+	assert.NoError(t, r.deleteNotificationsResources(a))
+	err = r.Client.Get(context.TODO(), types.NamespacedName{Name: clusterRole.Name}, clusterRole)
+	assert.True(t, errors.IsNotFound(err))
+	err = r.Client.Get(context.TODO(), types.NamespacedName{Name: clusterRoleBinding.Name}, clusterRoleBinding)
+	assert.True(t, errors.IsNotFound(err))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "team-a"}, namespace))
+	assert.NotContains(t, namespace.Labels, common.ArgoCDNotificationsManagedByClusterArgoCDLabel)
+	assert.Empty(t, r.ManagedNotificationsSourceNamespaces)
+	err = r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-argocd-notifications", Namespace: "team-a"}, &rbacv1.Role{})
+	assert.True(t, errors.IsNotFound(err))
+}
+
+func TestReconcileNotifications_SourceNamespacesNamespaceScoped(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.SourceNamespaces = []string{"team-a"}
+		a.Spec.Notifications.Enabled = true
+		a.Spec.Notifications.SourceNamespaces = []string{"team-a"}
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme, v1alpha1.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+	assert.NoError(t, createNamespace(r, "team-a", ""))
+
+	assert.NoError(t, r.reconcileNotificationsController(a))
+
+	// source namespaces are ignored and no cluster-wide permissions are granted to a namespace-scoped instance
+	deployment := &appsv1.Deployment{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: a.Name + "-notifications-controller", Namespace: a.Namespace}, deployment))
+	assert.NotContains(t, deployment.Spec.Template.Spec.Containers[0].Command, "--self-service-notification-enabled")
+
+	namespace := &corev1.Namespace{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "team-a"}, namespace))
+	assert.NotContains(t, namespace.Labels, common.ArgoCDNotificationsManagedByClusterArgoCDLabel)
+
+	clusterRole := &rbacv1.ClusterRole{}
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: GenerateUniqueResourceName(common.ArgoCDNotificationsControllerComponent, a)}, clusterRole)
+	assert.True(t, errors.IsNotFound(err))
+}
@@ -0,0 +1,249 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+const (
+	// Default name of an external OIDC provider on the Argo CD login page.
+	defaultOIDCProviderName = "OIDC"
+	// Path of the OpenID Connect discovery metadata relative to the issuer.
+	oidcDiscoveryPath = "/.well-known/openid-configuration"
+	// Timeout for fetching the OpenID Connect discovery metadata.
+	oidcDiscoveryTimeout = 10 * time.Second
+	// Duration the discovery metadata of an OIDC provider is cached for.
+	oidcDiscoveryCacheTTL = 10 * time.Minute
+	// Duration a failure to fetch the discovery metadata of an OIDC provider is cached for, so that an unreachable
+	// provider does not block every reconciliation.
+	oidcDiscoveryErrorCacheTTL = time.Minute
+)
+
+// oidcDiscoveryCacheEntry is the result of fetching the discovery metadata of an OIDC provider.
+type oidcDiscoveryCacheEntry struct {
+	discovery *oidcDiscovery
+	err       error
+	expires   time.Time
+}
+
+var (
+	// oidcDiscoveryCache holds the discovery metadata of the OIDC providers, by discovery endpoint and root CA.
+	oidcDiscoveryCache      = map[string]oidcDiscoveryCacheEntry{}
+	oidcDiscoveryCacheMutex sync.Mutex
+)
+
+// externalOIDCConfig is the oidc.config of Argo CD for an external OIDC provider.
+type externalOIDCConfig struct {
+	Name                   string                       `yaml:"name"`
+	Issuer                 string                       `yaml:"issuer"`
+	ClientID               string                       `yaml:"clientID"`
+	ClientSecret           string                       `yaml:"clientSecret,omitempty"`
+	RequestedScopes        []string                     `yaml:"requestedScopes"`
+	RequestedIDTokenClaims map[string]externalOIDCClaim `yaml:"requestedIDTokenClaims,omitempty"`
+	RootCA                 string                       `yaml:"rootCA,omitempty"`
+}
+
+// externalOIDCClaim is a claim requested from an external OIDC provider.
+type externalOIDCClaim struct {
+	Essential bool `yaml:"essential"`
+}
+
+// oidcDiscovery is the subset of the OpenID Connect discovery metadata validated by the operator.
+type oidcDiscovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// UseExternalOIDC determines whether Argo CD should be configured with an external OIDC provider or not
+func UseExternalOIDC(cr *argoproj.ArgoCD) bool {
+	return cr.Spec.SSO != nil && cr.Spec.SSO.Provider.ToLower() == argoproj.SSOProviderTypeOIDC && cr.Spec.SSO.OIDC != nil
+}
+
+// getExternalOIDCConfig will return the oidc.config property of the argocd-cm ConfigMap for the external OIDC
+// provider of the given ArgoCD.
+func getExternalOIDCConfig(cr *argoproj.ArgoCD) (string, error) {
+	spec := cr.Spec.SSO.OIDC
+
+	cfg := externalOIDCConfig{
+		Name:            spec.Name,
+		Issuer:          spec.Issuer,
+		ClientID:        spec.ClientID,
+		RequestedScopes: spec.RequestedScopes,
+		RootCA:          spec.RootCA,
+	}
+	if cfg.Name == "" {
+		cfg.Name = defaultOIDCProviderName
+	}
+	if len(cfg.RequestedScopes) == 0 {
+		cfg.RequestedScopes = []string{"openid", "profile", "email"}
+	}
+	if spec.ClientSecretRef != nil {
+		cfg.ClientSecret = "$" + common.ArgoCDOIDCSecretKey
+	}
+	if spec.GroupsClaim != "" {
+		cfg.RequestedIDTokenClaims = map[string]externalOIDCClaim{
+			spec.GroupsClaim: {Essential: true},
+		}
+	}
+
+	bytes, err := yaml.Marshal(cfg)
+	return string(bytes), err
+}
+
+// getExternalOIDCClientSecret will return the client secret of the external OIDC provider of the given ArgoCD, or
+// nil if no client secret is referenced.
+func (r *ReconcileArgoCD) getExternalOIDCClientSecret(cr *argoproj.ArgoCD) ([]byte, error) {
+	ref := cr.Spec.SSO.OIDC.ClientSecretRef
+	if ref == nil {
+		return nil, nil
+	}
+
+	secret := &corev1.Secret{}
+	if err := r.Client.Get(context.TODO(), types.NamespacedName{Name: ref.Name, Namespace: cr.Namespace}, secret); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, fmt.Errorf("client secret %q not found", ref.Name)
+		}
+		return nil, err
+	}
+	value, ok := secret.Data[ref.Key]
+	if !ok {
+		return nil, fmt.Errorf("key %q not found in client secret %q", ref.Key, ref.Name)
+	}
+	return value, nil
+}
+
+// reportExternalOIDCClientSecretError will report that the client secret of the external OIDC provider of the given
+// ArgoCD cannot be resolved, through the SSO status and a warning Event, without failing the reconciliation.
+func (r *ReconcileArgoCD) reportExternalOIDCClientSecretError(cr *argoproj.ArgoCD, err error) {
+	log.Error(err, fmt.Sprintf("unable to resolve the oidc client secret for ArgoCD %s in namespace %s", cr.Name, cr.Namespace))
+	r.recordEvent(cr, corev1.EventTypeWarning, "InvalidOIDCConfiguration", fmt.Sprintf("unable to resolve the oidc client secret: %s", err))
+	ssoConfigLegalStatus = ssoLegalFailed
+	if statusErr := r.reconcileStatusSSO(cr); statusErr != nil {
+		log.Error(statusErr, "failed to update the SSO status")
+	}
+}
+
+// validateExternalOIDC will return an error if the external OIDC provider of the given ArgoCD is incomplete, its
+// client secret cannot be resolved, or its discovery metadata cannot be fetched or does not match the issuer.
+func (r *ReconcileArgoCD) validateExternalOIDC(cr *argoproj.ArgoCD) error {
+	spec := cr.Spec.SSO.OIDC
+
+	if u, err := url.Parse(spec.Issuer); err != nil || u.Scheme != "https" || u.Host == "" {
+		return fmt.Errorf("oidc issuer %q must be a valid https URL", spec.Issuer)
+	}
+	if spec.ClientID == "" {
+		return fmt.Errorf("oidc clientID must not be empty")
+	}
+	if _, err := r.getExternalOIDCClientSecret(cr); err != nil {
+		return fmt.Errorf("oidc clientSecretRef: %w", err)
+	}
+
+	endpoint := spec.DiscoveryEndpoint
+	if endpoint == "" {
+		endpoint = strings.TrimSuffix(spec.Issuer, "/") + oidcDiscoveryPath
+	}
+	discovery, err := getOIDCDiscovery(endpoint, spec.RootCA)
+	if err != nil {
+		return fmt.Errorf("failed to fetch oidc discovery metadata from %s: %w", endpoint, err)
+	}
+	if strings.TrimSuffix(discovery.Issuer, "/") != strings.TrimSuffix(spec.Issuer, "/") {
+		return fmt.Errorf("oidc discovery metadata issuer %q does not match issuer %q", discovery.Issuer, spec.Issuer)
+	}
+	if discovery.AuthorizationEndpoint == "" || discovery.TokenEndpoint == "" {
+		return fmt.Errorf("oidc discovery metadata from %s is missing the authorization or token endpoint", endpoint)
+	}
+	return nil
+}
+
+// getOIDCDiscovery will return the OpenID Connect discovery metadata of the given endpoint, fetching it only if
+// it is not cached yet or the cached result has expired.
+func getOIDCDiscovery(endpoint, rootCA string) (*oidcDiscovery, error) {
+	key := endpoint + "\n" + rootCA
+
+	oidcDiscoveryCacheMutex.Lock()
+	entry, ok := oidcDiscoveryCache[key]
+	oidcDiscoveryCacheMutex.Unlock()
+	if ok && time.Now().Before(entry.expires) {
+		return entry.discovery, entry.err
+	}
+
+	discovery, err := fetchOIDCDiscovery(endpoint, rootCA)
+	entry = oidcDiscoveryCacheEntry{discovery: discovery, err: err, expires: time.Now().Add(oidcDiscoveryCacheTTL)}
+	if err != nil {
+		entry.expires = time.Now().Add(oidcDiscoveryErrorCacheTTL)
+	}
+
+	oidcDiscoveryCacheMutex.Lock()
+	oidcDiscoveryCache[key] = entry
+	oidcDiscoveryCacheMutex.Unlock()
+	return discovery, err
+}
+
+// fetchOIDCDiscovery will fetch the OpenID Connect discovery metadata from the given endpoint, trusting the
+// given PEM encoded CA bundle in addition to the system roots.
+func fetchOIDCDiscovery(endpoint, rootCA string) (*oidcDiscovery, error) {
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if rootCA != "" && !pool.AppendCertsFromPEM([]byte(rootCA)) {
+		return nil, fmt.Errorf("unable to load rootCA")
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{RootCAs: pool}
+	c := &http.Client{Transport: transport, Timeout: oidcDiscoveryTimeout}
+
+	res, err := c.Get(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", res.Status)
+	}
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	discovery := &oidcDiscovery{}
+	if err := json.Unmarshal(body, discovery); err != nil {
+		return nil, err
+	}
+	return discovery, nil
+}
@@ -0,0 +1,281 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+// newTestOIDCProvider returns a TLS server serving OpenID Connect discovery metadata for the given issuer, or
+// for the URL of the server if the issuer is empty, together with its PEM encoded certificate.
+func newTestOIDCProvider(t *testing.T, issuer string) (*httptest.Server, string) {
+	var server *httptest.Server
+	server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != oidcDiscoveryPath {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		iss := issuer
+		if iss == "" {
+			iss = server.URL
+		}
+		assert.NoError(t, json.NewEncoder(w).Encode(oidcDiscovery{
+			Issuer:                iss,
+			AuthorizationEndpoint: iss + "/authorize",
+			TokenEndpoint:         iss + "/token",
+		}))
+	}))
+	return server, string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+}
+
+func makeTestOIDCArgoCD(issuer, rootCA string) *argoproj.ArgoCD {
+	return makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.SSO = &argoproj.ArgoCDSSOSpec{
+			Provider: argoproj.SSOProviderTypeOIDC,
+			OIDC: &argoproj.ArgoCDOIDCSpec{
+				Issuer:          issuer,
+				ClientID:        "argocd",
+				ClientSecretRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "oidc"}, Key: "clientSecret"},
+				RootCA:          rootCA,
+				GroupsClaim:     "roles",
+			},
+		}
+	})
+}
+
+func TestReconcileSSO_externalOIDC(t *testing.T) {
+	server, rootCA := newTestOIDCProvider(t, "")
+	defer server.Close()
+
+	a := makeTestOIDCArgoCD(server.URL, rootCA)
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "oidc", Namespace: a.Namespace},
+		Data:       map[string][]byte{"clientSecret": []byte("s3cr3t")},
+	}
+
+	resObjs := []client.Object{a, secret}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileSSO(a))
+	assert.Equal(t, ssoLegalSuccess, ssoConfigLegalStatus)
+	assert.Equal(t, "Running", a.Status.SSO)
+
+	assert.NoError(t, r.reconcileArgoConfigMap(a))
+	cm := &corev1.ConfigMap{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDConfigMapName, Namespace: a.Namespace}, cm))
+	cfg := externalOIDCConfig{}
+	assert.NoError(t, yaml.Unmarshal([]byte(cm.Data[common.ArgoCDKeyOIDCConfig]), &cfg))
+	assert.Equal(t, externalOIDCConfig{
+		Name:                   defaultOIDCProviderName,
+		Issuer:                 server.URL,
+		ClientID:               "argocd",
+		ClientSecret:           "$oidc.clientSecret",
+		RequestedScopes:        []string{"openid", "profile", "email"},
+		RequestedIDTokenClaims: map[string]externalOIDCClaim{"roles": {Essential: true}},
+		RootCA:                 rootCA,
+	}, cfg)
+	assert.Equal(t, "[roles]", getRBACScopes(a))
+}
+
+func TestReconcileSSO_externalOIDCInvalid(t *testing.T) {
+	server, rootCA := newTestOIDCProvider(t, "https://other.example.com")
+	defer server.Close()
+
+	tests := []struct {
+		name    string
+		argoCD  *argoproj.ArgoCD
+		wantErr string
+	}{
+		{
+			name: "missing oidc configuration",
+			argoCD: makeTestArgoCD(func(a *argoproj.ArgoCD) {
+				a.Spec.SSO = &argoproj.ArgoCDSSOSpec{Provider: argoproj.SSOProviderTypeOIDC}
+			}),
+			wantErr: "must supply oidc configuration in .spec.sso.oidc when requested SSO provider is oidc",
+		},
+		{
+			name: "raw oidc configuration",
+			argoCD: func() *argoproj.ArgoCD {
+				a := makeTestOIDCArgoCD(server.URL, rootCA)
+				a.Spec.OIDCConfig = "name: test"
+				return a
+			}(),
+			wantErr: "cannot supply .spec.oidcConfig when requested SSO provider is oidc",
+		},
+		{
+			name:    "issuer is not https",
+			argoCD:  makeTestOIDCArgoCD("http://idp.example.com", ""),
+			wantErr: `oidc issuer "http://idp.example.com" must be a valid https URL`,
+		},
+		{
+			name:    "issuer does not match discovery metadata",
+			argoCD:  makeTestOIDCArgoCD(server.URL, rootCA),
+			wantErr: `oidc discovery metadata issuer "https://other.example.com" does not match issuer "` + server.URL + `"`,
+		},
+		{
+			name: "client secret not found",
+			argoCD: func() *argoproj.ArgoCD {
+				a := makeTestOIDCArgoCD(server.URL, rootCA)
+				a.Spec.SSO.OIDC.ClientSecretRef.Name = "missing"
+				return a
+			}(),
+			wantErr: `oidc clientSecretRef: client secret "missing" not found`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "oidc", Namespace: test.argoCD.Namespace},
+				Data:       map[string][]byte{"clientSecret": []byte("s3cr3t")},
+			}
+			resObjs := []client.Object{test.argoCD, secret}
+			subresObjs := []client.Object{test.argoCD}
+			runtimeObjs := []runtime.Object{}
+			sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+			cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+			r := makeTestReconciler(cl, sch)
+
+			err := r.reconcileSSO(test.argoCD)
+			assert.EqualError(t, err, illegalSSOConfiguration+test.wantErr)
+			assert.Equal(t, ssoLegalFailed, ssoConfigLegalStatus)
+		})
+	}
+}
+
+func TestReconcileArgoConfigMap_externalOIDCInvalid(t *testing.T) {
+	server, rootCA := newTestOIDCProvider(t, "https://other.example.com")
+	defer server.Close()
+
+	a := makeTestOIDCArgoCD(server.URL, rootCA)
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "oidc", Namespace: a.Namespace},
+		Data:       map[string][]byte{"clientSecret": []byte("s3cr3t")},
+	}
+
+	existing := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: common.ArgoCDConfigMapName, Namespace: a.Namespace},
+		Data:       map[string]string{common.ArgoCDKeyOIDCConfig: "name: previous"},
+	}
+
+	resObjs := []client.Object{a, secret, existing}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	// an invalid provider does not block the reconciliation, the existing oidc.config is kept
+	assert.NoError(t, r.reconcileArgoConfigMap(a))
+	assert.Equal(t, "Failed", a.Status.SSO)
+
+	cm := &corev1.ConfigMap{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDConfigMapName, Namespace: a.Namespace}, cm))
+	assert.Equal(t, "name: previous", cm.Data[common.ArgoCDKeyOIDCConfig])
+}
+
+func TestGetOIDCDiscovery_cached(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		requests++
+		if req.URL.Path == "/unavailable"+oidcDiscoveryPath {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		assert.NoError(t, json.NewEncoder(w).Encode(oidcDiscovery{Issuer: "https://idp.example.com"}))
+	}))
+	defer server.Close()
+
+	for i := 0; i < 2; i++ {
+		discovery, err := getOIDCDiscovery(server.URL+oidcDiscoveryPath, "")
+		assert.NoError(t, err)
+		assert.Equal(t, "https://idp.example.com", discovery.Issuer)
+	}
+	assert.Equal(t, 1, requests)
+
+	// failures are cached too, so that an unavailable provider does not block every reconciliation
+	for i := 0; i < 2; i++ {
+		_, err := getOIDCDiscovery(server.URL+"/unavailable"+oidcDiscoveryPath, "")
+		assert.EqualError(t, err, "unexpected status 503 Service Unavailable")
+	}
+	assert.Equal(t, 2, requests)
+}
+
+func TestReconcileArgoSecret_externalOIDC(t *testing.T) {
+	a := makeTestOIDCArgoCD("https://idp.example.com", "")
+	oidcSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "oidc", Namespace: a.Namespace},
+		Data:       map[string][]byte{"clientSecret": []byte("s3cr3t")},
+	}
+	clusterSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: a.Name + "-cluster", Namespace: a.Namespace},
+		Data:       map[string][]byte{common.ArgoCDKeyAdminPassword: []byte("admin")},
+	}
+	tlsSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: a.Name + "-tls", Namespace: a.Namespace},
+	}
+
+	resObjs := []client.Object{a, oidcSecret, clusterSecret, tlsSecret}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileArgoSecret(a))
+	secret := &corev1.Secret{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDSecretName, Namespace: a.Namespace}, secret))
+	assert.Equal(t, "s3cr3t", string(secret.Data[common.ArgoCDOIDCSecretKey]))
+
+	// rotated client secrets are copied again
+	oidcSecret.Data["clientSecret"] = []byte("r0t4t3d")
+	assert.NoError(t, r.Client.Update(context.TODO(), oidcSecret))
+	assert.NoError(t, r.reconcileArgoSecret(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDSecretName, Namespace: a.Namespace}, secret))
+	assert.Equal(t, "r0t4t3d", string(secret.Data[common.ArgoCDOIDCSecretKey]))
+
+	// a missing client secret does not block the reconciliation, the existing client secret is kept
+	assert.NoError(t, r.Client.Delete(context.TODO(), oidcSecret))
+	assert.NoError(t, r.reconcileArgoSecret(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDSecretName, Namespace: a.Namespace}, secret))
+	assert.Equal(t, "r0t4t3d", string(secret.Data[common.ArgoCDOIDCSecretKey]))
+	assert.Equal(t, "Failed", a.Status.SSO)
+
+	// the client secret is removed once the provider is no longer used
+	a.Spec.SSO = nil
+	assert.NoError(t, r.reconcileArgoSecret(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDSecretName, Namespace: a.Namespace}, secret))
+	assert.NotContains(t, secret.Data, common.ArgoCDOIDCSecretKey)
+}
@@ -17,10 +17,10 @@ package argocd
 import (
 	"context"
 	"fmt"
+	"reflect"
 
 	monitoringv1 "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
@@ -119,41 +119,10 @@ func newServiceMonitorWithName(name string, cr *argoproj.ArgoCD) *monitoringv1.S
 	return svcmon
 }
 
-// newServiceMonitorWithSuffix returns a new ServiceMonitor instance for the given ArgoCD using the given suffix.
-func newServiceMonitorWithSuffix(suffix string, cr *argoproj.ArgoCD) *monitoringv1.ServiceMonitor {
-	return newServiceMonitorWithName(fmt.Sprintf("%s-%s", cr.Name, suffix), cr)
-}
-
 // reconcileMetricsServiceMonitor will ensure that the ServiceMonitor is present for the ArgoCD metrics Service.
 func (r *ReconcileArgoCD) reconcileMetricsServiceMonitor(cr *argoproj.ArgoCD) error {
-	sm := newServiceMonitorWithSuffix(common.ArgoCDKeyMetrics, cr)
-	if argoutil.IsObjectFound(r.Client, cr.Namespace, sm.Name, sm) {
-		if !cr.Spec.Prometheus.Enabled {
-			// ServiceMonitor exists but enabled flag has been set to false, delete the ServiceMonitor
-			return r.Client.Delete(context.TODO(), sm)
-		}
-		return nil // ServiceMonitor found, do nothing
-	}
-
-	if !cr.Spec.Prometheus.Enabled {
-		return nil // Prometheus not enabled, do nothing.
-	}
-
-	sm.Spec.Selector = metav1.LabelSelector{
-		MatchLabels: map[string]string{
-			common.ArgoCDKeyName: nameWithSuffix(common.ArgoCDKeyMetrics, cr),
-		},
-	}
-	sm.Spec.Endpoints = []monitoringv1.Endpoint{
-		{
-			Port: common.ArgoCDKeyMetrics,
-		},
-	}
-
-	if err := controllerutil.SetControllerReference(cr, sm, r.Scheme); err != nil {
-		return err
-	}
-	return r.Client.Create(context.TODO(), sm)
+	return r.reconcileComponentServiceMonitor(cr, nameWithSuffix(common.ArgoCDKeyMetrics, cr), nameWithSuffix(common.ArgoCDKeyMetrics, cr),
+		newServiceMonitorEndpoint(cr.Spec.Monitoring.ServiceMonitors.ApplicationController), cr.Spec.Prometheus.Enabled)
 }
 
 // reconcilePrometheus will ensure that Prometheus is present for ArgoCD metrics.
@@ -187,69 +156,113 @@ func (r *ReconcileArgoCD) reconcilePrometheus(cr *argoproj.ArgoCD) error {
 
 // reconcileRepoServerServiceMonitor will ensure that the ServiceMonitor is present for the Repo Server metrics Service.
 func (r *ReconcileArgoCD) reconcileRepoServerServiceMonitor(cr *argoproj.ArgoCD) error {
-	sm := newServiceMonitorWithSuffix("repo-server-metrics", cr)
-	if argoutil.IsObjectFound(r.Client, cr.Namespace, sm.Name, sm) {
-		if !cr.Spec.Prometheus.Enabled {
-			// ServiceMonitor exists but enabled flag has been set to false, delete the ServiceMonitor
-			return r.Client.Delete(context.TODO(), sm)
-		}
-		return nil // ServiceMonitor found, do nothing
-	}
+	return r.reconcileComponentServiceMonitor(cr, nameWithSuffix("repo-server-metrics", cr), nameWithSuffix("repo-server", cr),
+		newServiceMonitorEndpoint(cr.Spec.Monitoring.ServiceMonitors.RepoServer), cr.Spec.Prometheus.Enabled)
+}
 
-	if !cr.Spec.Prometheus.Enabled {
-		return nil // Prometheus not enabled, do nothing.
-	}
+// reconcileServerMetricsServiceMonitor will ensure that the ServiceMonitor is present for the ArgoCD Server metrics Service.
+func (r *ReconcileArgoCD) reconcileServerMetricsServiceMonitor(cr *argoproj.ArgoCD) error {
+	return r.reconcileComponentServiceMonitor(cr, nameWithSuffix("server-metrics", cr), nameWithSuffix("server-metrics", cr),
+		newServiceMonitorEndpoint(cr.Spec.Monitoring.ServiceMonitors.Server), cr.Spec.Prometheus.Enabled)
+}
 
-	sm.Spec.Selector = metav1.LabelSelector{
-		MatchLabels: map[string]string{
-			common.ArgoCDKeyName: nameWithSuffix("repo-server", cr),
-		},
+// reconcileApplicationSetServiceMonitor will ensure that the ServiceMonitor is present for the ApplicationSet controller
+// Service when the ApplicationSet controller is enabled.
+func (r *ReconcileArgoCD) reconcileApplicationSetServiceMonitor(cr *argoproj.ArgoCD) error {
+	enabled := cr.Spec.Prometheus.Enabled && cr.Spec.ApplicationSet != nil && cr.Spec.ApplicationSet.IsEnabled()
+	return r.reconcileComponentServiceMonitor(cr, nameWithSuffix("applicationset-controller-metrics", cr),
+		nameWithSuffix(common.ApplicationSetServiceNameSuffix, cr),
+		newServiceMonitorEndpoint(cr.Spec.Monitoring.ServiceMonitors.ApplicationSetController), enabled)
+}
+
+// reconcileDexServiceMonitor will ensure that the ServiceMonitor is present for the Dex metrics Service when Dex is
+// enabled.
+func (r *ReconcileArgoCD) reconcileDexServiceMonitor(cr *argoproj.ArgoCD) error {
+	return r.reconcileComponentServiceMonitor(cr, nameWithSuffix("dex-server-metrics", cr), nameWithSuffix("dex-server-metrics", cr),
+		newServiceMonitorEndpoint(cr.Spec.Monitoring.ServiceMonitors.Dex), cr.Spec.Prometheus.Enabled && UseDex(cr))
+}
+
+// reconcileRedisServiceMonitor will ensure that the ServiceMonitor is present for the Redis exporter metrics Service when
+// the Redis exporter sidecar is enabled.
+func (r *ReconcileArgoCD) reconcileRedisServiceMonitor(cr *argoproj.ArgoCD) error {
+	return r.reconcileComponentServiceMonitor(cr, nameWithSuffix("redis-metrics", cr), nameWithSuffix("redis-metrics", cr),
+		newServiceMonitorEndpoint(cr.Spec.Monitoring.ServiceMonitors.Redis), cr.Spec.Prometheus.Enabled && isRedisExporterEnabled(cr))
+}
+
+// newServiceMonitorEndpoint returns the endpoint scraping the metrics port of a component with the given scrape
+// settings.
+func newServiceMonitorEndpoint(settings argoproj.ArgoCDMonitoringServiceMonitorSpec) monitoringv1.Endpoint {
+	endpoint := monitoringv1.Endpoint{
+		Port:     common.ArgoCDKeyMetrics,
+		Interval: settings.Interval,
 	}
-	sm.Spec.Endpoints = []monitoringv1.Endpoint{
-		{
-			Port: common.ArgoCDKeyMetrics,
-		},
+
+	for _, relabeling := range settings.Relabelings {
+		endpoint.RelabelConfigs = append(endpoint.RelabelConfigs, &monitoringv1.RelabelConfig{
+			SourceLabels: relabeling.SourceLabels,
+			Separator:    relabeling.Separator,
+			TargetLabel:  relabeling.TargetLabel,
+			Regex:        relabeling.Regex,
+			Modulus:      relabeling.Modulus,
+			Replacement:  relabeling.Replacement,
+			Action:       relabeling.Action,
+		})
 	}
 
-	if err := controllerutil.SetControllerReference(cr, sm, r.Scheme); err != nil {
-		return err
+	if settings.TLS != nil {
+		endpoint.Scheme = "https"
+		endpoint.TLSConfig = &monitoringv1.TLSConfig{
+			ServerName:         settings.TLS.ServerName,
+			InsecureSkipVerify: settings.TLS.InsecureSkipVerify,
+		}
+		if settings.TLS.CARef != nil {
+			endpoint.TLSConfig.CA = monitoringv1.SecretOrConfigMap{Secret: settings.TLS.CARef}
+		}
 	}
-	return r.Client.Create(context.TODO(), sm)
+
+	return endpoint
 }
 
-// reconcileServerMetricsServiceMonitor will ensure that the ServiceMonitor is present for the ArgoCD Server metrics Service.
-func (r *ReconcileArgoCD) reconcileServerMetricsServiceMonitor(cr *argoproj.ArgoCD) error {
-	sm := newServiceMonitorWithSuffix("server-metrics", cr)
+// reconcileComponentServiceMonitor will ensure that the ServiceMonitor with the given name, scraping the given endpoint
+// of the Services labelled with the given service name, is present and up to date when enabled, and removed otherwise.
+func (r *ReconcileArgoCD) reconcileComponentServiceMonitor(cr *argoproj.ArgoCD, name, serviceName string, endpoint monitoringv1.Endpoint, enabled bool) error {
+	sm := newServiceMonitorWithName(name, cr)
+	endpoints := []monitoringv1.Endpoint{endpoint}
+
 	if argoutil.IsObjectFound(r.Client, cr.Namespace, sm.Name, sm) {
-		if !cr.Spec.Prometheus.Enabled {
-			// ServiceMonitor exists but enabled flag has been set to false, delete the ServiceMonitor
+		if !enabled {
+			// ServiceMonitor exists but the component or Prometheus has been disabled, delete the ServiceMonitor
+			log.Info(fmt.Sprintf("deleting ServiceMonitor %s", sm.Name))
 			return r.Client.Delete(context.TODO(), sm)
 		}
-		return nil // ServiceMonitor found, do nothing
+		if reflect.DeepEqual(sm.Spec.Endpoints, endpoints) {
+			return nil // ServiceMonitor found with nothing to do, move along...
+		}
+		sm.Spec.Endpoints = endpoints
+		log.Info(fmt.Sprintf("updating ServiceMonitor %s", sm.Name))
+		return r.Client.Update(context.TODO(), sm)
 	}
 
-	if !cr.Spec.Prometheus.Enabled {
-		return nil // Prometheus not enabled, do nothing.
+	if !enabled {
+		return nil // Prometheus or the component not enabled, do nothing.
 	}
 
 	sm.Spec.Selector = metav1.LabelSelector{
 		MatchLabels: map[string]string{
-			common.ArgoCDKeyName: nameWithSuffix("server-metrics", cr),
-		},
-	}
-	sm.Spec.Endpoints = []monitoringv1.Endpoint{
-		{
-			Port: common.ArgoCDKeyMetrics,
+			common.ArgoCDKeyName: serviceName,
 		},
 	}
+	sm.Spec.Endpoints = endpoints
 
 	if err := controllerutil.SetControllerReference(cr, sm, r.Scheme); err != nil {
 		return err
 	}
+	log.Info(fmt.Sprintf("creating ServiceMonitor %s", sm.Name))
 	return r.Client.Create(context.TODO(), sm)
 }
 
-// reconcilePrometheusRule reconciles the PrometheusRule that triggers alerts based on workload statuses
+// reconcilePrometheusRule reconciles the PrometheusRule that triggers alerts based on workload statuses and on the
+// health of the Argo CD operations.
 func (r *ReconcileArgoCD) reconcilePrometheusRule(cr *argoproj.ArgoCD) error {
 
 	promRule := newPrometheusRule(cr.Namespace, "argocd-component-status-alert")
@@ -261,119 +274,22 @@ func (r *ReconcileArgoCD) reconcilePrometheusRule(cr *argoproj.ArgoCD) error {
 			log.Info("instance monitoring disabled, deleting component status tracking prometheusRule")
 			return r.Client.Delete(context.TODO(), promRule)
 		}
-		return nil // PrometheusRule found, do nothing
+
+		ruleGroups := getPrometheusRuleGroups(cr)
+		if reflect.DeepEqual(promRule.Spec.Groups, ruleGroups) {
+			return nil // PrometheusRule found and up to date, do nothing
+		}
+
+		promRule.Spec.Groups = ruleGroups
+		log.Info("instance monitoring configuration changed, updating component status tracking prometheusRule")
+		return r.Client.Update(context.TODO(), promRule)
 	}
 
 	if !cr.Spec.Monitoring.Enabled {
 		return nil // Monitoring not enabled, do nothing.
 	}
 
-	ruleGroups := []monitoringv1.RuleGroup{
-		{
-			Name: "ArgoCDComponentStatus",
-			Rules: []monitoringv1.Rule{
-				{
-					Alert: "ApplicationControllerNotReady",
-					Annotations: map[string]string{
-						"message": fmt.Sprintf("application controller deployment for Argo CD instance in namespace %s is not running", cr.Namespace),
-					},
-					Expr: intstr.IntOrString{
-						Type:   intstr.String,
-						StrVal: fmt.Sprintf("kube_statefulset_status_replicas{statefulset=\"%s\", namespace=\"%s\"} != kube_statefulset_status_replicas_ready{statefulset=\"%s\", namespace=\"%s\"} ", cr.Name+"-application-controller", cr.Namespace, cr.Name+"-application-controller", cr.Namespace),
-					},
-					For: "1m",
-					Labels: map[string]string{
-						"severity": "critical",
-					},
-				},
-				{
-					Alert: "ServerNotReady",
-					Annotations: map[string]string{
-						"message": fmt.Sprintf("server deployment for Argo CD instance in namespace %s is not running", cr.Namespace),
-					},
-					Expr: intstr.IntOrString{
-						Type:   intstr.String,
-						StrVal: fmt.Sprintf("kube_deployment_status_replicas{deployment=\"%s\", namespace=\"%s\"} != kube_deployment_status_replicas_ready{deployment=\"%s\", namespace=\"%s\"} ", cr.Name+"-server", cr.Namespace, cr.Name+"-server", cr.Namespace),
-					},
-					For: "1m",
-					Labels: map[string]string{
-						"severity": "critical",
-					},
-				},
-				{
-					Alert: "RepoServerNotReady",
-					Annotations: map[string]string{
-						"message": fmt.Sprintf("repo server deployment for Argo CD instance in namespace %s is not running", cr.Namespace),
-					},
-					Expr: intstr.IntOrString{
-						Type:   intstr.String,
-						StrVal: fmt.Sprintf("kube_deployment_status_replicas{deployment=\"%s\", namespace=\"%s\"} != kube_deployment_status_replicas_ready{deployment=\"%s\", namespace=\"%s\"} ", cr.Name+"-repo-server", cr.Namespace, cr.Name+"-repo-server", cr.Namespace),
-					},
-					For: "1m",
-					Labels: map[string]string{
-						"severity": "critical",
-					},
-				},
-				{
-					Alert: "ApplicationSetControllerNotReady",
-					Annotations: map[string]string{
-						"message": fmt.Sprintf("applicationSet controller deployment for Argo CD instance in namespace %s is not running", cr.Namespace),
-					},
-					Expr: intstr.IntOrString{
-						Type:   intstr.String,
-						StrVal: fmt.Sprintf("kube_deployment_status_replicas{deployment=\"%s\", namespace=\"%s\"} != kube_deployment_status_replicas_ready{deployment=\"%s\", namespace=\"%s\"} ", cr.Name+"-applicationset-controller", cr.Namespace, cr.Name+"-applicationset-controller", cr.Namespace),
-					},
-					For: "5m",
-					Labels: map[string]string{
-						"severity": "warning",
-					},
-				},
-				{
-					Alert: "DexNotReady",
-					Annotations: map[string]string{
-						"message": fmt.Sprintf("dex deployment for Argo CD instance in namespace %s is not running", cr.Namespace),
-					},
-					Expr: intstr.IntOrString{
-						Type:   intstr.String,
-						StrVal: fmt.Sprintf("kube_deployment_status_replicas{deployment=\"%s\", namespace=\"%s\"} != kube_deployment_status_replicas_ready{deployment=\"%s\", namespace=\"%s\"} ", cr.Name+"-dex-server", cr.Namespace, cr.Name+"-dex-server", cr.Namespace),
-					},
-					For: "5m",
-					Labels: map[string]string{
-						"severity": "warning",
-					},
-				},
-				{
-					Alert: "NotificationsControllerNotReady",
-					Annotations: map[string]string{
-						"message": fmt.Sprintf("notifications controller deployment for Argo CD instance in namespace %s is not running", cr.Namespace),
-					},
-					Expr: intstr.IntOrString{
-						Type:   intstr.String,
-						StrVal: fmt.Sprintf("kube_deployment_status_replicas{deployment=\"%s\", namespace=\"%s\"} != kube_deployment_status_replicas_ready{deployment=\"%s\", namespace=\"%s\"} ", cr.Name+"-notifications-controller", cr.Namespace, cr.Name+"-notifications-controller", cr.Namespace),
-					},
-					For: "5m",
-					Labels: map[string]string{
-						"severity": "warning",
-					},
-				},
-				{
-					Alert: "RedisNotReady",
-					Annotations: map[string]string{
-						"message": fmt.Sprintf("redis deployment for Argo CD instance in namespace %s is not running", cr.Namespace),
-					},
-					Expr: intstr.IntOrString{
-						Type:   intstr.String,
-						StrVal: fmt.Sprintf("kube_deployment_status_replicas{deployment=\"%s\", namespace=\"%s\"} != kube_deployment_status_replicas_ready{deployment=\"%s\", namespace=\"%s\"} ", cr.Name+"-redis", cr.Namespace, cr.Name+"-redis", cr.Namespace),
-					},
-					For: "5m",
-					Labels: map[string]string{
-						"severity": "warning",
-					},
-				},
-			},
-		},
-	}
-	promRule.Spec.Groups = ruleGroups
+	promRule.Spec.Groups = getPrometheusRuleGroups(cr)
 
 	if err := controllerutil.SetControllerReference(cr, promRule, r.Scheme); err != nil {
 		return err
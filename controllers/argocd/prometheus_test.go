@@ -201,10 +201,71 @@ func TestReconcileWorkloadStatusAlertRule(t *testing.T) {
 				}
 
 				if !test.existingPromRule {
-					assert.Equal(t, desiredRuleGroup, testRule.Spec.Groups)
+					assert.Equal(t, desiredRuleGroup, testRule.Spec.Groups[:1])
+					assert.Equal(t, "ArgoCDOperationalStatus", testRule.Spec.Groups[1].Name)
 				}
 
 			}
 		})
 	}
 }
+
+func TestReconcileArgoCD_reconcileComponentServiceMonitors(t *testing.T) {
+	a := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Spec.Prometheus.Enabled = true
+		cr.Spec.ApplicationSet = &argoproj.ArgoCDApplicationSet{}
+		cr.Spec.SSO = &argoproj.ArgoCDSSOSpec{Provider: argoproj.SSOProviderTypeDex}
+		cr.Spec.Redis.Exporter = &argoproj.ArgoCDRedisExporterSpec{Enabled: true}
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme, monitoringv1.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileApplicationSetServiceMonitor(a))
+	assert.NoError(t, r.reconcileDexServiceMonitor(a))
+	assert.NoError(t, r.reconcileRedisServiceMonitor(a))
+
+	for name, service := range map[string]string{
+		"argocd-applicationset-controller-metrics": "argocd-applicationset-controller",
+		"argocd-dex-server-metrics":                "argocd-dex-server-metrics",
+		"argocd-redis-metrics":                     "argocd-redis-metrics",
+	} {
+		sm := &monitoringv1.ServiceMonitor{}
+		assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: a.Namespace}, sm))
+		assert.Equal(t, service, sm.Spec.Selector.MatchLabels["app.kubernetes.io/name"])
+		assert.Equal(t, []monitoringv1.Endpoint{{Port: "metrics"}}, sm.Spec.Endpoints)
+	}
+
+	// the scrape settings of the monitoring spec are applied to the existing ServiceMonitor
+	a.Spec.Monitoring.ServiceMonitors.Redis = argoproj.ArgoCDMonitoringServiceMonitorSpec{
+		Interval: "15s",
+		Relabelings: []argoproj.ArgoCDMonitoringRelabelConfig{
+			{SourceLabels: []string{"__meta_kubernetes_pod_node_name"}, TargetLabel: "node"},
+		},
+		TLS: &argoproj.ArgoCDMonitoringTLSSpec{ServerName: "argocd-redis-metrics"},
+	}
+	assert.NoError(t, r.reconcileRedisServiceMonitor(a))
+
+	sm := &monitoringv1.ServiceMonitor{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-redis-metrics", Namespace: a.Namespace}, sm))
+	assert.Equal(t, []monitoringv1.Endpoint{{
+		Port:     "metrics",
+		Scheme:   "https",
+		Interval: "15s",
+		TLSConfig: &monitoringv1.TLSConfig{
+			ServerName: "argocd-redis-metrics",
+		},
+		RelabelConfigs: []*monitoringv1.RelabelConfig{
+			{SourceLabels: []string{"__meta_kubernetes_pod_node_name"}, TargetLabel: "node"},
+		},
+	}}, sm.Spec.Endpoints)
+
+	// the ServiceMonitor is removed with the component
+	a.Spec.Redis.Exporter.Enabled = false
+	assert.NoError(t, r.reconcileRedisServiceMonitor(a))
+	assert.Error(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-redis-metrics", Namespace: a.Namespace}, sm))
+}
@@ -0,0 +1,481 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/argoproj/argo-cd/v2/util/glob"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+// rbacPolicyResources is the set of resources that can be referenced by an Argo CD RBAC policy rule.
+var rbacPolicyResources = map[string]bool{
+	"accounts":        true,
+	"applications":    true,
+	"applicationsets": true,
+	"certificates":    true,
+	"clusters":        true,
+	"exec":            true,
+	"extensions":      true,
+	"gpgkeys":         true,
+	"logs":            true,
+	"projects":        true,
+	"repositories":    true,
+	"*":               true,
+}
+
+// rbacPolicyActions is the set of actions that can be referenced by an Argo CD RBAC policy rule, in
+// addition to resource actions prefixed with `action/`.
+var rbacPolicyActions = map[string]bool{
+	"create":   true,
+	"delete":   true,
+	"get":      true,
+	"invoke":   true,
+	"override": true,
+	"sync":     true,
+	"update":   true,
+	"*":        true,
+}
+
+// rbacFragmentResources is the set of project scoped resources that can be referenced by an RBAC policy fragment.
+var rbacFragmentResources = map[string]bool{
+	"applications":    true,
+	"applicationsets": true,
+	"exec":            true,
+	"logs":            true,
+	"projects":        true,
+}
+
+// rbacPolicyOverlayNameRegex matches the names that can be used to build a `policy.<name>.csv` key.
+var rbacPolicyOverlayNameRegex = regexp.MustCompile(`^[a-zA-Z0-9]([-a-zA-Z0-9_]*[a-zA-Z0-9])?$`)
+
+// getRBACPolicyOverlayKey returns the argocd-rbac-cm key for the RBAC policy overlay with the given name.
+func getRBACPolicyOverlayKey(name string) string {
+	return common.ArgoCDKeyRBACPolicyOverlayPrefix + name + common.ArgoCDKeyRBACPolicyOverlaySuffix
+}
+
+// getRBACPolicyData will return the rendered RBAC policy CSV data for the given ArgoCD, keyed by the
+// argocd-rbac-cm key it should be stored under, along with the policy entries that failed validation.
+func getRBACPolicyData(cr *argoproj.ArgoCD) (map[string]string, []string) {
+	var errs []string
+	data := make(map[string]string)
+
+	policy := getRBACPolicy(cr)
+	errs = append(errs, validateRBACPolicyCSV(policy)...)
+
+	lines, roleErrs := renderRBACRoles("roles", cr.Spec.RBAC.Roles)
+	errs = append(errs, roleErrs...)
+	data[common.ArgoCDKeyRBACPolicyCSV] = joinRBACPolicy(policy, lines)
+
+	overlays := make(map[string]bool)
+	for i, overlay := range cr.Spec.RBAC.Overlays {
+		path := fmt.Sprintf("overlays[%d]", i)
+		if !rbacPolicyOverlayNameRegex.MatchString(overlay.Name) {
+			errs = append(errs, fmt.Sprintf("%s.name: invalid overlay name %q", path, overlay.Name))
+			continue
+		}
+		if overlays[overlay.Name] {
+			errs = append(errs, fmt.Sprintf("%s.name: duplicate overlay name %q", path, overlay.Name))
+			continue
+		}
+		overlays[overlay.Name] = true
+
+		lines, roleErrs := renderRBACRoles(path+".roles", overlay.Roles)
+		errs = append(errs, roleErrs...)
+		data[getRBACPolicyOverlayKey(overlay.Name)] = joinRBACPolicy("", lines)
+	}
+
+	return data, errs
+}
+
+// renderRBACRoles will render the given roles into Argo CD RBAC policy CSV lines. Roles are rendered
+// sorted by name so that the output does not depend on the order they were declared in, and duplicate
+// lines are only rendered once.
+func renderRBACRoles(path string, roles []argoproj.ArgoCDRBACRole) ([]string, []string) {
+	var lines, errs []string
+	seen := make(map[string]bool)
+	add := func(line string) {
+		if !seen[line] {
+			seen[line] = true
+			lines = append(lines, line)
+		}
+	}
+
+	order := make([]int, len(roles))
+	for i := range roles {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return roles[order[i]].Name < roles[order[j]].Name
+	})
+
+	for _, i := range order {
+		role := roles[i]
+		rolePath := fmt.Sprintf("%s[%d]", path, i)
+		if err := validateRBACSubject(role.Name); err != nil {
+			errs = append(errs, fmt.Sprintf("%s.name: %v", rolePath, err))
+			continue
+		}
+		subject := "role:" + role.Name
+
+		for j, rule := range role.Policies {
+			if err := validateRBACPolicyRule(rule); err != nil {
+				errs = append(errs, fmt.Sprintf("%s.policies[%d]: %v", rolePath, j, err))
+				continue
+			}
+			effect := rule.Effect
+			if effect == "" {
+				effect = "allow"
+			}
+			add(fmt.Sprintf("p, %s, %s, %s, %s, %s", subject, rule.Resource, rule.Action, rule.Object, effect))
+		}
+
+		var groups []string
+		for j, group := range role.Groups {
+			if err := validateRBACSubject(group); err != nil {
+				errs = append(errs, fmt.Sprintf("%s.groups[%d]: %v", rolePath, j, err))
+				continue
+			}
+			groups = append(groups, group)
+		}
+		sort.Strings(groups)
+		for _, group := range groups {
+			add(fmt.Sprintf("g, %s, %s", group, subject))
+		}
+	}
+
+	return lines, errs
+}
+
+// validateRBACSubject will return an error if the given role or group name cannot be rendered into a
+// policy CSV line.
+func validateRBACSubject(subject string) error {
+	if strings.TrimSpace(subject) == "" {
+		return fmt.Errorf("must not be empty")
+	}
+	if strings.ContainsAny(subject, ",\n") {
+		return fmt.Errorf("%q must not contain commas or line breaks", subject)
+	}
+	return nil
+}
+
+// validateRBACPolicyRule will return an error if the given policy rule is not a valid Argo CD RBAC policy.
+func validateRBACPolicyRule(rule argoproj.ArgoCDRBACPolicyRule) error {
+	if !rbacPolicyResources[rule.Resource] {
+		return fmt.Errorf("unknown resource %q", rule.Resource)
+	}
+	if !rbacPolicyActions[rule.Action] && !strings.HasPrefix(rule.Action, "action/") {
+		return fmt.Errorf("unknown action %q", rule.Action)
+	}
+	if strings.ContainsAny(rule.Action, ",\n") {
+		return fmt.Errorf("action %q must not contain commas or line breaks", rule.Action)
+	}
+	if strings.TrimSpace(rule.Object) == "" {
+		return fmt.Errorf("object must not be empty")
+	}
+	if strings.ContainsAny(rule.Object, ",\n") {
+		return fmt.Errorf("object %q must not contain commas or line breaks", rule.Object)
+	}
+	if rule.Effect != "" && rule.Effect != "allow" && rule.Effect != "deny" {
+		return fmt.Errorf("unknown effect %q", rule.Effect)
+	}
+	return nil
+}
+
+// validateRBACPolicyCSV will return the lines of the given user-defined policy CSV that are not valid
+// policy (p) or grouping (g) lines. The policy CSV is rendered as written by the user, invalid lines included.
+func validateRBACPolicyCSV(policy string) []string {
+	var errs []string
+	for i, line := range strings.Split(policy, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields, err := parseRBACPolicyLine(line)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("policy line %d: invalid CSV in %q: %v", i+1, line, err))
+			continue
+		}
+		switch fields[0] {
+		case "p":
+			if len(fields) != 6 {
+				errs = append(errs, fmt.Sprintf("policy line %d: expected 6 fields in policy %q", i+1, line))
+			}
+		case "g":
+			if len(fields) != 3 {
+				errs = append(errs, fmt.Sprintf("policy line %d: expected 3 fields in grouping %q", i+1, line))
+			}
+		default:
+			errs = append(errs, fmt.Sprintf("policy line %d: unknown policy type in %q", i+1, line))
+		}
+	}
+	return errs
+}
+
+// parseRBACPolicyLine will return the trimmed fields of the given policy CSV line.
+func parseRBACPolicyLine(line string) ([]string, error) {
+	reader := csv.NewReader(strings.NewReader(line))
+	reader.TrimLeadingSpace = true
+	fields, err := reader.Read()
+	if err != nil {
+		// the position reported by the CSV reader is relative to the line, leave it out
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			return nil, parseErr.Err
+		}
+		return nil, err
+	}
+	for i := range fields {
+		fields[i] = strings.TrimSpace(fields[i])
+	}
+	return fields, nil
+}
+
+// getRBACPolicyFragments will return the RBAC policy fragments aggregated from the namespaces managed by the
+// given ArgoCD, keyed by the argocd-rbac-cm key they should be stored under, along with the fragment lines
+// that were rejected.
+func (r *ReconcileArgoCD) getRBACPolicyFragments(cr *argoproj.ArgoCD) (map[string]string, []string, error) {
+	data := make(map[string]string)
+	if cr.Spec.RBAC.Fragments == nil || !cr.Spec.RBAC.Fragments.Enabled || r.ManagedNamespaces == nil {
+		return data, nil, nil
+	}
+
+	projects, err := r.getRBACFragmentProjects(cr)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var namespaces []string
+	for _, ns := range r.ManagedNamespaces.Items {
+		if ns.Name != cr.Namespace {
+			namespaces = append(namespaces, ns.Name)
+		}
+	}
+	sort.Strings(namespaces)
+
+	var errs []string
+	for _, ns := range namespaces {
+		cms := &corev1.ConfigMapList{}
+		if err := r.Client.List(context.TODO(), cms, client.InNamespace(ns), client.HasLabels{common.ArgoCDRBACFragmentLabel}); err != nil {
+			return nil, nil, err
+		}
+		if len(cms.Items) == 0 {
+			continue
+		}
+		sort.Slice(cms.Items, func(i, j int) bool {
+			return cms.Items[i].Name < cms.Items[j].Name
+		})
+
+		// projects that allow Applications in this namespace
+		allowed := make(map[string]bool)
+		for name, sourceNamespaces := range projects {
+			if glob.MatchStringInList(sourceNamespaces, ns, glob.GLOB) {
+				allowed[name] = true
+			}
+		}
+
+		var lines []string
+		seen := make(map[string]bool)
+		for _, cm := range cms.Items {
+			for i, line := range strings.Split(cm.Data[common.ArgoCDKeyRBACPolicyCSV], "\n") {
+				line = strings.TrimSpace(line)
+				if line == "" || strings.HasPrefix(line, "#") {
+					continue
+				}
+				fields, err := parseRBACPolicyLine(line)
+				if err != nil {
+					errs = append(errs, fmt.Sprintf("fragment %s/%s line %d: invalid CSV: %v", ns, cm.Name, i+1, err))
+					continue
+				}
+				if err := validateRBACFragmentLine(ns, allowed, fields); err != nil {
+					errs = append(errs, fmt.Sprintf("fragment %s/%s line %d: %v", ns, cm.Name, i+1, err))
+					continue
+				}
+				line = strings.Join(fields, ", ")
+				if !seen[line] {
+					seen[line] = true
+					lines = append(lines, line)
+				}
+			}
+		}
+		data[getRBACPolicyOverlayKey("fragments."+ns)] = joinRBACPolicy("", lines)
+	}
+
+	return data, errs, nil
+}
+
+// getRBACFragmentProjects will return the sourceNamespaces of each AppProject in the namespace of the given ArgoCD,
+// keyed by project name.
+func (r *ReconcileArgoCD) getRBACFragmentProjects(cr *argoproj.ArgoCD) (map[string][]string, error) {
+	projects := make(map[string][]string)
+
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(schema.GroupVersionKind{Group: "argoproj.io", Version: "v1alpha1", Kind: "AppProjectList"})
+	if err := r.Client.List(context.TODO(), list, client.InNamespace(cr.Namespace)); err != nil {
+		if meta.IsNoMatchError(err) {
+			return projects, nil
+		}
+		return nil, err
+	}
+
+	for _, project := range list.Items {
+		sourceNamespaces, _, err := unstructured.NestedStringSlice(project.Object, "spec", "sourceNamespaces")
+		if err != nil {
+			return nil, err
+		}
+		projects[project.GetName()] = sourceNamespaces
+	}
+	return projects, nil
+}
+
+// validateRBACFragmentLine will return an error if the given policy CSV fields of an RBAC policy fragment in the
+// given namespace reference roles outside of the namespace or AppProjects that are not in the allowed set.
+func validateRBACFragmentLine(namespace string, projects map[string]bool, fields []string) error {
+	rolePrefix := "role:" + namespace + ":"
+
+	switch fields[0] {
+	case "p":
+		if len(fields) != 6 {
+			return fmt.Errorf("expected 6 fields in policy")
+		}
+		if !strings.HasPrefix(fields[1], rolePrefix) {
+			return fmt.Errorf("subject %q must be a role prefixed with %q", fields[1], rolePrefix)
+		}
+		rule := argoproj.ArgoCDRBACPolicyRule{Resource: fields[2], Action: fields[3], Object: fields[4], Effect: fields[5]}
+		if !rbacFragmentResources[rule.Resource] {
+			return fmt.Errorf("resource %q is not project scoped", rule.Resource)
+		}
+		if err := validateRBACPolicyRule(rule); err != nil {
+			return err
+		}
+		project := rule.Object
+		if rule.Resource != "projects" {
+			project = strings.SplitN(rule.Object, "/", 2)[0]
+		}
+		if !projects[project] {
+			return fmt.Errorf("object %q is outside of the AppProjects allowed for namespace %s", rule.Object, namespace)
+		}
+	case "g":
+		if len(fields) != 3 {
+			return fmt.Errorf("expected 3 fields in grouping")
+		}
+		if err := validateRBACSubject(fields[1]); err != nil {
+			return err
+		}
+		if strings.HasPrefix(fields[1], "role:") && !strings.HasPrefix(fields[1], rolePrefix) {
+			return fmt.Errorf("role %q must be prefixed with %q", fields[1], rolePrefix)
+		}
+		if !strings.HasPrefix(fields[2], rolePrefix) {
+			return fmt.Errorf("role %q must be prefixed with %q", fields[2], rolePrefix)
+		}
+	default:
+		return fmt.Errorf("unknown policy type %q", fields[0])
+	}
+	return nil
+}
+
+// joinRBACPolicy will append the given rendered policy lines to the given policy CSV.
+func joinRBACPolicy(policy string, lines []string) string {
+	if len(lines) == 0 {
+		return policy
+	}
+	if policy != "" && !strings.HasSuffix(policy, "\n") {
+		policy += "\n"
+	}
+	return policy + strings.Join(lines, "\n") + "\n"
+}
+
+// reconcileRBACPolicyOverlays will ensure that the RBAC policy overlay keys of the given ConfigMap match the
+// given rendered policy data, removing overlays that are no longer declared. It returns true if the ConfigMap
+// was changed.
+func reconcileRBACPolicyOverlays(cm *corev1.ConfigMap, data map[string]string) bool {
+	changed := false
+
+	var keys []string
+	for k, v := range data {
+		if k == common.ArgoCDKeyRBACPolicyCSV {
+			continue
+		}
+		keys = append(keys, k)
+		if current, ok := cm.Data[k]; !ok || current != v {
+			cm.Data[k] = v
+			changed = true
+		}
+	}
+	sort.Strings(keys)
+
+	if managed, ok := cm.Annotations[common.AnnotationRBACPolicyOverlays]; ok {
+		for _, k := range splitList(managed) {
+			if _, ok := data[k]; !ok {
+				if _, found := cm.Data[k]; found {
+					delete(cm.Data, k)
+					changed = true
+				}
+			}
+		}
+	}
+
+	annotation := strings.Join(keys, ",")
+	if cm.Annotations[common.AnnotationRBACPolicyOverlays] != annotation {
+		if len(keys) == 0 {
+			delete(cm.Annotations, common.AnnotationRBACPolicyOverlays)
+		} else {
+			if cm.Annotations == nil {
+				cm.Annotations = make(map[string]string)
+			}
+			cm.Annotations[common.AnnotationRBACPolicyOverlays] = annotation
+		}
+		changed = true
+	}
+
+	return changed
+}
+
+// reconcileStatusRBACPolicy will ensure that the RBAC policy errors status is updated for the given ArgoCD.
+func (r *ReconcileArgoCD) reconcileStatusRBACPolicy(cr *argoproj.ArgoCD, errs []string) error {
+	if len(errs) == 0 {
+		errs = nil
+	}
+	if !reflect.DeepEqual(cr.Status.RBACPolicyErrors, errs) {
+		if len(errs) > 0 {
+			r.recordEvent(cr, corev1.EventTypeWarning, "InvalidRBACPolicy", fmt.Sprintf("%d invalid RBAC policy entries, see .status.rbacPolicyErrors", len(errs)))
+		}
+		cr.Status.RBACPolicyErrors = errs
+		return r.Client.Status().Update(context.TODO(), cr)
+	}
+	return nil
+}
+
+// isRBACFragment returns whether the given object is labelled as an RBAC policy fragment.
+func isRBACFragment(o client.Object) bool {
+	_, ok := o.GetLabels()[common.ArgoCDRBACFragmentLabel]
+	return ok
+}
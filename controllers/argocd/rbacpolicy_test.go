@@ -0,0 +1,240 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+func TestGetRBACPolicyData(t *testing.T) {
+	policy := "g, system:cluster-admins, role:admin"
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.RBAC.Policy = &policy
+		a.Spec.RBAC.Roles = []argoproj.ArgoCDRBACRole{
+			{
+				Name: "team-b",
+				Policies: []argoproj.ArgoCDRBACPolicyRule{
+					{Resource: "applications", Action: "get", Object: "team-b/*"},
+				},
+				Groups: []string{"team-b-devs"},
+			},
+			{
+				Name: "team-a",
+				Policies: []argoproj.ArgoCDRBACPolicyRule{
+					{Resource: "applications", Action: "sync", Object: "team-a/*"},
+					{Resource: "applications", Action: "delete", Object: "team-a/*", Effect: "deny"},
+				},
+				Groups: []string{"team-a-ops", "team-a-devs"},
+			},
+		}
+		a.Spec.RBAC.Overlays = []argoproj.ArgoCDRBACPolicyOverlay{
+			{
+				Name: "platform",
+				Roles: []argoproj.ArgoCDRBACRole{
+					{Name: "admin", Groups: []string{"platform-admins"}},
+				},
+			},
+		}
+	})
+
+	data, errs := getRBACPolicyData(a)
+	assert.Empty(t, errs)
+	assert.Equal(t, `g, system:cluster-admins, role:admin
+p, role:team-a, applications, sync, team-a/*, allow
+p, role:team-a, applications, delete, team-a/*, deny
+g, team-a-devs, role:team-a
+g, team-a-ops, role:team-a
+p, role:team-b, applications, get, team-b/*, allow
+g, team-b-devs, role:team-b
+`, data[common.ArgoCDKeyRBACPolicyCSV])
+	assert.Equal(t, "g, platform-admins, role:admin\n", data["policy.platform.csv"])
+}
+
+func TestGetRBACPolicyData_invalidEntries(t *testing.T) {
+	policy := "g, system:cluster-admins, role:admin\nq, foo\np, role:bar, applications, get\np, role:bar, applications, get, \"bar/*\", allow\np, role:bar, \"applications"
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.RBAC.Policy = &policy
+		a.Spec.RBAC.Roles = []argoproj.ArgoCDRBACRole{
+			{
+				Name: "team-a",
+				Policies: []argoproj.ArgoCDRBACPolicyRule{
+					{Resource: "applications", Action: "get", Object: "team-a/*"},
+					{Resource: "widgets", Action: "get", Object: "*"},
+					{Resource: "applications", Action: "explode", Object: "*"},
+					{Resource: "applications", Action: "get", Object: ""},
+				},
+				Groups: []string{"team,a"},
+			},
+			{Name: ""},
+		}
+		a.Spec.RBAC.Overlays = []argoproj.ArgoCDRBACPolicyOverlay{
+			{Name: "dev"},
+			{Name: "dev"},
+			{Name: "not valid"},
+		}
+	})
+
+	data, errs := getRBACPolicyData(a)
+	assert.Equal(t, []string{
+		`policy line 2: unknown policy type in "q, foo"`,
+		`policy line 3: expected 6 fields in policy "p, role:bar, applications, get"`,
+		`policy line 5: invalid CSV in "p, role:bar, \"applications": extraneous or missing " in quoted-field`,
+		`roles[1].name: must not be empty`,
+		`roles[0].policies[1]: unknown resource "widgets"`,
+		`roles[0].policies[2]: unknown action "explode"`,
+		`roles[0].policies[3]: object must not be empty`,
+		`roles[0].groups[0]: "team,a" must not contain commas or line breaks`,
+		`overlays[1].name: duplicate overlay name "dev"`,
+		`overlays[2].name: invalid overlay name "not valid"`,
+	}, errs)
+	// the policy CSV of the user is rendered as written, invalid lines included
+	assert.Equal(t, policy+"\np, role:team-a, applications, get, team-a/*, allow\n", data[common.ArgoCDKeyRBACPolicyCSV])
+	assert.Contains(t, data, "policy.dev.csv")
+}
+
+func TestReconcileRBAC_withRolesAndOverlays(t *testing.T) {
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.RBAC.Roles = []argoproj.ArgoCDRBACRole{
+			{Name: "readers", Groups: []string{"everyone"}, Policies: []argoproj.ArgoCDRBACPolicyRule{
+				{Resource: "applications", Action: "get", Object: "*/*"},
+			}},
+		}
+		a.Spec.RBAC.Overlays = []argoproj.ArgoCDRBACPolicyOverlay{
+			{Name: "ops", Roles: []argoproj.ArgoCDRBACRole{{Name: "admin", Groups: []string{"ops"}}}},
+			{Name: "sec", Roles: []argoproj.ArgoCDRBACRole{{Name: "auditor", Groups: []string{"sec", "bad,group"}}}},
+		}
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileRBAC(a))
+
+	cm := &corev1.ConfigMap{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDRBACConfigMapName, Namespace: testNamespace}, cm))
+	assert.Equal(t, "p, role:readers, applications, get, */*, allow\ng, everyone, role:readers\n", cm.Data[common.ArgoCDKeyRBACPolicyCSV])
+	assert.Equal(t, "g, ops, role:admin\n", cm.Data["policy.ops.csv"])
+	assert.Equal(t, "g, sec, role:auditor\n", cm.Data["policy.sec.csv"])
+	assert.Equal(t, "policy.ops.csv,policy.sec.csv", cm.Annotations[common.AnnotationRBACPolicyOverlays])
+
+	updated := &argoproj.ArgoCD{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: a.Name, Namespace: a.Namespace}, updated))
+	assert.Equal(t, []string{`overlays[1].roles[0].groups[1]: "bad,group" must not contain commas or line breaks`}, updated.Status.RBACPolicyErrors)
+
+	// Removing an overlay removes the corresponding key, but keeps keys not managed by the operator.
+	cm.Data["policy.manual.csv"] = "g, manual, role:admin"
+	assert.NoError(t, r.Client.Update(context.TODO(), cm))
+	updated.Spec.RBAC.Overlays = updated.Spec.RBAC.Overlays[:1]
+	updated.Spec.RBAC.Overlays[0].Roles[0].Groups = []string{"ops"}
+	assert.NoError(t, r.reconcileRBAC(updated))
+
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDRBACConfigMapName, Namespace: testNamespace}, cm))
+	assert.NotContains(t, cm.Data, "policy.sec.csv")
+	assert.Equal(t, "g, ops, role:admin\n", cm.Data["policy.ops.csv"])
+	assert.Equal(t, "g, manual, role:admin", cm.Data["policy.manual.csv"])
+	assert.Equal(t, "policy.ops.csv", cm.Annotations[common.AnnotationRBACPolicyOverlays])
+
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: a.Name, Namespace: a.Namespace}, updated))
+	assert.Nil(t, updated.Status.RBACPolicyErrors)
+
+	// Without a policy or roles, a policy CSV edited by hand is left as is.
+	updated.Spec.RBAC.Roles = nil
+	cm.Data[common.ArgoCDKeyRBACPolicyCSV] = "g, hand-written, role:admin"
+	assert.NoError(t, r.Client.Update(context.TODO(), cm))
+	assert.NoError(t, r.reconcileRBAC(updated))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDRBACConfigMapName, Namespace: testNamespace}, cm))
+	assert.Equal(t, "g, hand-written, role:admin", cm.Data[common.ArgoCDKeyRBACPolicyCSV])
+}
+
+func TestGetRBACPolicyFragments(t *testing.T) {
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.RBAC.Fragments = &argoproj.ArgoCDRBACFragmentsSpec{Enabled: true}
+	})
+
+	project := &unstructured.Unstructured{}
+	project.SetGroupVersionKind(schema.GroupVersionKind{Group: "argoproj.io", Version: "v1alpha1", Kind: "AppProject"})
+	project.SetName("team-a")
+	project.SetNamespace(testNamespace)
+	assert.NoError(t, unstructured.SetNestedStringSlice(project.Object, []string{"team-*"}, "spec", "sourceNamespaces"))
+
+	fragment := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "rbac",
+			Namespace: "team-a",
+			Labels:    map[string]string{common.ArgoCDRBACFragmentLabel: "true"},
+		},
+		Data: map[string]string{
+			common.ArgoCDKeyRBACPolicyCSV: `# team a policies
+p,role:team-a:devs,applications,sync,team-a/*,allow
+p, role:team-a:devs, applications, sync, team-a/*, allow
+p, role:admin, applications, sync, team-a/*, allow
+p, role:team-a:devs, clusters, get, *, allow
+p, role:team-a:devs, applications, get, default/*, allow
+g, team-a-devs, role:team-a:devs
+g, team-a-devs, role:admin`,
+		},
+	}
+	unlabelled := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "team-a"},
+		Data:       map[string]string{common.ArgoCDKeyRBACPolicyCSV: "g, team-a-devs, role:team-a:devs"},
+	}
+
+	resObjs := []client.Object{a, project, fragment, unlabelled}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+	r.ManagedNamespaces = &corev1.NamespaceList{Items: []corev1.Namespace{
+		{ObjectMeta: metav1.ObjectMeta{Name: testNamespace}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "team-a"}},
+	}}
+
+	data, errs, err := r.getRBACPolicyFragments(a)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"policy.fragments.team-a.csv": "p, role:team-a:devs, applications, sync, team-a/*, allow\ng, team-a-devs, role:team-a:devs\n",
+	}, data)
+	assert.Equal(t, []string{
+		`fragment team-a/rbac line 4: subject "role:admin" must be a role prefixed with "role:team-a:"`,
+		`fragment team-a/rbac line 5: resource "clusters" is not project scoped`,
+		`fragment team-a/rbac line 6: object "default/*" is outside of the AppProjects allowed for namespace team-a`,
+		`fragment team-a/rbac line 8: role "role:admin" must be prefixed with "role:team-a:"`,
+	}, errs)
+
+	// fragments are ignored unless enabled
+	a.Spec.RBAC.Fragments.Enabled = false
+	data, errs, err = r.getRBACPolicyFragments(a)
+	assert.NoError(t, err)
+	assert.Empty(t, data)
+	assert.Empty(t, errs)
+}
@@ -0,0 +1,689 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+const (
+	// Name of the volume holding the CA bundle of an external Redis server.
+	redisExternalCAVolumeName = "redis-external-ca"
+
+	// Name of the file holding the CA bundle of an external Redis server in redisExternalCAVolumeName.
+	redisExternalCAFileName = "ca.crt"
+
+	// Annotation of the Redis HA pods holding the checksum of the Redis HA topology, so that the pods are restarted
+	// and the sentinels reconfigured when the topology changes.
+	redisHATopologyChecksumAnnotation = "checksum/topology"
+
+	// Default size of the PersistentVolumeClaim holding the Redis data.
+	redisDefaultPersistenceSize = "1Gi"
+
+	// Directory of the Redis data in the Redis containers.
+	redisDataDir = "/data"
+
+	// Default memory limit and eviction policy of the Redis HA servers.
+	redisHADefaultMaxMemory       = "0"
+	redisHADefaultMaxMemoryPolicy = "volatile-lru"
+)
+
+// Sentinel IDs of the first Redis HA servers, kept stable across operator versions.
+var redisHASentinelIDs = []string{
+	"3c0d9c0320bb34888c2df5757c718ce6ca992ce6",
+	"40000915ab58c3fa8fd888fb8b24711944e6cbb4",
+	"2bbec7894d954a8af3bb54d13eaec53cb024e2ca",
+}
+
+// Default RDB save points used when persistence is enabled without the append only file.
+var redisDefaultSavePoints = []string{"3600 1", "300 100", "60 10000"}
+
+// isRedisExternal returns whether the given ArgoCD connects to a Redis server that is not managed by the operator.
+func isRedisExternal(cr *argoproj.ArgoCD) bool {
+	return cr.Spec.Redis.External != nil && validateRedisExternal(cr) == nil
+}
+
+// isRedisRemote returns whether the operator should skip deploying Redis because the given ArgoCD uses a remote or an
+// external Redis server.
+func isRedisRemote(cr *argoproj.ArgoCD) bool {
+	if !cr.Spec.Redis.IsEnabled() {
+		return false
+	}
+	return isRedisExternal(cr) || (cr.Spec.Redis.Remote != nil && *cr.Spec.Redis.Remote != "")
+}
+
+// isRedisExporterEnabled returns whether the Redis exporter sidecar runs next to the Redis server of the given ArgoCD.
+func isRedisExporterEnabled(cr *argoproj.ArgoCD) bool {
+	if !cr.Spec.Redis.IsEnabled() || cr.Spec.HA.Enabled || isRedisRemote(cr) {
+		return false
+	}
+	return cr.Spec.Redis.Exporter != nil && cr.Spec.Redis.Exporter.Enabled
+}
+
+// getRedisExporterContainer returns the Redis exporter sidecar exposing the metrics of the Redis server of the given
+// ArgoCD.
+func getRedisExporterContainer(cr *argoproj.ArgoCD, useTLS bool) corev1.Container {
+	env := []corev1.EnvVar{
+		{
+			Name:  "REDIS_ADDR",
+			Value: fmt.Sprintf("redis://localhost:%d", common.ArgoCDDefaultRedisPort),
+		},
+		{
+			Name: "REDIS_PASSWORD",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: fmt.Sprintf("%s-%s", cr.Name, "redis-initial-password"),
+					},
+					Key: "admin.password",
+				},
+			},
+		},
+	}
+	if useTLS {
+		// Redis only serves TLS in this case, and its certificate is not issued for localhost.
+		env[0].Value = fmt.Sprintf("rediss://localhost:%d", common.ArgoCDDefaultRedisPort)
+		env = append(env, corev1.EnvVar{
+			Name:  "REDIS_EXPORTER_SKIP_TLS_VERIFICATION",
+			Value: "true",
+		})
+	}
+
+	resources := corev1.ResourceRequirements{}
+	if cr.Spec.Redis.Exporter.Resources != nil {
+		resources = *cr.Spec.Redis.Exporter.Resources
+	}
+
+	return corev1.Container{
+		Env:             env,
+		Image:           getRedisExporterContainerImage(cr),
+		ImagePullPolicy: corev1.PullAlways,
+		Name:            "redis-exporter",
+		Ports: []corev1.ContainerPort{
+			{
+				ContainerPort: common.ArgoCDDefaultRedisExporterPort,
+				Name:          common.ArgoCDKeyMetrics,
+			},
+		},
+		Resources: resources,
+		SecurityContext: &corev1.SecurityContext{
+			AllowPrivilegeEscalation: boolPtr(false),
+			Capabilities: &corev1.Capabilities{
+				Drop: []corev1.Capability{
+					"ALL",
+				},
+			},
+			RunAsNonRoot: boolPtr(true),
+			SeccompProfile: &corev1.SeccompProfile{
+				Type: "RuntimeDefault",
+			},
+		},
+	}
+}
+
+// isRedisExporterContainerChanged returns whether the existing redis exporter sidecars differ from the desired ones.
+// Only the fields set by the operator are compared, since the API server defaults the others.
+func isRedisExporterContainerChanged(desired, existing []corev1.Container) bool {
+	if len(desired) != len(existing) {
+		return true
+	}
+	for i := range desired {
+		d, e := desired[i], existing[i]
+		if d.Name != e.Name || d.Image != e.Image || d.ImagePullPolicy != e.ImagePullPolicy ||
+			!reflect.DeepEqual(d.Env, e.Env) ||
+			!reflect.DeepEqual(d.Resources, e.Resources) ||
+			!reflect.DeepEqual(d.SecurityContext, e.SecurityContext) ||
+			len(d.Ports) != len(e.Ports) {
+			return true
+		}
+		for j := range d.Ports {
+			if d.Ports[j].Name != e.Ports[j].Name || d.Ports[j].ContainerPort != e.Ports[j].ContainerPort {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// validateRedisExternal will return an error if the external Redis server of the given ArgoCD is incomplete. An
+// incomplete external Redis server is skipped, and the in-cluster Redis server is used instead.
+func validateRedisExternal(cr *argoproj.ArgoCD) error {
+	if cr.Spec.Redis.External == nil {
+		return nil
+	}
+	external := cr.Spec.Redis.External
+	if external.Sentinel == nil {
+		if external.Address == "" {
+			return fmt.Errorf("redis external address must not be empty when sentinel is not set")
+		}
+		return nil
+	}
+	if external.Sentinel.MasterName == "" {
+		return fmt.Errorf("redis external sentinel masterName must not be empty")
+	}
+	if len(external.Sentinel.Addresses) == 0 {
+		return fmt.Errorf("redis external sentinel addresses must not be empty")
+	}
+	return nil
+}
+
+// getRedisCommandArgs returns the Redis connection arguments of the Argo CD server, repo server and application
+// controller. tlsDir is the directory in which the component mounts the Redis TLS certificates.
+func getRedisCommandArgs(cr *argoproj.ArgoCD, useTLSForRedis bool, tlsDir string) []string {
+	args := make([]string, 0)
+
+	if !isRedisExternal(cr) {
+		args = append(args, "--redis", getRedisServerAddress(cr))
+		if useTLSForRedis {
+			args = append(args, "--redis-use-tls")
+			if isRedisTLSVerificationDisabled(cr) {
+				args = append(args, "--redis-insecure-skip-tls-verify")
+			} else {
+				args = append(args, "--redis-ca-certificate", filepath.Join(tlsDir, "redis", "tls.crt"))
+			}
+		}
+		return args
+	}
+
+	external := cr.Spec.Redis.External
+	if external.Sentinel != nil {
+		for _, address := range external.Sentinel.Addresses {
+			args = append(args, "--sentinel", address)
+		}
+		args = append(args, "--sentinelmaster", external.Sentinel.MasterName)
+	} else {
+		args = append(args, "--redis", getRedisServerAddress(cr))
+	}
+
+	if external.DB != nil {
+		args = append(args, "--redis-db", fmt.Sprint(*external.DB))
+	}
+
+	if external.TLS != nil {
+		args = append(args, "--redis-use-tls")
+		if external.TLS.InsecureSkipVerify {
+			args = append(args, "--redis-insecure-skip-tls-verify")
+		} else if external.TLS.CABundleRef != nil {
+			args = append(args, "--redis-ca-certificate", filepath.Join(tlsDir, redisExternalCAVolumeName, redisExternalCAFileName))
+		}
+	}
+
+	return args
+}
+
+// getRedisEnv returns the Redis credentials environment of the Argo CD server, repo server and application controller.
+func getRedisEnv(cr *argoproj.ArgoCD) []corev1.EnvVar {
+	if !isRedisExternal(cr) {
+		return []corev1.EnvVar{{
+			Name: "REDIS_PASSWORD",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: fmt.Sprintf("%s-%s", cr.Name, "redis-initial-password"),
+					},
+					Key: "admin.password",
+				},
+			},
+		}}
+	}
+
+	env := make([]corev1.EnvVar, 0)
+	external := cr.Spec.Redis.External
+	if external.PasswordRef != nil {
+		env = append(env, corev1.EnvVar{
+			Name:      "REDIS_PASSWORD",
+			ValueFrom: &corev1.EnvVarSource{SecretKeyRef: external.PasswordRef},
+		})
+	}
+	if external.UsernameRef != nil {
+		env = append(env, corev1.EnvVar{
+			Name:      "REDIS_USERNAME",
+			ValueFrom: &corev1.EnvVarSource{SecretKeyRef: external.UsernameRef},
+		})
+	}
+	return env
+}
+
+// getRedisExternalCAVolumes returns the volume holding the CA bundle of the external Redis server, if any.
+func getRedisExternalCAVolumes(cr *argoproj.ArgoCD) []corev1.Volume {
+	if !isRedisExternal(cr) || cr.Spec.Redis.External.TLS == nil || cr.Spec.Redis.External.TLS.CABundleRef == nil {
+		return nil
+	}
+	ref := cr.Spec.Redis.External.TLS.CABundleRef
+	return []corev1.Volume{{
+		Name: redisExternalCAVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: ref.Name,
+				Items: []corev1.KeyToPath{{
+					Key:  ref.Key,
+					Path: redisExternalCAFileName,
+				}},
+				Optional: ref.Optional,
+			},
+		},
+	}}
+}
+
+// getRedisExternalCAVolumeMounts returns the mount of the CA bundle of the external Redis server in tlsDir, if any.
+func getRedisExternalCAVolumeMounts(cr *argoproj.ArgoCD, tlsDir string) []corev1.VolumeMount {
+	if len(getRedisExternalCAVolumes(cr)) == 0 {
+		return nil
+	}
+	return []corev1.VolumeMount{{
+		Name:      redisExternalCAVolumeName,
+		MountPath: filepath.Join(tlsDir, redisExternalCAVolumeName),
+		ReadOnly:  true,
+	}}
+}
+
+// getRedisHAReplicas returns the number of Redis HA servers of the given ArgoCD. A number of servers below the
+// minimum is skipped, as reported by validateRedisHA.
+func getRedisHAReplicas(cr *argoproj.ArgoCD) *int32 {
+	replicas := common.ArgoCDDefaultRedisHAReplicas
+	if cr.Spec.HA.Redis != nil && cr.Spec.HA.Redis.Replicas != nil && *cr.Spec.HA.Redis.Replicas >= replicas {
+		replicas = *cr.Spec.HA.Redis.Replicas
+	}
+	return &replicas
+}
+
+// getRedisHAQuorum returns the sentinel quorum of the given ArgoCD, a majority of the Redis HA servers by default. A
+// quorum that is not between 1 and the number of servers is skipped, as reported by validateRedisHA.
+func getRedisHAQuorum(cr *argoproj.ArgoCD) int32 {
+	replicas := *getRedisHAReplicas(cr)
+	if cr.Spec.HA.Redis != nil && cr.Spec.HA.Redis.Quorum != nil {
+		if quorum := *cr.Spec.HA.Redis.Quorum; quorum >= 1 && quorum <= replicas {
+			return quorum
+		}
+	}
+	return replicas/2 + 1
+}
+
+// getRedisHAServers returns the indexes of the given number of Redis HA servers.
+func getRedisHAServers(replicas int32) []int32 {
+	servers := make([]int32, 0)
+	for i := int32(0); i < replicas; i++ {
+		servers = append(servers, i)
+	}
+	return servers
+}
+
+// getRedisHAServedReplicas returns the number of Redis HA servers that are still served by HAProxy and announced
+// for the given ArgoCD. While a scale down is in progress, the removed servers are kept until the master has failed
+// over to one of the kept servers and the removed pods are gone.
+func (r *ReconcileArgoCD) getRedisHAServedReplicas(cr *argoproj.ArgoCD) int32 {
+	replicas := *getRedisHAReplicas(cr)
+
+	ss := newStatefulSetWithSuffix("redis-ha-server", "redis", cr)
+	if !argoutil.IsObjectFound(r.Client, cr.Namespace, ss.Name, ss) || ss.Spec.Replicas == nil {
+		return replicas
+	}
+	if *ss.Spec.Replicas > replicas {
+		// the failover has not happened yet
+		return *ss.Spec.Replicas
+	}
+	if ss.Annotations[redisHASentinelResetAnnotation] == "true" && !isRedisHAScaleDownComplete(ss) && ss.Status.Replicas > replicas {
+		// the removed pods are not gone yet
+		return ss.Status.Replicas
+	}
+	return replicas
+}
+
+// getRedisHAProxyReplicas returns the number of Redis HAProxy replicas of the given ArgoCD.
+func getRedisHAProxyReplicas(cr *argoproj.ArgoCD) *int32 {
+	replicas := int32(1)
+	if cr.Spec.HA.Redis != nil && cr.Spec.HA.Redis.ProxyReplicas != nil {
+		replicas = *cr.Spec.HA.Redis.ProxyReplicas
+	}
+	return &replicas
+}
+
+// getRedisHASentinelResources returns the ResourceRequirements of the Redis HA sentinel container.
+func getRedisHASentinelResources(cr *argoproj.ArgoCD) corev1.ResourceRequirements {
+	if cr.Spec.HA.Redis != nil && cr.Spec.HA.Redis.SentinelResources != nil {
+		return *cr.Spec.HA.Redis.SentinelResources
+	}
+	return getRedisHAResources(cr)
+}
+
+// getRedisHAProxyResources returns the ResourceRequirements of the Redis HAProxy containers.
+func getRedisHAProxyResources(cr *argoproj.ArgoCD) corev1.ResourceRequirements {
+	if cr.Spec.HA.Redis != nil && cr.Spec.HA.Redis.ProxyResources != nil {
+		return *cr.Spec.HA.Redis.ProxyResources
+	}
+	return getRedisHAResources(cr)
+}
+
+// getRedisHAPersistence returns the persistence of the Redis HA servers, or nil if they are not persisted.
+func getRedisHAPersistence(cr *argoproj.ArgoCD) *argoproj.ArgoCDRedisPersistenceSpec {
+	if cr.Spec.HA.Redis == nil {
+		return nil
+	}
+	return cr.Spec.HA.Redis.Persistence
+}
+
+// getRedisSavePoints returns the RDB save points rendered into redis.conf for the given persistence.
+func getRedisSavePoints(persistence *argoproj.ArgoCDRedisPersistenceSpec) []string {
+	if persistence == nil {
+		return nil
+	}
+	if len(persistence.Save) == 0 && !persistence.AppendOnly {
+		return redisDefaultSavePoints
+	}
+	return persistence.Save
+}
+
+// getRedisPersistentVolumeClaimSpec returns the spec of the PersistentVolumeClaim holding the Redis data.
+func getRedisPersistentVolumeClaimSpec(persistence *argoproj.ArgoCDRedisPersistenceSpec) corev1.PersistentVolumeClaimSpec {
+	size := resource.MustParse(redisDefaultPersistenceSize)
+	if persistence.Size != nil {
+		size = *persistence.Size
+	}
+	return corev1.PersistentVolumeClaimSpec{
+		AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceStorage: size,
+			},
+		},
+		StorageClassName: persistence.StorageClassName,
+	}
+}
+
+// getRedisHAVolumeClaimTemplates returns the PersistentVolumeClaim templates of the Redis HA StatefulSet.
+func getRedisHAVolumeClaimTemplates(cr *argoproj.ArgoCD) []corev1.PersistentVolumeClaim {
+	persistence := getRedisHAPersistence(cr)
+	if persistence == nil {
+		return nil
+	}
+	return []corev1.PersistentVolumeClaim{{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "data",
+		},
+		Spec: getRedisPersistentVolumeClaimSpec(persistence),
+	}}
+}
+
+// redisVolumeClaimTemplatesChanged returns whether the PersistentVolumeClaim templates of a StatefulSet differ in
+// name, size or StorageClass. Only those fields are compared since the API server defaults the others.
+func redisVolumeClaimTemplatesChanged(existing, desired []corev1.PersistentVolumeClaim) bool {
+	if len(existing) != len(desired) {
+		return true
+	}
+	for i := range desired {
+		if existing[i].Name != desired[i].Name {
+			return true
+		}
+		if existing[i].Spec.Resources.Requests.Storage().Cmp(*desired[i].Spec.Resources.Requests.Storage()) != 0 {
+			return true
+		}
+		if desired[i].Spec.StorageClassName != nil &&
+			(existing[i].Spec.StorageClassName == nil || *existing[i].Spec.StorageClassName != *desired[i].Spec.StorageClassName) {
+			return true
+		}
+	}
+	return false
+}
+
+// getRedisHASentinelIDEnv returns the SENTINEL_ID_<index> environment variables used by the Redis HA init script.
+// Only the IDs of the first servers are set, the init script derives the IDs of additional servers from their
+// name, so that scaling does not change the pod template and restart the running servers.
+func getRedisHASentinelIDEnv(cr *argoproj.ArgoCD) []corev1.EnvVar {
+	env := make([]corev1.EnvVar, 0)
+	for i, id := range redisHASentinelIDs {
+		env = append(env, corev1.EnvVar{
+			Name:  fmt.Sprintf("SENTINEL_ID_%d", i),
+			Value: id,
+		})
+	}
+	return env
+}
+
+// getRedisHATopologyChecksum returns the checksum of the Redis HA topology of the given ArgoCD, or an empty string if
+// the default topology is used. The number of replicas is not part of the checksum: servers are added or removed
+// without restarting the others, and the sentinels are reset by the operator on scale down.
+func getRedisHATopologyChecksum(cr *argoproj.ArgoCD) string {
+	if cr.Spec.HA.Redis == nil {
+		return ""
+	}
+	topology, err := json.Marshal(struct {
+		Quorum      int32
+		Persistence *argoproj.ArgoCDRedisPersistenceSpec
+	}{getRedisHAQuorum(cr), getRedisHAPersistence(cr)})
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(topology))
+}
+
+// getRedisHAProxyTopologyChecksum returns the checksum of the Redis HA topology seen by HAProxy, which resolves the
+// given number of served Redis HA servers on startup, or an empty string if the default topology is used.
+func getRedisHAProxyTopologyChecksum(cr *argoproj.ArgoCD, servers int32) string {
+	if cr.Spec.HA.Redis == nil {
+		return ""
+	}
+	topology, err := json.Marshal(struct {
+		Replicas int32
+		Quorum   int32
+	}{servers, getRedisHAQuorum(cr)})
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(topology))
+}
+
+// validateRedisHA will return an error if the Redis HA topology of the given ArgoCD is inconsistent.
+func validateRedisHA(cr *argoproj.ArgoCD) error {
+	if !cr.Spec.HA.Enabled || cr.Spec.HA.Redis == nil {
+		return nil
+	}
+	if r := cr.Spec.HA.Redis.Replicas; r != nil && *r < common.ArgoCDDefaultRedisHAReplicas {
+		return fmt.Errorf("redis HA replicas must be at least %d", common.ArgoCDDefaultRedisHAReplicas)
+	}
+	replicas := *getRedisHAReplicas(cr)
+	if q := cr.Spec.HA.Redis.Quorum; q != nil && (*q < 1 || *q > replicas) {
+		return fmt.Errorf("redis HA quorum %d must be between 1 and the number of replicas %d", *q, replicas)
+	}
+	return nil
+}
+
+// getRedisPersistence returns the persistence of the non HA Redis server, or nil if it is not persisted.
+func getRedisPersistence(cr *argoproj.ArgoCD) *argoproj.ArgoCDRedisPersistenceSpec {
+	if cr.Spec.HA.Enabled || !cr.Spec.Redis.IsEnabled() || isRedisRemote(cr) {
+		return nil
+	}
+	return cr.Spec.Redis.Persistence
+}
+
+// getRedisPersistentVolumeClaimName returns the name of the PersistentVolumeClaim holding the non HA Redis data.
+func getRedisPersistentVolumeClaimName(cr *argoproj.ArgoCD) string {
+	return nameWithSuffix("redis-data", cr)
+}
+
+// getRedisMaxMemory returns the memory limit of the Redis HA servers.
+func getRedisMaxMemory(cr *argoproj.ArgoCD) string {
+	if cr.Spec.Redis.MaxMemory != "" {
+		return cr.Spec.Redis.MaxMemory
+	}
+	return redisHADefaultMaxMemory
+}
+
+// getRedisMaxMemoryPolicy returns the eviction policy of the Redis HA servers.
+func getRedisMaxMemoryPolicy(cr *argoproj.ArgoCD) string {
+	if cr.Spec.Redis.MaxMemoryPolicy != "" {
+		return cr.Spec.Redis.MaxMemoryPolicy
+	}
+	return redisHADefaultMaxMemoryPolicy
+}
+
+// getArgoRedisArgs will return the arguments of the non HA Redis server.
+func getArgoRedisArgs(cr *argoproj.ArgoCD, useTLS bool) []string {
+	args := make([]string, 0)
+
+	if persistence := getRedisPersistence(cr); persistence != nil {
+		args = append(args, "--dir", redisDataDir)
+		args = append(args, "--save", strings.Join(getRedisSavePoints(persistence), " "))
+		if persistence.AppendOnly {
+			args = append(args, "--appendonly", "yes")
+		} else {
+			args = append(args, "--appendonly", "no")
+		}
+	} else {
+		args = append(args, "--save", "")
+		args = append(args, "--appendonly", "no")
+	}
+	args = append(args, "--requirepass $(REDIS_PASSWORD)")
+
+	if cr.Spec.Redis.MaxMemory != "" {
+		args = append(args, "--maxmemory", cr.Spec.Redis.MaxMemory)
+	}
+	if cr.Spec.Redis.MaxMemoryPolicy != "" {
+		args = append(args, "--maxmemory-policy", cr.Spec.Redis.MaxMemoryPolicy)
+	}
+
+	if useTLS {
+		args = append(args, "--tls-port", "6379")
+		args = append(args, "--port", "0")
+
+		args = append(args, "--tls-cert-file", "/app/config/redis/tls/tls.crt")
+		args = append(args, "--tls-key-file", "/app/config/redis/tls/tls.key")
+		args = append(args, "--tls-auth-clients", "no")
+	}
+
+	return args
+}
+
+// getPodFSGroup returns the FSGroup of the given pod, or nil if it is not set.
+func getPodFSGroup(spec *corev1.PodSpec) *int64 {
+	if spec.SecurityContext == nil {
+		return nil
+	}
+	return spec.SecurityContext.FSGroup
+}
+
+// getRedisDataVolume returns the data volume of the given Redis Deployment and its mount in the redis container, or
+// nil if they are not present.
+func getRedisDataVolume(deploy *appsv1.Deployment) (*corev1.Volume, *corev1.VolumeMount) {
+	var volume *corev1.Volume
+	var mount *corev1.VolumeMount
+	for i := range deploy.Spec.Template.Spec.Volumes {
+		if deploy.Spec.Template.Spec.Volumes[i].Name == "data" {
+			volume = &deploy.Spec.Template.Spec.Volumes[i]
+		}
+	}
+	if len(deploy.Spec.Template.Spec.Containers) > 0 {
+		for i := range deploy.Spec.Template.Spec.Containers[0].VolumeMounts {
+			if deploy.Spec.Template.Spec.Containers[0].VolumeMounts[i].Name == "data" {
+				mount = &deploy.Spec.Template.Spec.Containers[0].VolumeMounts[i]
+			}
+		}
+	}
+	return volume, mount
+}
+
+// setRedisDataVolume replaces the data volume of the given Redis Deployment and its mount in the redis container
+// with the given ones, removing them if nil. Other volumes and mounts are kept.
+func setRedisDataVolume(deploy *appsv1.Deployment, volume *corev1.Volume, mount *corev1.VolumeMount) {
+	volumes := []corev1.Volume{}
+	for _, v := range deploy.Spec.Template.Spec.Volumes {
+		if v.Name != "data" {
+			volumes = append(volumes, v)
+		}
+	}
+	if volume != nil {
+		volumes = append(volumes, *volume)
+	}
+	deploy.Spec.Template.Spec.Volumes = volumes
+
+	mounts := []corev1.VolumeMount{}
+	for _, m := range deploy.Spec.Template.Spec.Containers[0].VolumeMounts {
+		if m.Name != "data" {
+			mounts = append(mounts, m)
+		}
+	}
+	if mount != nil {
+		mounts = append(mounts, *mount)
+	}
+	deploy.Spec.Template.Spec.Containers[0].VolumeMounts = mounts
+}
+
+// reconcileRedisPersistentVolumeClaim will ensure that the PersistentVolumeClaim holding the non HA Redis data is
+// present when persistence is enabled. When persistence is disabled, the claim is orphaned rather than deleted, so
+// that its data is kept until it is deleted explicitly, and it is adopted again when persistence is re-enabled. The
+// claim is only ever grown, as shrinking a PersistentVolumeClaim is rejected by the API server.
+func (r *ReconcileArgoCD) reconcileRedisPersistentVolumeClaim(cr *argoproj.ArgoCD) error {
+	persistence := getRedisPersistence(cr)
+	pvc := argoutil.NewPersistentVolumeClaimWithName(getRedisPersistentVolumeClaimName(cr), cr.ObjectMeta)
+
+	if argoutil.IsObjectFound(r.Client, cr.Namespace, pvc.Name, pvc) {
+		if persistence == nil {
+			if !metav1.IsControlledBy(pvc, cr) {
+				return nil // PersistentVolumeClaim already orphaned, move along...
+			}
+			log.Info(fmt.Sprintf("Redis persistence is disabled. Orphaning PersistentVolumeClaim %s, delete it to remove the redis data.", pvc.Name))
+			if err := controllerutil.RemoveOwnerReference(cr, pvc, r.Scheme); err != nil {
+				return err
+			}
+			return r.Client.Update(context.TODO(), pvc)
+		}
+
+		changed := false
+		if !metav1.IsControlledBy(pvc, cr) {
+			if err := controllerutil.SetControllerReference(cr, pvc, r.Scheme); err != nil {
+				return err
+			}
+			changed = true
+		}
+		spec := getRedisPersistentVolumeClaimSpec(persistence)
+		desired := spec.Resources.Requests.Storage()
+		if pvc.Spec.Resources.Requests.Storage().Cmp(*desired) < 0 {
+			if pvc.Spec.Resources.Requests == nil {
+				pvc.Spec.Resources.Requests = corev1.ResourceList{}
+			}
+			pvc.Spec.Resources.Requests[corev1.ResourceStorage] = *desired
+			changed = true
+		}
+		if changed {
+			return r.Client.Update(context.TODO(), pvc)
+		}
+		return nil // PersistentVolumeClaim found with nothing to do, move along...
+	}
+
+	if persistence == nil {
+		return nil
+	}
+
+	pvc.Spec = getRedisPersistentVolumeClaimSpec(persistence)
+	if err := controllerutil.SetControllerReference(cr, pvc, r.Scheme); err != nil {
+		return err
+	}
+	return r.Client.Create(context.TODO(), pvc)
+}
@@ -0,0 +1,217 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"bufio"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+const (
+	// Name of the master group monitored by the Redis HA sentinels.
+	redisHAMasterGroup = "argocd"
+
+	// Annotation of the Redis HA StatefulSet recording that the sentinels must be reset once the removed servers
+	// are gone, so that they forget the sentinels and replicas of those servers.
+	redisHASentinelResetAnnotation = "argocd.argoproj.io/redis-ha-sentinel-reset"
+
+	// Timeout of the commands sent to the Redis HA sentinels.
+	redisSentinelTimeout = 5 * time.Second
+)
+
+// redisSentinelCommand sends the given command to the Redis sentinel at the given address, over TLS if a TLS
+// config is given, and returns the reply.
+var redisSentinelCommand = sendRedisSentinelCommand
+
+// sendRedisSentinelCommand sends the given command to the Redis sentinel at the given address using the Redis
+// serialization protocol, and returns the decoded reply.
+func sendRedisSentinelCommand(addr string, tlsConfig *tls.Config, args ...string) (interface{}, error) {
+	dialer := &net.Dialer{Timeout: redisSentinelTimeout}
+	var conn net.Conn
+	var err error
+	if tlsConfig != nil {
+		conn, err = tls.DialWithDialer(dialer, "tcp", addr, tlsConfig)
+	} else {
+		conn, err = dialer.Dial("tcp", addr)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	if err := conn.SetDeadline(time.Now().Add(redisSentinelTimeout)); err != nil {
+		return nil, err
+	}
+
+	var cmd strings.Builder
+	fmt.Fprintf(&cmd, "*%d\r\n", len(args))
+	for _, arg := range args {
+		fmt.Fprintf(&cmd, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+	if _, err := io.WriteString(conn, cmd.String()); err != nil {
+		return nil, err
+	}
+	return readRedisReply(bufio.NewReader(conn))
+}
+
+// readRedisReply decodes a reply of the Redis serialization protocol into a string, an integer, nil or a list of
+// those. Error replies are returned as errors.
+func readRedisReply(r *bufio.Reader) (interface{}, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	line = strings.TrimSuffix(line, "\r\n")
+	if line == "" {
+		return nil, fmt.Errorf("empty redis reply")
+	}
+
+	switch line[0] {
+	case '+':
+		return line[1:], nil
+	case '-':
+		return nil, fmt.Errorf("redis sentinel error: %s", line[1:])
+	case ':':
+		return strconv.ParseInt(line[1:], 10, 64)
+	case '$':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil || n < 0 {
+			return nil, err
+		}
+		buf := make([]byte, n+2)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, err
+		}
+		return string(buf[:n]), nil
+	case '*':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil || n < 0 {
+			return nil, err
+		}
+		items := make([]interface{}, 0, n)
+		for i := 0; i < n; i++ {
+			item, err := readRedisReply(r)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		return items, nil
+	}
+	return nil, fmt.Errorf("unexpected redis reply %q", line)
+}
+
+// getRedisHASentinelAddress returns the address of the sentinel of the given Redis HA server.
+func getRedisHASentinelAddress(cr *argoproj.ArgoCD, index int32) string {
+	return fmt.Sprintf("%s.%s.svc.cluster.local:%d", nameWithSuffix(fmt.Sprintf("redis-ha-announce-%d", index), cr),
+		cr.Namespace, common.ArgoCDDefaultRedisSentinelPort)
+}
+
+// getRedisHASentinelTLSConfig returns the TLS config used to connect to the Redis HA sentinels, or nil if Redis
+// does not use TLS.
+func (r *ReconcileArgoCD) getRedisHASentinelTLSConfig(cr *argoproj.ArgoCD) (*tls.Config, error) {
+	if !r.redisShouldUseTLS(cr) {
+		return nil, nil
+	}
+
+	secret := argoutil.NewSecretWithName(cr, common.ArgoCDRedisServerTLSSecretName)
+	if err := argoutil.FetchObject(r.Client, cr.Namespace, secret.Name, secret); err != nil {
+		return nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(secret.Data[corev1.TLSCertKey]) {
+		return nil, fmt.Errorf("unable to load the certificate of secret %s", secret.Name)
+	}
+	return &tls.Config{RootCAs: pool, InsecureSkipVerify: isRedisTLSVerificationDisabled(cr)}, nil
+}
+
+// prepareRedisHAScaleDown returns whether the Redis HA master is one of the servers that are kept when scaling down
+// to the given number of replicas. Otherwise a failover is requested from the sentinels, and false is returned
+// until the master has moved to one of the kept servers.
+func (r *ReconcileArgoCD) prepareRedisHAScaleDown(cr *argoproj.ArgoCD, replicas int32) (bool, error) {
+	tlsConfig, err := r.getRedisHASentinelTLSConfig(cr)
+	if err != nil {
+		return false, err
+	}
+
+	// the servers announce themselves with the cluster IP of their announce Service
+	kept := make(map[string]bool)
+	for i := int32(0); i < replicas; i++ {
+		svc := newServiceWithSuffix(fmt.Sprintf("redis-ha-announce-%d", i), "redis", cr)
+		if argoutil.IsObjectFound(r.Client, cr.Namespace, svc.Name, svc) && svc.Spec.ClusterIP != "" {
+			kept[svc.Spec.ClusterIP] = true
+		}
+	}
+
+	var lastErr error
+	for i := int32(0); i < replicas; i++ {
+		addr := getRedisHASentinelAddress(cr, i)
+		reply, err := redisSentinelCommand(addr, tlsConfig, "SENTINEL", "GET-MASTER-ADDR-BY-NAME", redisHAMasterGroup)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		master, ok := reply.([]interface{})
+		if !ok || len(master) == 0 {
+			return false, fmt.Errorf("redis HA sentinel %s did not return a master for %s", addr, redisHAMasterGroup)
+		}
+		if kept[fmt.Sprint(master[0])] {
+			return true, nil
+		}
+
+		log.Info(fmt.Sprintf("redis HA master %v is removed by the scale down, requesting a failover", master[0]))
+		_, err = redisSentinelCommand(addr, tlsConfig, "SENTINEL", "FAILOVER", redisHAMasterGroup)
+		return false, err
+	}
+	return false, fmt.Errorf("unable to reach any redis HA sentinel: %v", lastErr)
+}
+
+// isRedisHAScaleDownComplete returns whether the pods removed by a scale down of the given Redis HA StatefulSet are
+// gone, and the remaining pods are ready.
+func isRedisHAScaleDownComplete(ss *appsv1.StatefulSet) bool {
+	return ss.Spec.Replicas != nil && ss.Status.ObservedGeneration == ss.Generation &&
+		ss.Status.Replicas == *ss.Spec.Replicas && ss.Status.ReadyReplicas == *ss.Spec.Replicas
+}
+
+// resetRedisHASentinels resets the sentinels of the given number of Redis HA servers one at a time, so that they
+// forget the sentinels and replicas of removed servers.
+func (r *ReconcileArgoCD) resetRedisHASentinels(cr *argoproj.ArgoCD, replicas int32) error {
+	tlsConfig, err := r.getRedisHASentinelTLSConfig(cr)
+	if err != nil {
+		return err
+	}
+	for i := int32(0); i < replicas; i++ {
+		addr := getRedisHASentinelAddress(cr, i)
+		if _, err := redisSentinelCommand(addr, tlsConfig, "SENTINEL", "RESET", redisHAMasterGroup); err != nil {
+			return fmt.Errorf("failed to reset redis HA sentinel %s: %w", addr, err)
+		}
+	}
+	log.Info(fmt.Sprintf("reset %d redis HA sentinels after scale down", replicas))
+	return nil
+}
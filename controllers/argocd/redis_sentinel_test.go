@@ -0,0 +1,66 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"bufio"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSendRedisSentinelCommand(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer listener.Close()
+
+	replies := []string{
+		"*2\r\n$8\r\n10.0.0.1\r\n$4\r\n6379\r\n",
+		"+OK\r\n",
+		":2\r\n",
+		"-ERR No such master with that name\r\n",
+	}
+	received := make(chan interface{}, len(replies))
+	go func() {
+		for _, reply := range replies {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			cmd, _ := readRedisReply(bufio.NewReader(conn))
+			received <- cmd
+			_, _ = conn.Write([]byte(reply))
+			conn.Close()
+		}
+	}()
+
+	addr := listener.Addr().String()
+	reply, err := sendRedisSentinelCommand(addr, nil, "SENTINEL", "GET-MASTER-ADDR-BY-NAME", "argocd")
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{"10.0.0.1", "6379"}, reply)
+	assert.Equal(t, []interface{}{"SENTINEL", "GET-MASTER-ADDR-BY-NAME", "argocd"}, <-received)
+
+	reply, err = sendRedisSentinelCommand(addr, nil, "SENTINEL", "FAILOVER", "argocd")
+	assert.NoError(t, err)
+	assert.Equal(t, "OK", reply)
+
+	reply, err = sendRedisSentinelCommand(addr, nil, "SENTINEL", "RESET", "argocd")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), reply)
+
+	_, err = sendRedisSentinelCommand(addr, nil, "SENTINEL", "RESET", "other")
+	assert.EqualError(t, err, "redis sentinel error: ERR No such master with that name")
+}
@@ -0,0 +1,618 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+func secretKeySelector(name, key string) *corev1.SecretKeySelector {
+	return &corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: name},
+		Key:                  key,
+	}
+}
+
+func makeTestRedisExternal() *argoproj.ArgoCDRedisExternalSpec {
+	db := int32(2)
+	return &argoproj.ArgoCDRedisExternalSpec{
+		Address:     "redis.example.com:6380",
+		DB:          &db,
+		UsernameRef: secretKeySelector("redis-credentials", "username"),
+		PasswordRef: secretKeySelector("redis-credentials", "password"),
+		TLS: &argoproj.ArgoCDRedisExternalTLSSpec{
+			CABundleRef: secretKeySelector("redis-ca", "ca.pem"),
+		},
+	}
+}
+
+func TestValidateRedisExternal(t *testing.T) {
+	tests := []struct {
+		name     string
+		external *argoproj.ArgoCDRedisExternalSpec
+		wantErr  string
+	}{
+		{
+			name: "no external redis",
+		},
+		{
+			name:     "address",
+			external: &argoproj.ArgoCDRedisExternalSpec{Address: "redis:6379"},
+		},
+		{
+			name:     "missing address",
+			external: &argoproj.ArgoCDRedisExternalSpec{},
+			wantErr:  "redis external address must not be empty when sentinel is not set",
+		},
+		{
+			name: "sentinel",
+			external: &argoproj.ArgoCDRedisExternalSpec{
+				Sentinel: &argoproj.ArgoCDRedisSentinelSpec{MasterName: "mymaster", Addresses: []string{"sentinel:26379"}},
+			},
+		},
+		{
+			name: "sentinel without master name",
+			external: &argoproj.ArgoCDRedisExternalSpec{
+				Sentinel: &argoproj.ArgoCDRedisSentinelSpec{Addresses: []string{"sentinel:26379"}},
+			},
+			wantErr: "redis external sentinel masterName must not be empty",
+		},
+		{
+			name: "sentinel without addresses",
+			external: &argoproj.ArgoCDRedisExternalSpec{
+				Sentinel: &argoproj.ArgoCDRedisSentinelSpec{MasterName: "mymaster"},
+			},
+			wantErr: "redis external sentinel addresses must not be empty",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cr := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+				cr.Spec.Redis.External = test.external
+			})
+			err := validateRedisExternal(cr)
+			if test.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.wantErr)
+			}
+			// an incomplete external Redis server is skipped
+			assert.Equal(t, test.external != nil && test.wantErr == "", isRedisExternal(cr))
+		})
+	}
+}
+
+func TestGetRedisCommandArgs(t *testing.T) {
+	t.Run("local redis", func(t *testing.T) {
+		cr := makeTestArgoCD()
+		assert.Equal(t, []string{"--redis", "argocd-redis.argocd.svc.cluster.local:6379",
+			"--redis-use-tls", "--redis-ca-certificate", "/app/config/server/tls/redis/tls.crt"},
+			getRedisCommandArgs(cr, true, "/app/config/server/tls"))
+	})
+
+	t.Run("external redis", func(t *testing.T) {
+		cr := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+			cr.Spec.Redis.External = makeTestRedisExternal()
+		})
+		// TLS of the local Redis server is ignored for an external Redis server.
+		assert.Equal(t, []string{"--redis", "redis.example.com:6380", "--redis-db", "2",
+			"--redis-use-tls", "--redis-ca-certificate", "/app/config/server/tls/redis-external-ca/ca.crt"},
+			getRedisCommandArgs(cr, false, "/app/config/server/tls"))
+
+		cr.Spec.Redis.External.TLS.InsecureSkipVerify = true
+		assert.Equal(t, []string{"--redis", "redis.example.com:6380", "--redis-db", "2",
+			"--redis-use-tls", "--redis-insecure-skip-tls-verify"},
+			getRedisCommandArgs(cr, true, "/app/config/server/tls"))
+	})
+
+	t.Run("external redis with sentinel", func(t *testing.T) {
+		cr := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+			cr.Spec.Redis.External = &argoproj.ArgoCDRedisExternalSpec{
+				Sentinel: &argoproj.ArgoCDRedisSentinelSpec{
+					MasterName: "mymaster",
+					Addresses:  []string{"sentinel-0:26379", "sentinel-1:26379"},
+				},
+			}
+		})
+		assert.Equal(t, []string{"--sentinel", "sentinel-0:26379", "--sentinel", "sentinel-1:26379", "--sentinelmaster", "mymaster"},
+			getRedisCommandArgs(cr, true, "/app/config/server/tls"))
+	})
+}
+
+func TestReconcileArgoCD_RedisExternal(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Spec.Redis.External = makeTestRedisExternal()
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	wantEnv := []corev1.EnvVar{
+		{Name: "REDIS_PASSWORD", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: secretKeySelector("redis-credentials", "password")}},
+		{Name: "REDIS_USERNAME", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: secretKeySelector("redis-credentials", "username")}},
+	}
+	wantVolume := corev1.Volume{
+		Name: "redis-external-ca",
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: "redis-ca",
+				Items:      []corev1.KeyToPath{{Key: "ca.pem", Path: "ca.crt"}},
+			},
+		},
+	}
+
+	assert.NoError(t, r.reconcileRedisDeployment(a, false))
+	assert.Error(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-redis", Namespace: testNamespace}, &appsv1.Deployment{}))
+
+	assert.NoError(t, r.reconcileServerDeployment(a, false))
+	deployment := &appsv1.Deployment{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-server", Namespace: testNamespace}, deployment))
+	container := deployment.Spec.Template.Spec.Containers[0]
+	assert.Subset(t, container.Env, wantEnv)
+	assert.Subset(t, container.Command, []string{"--redis", "redis.example.com:6380", "--redis-db", "2",
+		"--redis-ca-certificate", "/app/config/server/tls/redis-external-ca/ca.crt"})
+	assert.Contains(t, container.VolumeMounts, corev1.VolumeMount{Name: "redis-external-ca", MountPath: "/app/config/server/tls/redis-external-ca", ReadOnly: true})
+	assert.Contains(t, deployment.Spec.Template.Spec.Volumes, wantVolume)
+
+	assert.NoError(t, r.reconcileRepoDeployment(a, false))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-server", Namespace: testNamespace}, deployment))
+	container = deployment.Spec.Template.Spec.Containers[0]
+	assert.Subset(t, container.Env, wantEnv)
+	assert.Subset(t, container.Command, []string{"--redis-ca-certificate", "/app/config/reposerver/tls/redis-external-ca/ca.crt"})
+	assert.Contains(t, deployment.Spec.Template.Spec.Volumes, wantVolume)
+
+	assert.NoError(t, r.reconcileApplicationControllerStatefulSet(a, false))
+	ss := &appsv1.StatefulSet{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-application-controller", Namespace: testNamespace}, ss))
+	container = ss.Spec.Template.Spec.Containers[0]
+	assert.Subset(t, container.Env, wantEnv)
+	assert.Subset(t, container.Command, []string{"--redis-ca-certificate", "/app/config/controller/tls/redis-external-ca/ca.crt"})
+	assert.Contains(t, ss.Spec.Template.Spec.Volumes, wantVolume)
+}
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
+
+func TestValidateRedisHA(t *testing.T) {
+	tests := []struct {
+		name         string
+		redis        *argoproj.ArgoCDHARedisSpec
+		wantErr      string
+		wantReplicas int32
+		wantQuorum   int32
+	}{
+		{
+			name:         "default topology",
+			wantReplicas: 3,
+			wantQuorum:   2,
+		},
+		{
+			name:         "five replicas",
+			redis:        &argoproj.ArgoCDHARedisSpec{Replicas: int32Ptr(5), Quorum: int32Ptr(3)},
+			wantReplicas: 5,
+			wantQuorum:   3,
+		},
+		{
+			name:         "too few replicas",
+			redis:        &argoproj.ArgoCDHARedisSpec{Replicas: int32Ptr(2)},
+			wantErr:      "redis HA replicas must be at least 3",
+			wantReplicas: 3,
+			wantQuorum:   2,
+		},
+		{
+			name:         "quorum greater than replicas",
+			redis:        &argoproj.ArgoCDHARedisSpec{Quorum: int32Ptr(4)},
+			wantErr:      "redis HA quorum 4 must be between 1 and the number of replicas 3",
+			wantReplicas: 3,
+			wantQuorum:   2,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cr := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+				cr.Spec.HA.Enabled = true
+				cr.Spec.HA.Redis = test.redis
+			})
+			err := validateRedisHA(cr)
+			if test.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.wantErr)
+			}
+			// the invalid settings are skipped
+			assert.Equal(t, test.wantReplicas, *getRedisHAReplicas(cr))
+			assert.Equal(t, test.wantQuorum, getRedisHAQuorum(cr))
+		})
+	}
+}
+
+func TestRedisHATopology(t *testing.T) {
+	t.Setenv("REDIS_CONFIG_PATH", "../../build/redis")
+
+	cr := makeTestArgoCD()
+	assert.Equal(t, int32(3), *getRedisHAReplicas(cr))
+	assert.Equal(t, int32(2), getRedisHAQuorum(cr))
+	assert.Empty(t, getRedisHATopologyChecksum(cr))
+	assert.Equal(t, "SENTINEL_ID_0", getRedisHASentinelIDEnv(cr)[0].Name)
+	assert.Equal(t, "3c0d9c0320bb34888c2df5757c718ce6ca992ce6", getRedisHASentinelIDEnv(cr)[0].Value)
+	assert.Contains(t, getRedisConf(cr, false), "save \"\"\n")
+
+	cr.Spec.HA.Redis = &argoproj.ArgoCDHARedisSpec{
+		Replicas:    int32Ptr(5),
+		Persistence: &argoproj.ArgoCDRedisPersistenceSpec{AppendOnly: true, Save: []string{"900 1"}},
+	}
+	assert.Equal(t, int32(3), getRedisHAQuorum(cr))
+	assert.NotEmpty(t, getRedisHATopologyChecksum(cr))
+
+	// scaling restarts HAProxy, but not the Redis HA servers
+	checksum, proxyChecksum := getRedisHATopologyChecksum(cr), getRedisHAProxyTopologyChecksum(cr, 5)
+	cr.Spec.HA.Redis.Replicas = int32Ptr(7)
+	cr.Spec.HA.Redis.Quorum = int32Ptr(3)
+	assert.Equal(t, checksum, getRedisHATopologyChecksum(cr))
+	assert.NotEqual(t, proxyChecksum, getRedisHAProxyTopologyChecksum(cr, 7))
+	cr.Spec.HA.Redis.Replicas = int32Ptr(5)
+	cr.Spec.HA.Redis.Quorum = nil
+
+	// the init script derives the sentinel IDs of additional servers, so that the pod template does not change
+	assert.Equal(t, getRedisHASentinelIDEnv(makeTestArgoCD()), getRedisHASentinelIDEnv(cr))
+	assert.Contains(t, getRedisInitScript(cr, false), `sha1sum`)
+
+	haproxyConfig := getRedisHAProxyConfig(cr, false, 5)
+	assert.Contains(t, haproxyConfig, "backend check_if_redis_is_master_4")
+	assert.Contains(t, haproxyConfig, "use-server R4 if { srv_is_up(R4) } { nbsrv(check_if_redis_is_master_4) ge 3 }")
+	assert.Equal(t, 5*5, strings.Count(haproxyConfig, ":26379 check inter 3s"))
+	assert.Contains(t, getRedisHAProxyScript(cr, 5), "REPLACE_ANNOUNCE4")
+	assert.Contains(t, getRedisInitScript(cr, false), "QUORUM=\"3\"")
+
+	redisConf := getRedisConf(cr, false)
+	assert.Contains(t, redisConf, "save 900 1\nappendonly yes\n")
+	assert.NotContains(t, redisConf, "save \"\"")
+}
+
+func TestReconcileArgoCD_RedisHATopology(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	t.Setenv("REDIS_CONFIG_PATH", "../../build/redis")
+	a := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Spec.HA.Enabled = true
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileRedisStatefulSet(a))
+	assert.NoError(t, r.reconcileRedisHAAnnounceServices(a))
+	ss := &appsv1.StatefulSet{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-redis-ha-server", Namespace: testNamespace}, ss))
+	assert.Equal(t, int32(3), *ss.Spec.Replicas)
+	assert.Empty(t, ss.Spec.VolumeClaimTemplates)
+	assert.NotContains(t, ss.Spec.Template.Annotations, redisHATopologyChecksumAnnotation)
+
+	// scale up with persistence
+	sentinelResources := corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("64Mi")},
+	}
+	storageClass := "fast"
+	a.Spec.HA.Redis = &argoproj.ArgoCDHARedisSpec{
+		Replicas:          int32Ptr(5),
+		ProxyReplicas:     int32Ptr(2),
+		SentinelResources: &sentinelResources,
+		Persistence:       &argoproj.ArgoCDRedisPersistenceSpec{StorageClassName: &storageClass},
+	}
+
+	// the StatefulSet is recreated when persistence changes
+	assert.NoError(t, r.reconcileRedisStatefulSet(a))
+	assert.Error(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-redis-ha-server", Namespace: testNamespace}, ss))
+	assert.NoError(t, r.reconcileRedisStatefulSet(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-redis-ha-server", Namespace: testNamespace}, ss))
+	assert.Equal(t, int32(5), *ss.Spec.Replicas)
+	assert.Equal(t, getRedisHATopologyChecksum(a), ss.Spec.Template.Annotations[redisHATopologyChecksumAnnotation])
+	assert.Len(t, ss.Spec.VolumeClaimTemplates, 1)
+	assert.Equal(t, "data", ss.Spec.VolumeClaimTemplates[0].Name)
+	assert.Equal(t, "1Gi", ss.Spec.VolumeClaimTemplates[0].Spec.Resources.Requests.Storage().String())
+	assert.Equal(t, &storageClass, ss.Spec.VolumeClaimTemplates[0].Spec.StorageClassName)
+	for _, volume := range ss.Spec.Template.Spec.Volumes {
+		assert.NotEqual(t, "data", volume.Name)
+	}
+	assert.Equal(t, sentinelResources, ss.Spec.Template.Spec.Containers[1].Resources)
+	assert.Len(t, ss.Spec.Template.Spec.InitContainers[0].Env, 4)
+
+	assert.NoError(t, r.reconcileRedisHAProxyDeployment(a))
+	deployment := &appsv1.Deployment{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-redis-ha-haproxy", Namespace: testNamespace}, deployment))
+	assert.Equal(t, int32(2), *deployment.Spec.Replicas)
+	assert.Equal(t, getRedisHAProxyTopologyChecksum(a, 5), deployment.Spec.Template.Annotations[redisHATopologyChecksumAnnotation])
+
+	assert.NoError(t, r.reconcileRedisHAAnnounceServices(a))
+	for i := 0; i < 5; i++ {
+		assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: fmt.Sprintf("argocd-redis-ha-announce-%d", i), Namespace: testNamespace}, &corev1.Service{}))
+	}
+
+	// the servers announce themselves with the cluster IP of their announce Service
+	for i := 0; i < 5; i++ {
+		svc := &corev1.Service{}
+		assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: fmt.Sprintf("argocd-redis-ha-announce-%d", i), Namespace: testNamespace}, svc))
+		svc.Spec.ClusterIP = fmt.Sprintf("10.0.0.%d", i)
+		assert.NoError(t, r.Client.Update(context.TODO(), svc))
+	}
+
+	master := "10.0.0.4"
+	commands := []string{}
+	redisSentinelCommand = func(addr string, tlsConfig *tls.Config, args ...string) (interface{}, error) {
+		commands = append(commands, strings.Join(append([]string{addr}, args...), " "))
+		switch args[1] {
+		case "GET-MASTER-ADDR-BY-NAME":
+			return []interface{}{master, "6379"}, nil
+		case "FAILOVER":
+			master = "10.0.0.1"
+		}
+		return "OK", nil
+	}
+	defer func() { redisSentinelCommand = sendRedisSentinelCommand }()
+
+	// scale down to the default topology, the master is failed over to a kept server first
+	a.Spec.HA.Redis.Replicas = nil
+	assert.EqualError(t, r.reconcileRedisStatefulSet(a), "waiting for the redis HA master to fail over before scaling down to 3 replicas")
+	assert.Equal(t, []string{
+		"argocd-redis-ha-announce-0.argocd.svc.cluster.local:26379 SENTINEL GET-MASTER-ADDR-BY-NAME argocd",
+		"argocd-redis-ha-announce-0.argocd.svc.cluster.local:26379 SENTINEL FAILOVER argocd",
+	}, commands)
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-redis-ha-server", Namespace: testNamespace}, ss))
+	assert.Equal(t, int32(5), *ss.Spec.Replicas)
+
+	// the removed servers stay announced and served by HAProxy until the failover is done
+	assert.NoError(t, r.reconcileRedisHAAnnounceServices(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-redis-ha-announce-4", Namespace: testNamespace}, &corev1.Service{}))
+	assert.NoError(t, r.reconcileRedisHAConfigMap(a, false))
+	cm := &corev1.ConfigMap{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDRedisHAConfigMapName, Namespace: testNamespace}, cm))
+	assert.Contains(t, cm.Data["haproxy.cfg"], "backend check_if_redis_is_master_4")
+
+	assert.NoError(t, r.reconcileRedisStatefulSet(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-redis-ha-server", Namespace: testNamespace}, ss))
+	assert.Equal(t, int32(3), *ss.Spec.Replicas)
+	assert.Equal(t, getRedisHATopologyChecksum(a), ss.Spec.Template.Annotations[redisHATopologyChecksumAnnotation])
+	assert.Len(t, ss.Spec.Template.Spec.InitContainers[0].Env, 4)
+	assert.Equal(t, "true", ss.Annotations[redisHASentinelResetAnnotation])
+
+	// the removed servers stay announced until their pods are gone
+	ss.Status = appsv1.StatefulSetStatus{ObservedGeneration: ss.Generation, Replicas: 5, ReadyReplicas: 3}
+	assert.NoError(t, r.Client.Status().Update(context.TODO(), ss))
+	assert.NoError(t, r.reconcileRedisHAAnnounceServices(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-redis-ha-announce-4", Namespace: testNamespace}, &corev1.Service{}))
+
+	// the remaining sentinels are reset once the removed servers are gone
+	commands = []string{}
+	assert.NoError(t, r.reconcileRedisStatefulSet(a))
+	assert.Empty(t, commands)
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-redis-ha-server", Namespace: testNamespace}, ss))
+	ss.Status = appsv1.StatefulSetStatus{ObservedGeneration: ss.Generation, Replicas: 3, ReadyReplicas: 3}
+	assert.NoError(t, r.Client.Status().Update(context.TODO(), ss))
+	assert.NoError(t, r.reconcileRedisStatefulSet(a))
+	assert.Equal(t, []string{
+		"argocd-redis-ha-announce-0.argocd.svc.cluster.local:26379 SENTINEL RESET argocd",
+		"argocd-redis-ha-announce-1.argocd.svc.cluster.local:26379 SENTINEL RESET argocd",
+		"argocd-redis-ha-announce-2.argocd.svc.cluster.local:26379 SENTINEL RESET argocd",
+	}, commands)
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-redis-ha-server", Namespace: testNamespace}, ss))
+	assert.NotContains(t, ss.Annotations, redisHASentinelResetAnnotation)
+
+	assert.NoError(t, r.reconcileRedisHAConfigMap(a, false))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDRedisHAConfigMapName, Namespace: testNamespace}, cm))
+	assert.NotContains(t, cm.Data["haproxy.cfg"], "check_if_redis_is_master_3")
+
+	assert.NoError(t, r.reconcileRedisHAAnnounceServices(a))
+	for i := 0; i < 5; i++ {
+		err := r.Client.Get(context.TODO(), types.NamespacedName{Name: fmt.Sprintf("argocd-redis-ha-announce-%d", i), Namespace: testNamespace}, &corev1.Service{})
+		if i < 3 {
+			assert.NoError(t, err)
+		} else {
+			assert.Error(t, err)
+		}
+	}
+}
+
+func TestGetArgoRedisArgs(t *testing.T) {
+	t.Setenv("REDIS_CONFIG_PATH", "../../build/redis")
+
+	cr := makeTestArgoCD()
+	assert.Equal(t, []string{"--save", "", "--appendonly", "no", "--requirepass $(REDIS_PASSWORD)"}, getArgoRedisArgs(cr, false))
+	redisConf := getRedisConf(cr, false)
+	assert.Contains(t, redisConf, "maxmemory 0\nmaxmemory-policy volatile-lru\n")
+
+	cr.Spec.Redis.MaxMemory = "512mb"
+	cr.Spec.Redis.MaxMemoryPolicy = "allkeys-lru"
+	cr.Spec.Redis.Persistence = &argoproj.ArgoCDRedisPersistenceSpec{}
+	assert.Equal(t, []string{
+		"--dir", "/data", "--save", "3600 1 300 100 60 10000", "--appendonly", "no",
+		"--requirepass $(REDIS_PASSWORD)", "--maxmemory", "512mb", "--maxmemory-policy", "allkeys-lru",
+	}, getArgoRedisArgs(cr, false))
+	assert.Contains(t, getRedisConf(cr, false), "maxmemory 512mb\nmaxmemory-policy allkeys-lru\n")
+
+	cr.Spec.Redis.Persistence.AppendOnly = true
+	assert.Subset(t, getArgoRedisArgs(cr, false), []string{"--save", "", "--appendonly", "yes"})
+
+	// the persistence of the single Redis server is ignored in HA mode
+	cr.Spec.HA.Enabled = true
+	assert.Nil(t, getRedisPersistence(cr))
+}
+
+func TestReconcileArgoCD_RedisPersistence(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Spec.Redis.Persistence = &argoproj.ArgoCDRedisPersistenceSpec{}
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileRedisPersistentVolumeClaim(a))
+	pvc := &corev1.PersistentVolumeClaim{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-redis-data", Namespace: testNamespace}, pvc))
+	assert.Equal(t, "1Gi", pvc.Spec.Resources.Requests.Storage().String())
+	assert.Equal(t, []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}, pvc.Spec.AccessModes)
+
+	assert.NoError(t, r.reconcileRedisDeployment(a, false))
+	deployment := &appsv1.Deployment{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-redis", Namespace: testNamespace}, deployment))
+	assert.Equal(t, appsv1.RecreateDeploymentStrategyType, deployment.Spec.Strategy.Type)
+	assert.Equal(t, int64(999), *deployment.Spec.Template.Spec.SecurityContext.FSGroup)
+	assert.Contains(t, deployment.Spec.Template.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{Name: "data", MountPath: "/data"})
+	assert.Contains(t, deployment.Spec.Template.Spec.Volumes, corev1.Volume{
+		Name: "data",
+		VolumeSource: corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "argocd-redis-data"},
+		},
+	})
+
+	// fields defaulted by the API server do not cause an update
+	deployment.Spec.Template.Spec.Volumes[0].Secret.DefaultMode = int32Ptr(420)
+	assert.NoError(t, r.Client.Update(context.TODO(), deployment))
+	resourceVersion := deployment.ResourceVersion
+	assert.NoError(t, r.reconcileRedisDeployment(a, false))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-redis", Namespace: testNamespace}, deployment))
+	assert.Equal(t, resourceVersion, deployment.ResourceVersion)
+
+	// the claim is grown but never shrunk
+	size := resource.MustParse("5Gi")
+	a.Spec.Redis.Persistence.Size = &size
+	assert.NoError(t, r.reconcileRedisPersistentVolumeClaim(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-redis-data", Namespace: testNamespace}, pvc))
+	assert.Equal(t, "5Gi", pvc.Spec.Resources.Requests.Storage().String())
+
+	size = resource.MustParse("2Gi")
+	assert.NoError(t, r.reconcileRedisPersistentVolumeClaim(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-redis-data", Namespace: testNamespace}, pvc))
+	assert.Equal(t, "5Gi", pvc.Spec.Resources.Requests.Storage().String())
+
+	// disabling persistence orphans the claim, keeping its data, and removes the data volume
+	a.Spec.Redis.Persistence = nil
+	assert.NoError(t, r.reconcileRedisPersistentVolumeClaim(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-redis-data", Namespace: testNamespace}, pvc))
+	assert.Empty(t, pvc.OwnerReferences)
+	assert.Equal(t, "5Gi", pvc.Spec.Resources.Requests.Storage().String())
+
+	assert.NoError(t, r.reconcileRedisDeployment(a, false))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-redis", Namespace: testNamespace}, deployment))
+	assert.Equal(t, appsv1.DeploymentStrategy{}, deployment.Spec.Strategy)
+	assert.Nil(t, getPodFSGroup(&deployment.Spec.Template.Spec))
+	assert.Len(t, deployment.Spec.Template.Spec.Volumes, 1)
+
+	// re-enabling persistence adopts the orphaned claim
+	a.Spec.Redis.Persistence = &argoproj.ArgoCDRedisPersistenceSpec{}
+	assert.NoError(t, r.reconcileRedisPersistentVolumeClaim(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-redis-data", Namespace: testNamespace}, pvc))
+	assert.True(t, metav1.IsControlledBy(pvc, a))
+	assert.Equal(t, "5Gi", pvc.Spec.Resources.Requests.Storage().String())
+}
+
+func TestReconcileArgoCD_RedisExporter(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Spec.Redis.Exporter = &argoproj.ArgoCDRedisExporterSpec{Enabled: true}
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileRedisDeployment(a, true))
+	deployment := &appsv1.Deployment{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-redis", Namespace: testNamespace}, deployment))
+	assert.Len(t, deployment.Spec.Template.Spec.Containers, 2)
+	exporter := deployment.Spec.Template.Spec.Containers[1]
+	assert.Equal(t, "redis-exporter", exporter.Name)
+	assert.Equal(t, "quay.io/oliver006/redis_exporter:v1.62.0", exporter.Image)
+	assert.Equal(t, corev1.EnvVar{Name: "REDIS_ADDR", Value: "rediss://localhost:6379"}, exporter.Env[0])
+	assert.Contains(t, exporter.Env, corev1.EnvVar{Name: "REDIS_EXPORTER_SKIP_TLS_VERIFICATION", Value: "true"})
+
+	// fields defaulted by the API server do not cause an update
+	deployment.Spec.Template.Spec.Containers[1].TerminationMessagePath = corev1.TerminationMessagePathDefault
+	deployment.Spec.Template.Spec.Containers[1].TerminationMessagePolicy = corev1.TerminationMessageReadFile
+	deployment.Spec.Template.Spec.Containers[1].Ports[0].Protocol = corev1.ProtocolTCP
+	assert.NoError(t, r.Client.Update(context.TODO(), deployment))
+	resourceVersion := deployment.ResourceVersion
+	assert.NoError(t, r.reconcileRedisDeployment(a, true))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-redis", Namespace: testNamespace}, deployment))
+	assert.Equal(t, resourceVersion, deployment.ResourceVersion)
+
+	// changes to the exporter are applied to the existing sidecar
+	a.Spec.Redis.Exporter.Version = "v1.63.0"
+	assert.NoError(t, r.reconcileRedisDeployment(a, true))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-redis", Namespace: testNamespace}, deployment))
+	assert.Equal(t, "quay.io/oliver006/redis_exporter:v1.63.0", deployment.Spec.Template.Spec.Containers[1].Image)
+
+	assert.NoError(t, r.reconcileRedisMetricsService(a))
+	svc := &corev1.Service{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-redis-metrics", Namespace: testNamespace}, svc))
+	assert.Equal(t, int32(9121), svc.Spec.Ports[0].Port)
+
+	// the existing metrics Service is reverted to the desired ports
+	svc.Spec.Ports[0].Port = 8080
+	assert.NoError(t, r.Client.Update(context.TODO(), svc))
+	assert.NoError(t, r.reconcileRedisMetricsService(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-redis-metrics", Namespace: testNamespace}, svc))
+	assert.Equal(t, int32(9121), svc.Spec.Ports[0].Port)
+
+	// the exporter is not run next to a Redis server that is not managed by the operator
+	remote := "redis.example.com:6379"
+	a.Spec.Redis.Remote = &remote
+	assert.False(t, isRedisExporterEnabled(a))
+	a.Spec.Redis.Remote = nil
+
+	// disabling the exporter removes the sidecar and the metrics Service
+	a.Spec.Redis.Exporter.Enabled = false
+	assert.NoError(t, r.reconcileRedisDeployment(a, true))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-redis", Namespace: testNamespace}, deployment))
+	assert.Len(t, deployment.Spec.Template.Spec.Containers, 1)
+
+	assert.NoError(t, r.reconcileRedisMetricsService(a))
+	assert.Error(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-redis-metrics", Namespace: testNamespace}, svc))
+}
@@ -0,0 +1,635 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	certmanagerv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+const (
+	// Name suffix of the Secret holding the server bundle issued for the remote repo server pool.
+	repoServerRemoteTLSSuffix = "repo-server-remote-tls"
+
+	// Name suffix of the Secret holding the client certificate issued for the remote repo server pool.
+	repoServerRemoteClientTLSSuffix = "repo-server-remote-client-tls"
+
+	// Certificates issued for the remote repo server pool are issued again when they expire within this duration.
+	repoServerRemoteCertificateRenewBefore = 30 * 24 * time.Hour
+
+	// Name suffix of the ConfigMap holding the configuration of the Config Management Plugins.
+	repoServerPluginsConfigMapSuffix = "cmp-cm"
+
+	// Annotation of the repo server pods holding the checksum of the plugin configurations, so that the plugin
+	// sidecars are restarted when their configuration changes.
+	repoServerPluginsChecksumAnnotation = "checksum/cmp-plugins"
+
+	// Name of the plugin configuration file read by argocd-cmp-server.
+	repoServerPluginConfigFileName = "plugin.yaml"
+
+	// Name of the volume holding the tool binaries copied into the repo server.
+	repoServerCustomToolsVolumeName = "custom-tools"
+
+	// Directory of the tool binaries copied into the repo server.
+	repoServerCustomToolsPath = "/custom-tools"
+
+	// Default paths of the tool binaries in the images they are copied from.
+	repoServerDefaultKustomizeImagePath = "/usr/local/bin/kustomize"
+	repoServerDefaultHelmImagePath      = "/usr/local/bin/helm"
+)
+
+// isRepoServerRemote returns whether the given ArgoCD uses a remote repo server.
+func isRepoServerRemote(cr *argoproj.ArgoCD) bool {
+	return cr.Spec.Repo.Remote != nil && *cr.Spec.Repo.Remote != ""
+}
+
+// isRepoServerRemoteTLS returns whether the TLS connection to the remote repo server is configured. A configuration
+// with neither issued certificates nor a CA bundle is skipped, as reported by validateRepoServerRemoteTLS.
+func isRepoServerRemoteTLS(cr *argoproj.ArgoCD) bool {
+	return isRepoServerRemote(cr) && cr.Spec.Repo.RemoteTLS != nil &&
+		(cr.Spec.Repo.RemoteTLS.IssueCertificates || cr.Spec.Repo.RemoteTLS.CABundleRef != nil)
+}
+
+// isRepoServerRemoteTLSIssued returns whether the certificates of the remote repo server are issued by the operator.
+func isRepoServerRemoteTLSIssued(cr *argoproj.ArgoCD) bool {
+	return isRepoServerRemoteTLS(cr) && cr.Spec.Repo.RemoteTLS.IssueCertificates
+}
+
+// validateRepoServerRemoteTLS will return an error if the remote repo server TLS configuration is inconsistent. When
+// certificates are issued, the CA bundle and client certificate set alongside them are skipped.
+func validateRepoServerRemoteTLS(cr *argoproj.ArgoCD) error {
+	remoteTLS := cr.Spec.Repo.RemoteTLS
+	if remoteTLS == nil {
+		return nil
+	}
+	if !isRepoServerRemote(cr) {
+		return errors.New("repo remoteTLS requires repo remote to be set")
+	}
+	if remoteTLS.IssueCertificates {
+		if remoteTLS.CABundleRef != nil || remoteTLS.ClientCertificateSecret != "" {
+			return errors.New("repo remoteTLS caBundleRef and clientCertificateSecret cannot be set when issueCertificates is enabled")
+		}
+		return nil
+	}
+	if remoteTLS.CABundleRef == nil {
+		return errors.New("repo remoteTLS requires either issueCertificates or caBundleRef to be set")
+	}
+	return nil
+}
+
+// getRepoServerRemoteDNSNames returns the DNS names of the server certificate issued for the remote repo server.
+func getRepoServerRemoteDNSNames(cr *argoproj.ArgoCD) []string {
+	if len(cr.Spec.Repo.RemoteTLS.ServerDNSNames) > 0 {
+		return cr.Spec.Repo.RemoteTLS.ServerDNSNames
+	}
+	host, _, err := net.SplitHostPort(*cr.Spec.Repo.Remote)
+	if err != nil {
+		host = *cr.Spec.Repo.Remote
+	}
+	return []string{host}
+}
+
+// getRepoServerTLSSecretName returns the name of the Secret holding the TLS configuration used by the Argo CD
+// components to connect to the repo server.
+func getRepoServerTLSSecretName(cr *argoproj.ArgoCD) string {
+	if isRepoServerRemoteTLSIssued(cr) {
+		return nameWithSuffix(repoServerRemoteClientTLSSuffix, cr)
+	}
+	if isRepoServerRemoteTLS(cr) && cr.Spec.Repo.RemoteTLS.ClientCertificateSecret != "" {
+		return cr.Spec.Repo.RemoteTLS.ClientCertificateSecret
+	}
+	return common.ArgoCDRepoServerTLSSecretName
+}
+
+// getRepoServerTLSVolumeSource returns the source of the argocd-repo-server-tls volume mounted by the Argo CD
+// components connecting to the repo server. For a remote repo server with an external CA, the CA bundle is projected
+// as ca.crt next to the client certificate.
+func getRepoServerTLSVolumeSource(cr *argoproj.ArgoCD) corev1.VolumeSource {
+	if !isRepoServerRemoteTLS(cr) || isRepoServerRemoteTLSIssued(cr) {
+		return corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: getRepoServerTLSSecretName(cr),
+				Optional:   boolPtr(true),
+			},
+		}
+	}
+
+	remoteTLS := cr.Spec.Repo.RemoteTLS
+	sources := []corev1.VolumeProjection{{
+		Secret: &corev1.SecretProjection{
+			LocalObjectReference: remoteTLS.CABundleRef.LocalObjectReference,
+			Items: []corev1.KeyToPath{{
+				Key:  remoteTLS.CABundleRef.Key,
+				Path: corev1.ServiceAccountRootCAKey,
+			}},
+		},
+	}}
+	if remoteTLS.ClientCertificateSecret != "" {
+		sources = append(sources, corev1.VolumeProjection{
+			Secret: &corev1.SecretProjection{
+				LocalObjectReference: corev1.LocalObjectReference{Name: remoteTLS.ClientCertificateSecret},
+				Items: []corev1.KeyToPath{
+					{Key: corev1.TLSCertKey, Path: corev1.TLSCertKey},
+					{Key: corev1.TLSPrivateKeyKey, Path: corev1.TLSPrivateKeyKey},
+				},
+			},
+		})
+	}
+	return corev1.VolumeSource{
+		Projected: &corev1.ProjectedVolumeSource{
+			Sources: sources,
+		},
+	}
+}
+
+// newRepoServerRemoteCertificateSecret creates a new Secret using the given name suffix holding a certificate for the
+// remote repo server, signed by the operator CA, along with the CA certificate.
+func newRepoServerRemoteCertificateSecret(suffix string, dnsNames []string, caCert *x509.Certificate, caKey *rsa.PrivateKey, cr *argoproj.ArgoCD) (*corev1.Secret, error) {
+	secret := argoutil.NewTLSSecret(cr, suffix)
+
+	key, err := argoutil.NewPrivateKey()
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &certmanagerv1.CertificateSpec{
+		SecretName: secret.Name,
+		CommonName: secret.Name,
+		Subject: &certmanagerv1.X509Subject{
+			Organizations: []string{cr.ObjectMeta.Namespace},
+		},
+	}
+
+	cert, err := argoutil.NewSignedCertificate(cfg, dnsNames, key, caCert, caKey)
+	if err != nil {
+		return nil, err
+	}
+
+	secret.Data = map[string][]byte{
+		corev1.TLSCertKey:              argoutil.EncodeCertificatePEM(cert),
+		corev1.TLSPrivateKeyKey:        argoutil.EncodePrivateKeyPEM(key),
+		corev1.ServiceAccountRootCAKey: argoutil.EncodeCertificatePEM(caCert),
+	}
+
+	return secret, nil
+}
+
+// isRepoServerRemoteCertificateOutdated returns whether the certificate held by the given Secret must be issued again,
+// because it is missing, not signed by the given CA, expires soon, or issued for other DNS names.
+func isRepoServerRemoteCertificateOutdated(secret *corev1.Secret, dnsNames []string, caCert *x509.Certificate) bool {
+	cert, err := argoutil.ParsePEMEncodedCert(secret.Data[corev1.TLSCertKey])
+	if err != nil {
+		return true
+	}
+	if err := cert.CheckSignatureFrom(caCert); err != nil {
+		return true
+	}
+	if time.Now().Add(repoServerRemoteCertificateRenewBefore).After(cert.NotAfter) {
+		return true
+	}
+	actual := append([]string{}, cert.DNSNames...)
+	desired := append([]string{}, dnsNames...)
+	sort.Strings(actual)
+	sort.Strings(desired)
+	return !reflect.DeepEqual(actual, desired)
+}
+
+// reconcileRepoServerRemoteTLSSecrets will ensure that the server and client certificates of the remote repo server
+// are issued from the operator CA when requested, and removed otherwise.
+func (r *ReconcileArgoCD) reconcileRepoServerRemoteTLSSecrets(cr *argoproj.ArgoCD) error {
+	secrets := map[string][]string{
+		repoServerRemoteTLSSuffix:       nil,
+		repoServerRemoteClientTLSSuffix: {nameWithSuffix(repoServerRemoteClientTLSSuffix, cr)},
+	}
+
+	if !isRepoServerRemoteTLSIssued(cr) {
+		for suffix := range secrets {
+			secret := argoutil.NewTLSSecret(cr, suffix)
+			if argoutil.IsObjectFound(r.Client, cr.Namespace, secret.Name, secret) {
+				log.Info("Remote repo server certificates are not issued. Deleting existing secret " + secret.Name)
+				if err := r.Client.Delete(context.TODO(), secret); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+
+	secrets[repoServerRemoteTLSSuffix] = getRepoServerRemoteDNSNames(cr)
+
+	caSecret, err := argoutil.FetchSecret(r.Client, cr.ObjectMeta, nameWithSuffix("ca", cr))
+	if err != nil {
+		return err
+	}
+
+	caCert, err := argoutil.ParsePEMEncodedCert(caSecret.Data[corev1.TLSCertKey])
+	if err != nil {
+		return err
+	}
+
+	caKey, err := argoutil.ParsePEMEncodedPrivateKey(caSecret.Data[corev1.TLSPrivateKeyKey])
+	if err != nil {
+		return err
+	}
+
+	for _, suffix := range []string{repoServerRemoteTLSSuffix, repoServerRemoteClientTLSSuffix} {
+		dnsNames := secrets[suffix]
+		desired, err := newRepoServerRemoteCertificateSecret(suffix, dnsNames, caCert, caKey, cr)
+		if err != nil {
+			return err
+		}
+
+		existing := argoutil.NewTLSSecret(cr, suffix)
+		if argoutil.IsObjectFound(r.Client, cr.Namespace, existing.Name, existing) {
+			if !isRepoServerRemoteCertificateOutdated(existing, dnsNames, caCert) {
+				continue
+			}
+			existing.Data = desired.Data
+			if err := r.Client.Update(context.TODO(), existing); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := controllerutil.SetControllerReference(cr, desired, r.Scheme); err != nil {
+			return err
+		}
+		if err := r.Client.Create(context.TODO(), desired); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getRepoServerPluginsConfigMapName returns the name of the ConfigMap holding the configuration of the Config
+// Management Plugins.
+func getRepoServerPluginsConfigMapName(cr *argoproj.ArgoCD) string {
+	return nameWithSuffix(repoServerPluginsConfigMapSuffix, cr)
+}
+
+// getRepoServerPlugins returns the Config Management Plugins of the given ArgoCD, without the plugins that are defined
+// more than once or that do not set exactly one of configuration or configMapRef, along with the problems of the
+// skipped plugins.
+func getRepoServerPlugins(cr *argoproj.ArgoCD) ([]argoproj.ArgoCDRepoPluginSpec, []string) {
+	var plugins []argoproj.ArgoCDRepoPluginSpec
+	var problems []string
+	names := map[string]bool{}
+	for _, plugin := range cr.Spec.Repo.Plugins {
+		if names[plugin.Name] {
+			problems = append(problems, fmt.Sprintf("repo plugin %s is defined more than once", plugin.Name))
+			continue
+		}
+		names[plugin.Name] = true
+		if (plugin.Configuration == "") == (plugin.ConfigMapRef == nil) {
+			problems = append(problems, fmt.Sprintf("repo plugin %s requires exactly one of configuration or configMapRef to be set", plugin.Name))
+			continue
+		}
+		plugins = append(plugins, plugin)
+	}
+	return plugins, problems
+}
+
+// reportInvalidRepoServerPlugins will report the Config Management Plugins of the given ArgoCD that are skipped, with
+// a warning Event on the ArgoCD.
+func (r *ReconcileArgoCD) reportInvalidRepoServerPlugins(cr *argoproj.ArgoCD) {
+	_, problems := getRepoServerPlugins(cr)
+	for _, problem := range problems {
+		log.Info(fmt.Sprintf("skipping invalid %s for ArgoCD %s in namespace %s", problem, cr.Name, cr.Namespace))
+		r.recordEvent(cr, corev1.EventTypeWarning, "InvalidRepoServerConfiguration", "skipped invalid "+problem)
+	}
+}
+
+// getRepoServerPluginsConfigMapData returns the plugin.yaml files of the plugins configured inline, keyed by plugin.
+func getRepoServerPluginsConfigMapData(cr *argoproj.ArgoCD) map[string]string {
+	data := map[string]string{}
+	plugins, _ := getRepoServerPlugins(cr)
+	for _, plugin := range plugins {
+		if plugin.ConfigMapRef == nil {
+			data[plugin.Name+".yaml"] = plugin.Configuration
+		}
+	}
+	return data
+}
+
+// getRepoServerPluginsChecksum returns the checksum of the plugins configured inline, or an empty string if there are
+// none.
+func getRepoServerPluginsChecksum(cr *argoproj.ArgoCD) string {
+	data := getRepoServerPluginsConfigMapData(cr)
+	if len(data) == 0 {
+		return ""
+	}
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	sum := sha256.New()
+	for _, key := range keys {
+		sum.Write([]byte(key))
+		sum.Write([]byte(data[key]))
+	}
+	return fmt.Sprintf("%x", sum.Sum(nil))
+}
+
+// getRepoServerPluginContainers returns the sidecar containers running the Config Management Plugins with
+// argocd-cmp-server, copied to the var-files volume by the copyutil init container.
+func getRepoServerPluginContainers(cr *argoproj.ArgoCD) []corev1.Container {
+	containers := make([]corev1.Container, 0)
+	plugins, _ := getRepoServerPlugins(cr)
+	for _, plugin := range plugins {
+		resources := corev1.ResourceRequirements{}
+		if plugin.Resources != nil {
+			resources = *plugin.Resources
+		}
+		containers = append(containers, corev1.Container{
+			Name:            "cmp-" + plugin.Name,
+			Image:           plugin.Image,
+			Command:         []string{"/var/run/argocd/argocd-cmp-server"},
+			ImagePullPolicy: corev1.PullAlways,
+			Env:             argoutil.EnvMerge(plugin.Env, proxyEnvVars(), false),
+			Resources:       resources,
+			SecurityContext: &corev1.SecurityContext{
+				AllowPrivilegeEscalation: boolPtr(false),
+				Capabilities: &corev1.Capabilities{
+					Drop: []corev1.Capability{
+						"ALL",
+					},
+				},
+				RunAsNonRoot: boolPtr(true),
+				RunAsUser:    int64Ptr(999),
+				SeccompProfile: &corev1.SeccompProfile{
+					Type: "RuntimeDefault",
+				},
+			},
+			VolumeMounts: []corev1.VolumeMount{
+				{
+					Name:      "var-files",
+					MountPath: "/var/run/argocd",
+				},
+				{
+					Name:      "plugins",
+					MountPath: "/home/argocd/cmp-server/plugins",
+				},
+				{
+					Name:      fmt.Sprintf("cmp-%s-config", plugin.Name),
+					MountPath: "/home/argocd/cmp-server/config",
+				},
+				{
+					Name:      fmt.Sprintf("cmp-%s-tmp", plugin.Name),
+					MountPath: "/tmp",
+				},
+			},
+		})
+	}
+	return containers
+}
+
+// getRepoServerPluginVolumes returns the configuration and temporary volumes of the Config Management Plugins.
+func getRepoServerPluginVolumes(cr *argoproj.ArgoCD) []corev1.Volume {
+	volumes := make([]corev1.Volume, 0)
+	plugins, _ := getRepoServerPlugins(cr)
+	for _, plugin := range plugins {
+		configMap := &corev1.ConfigMapVolumeSource{
+			LocalObjectReference: corev1.LocalObjectReference{Name: getRepoServerPluginsConfigMapName(cr)},
+			Items:                []corev1.KeyToPath{{Key: plugin.Name + ".yaml", Path: repoServerPluginConfigFileName}},
+		}
+		if plugin.ConfigMapRef != nil {
+			configMap = &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: plugin.ConfigMapRef.LocalObjectReference,
+				Items:                []corev1.KeyToPath{{Key: plugin.ConfigMapRef.Key, Path: repoServerPluginConfigFileName}},
+			}
+		}
+		volumes = append(volumes, corev1.Volume{
+			Name: fmt.Sprintf("cmp-%s-config", plugin.Name),
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: configMap,
+			},
+		}, corev1.Volume{
+			Name: fmt.Sprintf("cmp-%s-tmp", plugin.Name),
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		})
+	}
+	return volumes
+}
+
+// reconcileRepoServerPluginsConfigMap will ensure that the ConfigMap holding the configuration of the plugins
+// configured inline is up to date, and removed when there are none.
+func (r *ReconcileArgoCD) reconcileRepoServerPluginsConfigMap(cr *argoproj.ArgoCD) error {
+	cm := newConfigMapWithName(getRepoServerPluginsConfigMapName(cr), cr)
+	data := getRepoServerPluginsConfigMapData(cr)
+
+	if argoutil.IsObjectFound(r.Client, cr.Namespace, cm.Name, cm) {
+		if len(data) == 0 || !cr.Spec.Repo.IsEnabled() {
+			log.Info("No repo plugin configured inline. Deleting existing plugin configuration.")
+			return r.Client.Delete(context.TODO(), cm)
+		}
+		if !reflect.DeepEqual(cm.Data, data) {
+			cm.Data = data
+			return r.Client.Update(context.TODO(), cm)
+		}
+		return nil // ConfigMap found with nothing to do, move along...
+	}
+
+	if len(data) == 0 || !cr.Spec.Repo.IsEnabled() {
+		return nil
+	}
+
+	cm.Data = data
+	if err := controllerutil.SetControllerReference(cr, cm, r.Scheme); err != nil {
+		return err
+	}
+	return r.Client.Create(context.TODO(), cm)
+}
+
+// getKustomizeVersionToolName returns the name of the kustomize binary of the given version copied into the repo server.
+func getKustomizeVersionToolName(kv argoproj.KustomizeVersionSpec) string {
+	return "kustomize-" + strings.ToLower(strings.ReplaceAll(kv.Version, ".", "-"))
+}
+
+// getKustomizeVersionPath returns the path of the given kustomize version on the filesystem of the repo server.
+func getKustomizeVersionPath(kv argoproj.KustomizeVersionSpec) string {
+	if kv.Image == "" {
+		return kv.Path
+	}
+	return fmt.Sprintf("%s/%s", repoServerCustomToolsPath, getKustomizeVersionToolName(kv))
+}
+
+// kustomizeVersionPattern is the format of the versions of the kustomize binaries copied from an image, which are
+// used in the names of the binaries and their init containers.
+var kustomizeVersionPattern = regexp.MustCompile(`^v[0-9]+\.[0-9]+\.[0-9]+$`)
+
+// getKustomizeVersions returns the kustomize versions of the given ArgoCD, without the versions copied from an image
+// that are not in the format vX.Y.Z, are defined more than once, or also set a path, along with the problems of the
+// skipped versions. The versions that only set a path are returned as is.
+func getKustomizeVersions(cr *argoproj.ArgoCD) ([]argoproj.KustomizeVersionSpec, []string) {
+	var versions []argoproj.KustomizeVersionSpec
+	var problems []string
+	images := map[string]bool{}
+	for _, kv := range cr.Spec.KustomizeVersions {
+		if kv.Image == "" {
+			versions = append(versions, kv)
+			continue
+		}
+		if !kustomizeVersionPattern.MatchString(kv.Version) {
+			problems = append(problems, fmt.Sprintf("kustomize version %q copied from image %s must be in the format vX.Y.Z", kv.Version, kv.Image))
+			continue
+		}
+		if images[kv.Version] {
+			problems = append(problems, fmt.Sprintf("kustomize version %s copied from image %s is defined more than once", kv.Version, kv.Image))
+			continue
+		}
+		if kv.Path != "" {
+			problems = append(problems, fmt.Sprintf("kustomize version %s must not set both a path and an image", kv.Version))
+			continue
+		}
+		images[kv.Version] = true
+		versions = append(versions, kv)
+	}
+	return versions, problems
+}
+
+// reportInvalidKustomizeVersions will report the kustomize versions of the given ArgoCD that are skipped, with a
+// warning Event on the ArgoCD.
+func (r *ReconcileArgoCD) reportInvalidKustomizeVersions(cr *argoproj.ArgoCD) {
+	_, problems := getKustomizeVersions(cr)
+	for _, problem := range problems {
+		log.Info(fmt.Sprintf("skipping invalid %s for ArgoCD %s in namespace %s", problem, cr.Name, cr.Namespace))
+		r.recordEvent(cr, corev1.EventTypeWarning, "InvalidKustomizeVersion", "skipped invalid "+problem)
+	}
+}
+
+// hasRepoServerCustomTools returns whether tool binaries are copied into the repo server for the given ArgoCD.
+func hasRepoServerCustomTools(cr *argoproj.ArgoCD) bool {
+	if cr.Spec.HelmVersion != nil {
+		return true
+	}
+	versions, _ := getKustomizeVersions(cr)
+	for _, kv := range versions {
+		if kv.Image != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// newRepoServerToolInitContainer returns an init container copying the binary at imagePath in the given image to
+// targetPath in the custom tools volume.
+func newRepoServerToolInitContainer(cr *argoproj.ArgoCD, name, image, imagePath, targetPath string) corev1.Container {
+	return corev1.Container{
+		Name:            name,
+		Image:           image,
+		Command:         []string{"cp", imagePath, targetPath},
+		ImagePullPolicy: corev1.PullAlways,
+		Resources:       getArgoRepoResources(cr),
+		Env:             proxyEnvVars(),
+		SecurityContext: &corev1.SecurityContext{
+			AllowPrivilegeEscalation: boolPtr(false),
+			Capabilities: &corev1.Capabilities{
+				Drop: []corev1.Capability{
+					"ALL",
+				},
+			},
+			RunAsNonRoot: boolPtr(true),
+			SeccompProfile: &corev1.SeccompProfile{
+				Type: "RuntimeDefault",
+			},
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      repoServerCustomToolsVolumeName,
+				MountPath: repoServerCustomToolsPath,
+			},
+		},
+	}
+}
+
+// getRepoServerToolInitContainers returns the init containers copying the kustomize and helm binaries from their
+// images into the repo server.
+func getRepoServerToolInitContainers(cr *argoproj.ArgoCD) []corev1.Container {
+	containers := make([]corev1.Container, 0)
+	versions, _ := getKustomizeVersions(cr)
+	for _, kv := range versions {
+		if kv.Image == "" {
+			continue
+		}
+		imagePath := kv.ImagePath
+		if imagePath == "" {
+			imagePath = repoServerDefaultKustomizeImagePath
+		}
+		targetPath := fmt.Sprintf("%s/%s", repoServerCustomToolsPath, getKustomizeVersionToolName(kv))
+		containers = append(containers, newRepoServerToolInitContainer(cr, getKustomizeVersionToolName(kv), kv.Image, imagePath, targetPath))
+	}
+	if helm := cr.Spec.HelmVersion; helm != nil {
+		imagePath := helm.ImagePath
+		if imagePath == "" {
+			imagePath = repoServerDefaultHelmImagePath
+		}
+		containers = append(containers, newRepoServerToolInitContainer(cr, "helm", helm.Image, imagePath, repoServerCustomToolsPath+"/helm"))
+	}
+	return containers
+}
+
+// getRepoServerToolVolumeMounts returns the mounts of the tool binaries copied into the repo server. The helm binary
+// is mounted over the bundled one, since the repo server looks it up in its PATH.
+func getRepoServerToolVolumeMounts(cr *argoproj.ArgoCD) []corev1.VolumeMount {
+	if !hasRepoServerCustomTools(cr) {
+		return nil
+	}
+	mounts := []corev1.VolumeMount{{
+		Name:      repoServerCustomToolsVolumeName,
+		MountPath: repoServerCustomToolsPath,
+	}}
+	if cr.Spec.HelmVersion != nil {
+		mounts = append(mounts, corev1.VolumeMount{
+			Name:      repoServerCustomToolsVolumeName,
+			MountPath: repoServerDefaultHelmImagePath,
+			SubPath:   "helm",
+		})
+	}
+	return mounts
+}
+
+// getRepoServerToolVolumes returns the volume holding the tool binaries copied into the repo server.
+func getRepoServerToolVolumes(cr *argoproj.ArgoCD) []corev1.Volume {
+	if !hasRepoServerCustomTools(cr) {
+		return nil
+	}
+	return []corev1.Volume{{
+		Name: repoServerCustomToolsVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		},
+	}}
+}
@@ -0,0 +1,440 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/x509"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+func makeTestRepoServerRemote(remoteTLS *argoproj.ArgoCDRepoRemoteTLSSpec) argoCDOpt {
+	return func(cr *argoproj.ArgoCD) {
+		remote := "repo.example.com:8081"
+		cr.Spec.Repo.Remote = &remote
+		cr.Spec.Repo.RemoteTLS = remoteTLS
+	}
+}
+
+func TestValidateRepoServerRemoteTLS(t *testing.T) {
+	tests := []struct {
+		name    string
+		cr      *argoproj.ArgoCD
+		wantErr bool
+	}{
+		{
+			name: "not configured",
+			cr:   makeTestArgoCD(),
+		},
+		{
+			name: "issued certificates",
+			cr:   makeTestArgoCD(makeTestRepoServerRemote(&argoproj.ArgoCDRepoRemoteTLSSpec{IssueCertificates: true})),
+		},
+		{
+			name: "external CA",
+			cr: makeTestArgoCD(makeTestRepoServerRemote(&argoproj.ArgoCDRepoRemoteTLSSpec{
+				CABundleRef:             secretKeySelector("repo-ca", "ca.pem"),
+				ClientCertificateSecret: "repo-client",
+			})),
+		},
+		{
+			name: "without remote",
+			cr: makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+				cr.Spec.Repo.RemoteTLS = &argoproj.ArgoCDRepoRemoteTLSSpec{IssueCertificates: true}
+			}),
+			wantErr: true,
+		},
+		{
+			name:    "without CA",
+			cr:      makeTestArgoCD(makeTestRepoServerRemote(&argoproj.ArgoCDRepoRemoteTLSSpec{})),
+			wantErr: true,
+		},
+		{
+			name: "issued certificates with external CA",
+			cr: makeTestArgoCD(makeTestRepoServerRemote(&argoproj.ArgoCDRepoRemoteTLSSpec{
+				IssueCertificates: true,
+				CABundleRef:       secretKeySelector("repo-ca", "ca.pem"),
+			})),
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := validateRepoServerRemoteTLS(test.cr)
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+
+	// a configuration without a CA is skipped, the default TLS Secret is used
+	cr := makeTestArgoCD(makeTestRepoServerRemote(&argoproj.ArgoCDRepoRemoteTLSSpec{}))
+	assert.False(t, isRepoServerRemoteTLS(cr))
+	assert.Equal(t, "argocd-repo-server-tls", getRepoServerTLSSecretName(cr))
+}
+
+func TestGetRepoServerTLSVolumeSource(t *testing.T) {
+	cr := makeTestArgoCD()
+	assert.Equal(t, "argocd-repo-server-tls", getRepoServerTLSVolumeSource(cr).Secret.SecretName)
+	assert.False(t, isRepoServerTLSVerificationRequested(cr))
+
+	cr = makeTestArgoCD(makeTestRepoServerRemote(&argoproj.ArgoCDRepoRemoteTLSSpec{IssueCertificates: true}))
+	assert.Equal(t, "argocd-repo-server-remote-client-tls", getRepoServerTLSVolumeSource(cr).Secret.SecretName)
+	assert.Equal(t, []string{"repo.example.com"}, getRepoServerRemoteDNSNames(cr))
+	assert.True(t, isRepoServerTLSVerificationRequested(cr))
+
+	cr = makeTestArgoCD(makeTestRepoServerRemote(&argoproj.ArgoCDRepoRemoteTLSSpec{
+		CABundleRef:             secretKeySelector("repo-ca", "ca.pem"),
+		ClientCertificateSecret: "repo-client",
+	}))
+	source := getRepoServerTLSVolumeSource(cr)
+	assert.Nil(t, source.Secret)
+	assert.Equal(t, []corev1.VolumeProjection{
+		{Secret: &corev1.SecretProjection{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "repo-ca"},
+			Items:                []corev1.KeyToPath{{Key: "ca.pem", Path: "ca.crt"}},
+		}},
+		{Secret: &corev1.SecretProjection{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "repo-client"},
+			Items:                []corev1.KeyToPath{{Key: "tls.crt", Path: "tls.crt"}, {Key: "tls.key", Path: "tls.key"}},
+		}},
+	}, source.Projected.Sources)
+	assert.Equal(t, "repo-client", getRepoServerTLSSecretName(cr))
+}
+
+func TestIsRepoServerRemoteCertificateOutdated(t *testing.T) {
+	caKey, err := argoutil.NewPrivateKey()
+	assert.NoError(t, err)
+	caCert, err := argoutil.NewSelfSignedCACertificate("test", caKey)
+	assert.NoError(t, err)
+
+	newSecret := func(notAfter time.Time) *corev1.Secret {
+		key, err := argoutil.NewPrivateKey()
+		assert.NoError(t, err)
+		tmpl := &x509.Certificate{
+			SerialNumber: big.NewInt(1),
+			DNSNames:     []string{"repo.example.com"},
+			NotBefore:    caCert.NotBefore,
+			NotAfter:     notAfter,
+		}
+		der, err := x509.CreateCertificate(rand.Reader, tmpl, caCert, key.Public(), caKey)
+		assert.NoError(t, err)
+		cert, err := x509.ParseCertificate(der)
+		assert.NoError(t, err)
+		return &corev1.Secret{Data: map[string][]byte{corev1.TLSCertKey: argoutil.EncodeCertificatePEM(cert)}}
+	}
+
+	valid := newSecret(time.Now().Add(90 * 24 * time.Hour))
+	assert.False(t, isRepoServerRemoteCertificateOutdated(valid, []string{"repo.example.com"}, caCert))
+	assert.True(t, isRepoServerRemoteCertificateOutdated(valid, []string{"repo-0.example.com"}, caCert))
+	assert.True(t, isRepoServerRemoteCertificateOutdated(&corev1.Secret{}, []string{"repo.example.com"}, caCert))
+
+	// certificates are renewed before they expire
+	expiring := newSecret(time.Now().Add(7 * 24 * time.Hour))
+	assert.True(t, isRepoServerRemoteCertificateOutdated(expiring, []string{"repo.example.com"}, caCert))
+}
+
+func TestReconcileArgoCD_RepoServerRemoteTLS(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(makeTestRepoServerRemote(&argoproj.ArgoCDRepoRemoteTLSSpec{IssueCertificates: true}))
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileClusterCASecret(a))
+	assert.NoError(t, r.reconcileRepoServerRemoteTLSSecrets(a))
+
+	caSecret := &corev1.Secret{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-ca", Namespace: testNamespace}, caSecret))
+
+	serverSecret := &corev1.Secret{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-server-remote-tls", Namespace: testNamespace}, serverSecret))
+	assert.Equal(t, corev1.SecretTypeTLS, serverSecret.Type)
+	assert.Equal(t, caSecret.Data[corev1.TLSCertKey], serverSecret.Data[corev1.ServiceAccountRootCAKey])
+	cert, err := argoutil.ParsePEMEncodedCert(serverSecret.Data[corev1.TLSCertKey])
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"repo.example.com"}, cert.DNSNames)
+
+	clientSecret := &corev1.Secret{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-server-remote-client-tls", Namespace: testNamespace}, clientSecret))
+	assert.NotEmpty(t, clientSecret.Data[corev1.TLSPrivateKeyKey])
+
+	// certificates are kept as long as they are up to date
+	assert.NoError(t, r.reconcileRepoServerRemoteTLSSecrets(a))
+	updated := &corev1.Secret{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-server-remote-client-tls", Namespace: testNamespace}, updated))
+	assert.Equal(t, clientSecret.Data, updated.Data)
+
+	// the server certificate is issued again when the DNS names change
+	a.Spec.Repo.RemoteTLS.ServerDNSNames = []string{"repo.example.com", "repo-0.example.com"}
+	assert.NoError(t, r.reconcileRepoServerRemoteTLSSecrets(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-server-remote-tls", Namespace: testNamespace}, serverSecret))
+	cert, err = argoutil.ParsePEMEncodedCert(serverSecret.Data[corev1.TLSCertKey])
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"repo.example.com", "repo-0.example.com"}, cert.DNSNames)
+
+	// consumers mount the client bundle and use strict TLS
+	assert.NoError(t, r.reconcileServerDeployment(a, false))
+	deployment := &appsv1.Deployment{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-server", Namespace: testNamespace}, deployment))
+	assert.Contains(t, deployment.Spec.Template.Spec.Containers[0].Command, "--repo-server-strict-tls")
+	assert.Contains(t, deployment.Spec.Template.Spec.Volumes, corev1.Volume{
+		Name:         "argocd-repo-server-tls",
+		VolumeSource: getRepoServerTLSVolumeSource(a),
+	})
+
+	assert.NoError(t, r.reconcileApplicationControllerStatefulSet(a, false))
+	ss := &appsv1.StatefulSet{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-application-controller", Namespace: testNamespace}, ss))
+	assert.Contains(t, ss.Spec.Template.Spec.Containers[0].Command, "--repo-server-strict-tls")
+
+	a.Spec.ApplicationSet = &argoproj.ArgoCDApplicationSet{}
+	assert.NoError(t, r.reconcileApplicationSetDeployment(a, &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "argocd-applicationset-controller"}}))
+	appset := &appsv1.Deployment{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-applicationset-controller", Namespace: testNamespace}, appset))
+	assert.Contains(t, appset.Spec.Template.Spec.Containers[0].Command, "--repo-server-strict-tls")
+	assert.Contains(t, appset.Spec.Template.Spec.Volumes, corev1.Volume{
+		Name:         "argocd-repo-server-tls",
+		VolumeSource: getRepoServerTLSVolumeSource(a),
+	})
+	assert.Contains(t, appset.Spec.Template.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{
+		Name:      "argocd-repo-server-tls",
+		MountPath: "/app/config/reposerver/tls",
+	})
+	a.Spec.ApplicationSet = nil
+
+	// the issued certificates are removed along with the remote TLS configuration
+	a.Spec.Repo.RemoteTLS = nil
+	assert.NoError(t, r.reconcileRepoServerRemoteTLSSecrets(a))
+	assert.Error(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-server-remote-tls", Namespace: testNamespace}, serverSecret))
+	assert.Error(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-server-remote-client-tls", Namespace: testNamespace}, clientSecret))
+}
+
+func TestGetRepoServerPlugins(t *testing.T) {
+	tanka := argoproj.ArgoCDRepoPluginSpec{Name: "tanka", Image: "tanka:latest", Configuration: "kind: ConfigManagementPlugin"}
+	cue := argoproj.ArgoCDRepoPluginSpec{Name: "cue", Image: "cue:latest", ConfigMapRef: &corev1.ConfigMapKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: "cue-plugin"},
+		Key:                  "plugin.yaml",
+	}}
+	cr := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Spec.Repo.Plugins = []argoproj.ArgoCDRepoPluginSpec{
+			tanka,
+			cue,
+			{Name: "both", Image: "both:latest", Configuration: "kind: ConfigManagementPlugin", ConfigMapRef: cue.ConfigMapRef},
+			{Name: "neither", Image: "neither:latest"},
+			tanka,
+		}
+	})
+
+	plugins, problems := getRepoServerPlugins(cr)
+	assert.Equal(t, []argoproj.ArgoCDRepoPluginSpec{tanka, cue}, plugins)
+	assert.Equal(t, []string{
+		"repo plugin both requires exactly one of configuration or configMapRef to be set",
+		"repo plugin neither requires exactly one of configuration or configMapRef to be set",
+		"repo plugin tanka is defined more than once",
+	}, problems)
+
+	// the skipped plugins do not get a sidecar
+	containers := getRepoServerPluginContainers(cr)
+	assert.Len(t, containers, 2)
+
+	r := &ReconcileArgoCD{}
+	recorder := record.NewFakeRecorder(10)
+	r.recorder = recorder
+	r.reportInvalidRepoServerPlugins(cr)
+	assert.Equal(t, "Warning InvalidRepoServerConfiguration skipped invalid repo plugin both requires exactly one of configuration or configMapRef to be set", <-recorder.Events)
+}
+
+func TestGetKustomizeVersions(t *testing.T) {
+	cr := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Spec.KustomizeVersions = []argoproj.KustomizeVersionSpec{
+			{Version: "v4.1.0", Path: "/path/to/kustomize-4.1"},
+			{Version: "v5.3.0", Image: "kustomize:v5.3.0"},
+			// the versions that only set a path are not validated
+			{Version: "4.5.7", Path: "/path/to/kustomize-4.5.7"},
+			{Path: "/path/to/kustomize"},
+		}
+	})
+	versions, problems := getKustomizeVersions(cr)
+	assert.Equal(t, cr.Spec.KustomizeVersions, versions)
+	assert.Empty(t, problems)
+
+	cr.Spec.KustomizeVersions = []argoproj.KustomizeVersionSpec{
+		{Version: "v4.1.0", Path: "/path/to/kustomize-4.1"},
+		{Version: "v5.3.0", Image: "kustomize:v5.3.0"},
+		{Version: "v5.0.0-rc1", Image: "kustomize:v5.0.0-rc1"},
+		{Version: "v5.3.0", Image: "kustomize:latest"},
+		{Version: "v5.4.0", Path: "/path/to/kustomize-5.4", Image: "kustomize:v5.4.0"},
+	}
+	versions, problems = getKustomizeVersions(cr)
+	assert.Equal(t, cr.Spec.KustomizeVersions[:2], versions)
+	assert.Equal(t, []string{
+		`kustomize version "v5.0.0-rc1" copied from image kustomize:v5.0.0-rc1 must be in the format vX.Y.Z`,
+		"kustomize version v5.3.0 copied from image kustomize:latest is defined more than once",
+		"kustomize version v5.4.0 must not set both a path and an image",
+	}, problems)
+}
+
+func TestReconcileArgoCD_RepoServerPlugins(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Spec.Repo.Plugins = []argoproj.ArgoCDRepoPluginSpec{
+			{
+				Name:          "tanka",
+				Image:         "tanka:latest",
+				Configuration: "kind: ConfigManagementPlugin",
+				Env:           []corev1.EnvVar{{Name: "TK_LOG_LEVEL", Value: "debug"}},
+			},
+			{Name: "cue", Image: "cue:latest", ConfigMapRef: &corev1.ConfigMapKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "cue-plugin"},
+				Key:                  "cue.yaml",
+			}},
+		}
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileRepoServerPluginsConfigMap(a))
+	cm := &corev1.ConfigMap{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-cmp-cm", Namespace: testNamespace}, cm))
+	assert.Equal(t, map[string]string{"tanka.yaml": "kind: ConfigManagementPlugin"}, cm.Data)
+
+	assert.NoError(t, r.reconcileRepoDeployment(a, false))
+	deployment := &appsv1.Deployment{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-server", Namespace: testNamespace}, deployment))
+	containers := deployment.Spec.Template.Spec.Containers
+	assert.Len(t, containers, 3)
+	assert.Equal(t, "cmp-tanka", containers[1].Name)
+	assert.Equal(t, "tanka:latest", containers[1].Image)
+	assert.Equal(t, []string{"/var/run/argocd/argocd-cmp-server"}, containers[1].Command)
+	assert.Contains(t, containers[1].Env, corev1.EnvVar{Name: "TK_LOG_LEVEL", Value: "debug"})
+	assert.Contains(t, containers[1].VolumeMounts, corev1.VolumeMount{Name: "cmp-tanka-config", MountPath: "/home/argocd/cmp-server/config"})
+	assert.Equal(t, "cmp-cue", containers[2].Name)
+	assert.Contains(t, deployment.Spec.Template.Spec.Volumes, corev1.Volume{
+		Name: "cmp-tanka-config",
+		VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "argocd-cmp-cm"},
+			Items:                []corev1.KeyToPath{{Key: "tanka.yaml", Path: "plugin.yaml"}},
+		}},
+	})
+	assert.Contains(t, deployment.Spec.Template.Spec.Volumes, corev1.Volume{
+		Name: "cmp-cue-config",
+		VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "cue-plugin"},
+			Items:                []corev1.KeyToPath{{Key: "cue.yaml", Path: "plugin.yaml"}},
+		}},
+	})
+	checksum := deployment.Spec.Template.Annotations[repoServerPluginsChecksumAnnotation]
+	assert.NotEmpty(t, checksum)
+
+	// changing the inline configuration restarts the sidecars
+	a.Spec.Repo.Plugins[0].Configuration = "kind: ConfigManagementPlugin\nspec: {}"
+	assert.NoError(t, r.reconcileRepoServerPluginsConfigMap(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-cmp-cm", Namespace: testNamespace}, cm))
+	assert.Equal(t, "kind: ConfigManagementPlugin\nspec: {}", cm.Data["tanka.yaml"])
+	assert.NoError(t, r.reconcileRepoDeployment(a, false))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-server", Namespace: testNamespace}, deployment))
+	assert.NotEqual(t, checksum, deployment.Spec.Template.Annotations[repoServerPluginsChecksumAnnotation])
+
+	// removing the plugins removes the sidecars and their configuration
+	a.Spec.Repo.Plugins = nil
+	assert.NoError(t, r.reconcileRepoServerPluginsConfigMap(a))
+	assert.Error(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-cmp-cm", Namespace: testNamespace}, cm))
+	assert.NoError(t, r.reconcileRepoDeployment(a, false))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-server", Namespace: testNamespace}, deployment))
+	assert.Len(t, deployment.Spec.Template.Spec.Containers, 1)
+	assert.NotContains(t, deployment.Spec.Template.Annotations, repoServerPluginsChecksumAnnotation)
+}
+
+func TestReconcileArgoCD_RepoServerCustomTools(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Spec.KustomizeVersions = []argoproj.KustomizeVersionSpec{
+			{Version: "v4.1.0", Path: "/path/to/kustomize-4.1"},
+			{Version: "v5.3.0", Image: "kustomize:v5.3.0", ImagePath: "/app/kustomize"},
+		}
+		cr.Spec.HelmVersion = &argoproj.HelmVersionSpec{Version: "v3.14.0", Image: "helm:v3.14.0"}
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileArgoConfigMap(a))
+	cm := &corev1.ConfigMap{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-cm", Namespace: testNamespace}, cm))
+	assert.Equal(t, "/path/to/kustomize-4.1", cm.Data["kustomize.version.v4.1.0"])
+	assert.Equal(t, "/custom-tools/kustomize-v5-3-0", cm.Data["kustomize.version.v5.3.0"])
+
+	assert.NoError(t, r.reconcileRepoDeployment(a, false))
+	deployment := &appsv1.Deployment{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-server", Namespace: testNamespace}, deployment))
+	initContainers := deployment.Spec.Template.Spec.InitContainers
+	assert.Len(t, initContainers, 3)
+	assert.Equal(t, "kustomize-v5-3-0", initContainers[1].Name)
+	assert.Equal(t, "kustomize:v5.3.0", initContainers[1].Image)
+	assert.Equal(t, []string{"cp", "/app/kustomize", "/custom-tools/kustomize-v5-3-0"}, initContainers[1].Command)
+	assert.Equal(t, "helm", initContainers[2].Name)
+	assert.Equal(t, []string{"cp", "/usr/local/bin/helm", "/custom-tools/helm"}, initContainers[2].Command)
+
+	mounts := deployment.Spec.Template.Spec.Containers[0].VolumeMounts
+	assert.Contains(t, mounts, corev1.VolumeMount{Name: "custom-tools", MountPath: "/custom-tools"})
+	assert.Contains(t, mounts, corev1.VolumeMount{Name: "custom-tools", MountPath: "/usr/local/bin/helm", SubPath: "helm"})
+	assert.Contains(t, deployment.Spec.Template.Spec.Volumes, corev1.Volume{
+		Name:         "custom-tools",
+		VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+	})
+
+	// without images, the repo server is left untouched
+	a.Spec.KustomizeVersions = a.Spec.KustomizeVersions[:1]
+	a.Spec.HelmVersion = nil
+	assert.NoError(t, r.reconcileRepoDeployment(a, false))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-server", Namespace: testNamespace}, deployment))
+	assert.Len(t, deployment.Spec.Template.Spec.InitContainers, 1)
+	for _, volume := range deployment.Spec.Template.Spec.Volumes {
+		assert.NotEqual(t, "custom-tools", volume.Name)
+	}
+}
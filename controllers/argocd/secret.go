@@ -15,6 +15,7 @@
 package argocd
 
 import (
+	"bytes"
 	"context"
 	"crypto/rsa"
 	"crypto/sha256"
@@ -193,6 +194,15 @@ func (r *ReconcileArgoCD) reconcileArgoSecret(cr *argoproj.ArgoCD) error {
 		secret.Data[common.ArgoCDDexSecretKey] = []byte(*dexOIDCClientSecret)
 	}
 
+	if UseExternalOIDC(cr) {
+		oidcClientSecret, err := r.getExternalOIDCClientSecret(cr)
+		if err != nil {
+			r.reportExternalOIDCClientSecretError(cr, err)
+		} else if oidcClientSecret != nil {
+			secret.Data[common.ArgoCDOIDCSecretKey] = oidcClientSecret
+		}
+	}
+
 	if err := controllerutil.SetControllerReference(cr, secret, r.Scheme); err != nil {
 		return err
 	}
@@ -292,6 +302,10 @@ func (r *ReconcileArgoCD) reconcileClusterSecrets(cr *argoproj.ArgoCD) error {
 		return err
 	}
 
+	if err := r.reconcileRepoServerRemoteTLSSecrets(cr); err != nil {
+		return err
+	}
+
 	if err := r.reconcileClusterPermissionsSecret(cr); err != nil {
 		return err
 	}
@@ -355,6 +369,27 @@ func (r *ReconcileArgoCD) reconcileExistingArgoSecret(cr *argoproj.ArgoCD, secre
 		}
 	}
 
+	var oidcClientSecret []byte
+	keepOIDCClientSecret := false
+	if UseExternalOIDC(cr) {
+		var err error
+		oidcClientSecret, err = r.getExternalOIDCClientSecret(cr)
+		if err != nil {
+			// keep the existing client secret, so that Argo CD can still log in until the reference is fixed
+			r.reportExternalOIDCClientSecretError(cr, err)
+			keepOIDCClientSecret = true
+		}
+	}
+	if keepOIDCClientSecret {
+		// nothing to do, the existing client secret is kept
+	} else if actual, ok := secret.Data[common.ArgoCDOIDCSecretKey]; oidcClientSecret == nil && ok {
+		delete(secret.Data, common.ArgoCDOIDCSecretKey)
+		changed = true
+	} else if oidcClientSecret != nil && !bytes.Equal(actual, oidcClientSecret) {
+		secret.Data[common.ArgoCDOIDCSecretKey] = oidcClientSecret
+		changed = true
+	}
+
 	if changed {
 		log.Info("updating argo secret")
 		if err := r.Client.Update(context.TODO(), secret); err != nil {
@@ -465,7 +500,7 @@ func (r *ReconcileArgoCD) reconcileRepoServerTLSSecret(cr *argoproj.ArgoCD) erro
 
 	log.Info("reconciling repo-server TLS secret")
 
-	tlsSecretName := types.NamespacedName{Namespace: cr.Namespace, Name: common.ArgoCDRepoServerTLSSecretName}
+	tlsSecretName := types.NamespacedName{Namespace: cr.Namespace, Name: getRepoServerTLSSecretName(cr)}
 	err := r.Client.Get(context.TODO(), tlsSecretName, &tlsSecretObj)
 	if err != nil {
 		if !apierrors.IsNotFound(err) {
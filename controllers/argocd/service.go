@@ -17,6 +17,9 @@ package argocd
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -117,17 +120,18 @@ func (r *ReconcileArgoCD) reconcileMetricsService(cr *argoproj.ArgoCD) error {
 
 // reconcileRedisHAAnnounceServices will ensure that the announce Services are present for Redis when running in HA mode.
 func (r *ReconcileArgoCD) reconcileRedisHAAnnounceServices(cr *argoproj.ArgoCD) error {
-	for i := int32(0); i < common.ArgoCDDefaultRedisHAReplicas; i++ {
+	if err := r.removeRedisHAAnnounceServices(cr); err != nil {
+		return err
+	}
+
+	if !cr.Spec.HA.Enabled || !cr.Spec.Redis.IsEnabled() {
+		return nil //return as Ha is not enabled do nothing
+	}
+
+	for _, i := range getRedisHAServers(*getRedisHAReplicas(cr)) {
 		svc := newServiceWithSuffix(fmt.Sprintf("redis-ha-announce-%d", i), "redis", cr)
 		if argoutil.IsObjectFound(r.Client, cr.Namespace, svc.Name, svc) {
-			if !cr.Spec.HA.Enabled || !cr.Spec.Redis.IsEnabled() {
-				return r.Client.Delete(context.TODO(), svc)
-			}
-			return nil // Service found, do nothing
-		}
-
-		if !cr.Spec.HA.Enabled || !cr.Spec.Redis.IsEnabled() {
-			return nil //return as Ha is not enabled do nothing
+			continue // Service found, do nothing
 		}
 
 		svc.ObjectMeta.Annotations = map[string]string{
@@ -166,6 +170,38 @@ func (r *ReconcileArgoCD) reconcileRedisHAAnnounceServices(cr *argoproj.ArgoCD)
 	return nil
 }
 
+// removeRedisHAAnnounceServices will delete the announce Services of the Redis HA servers that are not part of the
+// topology of the given ArgoCD anymore, or all of them when Redis is not running in HA mode. On scale down, they are
+// only deleted once the master has failed over to a kept server and the removed servers are gone.
+func (r *ReconcileArgoCD) removeRedisHAAnnounceServices(cr *argoproj.ArgoCD) error {
+	services := &corev1.ServiceList{}
+	opts := []client.ListOption{
+		client.InNamespace(cr.Namespace),
+		client.MatchingLabels{common.ArgoCDKeyComponent: "redis"},
+	}
+	if err := r.Client.List(context.TODO(), services, opts...); err != nil {
+		return fmt.Errorf("failed to list redis services in namespace %s : %s", cr.Namespace, err)
+	}
+
+	replicas := r.getRedisHAServedReplicas(cr)
+	if !cr.Spec.HA.Enabled || !cr.Spec.Redis.IsEnabled() {
+		replicas = 0
+	}
+	prefix := nameWithSuffix("redis-ha-announce-", cr)
+	for i := range services.Items {
+		svc := &services.Items[i]
+		index, err := strconv.Atoi(strings.TrimPrefix(svc.Name, prefix))
+		if !strings.HasPrefix(svc.Name, prefix) || err != nil || int32(index) < replicas {
+			continue
+		}
+		log.Info(fmt.Sprintf("deleting redis HA announce service %s", svc.Name))
+		if err := r.Client.Delete(context.TODO(), svc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // reconcileRedisHAMasterService will ensure that the "master" Service is present for Redis when running in HA mode.
 func (r *ReconcileArgoCD) reconcileRedisHAMasterService(cr *argoproj.ArgoCD) error {
 	svc := newServiceWithSuffix("redis-ha", "redis", cr)
@@ -303,6 +339,61 @@ func (r *ReconcileArgoCD) reconcileRedisService(cr *argoproj.ArgoCD) error {
 	return r.Client.Create(context.TODO(), svc)
 }
 
+// reconcileRedisMetricsService will ensure that the Service for the Redis exporter metrics is present when the Redis
+// exporter sidecar is enabled.
+func (r *ReconcileArgoCD) reconcileRedisMetricsService(cr *argoproj.ArgoCD) error {
+	svc := newServiceWithSuffix("redis-metrics", "redis", cr)
+	svc.Spec.Selector = map[string]string{
+		common.ArgoCDKeyName: nameWithSuffix("redis", cr),
+	}
+	svc.Spec.Ports = []corev1.ServicePort{
+		{
+			Name:       common.ArgoCDKeyMetrics,
+			Port:       common.ArgoCDDefaultRedisExporterPort,
+			Protocol:   corev1.ProtocolTCP,
+			TargetPort: intstr.FromInt(common.ArgoCDDefaultRedisExporterPort),
+		},
+	}
+
+	existing := newServiceWithSuffix("redis-metrics", "redis", cr)
+	if argoutil.IsObjectFound(r.Client, cr.Namespace, existing.Name, existing) {
+		if !isRedisExporterEnabled(cr) {
+			log.Info(fmt.Sprintf("deleting service %s as the redis exporter is disabled", existing.Name))
+			return r.Client.Delete(context.TODO(), existing)
+		}
+		if updateServiceSelectorAndPorts(existing, svc) {
+			log.Info(fmt.Sprintf("updating service %s for Argo CD instance %s in namespace %s", existing.Name, cr.Name, cr.Namespace))
+			return r.Client.Update(context.TODO(), existing)
+		}
+		return nil // Service found with nothing to do, move along...
+	}
+
+	if !isRedisExporterEnabled(cr) {
+		return nil // Redis exporter not enabled, do nothing.
+	}
+
+	if err := controllerutil.SetControllerReference(cr, svc, r.Scheme); err != nil {
+		return err
+	}
+	log.Info(fmt.Sprintf("creating service %s for Argo CD instance %s in namespace %s", svc.Name, cr.Name, cr.Namespace))
+	return r.Client.Create(context.TODO(), svc)
+}
+
+// updateServiceSelectorAndPorts will set the selector and ports of the desired Service on the existing one, and return
+// whether they changed.
+func updateServiceSelectorAndPorts(existing, desired *corev1.Service) bool {
+	changed := false
+	if !reflect.DeepEqual(existing.Spec.Selector, desired.Spec.Selector) {
+		existing.Spec.Selector = desired.Spec.Selector
+		changed = true
+	}
+	if !reflect.DeepEqual(existing.Spec.Ports, desired.Spec.Ports) {
+		existing.Spec.Ports = desired.Spec.Ports
+		changed = true
+	}
+	return changed
+}
+
 // ensureAutoTLSAnnotation ensures that the service svc has the desired state
 // of the auto TLS annotation set, which is either set (when enabled is true)
 // or unset (when enabled is false).
@@ -471,6 +562,10 @@ func (r *ReconcileArgoCD) reconcileServices(cr *argoproj.ArgoCD) error {
 		log.Error(err, "error reconciling dex service")
 	}
 
+	if err := r.reconcileDexMetricsService(cr); err != nil {
+		log.Error(err, "error reconciling dex metrics service")
+	}
+
 	err := r.reconcileGrafanaService(cr)
 	if err != nil {
 		return err
@@ -491,6 +586,11 @@ func (r *ReconcileArgoCD) reconcileServices(cr *argoproj.ArgoCD) error {
 		return err
 	}
 
+	err = r.reconcileRedisMetricsService(cr)
+	if err != nil {
+		return err
+	}
+
 	err = r.reconcileRepoService(cr)
 	if err != nil {
 		return err
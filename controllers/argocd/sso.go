@@ -20,6 +20,7 @@ import (
 
 	deploymentConfig "github.com/openshift/api/apps/v1"
 	template "github.com/openshift/api/template/v1"
+	corev1 "k8s.io/api/core/v1"
 	apiErrors "k8s.io/apimachinery/pkg/api/errors"
 
 	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
@@ -37,6 +38,9 @@ var (
 	templateAPIFound         = false
 	deploymentConfigAPIFound = false
 	ssoConfigLegalStatus     string
+	// dexConnectorsErr holds the error found validating the typed Dex connectors in the current SSO reconciliation
+	// round, so that the existing dex.config is kept rather than rendering connectors that would crash Dex.
+	dexConnectorsErr error
 )
 
 // CanUseKeycloakWithTemplate checks if the required APIs are available to
@@ -69,6 +73,7 @@ func (r *ReconcileArgoCD) reconcileSSO(cr *argoproj.ArgoCD) error {
 
 	// reset ssoConfigLegalStatus at the beginning of each SSO reconciliation round
 	ssoConfigLegalStatus = ssoLegalUnknown
+	dexConnectorsErr = nil
 
 	// case 1
 	if cr.Spec.SSO == nil {
@@ -86,7 +91,10 @@ func (r *ReconcileArgoCD) reconcileSSO(cr *argoproj.ArgoCD) error {
 		if cr.Spec.SSO.Provider.ToLower() == argoproj.SSOProviderTypeDex {
 			// Relevant SSO settings at play are `.spec.sso.dex` fields, `.spec.sso.keycloak`
 
-			if cr.Spec.SSO.Dex == nil || (cr.Spec.SSO.Dex != nil && !cr.Spec.SSO.Dex.OpenShiftOAuth && cr.Spec.SSO.Dex.Config == "") {
+			// the typed dex connectors are validated once per round, the result is used when rendering dex.config
+			dexConnectorsErr = r.validateDexConnectors(cr)
+
+			if cr.Spec.SSO.Dex == nil || (cr.Spec.SSO.Dex != nil && !cr.Spec.SSO.Dex.OpenShiftOAuth && cr.Spec.SSO.Dex.Config == "" && len(cr.Spec.SSO.Dex.Connectors) == 0) {
 				// sso provider specified as dex but no dexconfig supplied. This will cause health probe to fail as per
 				// https://github.com/argoproj-labs/argocd-operator/pull/615 ==> conflict
 				errMsg = "must supply valid dex configuration when requested SSO provider is dex"
@@ -95,6 +103,16 @@ func (r *ReconcileArgoCD) reconcileSSO(cr *argoproj.ArgoCD) error {
 				// new keycloak spec fields are expressed when `.spec.sso.provider` is set to dex ==> conflict
 				errMsg = "cannot supply keycloak configuration in .spec.sso.keycloak when requested SSO provider is dex"
 				isError = true
+			} else if cr.Spec.SSO.OIDC != nil {
+				// oidc spec fields are expressed when `.spec.sso.provider` is set to dex ==> conflict
+				errMsg = "cannot supply oidc configuration in .spec.sso.oidc when requested SSO provider is dex"
+				isError = true
+			} else if dexConnectorsErr != nil {
+				// typed dex connectors are incomplete or reference unusable secrets, which would crash dex ==> reject,
+				// the existing dex.config is kept
+				errMsg = dexConnectorsErr.Error()
+				isError = true
+				r.recordEvent(cr, corev1.EventTypeWarning, "InvalidDexConfiguration", fmt.Sprintf("keeping the existing dex.config: %s", dexConnectorsErr))
 			}
 
 			if isError {
@@ -115,6 +133,11 @@ func (r *ReconcileArgoCD) reconcileSSO(cr *argoproj.ArgoCD) error {
 				errMsg = "cannot supply dex configuration when requested SSO provider is keycloak"
 				err = errors.New(illegalSSOConfiguration + errMsg)
 				isError = true
+			} else if cr.Spec.SSO.OIDC != nil {
+				// oidc spec fields are expressed when `.spec.sso.provider` is set to keycloak ==> conflict
+				errMsg = "cannot supply oidc configuration in .spec.sso.oidc when requested SSO provider is keycloak"
+				err = errors.New(illegalSSOConfiguration + errMsg)
+				isError = true
 			}
 
 			if isError {
@@ -136,12 +159,41 @@ func (r *ReconcileArgoCD) reconcileSSO(cr *argoproj.ArgoCD) error {
 		}
 
 		// case 4
+		if cr.Spec.SSO.Provider.ToLower() == argoproj.SSOProviderTypeOIDC {
+			// Relevant SSO settings at play are `.spec.sso.oidc` fields, `.spec.sso.dex`, `.spec.sso.keycloak` and `.spec.oidcConfig`
+
+			if cr.Spec.SSO.OIDC == nil {
+				// sso provider specified as oidc but no oidc configuration supplied ==> conflict
+				errMsg = "must supply oidc configuration in .spec.sso.oidc when requested SSO provider is oidc"
+			} else if cr.Spec.SSO.Dex != nil || cr.Spec.SSO.Keycloak != nil {
+				// dex or keycloak spec fields are expressed when `.spec.sso.provider` is set to oidc ==> conflict
+				errMsg = "cannot supply dex or keycloak configuration when requested SSO provider is oidc"
+			} else if cr.Spec.OIDCConfig != "" {
+				// raw oidc configuration is expressed when `.spec.sso.provider` is set to oidc ==> conflict
+				errMsg = "cannot supply .spec.oidcConfig when requested SSO provider is oidc"
+			} else if err := r.validateExternalOIDC(cr); err != nil {
+				// the provider cannot be reached or does not match the configuration ==> reject
+				errMsg = err.Error()
+			}
+
+			if errMsg != "" {
+				err = errors.New(illegalSSOConfiguration + errMsg)
+				log.Error(err, fmt.Sprintf("Illegal expression of SSO configuration detected for Argo CD %s in namespace %s. %s", cr.Name, cr.Namespace, errMsg))
+				ssoConfigLegalStatus = ssoLegalFailed // set global indicator that SSO config has gone wrong
+				_ = r.reconcileStatusSSO(cr)
+				return err
+			}
+		}
+
+		// case 5
 		if cr.Spec.SSO.Provider.ToLower() == "" {
 
 			if cr.Spec.SSO.Dex != nil ||
 				// `.spec.sso.dex` expressed without specifying SSO provider ==> conflict
-				cr.Spec.SSO.Keycloak != nil {
+				cr.Spec.SSO.Keycloak != nil ||
 				// `.spec.sso.keycloak` expressed without specifying SSO provider ==> conflict
+				cr.Spec.SSO.OIDC != nil {
+				// `.spec.sso.oidc` expressed without specifying SSO provider ==> conflict
 
 				errMsg = "Cannot specify SSO provider spec without specifying SSO provider type"
 				err = errors.New(illegalSSOConfiguration + errMsg)
@@ -152,11 +204,12 @@ func (r *ReconcileArgoCD) reconcileSSO(cr *argoproj.ArgoCD) error {
 			}
 		}
 
-		// case 5
-		if cr.Spec.SSO.Provider.ToLower() != argoproj.SSOProviderTypeDex && cr.Spec.SSO.Provider.ToLower() != argoproj.SSOProviderTypeKeycloak {
+		// case 6
+		if cr.Spec.SSO.Provider.ToLower() != argoproj.SSOProviderTypeDex && cr.Spec.SSO.Provider.ToLower() != argoproj.SSOProviderTypeKeycloak &&
+			cr.Spec.SSO.Provider.ToLower() != argoproj.SSOProviderTypeOIDC {
 			// `.spec.sso.provider` contains unsupported value
 
-			errMsg = fmt.Sprintf("Unsupported SSO provider type. Supported providers are %s and %s", argoproj.SSOProviderTypeDex, argoproj.SSOProviderTypeKeycloak)
+			errMsg = fmt.Sprintf("Unsupported SSO provider type. Supported providers are %s, %s and %s", argoproj.SSOProviderTypeDex, argoproj.SSOProviderTypeKeycloak, argoproj.SSOProviderTypeOIDC)
 			err = errors.New(illegalSSOConfiguration + errMsg)
 			log.Error(err, fmt.Sprintf("Unsupported SSO provider type for Argo CD %s in namespace %s.", cr.Name, cr.Namespace))
 			ssoConfigLegalStatus = ssoLegalFailed // set global indicator that SSO config has gone wrong
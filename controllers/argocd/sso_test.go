@@ -124,6 +124,23 @@ func TestReconcile_illegalSSOConfiguration(t *testing.T) {
 			wantErr:                  false,
 			wantSSOConfigLegalStatus: "Success",
 		},
+		{
+			name: "sso provider dex with invalid typed connectors",
+			argoCD: makeTestArgoCD(func(ac *argoproj.ArgoCD) {
+				ac.Spec.SSO = &argoproj.ArgoCDSSOSpec{
+					Provider: "dex",
+					Dex: &argoproj.ArgoCDDexSpec{
+						Connectors: []argoproj.ArgoCDDexConnector{
+							{ID: "github", Name: "GitHub", GitHub: &argoproj.ArgoCDDexGitHubConnector{}},
+						},
+					},
+				}
+			}),
+			wantErr: true,
+			Err: errors.New(illegalSSOConfiguration + "invalid dex connectors: connectors[0].github.clientID: must not be empty; " +
+				"connectors[0].github.clientSecretRef: secret name and key must not be empty"),
+			wantSSOConfigLegalStatus: "Failed",
+		},
 		{
 			name: "no conflict - valid keycloak sso configurations",
 			argoCD: makeTestArgoCD(func(ac *argoproj.ArgoCD) {
@@ -207,7 +224,7 @@ func TestReconcile_illegalSSOConfiguration(t *testing.T) {
 				}
 			}),
 			wantErr:                  true,
-			Err:                      errors.New("illegal SSO configuration: Unsupported SSO provider type. Supported providers are dex and keycloak"),
+			Err:                      errors.New("illegal SSO configuration: Unsupported SSO provider type. Supported providers are dex, keycloak and oidc"),
 			wantSSOConfigLegalStatus: "Failed",
 		},
 	}
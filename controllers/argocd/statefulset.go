@@ -33,12 +33,6 @@ import (
 	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
 )
 
-func getRedisHAReplicas() *int32 {
-	replicas := common.ArgoCDDefaultRedisHAReplicas
-	// TODO: Allow override of this value through CR?
-	return &replicas
-}
-
 // newStatefulSet returns a new StatefulSet instance for the given ArgoCD instance.
 func newStatefulSet(cr *argoproj.ArgoCD) *appsv1.StatefulSet {
 	return &appsv1.StatefulSet{
@@ -107,7 +101,7 @@ func (r *ReconcileArgoCD) reconcileRedisStatefulSet(cr *argoproj.ArgoCD) error {
 	})
 
 	ss.Spec.PodManagementPolicy = appsv1.OrderedReadyPodManagement
-	ss.Spec.Replicas = getRedisHAReplicas()
+	ss.Spec.Replicas = getRedisHAReplicas(cr)
 	ss.Spec.Selector = &metav1.LabelSelector{
 		MatchLabels: map[string]string{
 			common.ArgoCDKeyName: nameWithSuffix("redis-ha", cr),
@@ -124,6 +118,9 @@ func (r *ReconcileArgoCD) reconcileRedisStatefulSet(cr *argoproj.ArgoCD) error {
 			common.ArgoCDKeyName: nameWithSuffix("redis-ha", cr),
 		},
 	}
+	if checksum := getRedisHATopologyChecksum(cr); checksum != "" {
+		ss.Spec.Template.ObjectMeta.Annotations[redisHATopologyChecksumAnnotation] = checksum
+	}
 
 	ss.Spec.Template.Spec.Affinity = &corev1.Affinity{
 		PodAntiAffinity: &corev1.PodAntiAffinity{
@@ -264,7 +261,7 @@ func (r *ReconcileArgoCD) reconcileRedisStatefulSet(cr *argoproj.ArgoCD) error {
 				SuccessThreshold:    int32(1),
 				TimeoutSeconds:      int32(15),
 			},
-			Resources: getRedisHAResources(cr),
+			Resources: getRedisHASentinelResources(cr),
 			SecurityContext: &corev1.SecurityContext{
 				AllowPrivilegeEscalation: boolPtr(false),
 				Capabilities: &corev1.Capabilities{
@@ -301,31 +298,17 @@ func (r *ReconcileArgoCD) reconcileRedisStatefulSet(cr *argoproj.ArgoCD) error {
 		Command: []string{
 			"sh",
 		},
-		Env: []corev1.EnvVar{
-			{
-				Name:  "SENTINEL_ID_0",
-				Value: "3c0d9c0320bb34888c2df5757c718ce6ca992ce6", // TODO: Should this be hard-coded?
-			},
-			{
-				Name:  "SENTINEL_ID_1",
-				Value: "40000915ab58c3fa8fd888fb8b24711944e6cbb4", // TODO: Should this be hard-coded?
-			},
-			{
-				Name:  "SENTINEL_ID_2",
-				Value: "2bbec7894d954a8af3bb54d13eaec53cb024e2ca", // TODO: Should this be hard-coded?
-			},
-			{
-				Name: "AUTH",
-				ValueFrom: &corev1.EnvVarSource{
-					SecretKeyRef: &corev1.SecretKeySelector{
-						LocalObjectReference: corev1.LocalObjectReference{
-							Name: fmt.Sprintf("%s-%s", cr.Name, "redis-initial-password"),
-						},
-						Key: "admin.password",
+		Env: append(getRedisHASentinelIDEnv(cr), corev1.EnvVar{
+			Name: "AUTH",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: fmt.Sprintf("%s-%s", cr.Name, "redis-initial-password"),
 					},
+					Key: "admin.password",
 				},
 			},
-		},
+		}),
 		Image:           getRedisHAContainerImage(cr),
 		ImagePullPolicy: corev1.PullIfNotPresent,
 		Name:            "config-init",
@@ -398,12 +381,6 @@ func (r *ReconcileArgoCD) reconcileRedisStatefulSet(cr *argoproj.ArgoCD) error {
 				},
 			},
 		},
-		{
-			Name: "data",
-			VolumeSource: corev1.VolumeSource{
-				EmptyDir: &corev1.EmptyDirVolumeSource{},
-			},
-		},
 		{
 			Name: common.ArgoCDRedisServerTLSSecretName,
 			VolumeSource: corev1.VolumeSource{
@@ -415,6 +392,23 @@ func (r *ReconcileArgoCD) reconcileRedisStatefulSet(cr *argoproj.ArgoCD) error {
 		},
 	}
 
+	// The data of the Redis servers is either persisted on PersistentVolumeClaims, or lost on restart
+	if claims := getRedisHAVolumeClaimTemplates(cr); len(claims) > 0 {
+		ss.Spec.VolumeClaimTemplates = claims
+		// PersistentVolumeClaims of removed servers hold stale data, clean them up on scale down
+		ss.Spec.PersistentVolumeClaimRetentionPolicy = &appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy{
+			WhenDeleted: appsv1.RetainPersistentVolumeClaimRetentionPolicyType,
+			WhenScaled:  appsv1.DeletePersistentVolumeClaimRetentionPolicyType,
+		}
+	} else {
+		ss.Spec.Template.Spec.Volumes = append(ss.Spec.Template.Spec.Volumes, corev1.Volume{
+			Name: "data",
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		})
+	}
+
 	ss.Spec.UpdateStrategy = appsv1.StatefulSetUpdateStrategy{
 		Type: appsv1.RollingUpdateStatefulSetStrategyType,
 	}
@@ -430,6 +424,13 @@ func (r *ReconcileArgoCD) reconcileRedisStatefulSet(cr *argoproj.ArgoCD) error {
 			return r.Client.Delete(context.TODO(), existing)
 		}
 
+		if redisVolumeClaimTemplatesChanged(existing.Spec.VolumeClaimTemplates, ss.Spec.VolumeClaimTemplates) {
+			// The PersistentVolumeClaim templates of a StatefulSet are immutable, recreate it on the next reconciliation.
+			// The pods are orphaned so that Redis keeps serving, and are adopted and rolled by the new StatefulSet.
+			log.Info("Redis HA persistence changed. Recreating the Redis HA StatefulSet.")
+			return r.Client.Delete(context.TODO(), existing, client.PropagationPolicy(metav1.DeletePropagationOrphan))
+		}
+
 		desiredImage := getRedisHAContainerImage(cr)
 		changed := false
 		updateNodePlacementStateful(existing, ss, &changed)
@@ -461,6 +462,46 @@ func (r *ReconcileArgoCD) reconcileRedisStatefulSet(cr *argoproj.ArgoCD) error {
 			changed = true
 		}
 
+		if !reflect.DeepEqual(ss.Spec.Template.Spec.InitContainers[0].Env, existing.Spec.Template.Spec.InitContainers[0].Env) {
+			existing.Spec.Template.Spec.InitContainers[0].Env = ss.Spec.Template.Spec.InitContainers[0].Env
+			changed = true
+		}
+
+		// Added servers are configured by the init script from the running sentinels. Before servers are removed,
+		// the master is failed over to one of the kept servers, and once they are gone the remaining sentinels are
+		// reset so that they forget the removed sentinels and replicas.
+		if existing.Spec.Replicas != nil && *ss.Spec.Replicas < *existing.Spec.Replicas {
+			ready, err := r.prepareRedisHAScaleDown(cr, *ss.Spec.Replicas)
+			if err != nil {
+				return err
+			}
+			if !ready {
+				return fmt.Errorf("waiting for the redis HA master to fail over before scaling down to %d replicas", *ss.Spec.Replicas)
+			}
+			existing.Annotations = argoutil.AppendStringMap(existing.Annotations, map[string]string{redisHASentinelResetAnnotation: "true"})
+			existing.Spec.Replicas = ss.Spec.Replicas
+			changed = true
+		} else if !reflect.DeepEqual(ss.Spec.Replicas, existing.Spec.Replicas) {
+			existing.Spec.Replicas = ss.Spec.Replicas
+			changed = true
+		} else if existing.Annotations[redisHASentinelResetAnnotation] == "true" && isRedisHAScaleDownComplete(existing) {
+			if err := r.resetRedisHASentinels(cr, *existing.Spec.Replicas); err != nil {
+				return err
+			}
+			delete(existing.Annotations, redisHASentinelResetAnnotation)
+			changed = true
+		}
+
+		if existing.Spec.Template.Annotations[redisHATopologyChecksumAnnotation] != ss.Spec.Template.Annotations[redisHATopologyChecksumAnnotation] {
+			existing.Spec.Template.Annotations = argoutil.AppendStringMap(existing.Spec.Template.Annotations, nil)
+			if checksum := ss.Spec.Template.Annotations[redisHATopologyChecksumAnnotation]; checksum != "" {
+				existing.Spec.Template.Annotations[redisHATopologyChecksumAnnotation] = checksum
+			} else {
+				delete(existing.Spec.Template.Annotations, redisHATopologyChecksumAnnotation)
+			}
+			changed = true
+		}
+
 		if changed {
 			return r.Client.Update(context.TODO(), existing)
 		}
@@ -468,7 +509,7 @@ func (r *ReconcileArgoCD) reconcileRedisStatefulSet(cr *argoproj.ArgoCD) error {
 		return nil // StatefulSet found, do nothing
 	}
 
-	if cr.Spec.Redis.IsEnabled() && cr.Spec.Redis.Remote != nil && *cr.Spec.Redis.Remote != "" {
+	if isRedisRemote(cr) {
 		log.Info("Custom Redis Endpoint. Skipping starting redis.")
 		return nil
 	}
@@ -496,17 +537,7 @@ func getArgoControllerContainerEnv(cr *argoproj.ArgoCD) []corev1.EnvVar {
 		Value: "/home/argocd",
 	})
 
-	env = append(env, corev1.EnvVar{
-		Name: "REDIS_PASSWORD",
-		ValueFrom: &corev1.EnvVarSource{
-			SecretKeyRef: &corev1.SecretKeySelector{
-				LocalObjectReference: corev1.LocalObjectReference{
-					Name: fmt.Sprintf("%s-%s", cr.Name, "redis-initial-password"),
-				},
-				Key: "admin.password",
-			},
-		},
-	})
+	env = append(env, getRedisEnv(cr)...)
 
 	if cr.Spec.Controller.Sharding.Enabled {
 		env = append(env, corev1.EnvVar{
@@ -586,6 +617,7 @@ func (r *ReconcileArgoCD) reconcileApplicationControllerStatefulSet(cr *argoproj
 	controllerEnv = argoutil.EnvMerge(controllerEnv, getArgoControllerContainerEnv(cr), true)
 	// Let user specify their own environment first
 	controllerEnv = argoutil.EnvMerge(controllerEnv, proxyEnvVars(), false)
+	controllerEnv = argoutil.EnvMerge(controllerEnv, getTracingEnv(cr), false)
 
 	if cr.Spec.Controller.InitContainers != nil {
 		ss.Spec.Template.Spec.InitContainers = append(ss.Spec.Template.Spec.InitContainers, cr.Spec.Controller.InitContainers...)
@@ -601,6 +633,8 @@ func (r *ReconcileArgoCD) reconcileApplicationControllerStatefulSet(cr *argoproj
 			MountPath: "/app/config/controller/tls/redis",
 		},
 	}
+	controllerVolumeMounts = append(controllerVolumeMounts, getRedisExternalCAVolumeMounts(cr, "/app/config/controller/tls")...)
+	controllerVolumeMounts = append(controllerVolumeMounts, r.getTrustedCABundleVolumeMounts(cr)...)
 
 	if cr.Spec.Controller.VolumeMounts != nil {
 		controllerVolumeMounts = append(controllerVolumeMounts, cr.Spec.Controller.VolumeMounts...)
@@ -653,13 +687,8 @@ func (r *ReconcileArgoCD) reconcileApplicationControllerStatefulSet(cr *argoproj
 
 	controllerVolumes := []corev1.Volume{
 		{
-			Name: "argocd-repo-server-tls",
-			VolumeSource: corev1.VolumeSource{
-				Secret: &corev1.SecretVolumeSource{
-					SecretName: common.ArgoCDRepoServerTLSSecretName,
-					Optional:   boolPtr(true),
-				},
-			},
+			Name:         "argocd-repo-server-tls",
+			VolumeSource: getRepoServerTLSVolumeSource(cr),
 		},
 		{
 			Name: common.ArgoCDRedisServerTLSSecretName,
@@ -672,11 +701,15 @@ func (r *ReconcileArgoCD) reconcileApplicationControllerStatefulSet(cr *argoproj
 		},
 	}
 
+	controllerVolumes = append(controllerVolumes, getRedisExternalCAVolumes(cr)...)
+	controllerVolumes = append(controllerVolumes, r.getTrustedCABundleVolumes(cr)...)
+
 	if cr.Spec.Controller.Volumes != nil {
 		controllerVolumes = append(controllerVolumes, cr.Spec.Controller.Volumes...)
 	}
 
 	podSpec.Volumes = controllerVolumes
+	setTrustedCABundleChecksum(&ss.Spec.Template, r.getTrustedCABundleChecksum(cr))
 
 	ss.Spec.Template.Spec.Affinity = &corev1.Affinity{
 		PodAntiAffinity: &corev1.PodAntiAffinity{
@@ -758,9 +791,6 @@ func (r *ReconcileArgoCD) reconcileApplicationControllerStatefulSet(cr *argoproj
 			changed = true
 		}
 		desiredCommand := getArgoApplicationControllerCommand(cr, useTLSForRedis)
-		if isRepoServerTLSVerificationRequested(cr) {
-			desiredCommand = append(desiredCommand, "--repo-server-strict-tls")
-		}
 		updateNodePlacementStateful(existing, ss, &changed)
 		if !reflect.DeepEqual(desiredCommand, existing.Spec.Template.Spec.Containers[0].Command) {
 			existing.Spec.Template.Spec.Containers[0].Command = desiredCommand
@@ -796,6 +826,9 @@ func (r *ReconcileArgoCD) reconcileApplicationControllerStatefulSet(cr *argoproj
 			existing.Spec.Replicas = ss.Spec.Replicas
 			changed = true
 		}
+		if updateTrustedCABundleChecksum(&existing.Spec.Template, &ss.Spec.Template) {
+			changed = true
+		}
 
 		if !reflect.DeepEqual(ss.Spec.Template.Spec.Containers[1:],
 			existing.Spec.Template.Spec.Containers[1:]) {
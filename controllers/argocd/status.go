@@ -25,6 +25,8 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 
 	appsv1 "k8s.io/api/apps/v1"
 
@@ -226,6 +228,12 @@ func (r *ReconcileArgoCD) reconcileStatusSSO(cr *argoproj.ArgoCD) error {
 			return r.reconcileStatusDex(cr)
 		} else if cr.Spec.SSO != nil && cr.Spec.SSO.Provider.ToLower() == argoproj.SSOProviderTypeKeycloak {
 			return r.reconcileStatusKeycloak(cr)
+		} else if UseExternalOIDC(cr) {
+			// the external provider has been validated, there are no SSO workloads to track
+			if cr.Status.SSO != "Running" {
+				cr.Status.SSO = "Running"
+				return r.Client.Status().Update(context.TODO(), cr)
+			}
 		}
 	} else {
 		// illegal/unknown sso configurations
@@ -243,7 +251,7 @@ func (r *ReconcileArgoCD) reconcileStatusPhase(cr *argoproj.ArgoCD) error {
 	var phase string
 
 	if ((!cr.Spec.Controller.IsEnabled() && cr.Status.ApplicationController == "Unknown") || cr.Status.ApplicationController == "Running") &&
-		((!cr.Spec.Redis.IsEnabled() && cr.Status.Redis == "Unknown") || cr.Status.Redis == "Running" || (cr.Spec.Redis.IsEnabled() && cr.Spec.Redis.Remote != nil && *cr.Spec.Redis.Remote != "")) &&
+		((!cr.Spec.Redis.IsEnabled() && cr.Status.Redis == "Unknown") || cr.Status.Redis == "Running" || isRedisRemote(cr)) &&
 		((!cr.Spec.Repo.IsEnabled() && cr.Status.Repo == "Unknown") || cr.Status.Repo == "Running") &&
 		((!cr.Spec.Server.IsEnabled() && cr.Status.Server == "Unknown") || cr.Status.Server == "Running") {
 		phase = "Available"
@@ -398,6 +406,7 @@ func (r *ReconcileArgoCD) reconcileStatusNotifications(cr *argoproj.ArgoCD) erro
 // reconcileStatusHost will ensure that the host status is updated for the given ArgoCD.
 func (r *ReconcileArgoCD) reconcileStatusHost(cr *argoproj.ArgoCD) error {
 	cr.Status.Host = ""
+	cr.Status.URLs = nil
 
 	if (cr.Spec.Server.Route.Enabled || cr.Spec.Server.Ingress.Enabled) && IsRouteAPIAvailable() {
 		route := newRouteWithSuffix("server", cr)
@@ -449,6 +458,39 @@ func (r *ReconcileArgoCD) reconcileStatusHost(cr *argoproj.ArgoCD) error {
 				}
 			}
 		}
+	} else if cr.Spec.Server.Gateway.Enabled && IsGatewayAPIAvailable() {
+		var routeStatus gatewayv1.RouteStatus
+		var hostnames []gatewayv1.Hostname
+		if cr.Spec.Server.Gateway.TLSPassthrough {
+			route := &gatewayv1alpha2.TLSRoute{}
+			if !IsGatewayExperimentalAPIAvailable() || !argoutil.IsObjectFound(r.Client, cr.Namespace, nameWithSuffix("server", cr), route) {
+				log.Info("argocd-server tlsroute requested but not found on cluster")
+				cr.Status.Phase = "Pending"
+				return nil
+			}
+			routeStatus, hostnames = route.Status.RouteStatus, route.Spec.Hostnames
+		} else {
+			route := &gatewayv1.HTTPRoute{}
+			if !argoutil.IsObjectFound(r.Client, cr.Namespace, nameWithSuffix("server", cr), route) {
+				log.Info("argocd-server httproute requested but not found on cluster")
+				cr.Status.Phase = "Pending"
+				return nil
+			}
+			routeStatus, hostnames = route.Status.RouteStatus, route.Spec.Hostnames
+		}
+
+		if isGatewayRouteAccepted(routeStatus) {
+			var s []string
+			for _, hostname := range hostnames {
+				s = append(s, string(hostname))
+			}
+			if len(s) == 0 {
+				s = append(s, getArgoServerHost(cr))
+			}
+			cr.Status.Host = strings.Join(s, ", ")
+		} else {
+			cr.Status.Phase = "Pending"
+		}
 	} else if cr.Spec.Server.Ingress.Enabled {
 		ingress := newIngressWithSuffix("server", cr)
 		if !argoutil.IsObjectFound(r.Client, cr.Namespace, ingress.Name, ingress) {
@@ -456,6 +498,7 @@ func (r *ReconcileArgoCD) reconcileStatusHost(cr *argoproj.ArgoCD) error {
 			cr.Status.Phase = "Pending"
 			return nil
 		} else {
+			cr.Status.URLs = getIngressURLs(ingress)
 			if !reflect.DeepEqual(ingress.Status.LoadBalancer, corev1.LoadBalancerStatus{}) && len(ingress.Status.LoadBalancer.Ingress) > 0 {
 				var s []string
 				var hosts string
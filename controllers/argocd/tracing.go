@@ -0,0 +1,158 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/credentials"
+	corev1 "k8s.io/api/core/v1"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/version"
+)
+
+// tracerName is the name of the tracer creating the spans of the operator.
+const tracerName = "github.com/argoproj-labs/argocd-operator/controllers/argocd"
+
+// InitTracer sets up the export of the operator spans to the OTLP collector at the given address, sampling the given
+// ratio of the traces. The returned function flushes the pending spans and stops the export.
+func InitTracer(ctx context.Context, otlpAddress string, otlpInsecure bool, samplingRatio float64) (func(context.Context) error, error) {
+	secureOption := otlptracegrpc.WithTLSCredentials(credentials.NewClientTLSFromCert(nil, ""))
+	if otlpInsecure {
+		secureOption = otlptracegrpc.WithInsecure()
+	}
+
+	exporter, err := otlptracegrpc.New(ctx, secureOption, otlptracegrpc.WithEndpoint(otlpAddress))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create trace exporter: %w", err)
+	}
+
+	res, err := resource.New(ctx, resource.WithAttributes(
+		semconv.ServiceName("argocd-operator"),
+		semconv.ServiceVersion(version.Version),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create trace resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(samplingRatio))),
+		sdktrace.WithResource(res),
+		sdktrace.WithBatcher(exporter),
+	)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
+
+// startReconcileSpan starts the span covering the reconciliation of the ArgoCD with the given namespace and name.
+func startReconcileSpan(ctx context.Context, namespace, name string) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, "Reconcile", trace.WithAttributes(
+		attribute.String("argocd.namespace", namespace),
+		attribute.String("argocd.name", name),
+	))
+}
+
+// runReconcileStep runs the given reconcile step for the given ArgoCD within a span named after the step, and records
+// the duration and the errors of the step.
+func (r *ReconcileArgoCD) runReconcileStep(ctx context.Context, cr *argoproj.ArgoCD, name string, step func() error) error {
+	_, span := otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(
+		attribute.String("argocd.namespace", cr.Namespace),
+		attribute.String("argocd.name", cr.Name),
+	))
+	defer span.End()
+
+	stepStartTS := time.Now()
+	err := step()
+	ReconcileStepTime.WithLabelValues(cr.Namespace, name).Observe(time.Since(stepStartTS).Seconds())
+
+	if err != nil {
+		ReconcileStepErrors.WithLabelValues(cr.Namespace, name).Inc()
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+// validateTracing will ensure that the collector endpoint is set when tracing is enabled for the given ArgoCD, and
+// that the sampling ratio is only set for the samplers using it. Tracing is skipped without a collector endpoint, and
+// the sampling ratio is skipped for the samplers not using it.
+func validateTracing(cr *argoproj.ArgoCD) error {
+	tracing := cr.Spec.Observability.Tracing
+	if !tracing.Enabled {
+		return nil
+	}
+	if tracing.Endpoint == "" {
+		return fmt.Errorf("tracing is enabled but no collector endpoint is set in .spec.observability.tracing.endpoint")
+	}
+	if sampling := tracing.Sampling; sampling != nil && sampling.Ratio != "" && !strings.HasSuffix(sampling.Sampler, "traceidratio") {
+		return fmt.Errorf("tracing sampling ratio is only used by the traceidratio and parentbased_traceidratio samplers, not by %s", sampling.Sampler)
+	}
+	return nil
+}
+
+// getTracingCommandArgs will return the command arguments enabling the export of traces for the given ArgoCD.
+func getTracingCommandArgs(cr *argoproj.ArgoCD) []string {
+	tracing := cr.Spec.Observability.Tracing
+	if !tracing.Enabled || tracing.Endpoint == "" {
+		return nil
+	}
+
+	args := []string{
+		"--otlp-address", tracing.Endpoint,
+		"--otlp-insecure=" + strconv.FormatBool(tracing.Insecure),
+	}
+
+	if len(tracing.Attributes) > 0 {
+		attrs := make([]string, 0, len(tracing.Attributes))
+		for k, v := range tracing.Attributes {
+			attrs = append(attrs, fmt.Sprintf("%s:%s", k, v))
+		}
+		sort.Strings(attrs)
+		args = append(args, "--otlp-attrs", strings.Join(attrs, ","))
+	}
+
+	return args
+}
+
+// getTracingEnv will return the environment variables configuring the sampling of the traces for the given ArgoCD.
+func getTracingEnv(cr *argoproj.ArgoCD) []corev1.EnvVar {
+	tracing := cr.Spec.Observability.Tracing
+	if !tracing.Enabled || tracing.Endpoint == "" || tracing.Sampling == nil {
+		return nil
+	}
+
+	env := []corev1.EnvVar{{Name: "OTEL_TRACES_SAMPLER", Value: tracing.Sampling.Sampler}}
+	if tracing.Sampling.Ratio != "" && strings.HasSuffix(tracing.Sampling.Sampler, "traceidratio") {
+		env = append(env, corev1.EnvVar{Name: "OTEL_TRACES_SAMPLER_ARG", Value: tracing.Sampling.Ratio})
+	}
+	return env
+}
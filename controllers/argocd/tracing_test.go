@@ -0,0 +1,187 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	corev1 "k8s.io/api/core/v1"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+)
+
+func TestGetTracingCommandArgs(t *testing.T) {
+	tests := []struct {
+		name    string
+		tracing argoproj.ArgoCDTracingSpec
+		want    []string
+	}{
+		{
+			name:    "tracing disabled",
+			tracing: argoproj.ArgoCDTracingSpec{Endpoint: "otel-collector:4317"},
+			want:    nil,
+		},
+		{
+			name:    "tracing enabled",
+			tracing: argoproj.ArgoCDTracingSpec{Enabled: true, Endpoint: "otel-collector:4317"},
+			want:    []string{"--otlp-address", "otel-collector:4317", "--otlp-insecure=false"},
+		},
+		{
+			name: "tracing enabled with insecure connection and attributes",
+			tracing: argoproj.ArgoCDTracingSpec{
+				Enabled:    true,
+				Endpoint:   "otel-collector:4317",
+				Insecure:   true,
+				Attributes: map[string]string{"env": "prod", "cluster": "east"},
+			},
+			want: []string{"--otlp-address", "otel-collector:4317", "--otlp-insecure=true", "--otlp-attrs", "cluster:east,env:prod"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cr := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+				cr.Spec.Observability.Tracing = test.tracing
+			})
+			assert.Equal(t, test.want, getTracingCommandArgs(cr))
+		})
+	}
+}
+
+func TestValidateTracing(t *testing.T) {
+	tests := []struct {
+		name    string
+		tracing argoproj.ArgoCDTracingSpec
+		wantErr string
+	}{
+		{
+			name:    "tracing disabled without endpoint",
+			tracing: argoproj.ArgoCDTracingSpec{},
+		},
+		{
+			name:    "tracing enabled without endpoint",
+			tracing: argoproj.ArgoCDTracingSpec{Enabled: true},
+			wantErr: "tracing is enabled but no collector endpoint is set in .spec.observability.tracing.endpoint",
+		},
+		{
+			name: "ratio sampler",
+			tracing: argoproj.ArgoCDTracingSpec{
+				Enabled:  true,
+				Endpoint: "otel-collector:4317",
+				Sampling: &argoproj.ArgoCDTracingSamplingSpec{Sampler: "parentbased_traceidratio", Ratio: "0.1"},
+			},
+		},
+		{
+			name: "ratio with a sampler not using it",
+			tracing: argoproj.ArgoCDTracingSpec{
+				Enabled:  true,
+				Endpoint: "otel-collector:4317",
+				Sampling: &argoproj.ArgoCDTracingSamplingSpec{Sampler: "always_on", Ratio: "0.1"},
+			},
+			wantErr: "tracing sampling ratio is only used by the traceidratio and parentbased_traceidratio samplers, not by always_on",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cr := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+				cr.Spec.Observability.Tracing = test.tracing
+			})
+			err := validateTracing(cr)
+			if test.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestGetTracingEnv(t *testing.T) {
+	cr := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Spec.Observability.Tracing = argoproj.ArgoCDTracingSpec{Enabled: true, Endpoint: "otel-collector:4317"}
+	})
+	assert.Empty(t, getTracingEnv(cr))
+
+	cr.Spec.Observability.Tracing.Sampling = &argoproj.ArgoCDTracingSamplingSpec{Sampler: "always_off"}
+	assert.Equal(t, []corev1.EnvVar{{Name: "OTEL_TRACES_SAMPLER", Value: "always_off"}}, getTracingEnv(cr))
+
+	cr.Spec.Observability.Tracing.Sampling = &argoproj.ArgoCDTracingSamplingSpec{Sampler: "traceidratio", Ratio: "0.25"}
+	assert.Equal(t, []corev1.EnvVar{
+		{Name: "OTEL_TRACES_SAMPLER", Value: "traceidratio"},
+		{Name: "OTEL_TRACES_SAMPLER_ARG", Value: "0.25"},
+	}, getTracingEnv(cr))
+
+	// the ratio is skipped for the samplers not using it
+	cr.Spec.Observability.Tracing.Sampling = &argoproj.ArgoCDTracingSamplingSpec{Sampler: "always_on", Ratio: "0.25"}
+	assert.Equal(t, []corev1.EnvVar{{Name: "OTEL_TRACES_SAMPLER", Value: "always_on"}}, getTracingEnv(cr))
+
+	// tracing is skipped without a collector endpoint
+	cr.Spec.Observability.Tracing.Endpoint = ""
+	assert.Empty(t, getTracingEnv(cr))
+	assert.Empty(t, getTracingCommandArgs(cr))
+
+	cr.Spec.Observability.Tracing.Enabled = false
+	assert.Empty(t, getTracingEnv(cr))
+}
+
+func TestGetArgoCommands_tracing(t *testing.T) {
+	cr := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Spec.Observability.Tracing = argoproj.ArgoCDTracingSpec{Enabled: true, Endpoint: "otel-collector:4317"}
+	})
+
+	for _, cmd := range [][]string{
+		getArgoServerCommand(cr, false),
+		getArgoRepoCommand(cr, false),
+		getArgoApplicationControllerCommand(cr, false),
+	} {
+		assert.Subset(t, cmd, []string{"--otlp-address", "otel-collector:4317", "--otlp-insecure=false"})
+	}
+}
+
+func TestReconcileArgoCD_runReconcileStep(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previous)
+	})
+
+	cr := makeTestArgoCD()
+	r := &ReconcileArgoCD{}
+
+	ctx, span := startReconcileSpan(context.TODO(), cr.Namespace, cr.Name)
+	assert.NoError(t, r.runReconcileStep(ctx, cr, "reconcileRoles", func() error { return nil }))
+	assert.Error(t, r.runReconcileStep(ctx, cr, "reconcileSecrets", func() error { return errors.New("boom") }))
+	span.End()
+
+	spans := recorder.Ended()
+	assert.Len(t, spans, 3)
+	assert.Equal(t, "reconcileRoles", spans[0].Name())
+	assert.Equal(t, codes.Unset, spans[0].Status().Code)
+	assert.Equal(t, "reconcileSecrets", spans[1].Name())
+	assert.Equal(t, codes.Error, spans[1].Status().Code)
+	assert.Equal(t, "Reconcile", spans[2].Name())
+	assert.Equal(t, spans[2].SpanContext().SpanID(), spans[0].Parent().SpanID())
+	assert.Equal(t, spans[2].SpanContext().SpanID(), spans[1].Parent().SpanID())
+}
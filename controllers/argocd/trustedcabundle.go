@@ -0,0 +1,199 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+const (
+	trustedCABundleConfigMapSuffix    = "trusted-ca-bundle"
+	trustedCABundleVolumeName         = "trusted-ca-bundle"
+	trustedCABundleMountPath          = "/etc/ssl/certs/argocd-trusted-ca-bundle.crt"
+	trustedCABundleChecksumAnnotation = "checksum/trusted-ca-bundle"
+)
+
+// getTrustedCABundleConfigMapName returns the name of the ConfigMap holding the trusted CA bundle of the given ArgoCD.
+func getTrustedCABundleConfigMapName(cr *argoproj.ArgoCD) string {
+	if cr.Spec.TrustedCABundle != nil && cr.Spec.TrustedCABundle.ConfigMapName != "" {
+		return cr.Spec.TrustedCABundle.ConfigMapName
+	}
+	return nameWithSuffix(trustedCABundleConfigMapSuffix, cr)
+}
+
+// isTrustedCABundleUsed returns true if the given ArgoCD mounts the trusted CA bundle into its components, or trusts it
+// for some TLS hosts.
+func isTrustedCABundleUsed(cr *argoproj.ArgoCD) bool {
+	return cr.Spec.TrustedCABundle != nil || len(cr.Spec.TLS.TrustedCABundleHosts) > 0
+}
+
+// isTrustedCABundleInjected returns true if the ConfigMap holding the trusted CA bundle of the given ArgoCD should be
+// labelled for injection. Hosts trusting the bundle without a trustedCABundle spec use the injected default ConfigMap.
+func isTrustedCABundleInjected(cr *argoproj.ArgoCD) bool {
+	if cr.Spec.TrustedCABundle != nil {
+		return cr.Spec.TrustedCABundle.Inject
+	}
+	return len(cr.Spec.TLS.TrustedCABundleHosts) > 0
+}
+
+// getTrustedCABundleKey returns the key of the trusted CA bundle in its ConfigMap.
+func getTrustedCABundleKey(cr *argoproj.ArgoCD) string {
+	if cr.Spec.TrustedCABundle != nil && cr.Spec.TrustedCABundle.Key != "" {
+		return cr.Spec.TrustedCABundle.Key
+	}
+	return common.ArgoCDKeyTrustedCABundle
+}
+
+// isTrustedCABundleMounted returns true if the trusted CA bundle of the given ArgoCD is mounted into its components,
+// which is only the case once its ConfigMap holds the bundle, e.g. after it has been injected.
+func (r *ReconcileArgoCD) isTrustedCABundleMounted(cr *argoproj.ArgoCD) bool {
+	return r.getTrustedCABundleChecksum(cr) != ""
+}
+
+// getTrustedCABundleVolumes returns the volume holding the trusted CA bundle, if it is mounted.
+func (r *ReconcileArgoCD) getTrustedCABundleVolumes(cr *argoproj.ArgoCD) []corev1.Volume {
+	if !r.isTrustedCABundleMounted(cr) {
+		return nil
+	}
+	return []corev1.Volume{{
+		Name: trustedCABundleVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: getTrustedCABundleConfigMapName(cr)},
+				Items: []corev1.KeyToPath{{
+					Key:  getTrustedCABundleKey(cr),
+					Path: common.ArgoCDKeyTrustedCABundle,
+				}},
+				// the pods still start if the bundle is removed before they do
+				Optional: boolPtr(true),
+			},
+		},
+	}}
+}
+
+// getTrustedCABundleVolumeMounts returns the mount of the trusted CA bundle into the system certificate directory,
+// alongside the system trust store, if it is mounted.
+func (r *ReconcileArgoCD) getTrustedCABundleVolumeMounts(cr *argoproj.ArgoCD) []corev1.VolumeMount {
+	if !r.isTrustedCABundleMounted(cr) {
+		return nil
+	}
+	return []corev1.VolumeMount{{
+		Name:      trustedCABundleVolumeName,
+		MountPath: trustedCABundleMountPath,
+		SubPath:   common.ArgoCDKeyTrustedCABundle,
+		ReadOnly:  true,
+	}}
+}
+
+// getTrustedCABundle returns the trusted CA bundle of the given ArgoCD, if any.
+func (r *ReconcileArgoCD) getTrustedCABundle(cr *argoproj.ArgoCD) string {
+	if !isTrustedCABundleUsed(cr) {
+		return ""
+	}
+	cm := &corev1.ConfigMap{}
+	if !argoutil.IsObjectFound(r.Client, cr.Namespace, getTrustedCABundleConfigMapName(cr), cm) {
+		return ""
+	}
+	return cm.Data[getTrustedCABundleKey(cr)]
+}
+
+// getTrustedCABundleChecksum returns the checksum of the trusted CA bundle, used to roll out the components
+// when it changes, since files mounted through a subPath are not updated in running pods.
+func (r *ReconcileArgoCD) getTrustedCABundleChecksum(cr *argoproj.ArgoCD) string {
+	if cr.Spec.TrustedCABundle == nil {
+		return ""
+	}
+	cm := &corev1.ConfigMap{}
+	if !argoutil.IsObjectFound(r.Client, cr.Namespace, getTrustedCABundleConfigMapName(cr), cm) {
+		return ""
+	}
+	bundle, ok := cm.Data[getTrustedCABundleKey(cr)]
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(bundle)))
+}
+
+// setTrustedCABundleChecksum sets the trusted CA bundle checksum annotation on the given pod template.
+func setTrustedCABundleChecksum(template *corev1.PodTemplateSpec, checksum string) {
+	if checksum == "" {
+		return
+	}
+	if template.Annotations == nil {
+		template.Annotations = map[string]string{}
+	}
+	template.Annotations[trustedCABundleChecksumAnnotation] = checksum
+}
+
+// updateTrustedCABundleChecksum syncs the trusted CA bundle checksum annotation of the existing pod template with
+// the desired one, and returns true if it changed.
+func updateTrustedCABundleChecksum(existing, desired *corev1.PodTemplateSpec) bool {
+	checksum := desired.Annotations[trustedCABundleChecksumAnnotation]
+	if checksum == existing.Annotations[trustedCABundleChecksumAnnotation] {
+		return false
+	}
+	if checksum == "" {
+		delete(existing.Annotations, trustedCABundleChecksumAnnotation)
+		return true
+	}
+	if existing.Annotations == nil {
+		existing.Annotations = map[string]string{}
+	}
+	existing.Annotations[trustedCABundleChecksumAnnotation] = checksum
+	return true
+}
+
+// reconcileTrustedCABundleConfigMap will ensure that the ConfigMap holding the trusted CA bundle is labelled for
+// injection when requested, and that the ConfigMap managed by default is removed when no longer in use.
+func (r *ReconcileArgoCD) reconcileTrustedCABundleConfigMap(cr *argoproj.ArgoCD) error {
+	cm := newConfigMapWithName(getTrustedCABundleConfigMapName(cr), cr)
+	exists := argoutil.IsObjectFound(r.Client, cr.Namespace, cm.Name, cm)
+
+	if !isTrustedCABundleInjected(cr) {
+		// only the ConfigMap created by the operator is removed, never one provided by the user
+		if exists && !isTrustedCABundleUsed(cr) && cm.Labels[common.OpenShiftInjectTrustedCABundleLabel] == "true" &&
+			metav1.IsControlledBy(cm, cr) {
+			return r.Client.Delete(context.TODO(), cm)
+		}
+		return nil
+	}
+
+	if exists {
+		if cm.Labels[common.OpenShiftInjectTrustedCABundleLabel] == "true" {
+			return nil
+		}
+		if cm.Labels == nil {
+			cm.Labels = map[string]string{}
+		}
+		cm.Labels[common.OpenShiftInjectTrustedCABundleLabel] = "true"
+		return r.Client.Update(context.TODO(), cm)
+	}
+
+	cm.Labels[common.OpenShiftInjectTrustedCABundleLabel] = "true"
+	if err := controllerutil.SetControllerReference(cr, cm, r.Scheme); err != nil {
+		return err
+	}
+	return r.Client.Create(context.TODO(), cm)
+}
@@ -0,0 +1,195 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+func TestReconcileArgoCD_TrustedCABundleConfigMap(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Spec.TrustedCABundle = &argoproj.ArgoCDTrustedCABundleSpec{Inject: true}
+	})
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileTrustedCABundleConfigMap(a))
+	cm := &corev1.ConfigMap{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-trusted-ca-bundle", Namespace: testNamespace}, cm))
+	assert.Equal(t, "true", cm.Labels[common.OpenShiftInjectTrustedCABundleLabel])
+
+	// the ConfigMap created by the operator is removed along with the spec
+	a.Spec.TrustedCABundle = nil
+	assert.NoError(t, r.reconcileTrustedCABundleConfigMap(a))
+	assert.False(t, argoutil.IsObjectFound(r.Client, testNamespace, "argocd-trusted-ca-bundle", cm))
+
+	// a ConfigMap provided by the user is labelled but never removed
+	user := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "corporate-ca", Namespace: testNamespace},
+		Data:       map[string]string{"ca.pem": "bundle"},
+	}
+	assert.NoError(t, r.Client.Create(context.TODO(), user))
+	a.Spec.TrustedCABundle = &argoproj.ArgoCDTrustedCABundleSpec{ConfigMapName: "corporate-ca", Key: "ca.pem", Inject: true}
+	assert.NoError(t, r.reconcileTrustedCABundleConfigMap(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "corporate-ca", Namespace: testNamespace}, cm))
+	assert.Equal(t, "true", cm.Labels[common.OpenShiftInjectTrustedCABundleLabel])
+	assert.Equal(t, "bundle", cm.Data["ca.pem"])
+
+	a.Spec.TrustedCABundle = nil
+	assert.NoError(t, r.reconcileTrustedCABundleConfigMap(a))
+	assert.True(t, argoutil.IsObjectFound(r.Client, testNamespace, "corporate-ca", cm))
+}
+
+func TestReconcileArgoCD_TrustedCABundleMounts(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Spec.TrustedCABundle = &argoproj.ArgoCDTrustedCABundleSpec{ConfigMapName: "corporate-ca", Key: "ca.pem"}
+	})
+	bundle := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "corporate-ca", Namespace: testNamespace},
+		Data:       map[string]string{"ca.pem": "bundle"},
+	}
+	resObjs := []client.Object{a, bundle}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	volume := corev1.Volume{
+		Name: "trusted-ca-bundle",
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "corporate-ca"},
+				Items:                []corev1.KeyToPath{{Key: "ca.pem", Path: "ca-bundle.crt"}},
+				Optional:             boolPtr(true),
+			},
+		},
+	}
+	mount := corev1.VolumeMount{
+		Name:      "trusted-ca-bundle",
+		MountPath: "/etc/ssl/certs/argocd-trusted-ca-bundle.crt",
+		SubPath:   "ca-bundle.crt",
+		ReadOnly:  true,
+	}
+
+	assert.NoError(t, r.reconcileServerDeployment(a, false))
+	assert.NoError(t, r.reconcileRepoDeployment(a, false))
+	for _, name := range []string{"argocd-server", "argocd-repo-server"} {
+		deployment := &appsv1.Deployment{}
+		assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: testNamespace}, deployment))
+		assert.Contains(t, deployment.Spec.Template.Spec.Volumes, volume, name)
+		assert.Contains(t, deployment.Spec.Template.Spec.Containers[0].VolumeMounts, mount, name)
+		assert.NotEmpty(t, deployment.Spec.Template.Annotations[trustedCABundleChecksumAnnotation], name)
+	}
+
+	assert.NoError(t, r.reconcileApplicationControllerStatefulSet(a, false))
+	ss := &appsv1.StatefulSet{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-application-controller", Namespace: testNamespace}, ss))
+	assert.Contains(t, ss.Spec.Template.Spec.Volumes, volume)
+	assert.Contains(t, ss.Spec.Template.Spec.Containers[0].VolumeMounts, mount)
+
+	// a new bundle rolls out the components
+	deployment := &appsv1.Deployment{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-server", Namespace: testNamespace}, deployment))
+	checksum := deployment.Spec.Template.Annotations[trustedCABundleChecksumAnnotation]
+	bundle.Data["ca.pem"] = "new bundle"
+	assert.NoError(t, r.Client.Update(context.TODO(), bundle))
+	assert.NoError(t, r.reconcileServerDeployment(a, false))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-server", Namespace: testNamespace}, deployment))
+	assert.NotEqual(t, checksum, deployment.Spec.Template.Annotations[trustedCABundleChecksumAnnotation])
+
+	// a ConfigMap without the bundle, e.g. not injected yet, is not mounted
+	delete(bundle.Data, "ca.pem")
+	assert.NoError(t, r.Client.Update(context.TODO(), bundle))
+	assert.NoError(t, r.reconcileServerDeployment(a, false))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-server", Namespace: testNamespace}, deployment))
+	assert.NotContains(t, deployment.Spec.Template.Spec.Volumes, volume)
+	assert.NotContains(t, deployment.Spec.Template.Spec.Containers[0].VolumeMounts, mount)
+
+	bundle.Data["ca.pem"] = "bundle"
+	assert.NoError(t, r.Client.Update(context.TODO(), bundle))
+	assert.NoError(t, r.reconcileServerDeployment(a, false))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-server", Namespace: testNamespace}, deployment))
+	assert.Contains(t, deployment.Spec.Template.Spec.Volumes, volume)
+
+	// and removing the spec unmounts it
+	a.Spec.TrustedCABundle = nil
+	assert.NoError(t, r.reconcileServerDeployment(a, false))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-server", Namespace: testNamespace}, deployment))
+	assert.NotContains(t, deployment.Spec.Template.Spec.Volumes, volume)
+	assert.NotContains(t, deployment.Spec.Template.Spec.Containers[0].VolumeMounts, mount)
+	assert.NotContains(t, deployment.Spec.Template.Annotations, trustedCABundleChecksumAnnotation)
+}
+
+func TestReconcileArgoCD_trustedCABundleConfigMapMapper(t *testing.T) {
+	a := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Spec.TrustedCABundle = &argoproj.ArgoCDTrustedCABundleSpec{ConfigMapName: "corporate-ca"}
+	})
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "corporate-ca", Namespace: testNamespace}}
+	assert.Len(t, r.trustedCABundleConfigMapMapper(context.TODO(), cm), 1)
+
+	cm.Name = "other"
+	assert.Empty(t, r.trustedCABundleConfigMapMapper(context.TODO(), cm))
+
+	// hosts trusting the bundle use the default ConfigMap
+	a.Spec.TrustedCABundle = nil
+	a.Spec.TLS.TrustedCABundleHosts = []string{"git.internal.com"}
+	assert.NoError(t, r.Client.Update(context.TODO(), a))
+	cm.Name = "argocd-trusted-ca-bundle"
+	assert.Len(t, r.trustedCABundleConfigMapMapper(context.TODO(), cm), 1)
+}
+
+func TestReconcileArgoCD_isTrustedCABundleConfigMap(t *testing.T) {
+	a := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Spec.TrustedCABundle = &argoproj.ArgoCDTrustedCABundleSpec{ConfigMapName: "corporate-ca"}
+	})
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.True(t, r.isTrustedCABundleConfigMap(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "corporate-ca", Namespace: testNamespace}}))
+	assert.False(t, r.isTrustedCABundleConfigMap(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: testNamespace}}))
+	assert.False(t, r.isTrustedCABundleConfigMap(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "corporate-ca", Namespace: "other"}}))
+}
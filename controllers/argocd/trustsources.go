@@ -0,0 +1,296 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"encoding/pem"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+// trustSource holds the data of a ConfigMap or Secret labelled as a source of SSH known hosts or TLS certificates.
+type trustSource struct {
+	name string
+	data map[string]string
+	// allowedHosts are the host name patterns the source may add entries for, when restricted.
+	allowedHosts []string
+	restricted   bool
+}
+
+// allowsHost returns true if the source may add entries for the given host name.
+func (s trustSource) allowsHost(host string) bool {
+	if !s.restricted {
+		return true
+	}
+	for _, pattern := range s.allowedHosts {
+		if ok, err := path.Match(strings.ToLower(pattern), strings.ToLower(host)); err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
+
+// allowsAllHosts returns true if the source may add the given known hosts entry, that is all of its host names are
+// allowed.
+func (s trustSource) allowsAllHosts(line string) bool {
+	hosts, ok := getSSHKnownHostsEntryHosts(line)
+	if !ok {
+		return false
+	}
+	for _, host := range hosts {
+		if !s.allowsHost(host) {
+			return false
+		}
+	}
+	return true
+}
+
+// isSSHKnownHostsReconciled returns true if the SSH known hosts ConfigMap should be kept in sync with the given ArgoCD.
+func isSSHKnownHostsReconciled(cr *argoproj.ArgoCD) bool {
+	return cr.Spec.InitialSSHKnownHosts.Reconcile || cr.Spec.InitialSSHKnownHosts.AggregateFromNamespaces
+}
+
+// isTLSCertsReconciled returns true if the TLS certs ConfigMap should be kept in sync with the given ArgoCD.
+func isTLSCertsReconciled(cr *argoproj.ArgoCD) bool {
+	return cr.Spec.TLS.ReconcileCerts || cr.Spec.TLS.AggregateCertsFromNamespaces || len(cr.Spec.TLS.TrustedCABundleHosts) > 0
+}
+
+// isPEMCertificate returns true if the given data holds at least one PEM encoded certificate.
+func isPEMCertificate(data string) bool {
+	rest := []byte(data)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			return false
+		}
+		if block.Type == "CERTIFICATE" {
+			return true
+		}
+	}
+}
+
+// isTrustSourceNamespace returns true if the sources of the given namespace are aggregated by the given ArgoCD,
+// according to the given spec.
+func isTrustSourceNamespace(cr *argoproj.ArgoCD, spec *argoproj.ArgoCDTrustSourceNamespacesSpec, namespace string) bool {
+	if namespace == cr.Namespace {
+		return true
+	}
+	return spec != nil && containsString(spec.Namespaces, namespace)
+}
+
+// getTrustSourceNamespaces returns the managed namespaces listed in the given spec, other than the namespace of the
+// given ArgoCD, in order.
+func (r *ReconcileArgoCD) getTrustSourceNamespaces(cr *argoproj.ArgoCD, spec *argoproj.ArgoCDTrustSourceNamespacesSpec) []string {
+	if spec == nil {
+		return nil
+	}
+
+	managed := make(map[string]bool)
+	if r.ManagedNamespaces != nil {
+		for _, ns := range r.ManagedNamespaces.Items {
+			managed[ns.Name] = true
+		}
+	}
+
+	var namespaces []string
+	for _, ns := range spec.Namespaces {
+		if ns == cr.Namespace {
+			continue
+		}
+		if !managed[ns] {
+			log.Info(fmt.Sprintf("ignoring trust sources of namespace %s, it is not managed by ArgoCD %s", ns, cr.Name))
+			continue
+		}
+		namespaces = append(namespaces, ns)
+	}
+	sort.Strings(namespaces)
+	return namespaces
+}
+
+// getTrustSources returns the ConfigMaps and Secrets carrying the given label in the namespace of the given ArgoCD,
+// followed by those in the managed namespaces listed in the given spec, ordered by namespace and name, ConfigMaps
+// first. The sources of the managed namespaces may only add entries for the allowed hosts of the spec.
+func (r *ReconcileArgoCD) getTrustSources(cr *argoproj.ArgoCD, label string, spec *argoproj.ArgoCDTrustSourceNamespacesSpec) ([]trustSource, error) {
+	var sources []trustSource
+	for _, ns := range append([]string{cr.Namespace}, r.getTrustSourceNamespaces(cr, spec)...) {
+		restricted := ns != cr.Namespace
+		var allowedHosts []string
+		if restricted {
+			allowedHosts = spec.AllowedHosts
+		}
+
+		cms := &corev1.ConfigMapList{}
+		if err := r.Client.List(context.TODO(), cms, client.InNamespace(ns), client.HasLabels{label}); err != nil {
+			return nil, err
+		}
+		sort.Slice(cms.Items, func(i, j int) bool {
+			return cms.Items[i].Name < cms.Items[j].Name
+		})
+		for _, cm := range cms.Items {
+			sources = append(sources, trustSource{
+				name:         fmt.Sprintf("configmap %s/%s", ns, cm.Name),
+				data:         cm.Data,
+				allowedHosts: allowedHosts,
+				restricted:   restricted,
+			})
+		}
+
+		secrets := &corev1.SecretList{}
+		if err := r.Client.List(context.TODO(), secrets, client.InNamespace(ns), client.HasLabels{label}); err != nil {
+			return nil, err
+		}
+		sort.Slice(secrets.Items, func(i, j int) bool {
+			return secrets.Items[i].Name < secrets.Items[j].Name
+		})
+		for _, secret := range secrets.Items {
+			data := make(map[string]string, len(secret.Data))
+			for k, v := range secret.Data {
+				data[k] = string(v)
+			}
+			sources = append(sources, trustSource{
+				name:         fmt.Sprintf("secret %s/%s", ns, secret.Name),
+				data:         data,
+				allowedHosts: allowedHosts,
+				restricted:   restricted,
+			})
+		}
+	}
+	return sources, nil
+}
+
+// getSSHKnownHostsEntryHosts returns the host names of the given known hosts entry, without brackets and port, or
+// false if they cannot be determined, such as for hashed or negated host names.
+func getSSHKnownHostsEntryHosts(line string) ([]string, bool) {
+	fields := strings.Fields(line)
+	if len(fields) > 0 && strings.HasPrefix(fields[0], "@") {
+		fields = fields[1:] // @cert-authority or @revoked marker
+	}
+	if len(fields) == 0 {
+		return nil, false
+	}
+
+	var hosts []string
+	for _, host := range strings.Split(fields[0], ",") {
+		if host == "" || strings.HasPrefix(host, "|") || strings.HasPrefix(host, "!") {
+			return nil, false
+		}
+		if strings.HasPrefix(host, "[") {
+			if i := strings.Index(host, "]"); i > 0 {
+				host = host[1:i]
+			}
+		}
+		hosts = append(hosts, host)
+	}
+	return hosts, true
+}
+
+// getSSHKnownHosts will return the SSH known hosts for the given ArgoCD, including the entries of the known hosts
+// sources when aggregation is enabled.
+func (r *ReconcileArgoCD) getSSHKnownHosts(cr *argoproj.ArgoCD) (string, error) {
+	skh := getInitialSSHKnownHosts(cr)
+	if !cr.Spec.InitialSSHKnownHosts.AggregateFromNamespaces {
+		return skh, nil
+	}
+
+	sources, err := r.getTrustSources(cr, common.ArgoCDSSHKnownHostsSourceLabel, cr.Spec.InitialSSHKnownHosts.SourceNamespaces)
+	if err != nil {
+		return "", err
+	}
+
+	seen := make(map[string]bool)
+	for _, line := range strings.Split(skh, "\n") {
+		seen[strings.TrimSpace(line)] = true
+	}
+	for _, source := range sources {
+		for _, line := range strings.Split(source.data[common.ArgoCDKeySSHKnownHosts], "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" || strings.HasPrefix(line, "#") || seen[line] {
+				continue
+			}
+			if source.restricted && !source.allowsAllHosts(line) {
+				log.Info(fmt.Sprintf("ignoring known hosts entry of %s, it is not for allowed hosts", source.name))
+				continue
+			}
+			seen[line] = true
+			if skh != "" && !strings.HasSuffix(skh, "\n") {
+				skh += "\n"
+			}
+			skh += line + "\n"
+		}
+	}
+	return skh, nil
+}
+
+// getTLSCerts will return the TLS certs for the given ArgoCD, including the certificates of the TLS certs sources
+// when aggregation is enabled and the cluster's trusted CA bundle for the hosts that trust it.
+func (r *ReconcileArgoCD) getTLSCerts(cr *argoproj.ArgoCD) (map[string]string, error) {
+	certs := make(map[string]string)
+	for host, cert := range cr.Spec.TLS.InitialCerts {
+		certs[host] = cert
+	}
+
+	add := func(host, cert string) {
+		cert = strings.TrimSpace(cert) + "\n"
+		if strings.Contains(certs[host], cert) {
+			return
+		}
+		if certs[host] != "" && !strings.HasSuffix(certs[host], "\n") {
+			certs[host] += "\n"
+		}
+		certs[host] += cert
+	}
+
+	if cr.Spec.TLS.AggregateCertsFromNamespaces {
+		sources, err := r.getTrustSources(cr, common.ArgoCDTLSCertsSourceLabel, cr.Spec.TLS.CertsSourceNamespaces)
+		if err != nil {
+			return nil, err
+		}
+		for _, source := range sources {
+			hosts := make([]string, 0, len(source.data))
+			for host := range source.data {
+				hosts = append(hosts, host)
+			}
+			sort.Strings(hosts)
+			for _, host := range hosts {
+				if !source.allowsHost(host) {
+					log.Info(fmt.Sprintf("ignoring entry %s of %s, it is not an allowed host", host, source.name))
+					continue
+				}
+				if !isPEMCertificate(source.data[host]) {
+					log.Info(fmt.Sprintf("ignoring entry %s of %s, it does not hold a PEM certificate", host, source.name))
+					continue
+				}
+				add(host, source.data[host])
+			}
+		}
+	}
+
+	if bundle := r.getTrustedCABundle(cr); isPEMCertificate(bundle) {
+		for _, host := range cr.Spec.TLS.TrustedCABundleHosts {
+			add(host, bundle)
+		}
+	}
+	return certs, nil
+}
@@ -0,0 +1,255 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+// makeTestCertificatePEM returns a new PEM encoded self-signed certificate.
+func makeTestCertificatePEM(t *testing.T, name string) string {
+	key, err := argoutil.NewPrivateKey()
+	assert.NoError(t, err)
+	cert, err := argoutil.NewSelfSignedCACertificate(name, key)
+	assert.NoError(t, err)
+	return string(argoutil.EncodeCertificatePEM(cert))
+}
+
+func TestReconcileArgoCD_reconcileSSHKnownHosts_aggregate(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Spec.InitialSSHKnownHosts = argoproj.SSHHostsSpec{
+			ExcludeDefaultHosts:     true,
+			Keys:                    "git.example.com ssh-ed25519 AAAAbase",
+			AggregateFromNamespaces: true,
+			SourceNamespaces: &argoproj.ArgoCDTrustSourceNamespacesSpec{
+				Namespaces:   []string{"team-a", "team-b", "other"},
+				AllowedHosts: []string{"git.team-a.com", "*.team-b.com"},
+			},
+		}
+	})
+	instance := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "known-hosts",
+			Namespace: testNamespace,
+			Labels:    map[string]string{common.ArgoCDSSHKnownHostsSourceLabel: "true"},
+		},
+		Data: map[string]string{
+			common.ArgoCDKeySSHKnownHosts: "github.com ssh-ed25519 AAAAgithub\n",
+		},
+	}
+	teamA := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "known-hosts",
+			Namespace: "team-a",
+			Labels:    map[string]string{common.ArgoCDSSHKnownHostsSourceLabel: "true"},
+		},
+		Data: map[string]string{
+			common.ArgoCDKeySSHKnownHosts: "# team a\ngit.example.com ssh-ed25519 AAAAbase\ngit.team-a.com ssh-ed25519 AAAAteama\n" +
+				"github.com ssh-ed25519 AAAAevil\n[git.team-a.com]:2222,github.com ssh-ed25519 AAAAevil\n|1|aGFzaA==|aGFzaA== ssh-ed25519 AAAAevil\n",
+		},
+	}
+	teamB := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "known-hosts",
+			Namespace: "team-b",
+			Labels:    map[string]string{common.ArgoCDSSHKnownHostsSourceLabel: "true"},
+		},
+		Data: map[string][]byte{
+			common.ArgoCDKeySSHKnownHosts: []byte("[git.team-b.com]:2222 ssh-ed25519 AAAAteamb"),
+		},
+	}
+	unmanaged := teamA.DeepCopy()
+	unmanaged.Namespace = "other"
+
+	resObjs := []client.Object{a, instance, teamA, teamB, unmanaged}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+	r.ManagedNamespaces = &corev1.NamespaceList{Items: []corev1.Namespace{
+		{ObjectMeta: metav1.ObjectMeta{Name: "team-b"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "team-a"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: testNamespace}},
+	}}
+
+	assert.NoError(t, r.reconcileSSHKnownHosts(a))
+	cm := &corev1.ConfigMap{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDKnownHostsConfigMapName, Namespace: testNamespace}, cm))
+	// the managed namespaces only add entries for their allowed hosts
+	assert.Equal(t, "git.example.com ssh-ed25519 AAAAbase\ngithub.com ssh-ed25519 AAAAgithub\ngit.team-a.com ssh-ed25519 AAAAteama\n"+
+		"[git.team-b.com]:2222 ssh-ed25519 AAAAteamb\n", cm.Data[common.ArgoCDKeySSHKnownHosts])
+	assert.NotContains(t, cm.Data[common.ArgoCDKeySSHKnownHosts], "AAAAevil")
+
+	// entries follow their sources
+	assert.NoError(t, r.Client.Delete(context.TODO(), teamB))
+	assert.NoError(t, r.reconcileSSHKnownHosts(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDKnownHostsConfigMapName, Namespace: testNamespace}, cm))
+	assert.NotContains(t, cm.Data[common.ArgoCDKeySSHKnownHosts], "git.team-b.com")
+
+	// namespaces that are not listed are not aggregated
+	a.Spec.InitialSSHKnownHosts.SourceNamespaces = nil
+	assert.NoError(t, r.reconcileSSHKnownHosts(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDKnownHostsConfigMapName, Namespace: testNamespace}, cm))
+	assert.Equal(t, "git.example.com ssh-ed25519 AAAAbase\ngithub.com ssh-ed25519 AAAAgithub\n", cm.Data[common.ArgoCDKeySSHKnownHosts])
+	a.Spec.InitialSSHKnownHosts.SourceNamespaces = &argoproj.ArgoCDTrustSourceNamespacesSpec{Namespaces: []string{"team-a"}, AllowedHosts: []string{"git.team-a.com"}}
+	assert.NoError(t, r.reconcileSSHKnownHosts(a))
+
+	// without reconciliation, the ConfigMap is only initialized
+	a.Spec.InitialSSHKnownHosts = argoproj.SSHHostsSpec{}
+	assert.NoError(t, r.reconcileSSHKnownHosts(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDKnownHostsConfigMapName, Namespace: testNamespace}, cm))
+	assert.Contains(t, cm.Data[common.ArgoCDKeySSHKnownHosts], "git.team-a.com")
+
+	a.Spec.InitialSSHKnownHosts.Reconcile = true
+	assert.NoError(t, r.reconcileSSHKnownHosts(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDKnownHostsConfigMapName, Namespace: testNamespace}, cm))
+	assert.Equal(t, common.ArgoCDDefaultSSHKnownHosts, cm.Data[common.ArgoCDKeySSHKnownHosts])
+}
+
+func TestReconcileArgoCD_reconcileTLSCerts_aggregate(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	initial := makeTestCertificatePEM(t, "initial")
+	teamA := makeTestCertificatePEM(t, "team-a")
+	ca := makeTestCertificatePEM(t, "ca")
+
+	a := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Spec.TLS.InitialCerts = map[string]string{"git.example.com": initial}
+		cr.Spec.TLS.AggregateCertsFromNamespaces = true
+		cr.Spec.TLS.CertsSourceNamespaces = &argoproj.ArgoCDTrustSourceNamespacesSpec{
+			Namespaces:   []string{"team-a"},
+			AllowedHosts: []string{"*.team-a.com"},
+		}
+		cr.Spec.TLS.TrustedCABundleHosts = []string{"git.internal.com"}
+	})
+	source := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "certs",
+			Namespace: "team-a",
+			Labels:    map[string]string{common.ArgoCDTLSCertsSourceLabel: "true"},
+		},
+		Data: map[string]string{
+			"git.example.com": teamA,
+			"git.team-a.com":  teamA,
+			"broken.com":      "not a certificate",
+		},
+	}
+	manual := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: common.ArgoCDTLSCertsConfigMapName, Namespace: testNamespace},
+		Data:       map[string]string{"manual.com": initial},
+	}
+
+	resObjs := []client.Object{a, source, manual}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+	assert.NoError(t, createNamespace(r, "team-a", testNamespace))
+
+	assert.NoError(t, r.reconcileTLSCerts(a))
+	cm := &corev1.ConfigMap{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDTLSCertsConfigMapName, Namespace: testNamespace}, cm))
+	assert.Equal(t, map[string]string{
+		"git.example.com": initial,
+		"git.team-a.com":  teamA,
+	}, cm.Data)
+
+	// the trusted CA bundle ConfigMap is created for injection
+	assert.NoError(t, r.reconcileTrustedCABundleConfigMap(a))
+	bundle := &corev1.ConfigMap{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-trusted-ca-bundle", Namespace: testNamespace}, bundle))
+	assert.Equal(t, "true", bundle.Labels[common.OpenShiftInjectTrustedCABundleLabel])
+
+	bundle.Data = map[string]string{common.ArgoCDKeyTrustedCABundle: ca}
+	assert.NoError(t, r.Client.Update(context.TODO(), bundle))
+	assert.NoError(t, r.reconcileTLSCerts(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDTLSCertsConfigMapName, Namespace: testNamespace}, cm))
+	assert.Equal(t, ca, cm.Data["git.internal.com"])
+	assert.Equal(t, 1, strings.Count(cm.Data["git.team-a.com"], teamA))
+
+	// the bundle ConfigMap is removed along with the last host trusting it
+	a.Spec.TLS.TrustedCABundleHosts = nil
+	a.Spec.TLS.AggregateCertsFromNamespaces = false
+	assert.NoError(t, r.reconcileTrustedCABundleConfigMap(a))
+	assert.NoError(t, r.reconcileTLSCerts(a))
+	assert.True(t, argoutil.IsObjectFound(r.Client, testNamespace, common.ArgoCDTLSCertsConfigMapName, cm))
+	assert.Contains(t, cm.Data, "git.internal.com")
+	assert.False(t, argoutil.IsObjectFound(r.Client, testNamespace, "argocd-trusted-ca-bundle", bundle))
+
+	a.Spec.TLS.ReconcileCerts = true
+	assert.NoError(t, r.reconcileTLSCerts(a))
+	assert.True(t, argoutil.IsObjectFound(r.Client, testNamespace, common.ArgoCDTLSCertsConfigMapName, cm))
+	assert.Equal(t, map[string]string{"git.example.com": initial}, cm.Data)
+
+	// the bundle of the trustedCABundle spec is trusted, without creating the default ConfigMap
+	a.Spec.TLS.TrustedCABundleHosts = []string{"git.internal.com"}
+	a.Spec.TrustedCABundle = &argoproj.ArgoCDTrustedCABundleSpec{ConfigMapName: "corporate-ca", Key: "ca.pem"}
+	assert.NoError(t, r.Client.Create(context.TODO(), &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "corporate-ca", Namespace: testNamespace},
+		Data:       map[string]string{"ca.pem": ca},
+	}))
+	assert.NoError(t, r.reconcileTrustedCABundleConfigMap(a))
+	assert.NoError(t, r.reconcileTLSCerts(a))
+	assert.True(t, argoutil.IsObjectFound(r.Client, testNamespace, common.ArgoCDTLSCertsConfigMapName, cm))
+	assert.Equal(t, ca, cm.Data["git.internal.com"])
+	assert.False(t, argoutil.IsObjectFound(r.Client, testNamespace, "argocd-trusted-ca-bundle", bundle))
+}
+
+func TestReconcileArgoCD_trustSourceMapper(t *testing.T) {
+	a := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Spec.TLS.AggregateCertsFromNamespaces = true
+	})
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+	assert.NoError(t, createNamespace(r, "team-a", testNamespace))
+
+	source := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+		Name:      "certs",
+		Namespace: "team-a",
+		Labels:    map[string]string{common.ArgoCDTLSCertsSourceLabel: "true"},
+	}}
+	// team-a is not a certificate source namespace of the instance
+	assert.Empty(t, r.trustSourceMapper(context.TODO(), source))
+
+	a.Spec.TLS.CertsSourceNamespaces = &argoproj.ArgoCDTrustSourceNamespacesSpec{Namespaces: []string{"team-a"}}
+	assert.NoError(t, r.Client.Update(context.TODO(), a))
+	requests := r.trustSourceMapper(context.TODO(), source)
+	assert.Len(t, requests, 1)
+	assert.Equal(t, types.NamespacedName{Name: a.Name, Namespace: a.Namespace}, requests[0].NamespacedName)
+
+	// known hosts aggregation is not enabled
+	source.Labels = map[string]string{common.ArgoCDSSHKnownHostsSourceLabel: "true"}
+	assert.Empty(t, r.trustSourceMapper(context.TODO(), source))
+}
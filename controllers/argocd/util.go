@@ -63,6 +63,8 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 )
 
 const (
@@ -141,26 +143,21 @@ func getArgoApplicationControllerCommand(cr *argoproj.ArgoCD, useTLSForRedis boo
 	}
 
 	if cr.Spec.Redis.IsEnabled() {
-		cmd = append(cmd, "--redis", getRedisServerAddress(cr))
+		cmd = append(cmd, getRedisCommandArgs(cr, useTLSForRedis, "/app/config/controller/tls")...)
 	} else {
 		log.Info("Redis is Disabled. Skipping adding Redis configuration to Application Controller.")
 	}
 
-	if useTLSForRedis {
-		cmd = append(cmd, "--redis-use-tls")
-		if isRedisTLSVerificationDisabled(cr) {
-			cmd = append(cmd, "--redis-insecure-skip-tls-verify")
-		} else {
-			cmd = append(cmd, "--redis-ca-certificate", "/app/config/controller/tls/redis/tls.crt")
-		}
-	}
-
 	if cr.Spec.Repo.IsEnabled() {
 		cmd = append(cmd, "--repo-server", getRepoServerAddress(cr))
 	} else {
 		log.Info("Repo Server is disabled. This would affect the functioning of Application Controller.")
 	}
 
+	if isRepoServerTLSVerificationRequested(cr) {
+		cmd = append(cmd, "--repo-server-strict-tls")
+	}
+
 	cmd = append(cmd, "--status-processors", fmt.Sprint(getArgoServerStatusProcessors(cr)))
 	cmd = append(cmd, "--kubectl-parallelism-limit", fmt.Sprint(getArgoControllerParellismLimit(cr)))
 
@@ -168,6 +165,8 @@ func getArgoApplicationControllerCommand(cr *argoproj.ArgoCD, useTLSForRedis boo
 		cmd = append(cmd, "--application-namespaces", fmt.Sprint(strings.Join(cr.Spec.SourceNamespaces, ",")))
 	}
 
+	cmd = append(cmd, getTracingCommandArgs(cr)...)
+
 	cmd = append(cmd, "--loglevel")
 	cmd = append(cmd, getLogLevel(cr.Spec.Controller.LogLevel))
 
@@ -262,7 +261,7 @@ func getArgoServerInsecure(cr *argoproj.ArgoCD) bool {
 }
 
 func isRepoServerTLSVerificationRequested(cr *argoproj.ArgoCD) bool {
-	return cr.Spec.Repo.VerifyTLS
+	return cr.Spec.Repo.VerifyTLS || isRepoServerRemoteTLS(cr)
 }
 
 func isRedisTLSVerificationDisabled(cr *argoproj.ArgoCD) bool {
@@ -394,12 +393,17 @@ func getRedisConfigPath() string {
 	return common.ArgoCDDefaultRedisConfigPath
 }
 
-// getRedisInitScript will load the redis configuration from a template on disk for the given ArgoCD.
+// getRedisConf will load the redis configuration from a template on disk for the given ArgoCD.
 // If an error occurs, an empty string value will be returned.
-func getRedisConf(useTLSForRedis bool) string {
+func getRedisConf(cr *argoproj.ArgoCD, useTLSForRedis bool) string {
 	path := fmt.Sprintf("%s/redis.conf.tpl", getRedisConfigPath())
-	params := map[string]string{
-		"UseTLS": strconv.FormatBool(useTLSForRedis),
+	persistence := getRedisHAPersistence(cr)
+	params := map[string]interface{}{
+		"UseTLS":          strconv.FormatBool(useTLSForRedis),
+		"Save":            getRedisSavePoints(persistence),
+		"AppendOnly":      persistence != nil && persistence.AppendOnly,
+		"MaxMemory":       getRedisMaxMemory(cr),
+		"MaxMemoryPolicy": getRedisMaxMemoryPolicy(cr),
 	}
 	conf, err := loadTemplateFile(path, params)
 	if err != nil {
@@ -428,6 +432,25 @@ func getRedisContainerImage(cr *argoproj.ArgoCD) string {
 	return argoutil.CombineImageTag(img, tag)
 }
 
+// getRedisExporterContainerImage will return the container image for the Redis exporter sidecar.
+func getRedisExporterContainerImage(cr *argoproj.ArgoCD) string {
+	defaultImg, defaultTag := false, false
+	img := cr.Spec.Redis.Exporter.Image
+	if img == "" {
+		img = common.ArgoCDDefaultRedisExporterImage
+		defaultImg = true
+	}
+	tag := cr.Spec.Redis.Exporter.Version
+	if tag == "" {
+		tag = common.ArgoCDDefaultRedisExporterVersion
+		defaultTag = true
+	}
+	if e := os.Getenv(common.ArgoCDRedisExporterImageEnvName); e != "" && (defaultTag && defaultImg) {
+		return e
+	}
+	return argoutil.CombineImageTag(img, tag)
+}
+
 // getRedisHAContainerImage will return the container image for the Redis server in HA mode.
 func getRedisHAContainerImage(cr *argoproj.ArgoCD) string {
 	defaultImg, defaultTag := false, false
@@ -478,9 +501,10 @@ func getRedisHAProxyContainerImage(cr *argoproj.ArgoCD) string {
 // If an error occurs, an empty string value will be returned.
 func getRedisInitScript(cr *argoproj.ArgoCD, useTLSForRedis bool) string {
 	path := fmt.Sprintf("%s/init.sh.tpl", getRedisConfigPath())
-	vars := map[string]string{
+	vars := map[string]interface{}{
 		"ServiceName": nameWithSuffix("redis-ha", cr),
 		"UseTLS":      strconv.FormatBool(useTLSForRedis),
+		"Quorum":      getRedisHAQuorum(cr),
 	}
 
 	script, err := loadTemplateFile(path, vars)
@@ -493,11 +517,13 @@ func getRedisInitScript(cr *argoproj.ArgoCD, useTLSForRedis bool) string {
 
 // getRedisHAProxySConfig will load the Redis HA Proxy configuration from a template on disk for the given ArgoCD.
 // If an error occurs, an empty string value will be returned.
-func getRedisHAProxyConfig(cr *argoproj.ArgoCD, useTLSForRedis bool) string {
+func getRedisHAProxyConfig(cr *argoproj.ArgoCD, useTLSForRedis bool, servers int32) string {
 	path := fmt.Sprintf("%s/haproxy.cfg.tpl", getRedisConfigPath())
-	vars := map[string]string{
+	vars := map[string]interface{}{
 		"ServiceName": nameWithSuffix("redis-ha", cr),
 		"UseTLS":      strconv.FormatBool(useTLSForRedis),
+		"Servers":     getRedisHAServers(servers),
+		"Quorum":      getRedisHAQuorum(cr),
 	}
 
 	script, err := loadTemplateFile(path, vars)
@@ -510,10 +536,11 @@ func getRedisHAProxyConfig(cr *argoproj.ArgoCD, useTLSForRedis bool) string {
 
 // getRedisHAProxyScript will load the Redis HA Proxy init script from a template on disk for the given ArgoCD.
 // If an error occurs, an empty string value will be returned.
-func getRedisHAProxyScript(cr *argoproj.ArgoCD) string {
+func getRedisHAProxyScript(cr *argoproj.ArgoCD, servers int32) string {
 	path := fmt.Sprintf("%s/haproxy_init.sh.tpl", getRedisConfigPath())
-	vars := map[string]string{
+	vars := map[string]interface{}{
 		"ServiceName": nameWithSuffix("redis-ha", cr),
+		"Servers":     getRedisHAServers(servers),
 	}
 
 	script, err := loadTemplateFile(path, vars)
@@ -610,6 +637,9 @@ func getSentinelLivenessScript(useTLSForRedis bool) string {
 
 // getRedisServerAddress will return the Redis service address for the given ArgoCD.
 func getRedisServerAddress(cr *argoproj.ArgoCD) string {
+	if isRedisExternal(cr) && cr.Spec.Redis.External.Address != "" {
+		return cr.Spec.Redis.External.Address
+	}
 	if cr.Spec.Redis.Remote != nil && *cr.Spec.Redis.Remote != "" {
 		return *cr.Spec.Redis.Remote
 	}
@@ -620,7 +650,7 @@ func getRedisServerAddress(cr *argoproj.ArgoCD) string {
 }
 
 // loadTemplateFile will parse a template with the given path and execute it with the given params.
-func loadTemplateFile(path string, params map[string]string) (string, error) {
+func loadTemplateFile(path string, params interface{}) (string, error) {
 	tmpl, err := template.ParseFiles(path)
 	if err != nil {
 		log.Error(err, "unable to parse template")
@@ -660,6 +690,10 @@ func InspectCluster() error {
 		return err
 	}
 
+	if err := verifyGatewayAPI(); err != nil {
+		return err
+	}
+
 	if err := verifyKeycloakTemplateAPIs(); err != nil {
 		return err
 	}
@@ -734,107 +768,133 @@ func (r *ReconcileArgoCD) redisShouldUseTLS(cr *argoproj.ArgoCD) bool {
 }
 
 // reconcileResources will reconcile common ArgoCD resources.
-func (r *ReconcileArgoCD) reconcileResources(cr *argoproj.ArgoCD) error {
+func (r *ReconcileArgoCD) reconcileResources(ctx context.Context, cr *argoproj.ArgoCD) error {
 
 	// we reconcile SSO first so that we can catch and throw errors for any illegal SSO configurations right away, and return control from here
 	// preventing dex resources from getting created anyway through the other function calls, effectively bypassing the SSO checks
 	log.Info("reconciling SSO")
-	if err := r.reconcileSSO(cr); err != nil {
+	if err := r.runReconcileStep(ctx, cr, "reconcileSSO", func() error { return r.reconcileSSO(cr) }); err != nil {
 		log.Info(err.Error())
 	}
 
 	log.Info("reconciling status")
-	if err := r.reconcileStatus(cr); err != nil {
+	if err := r.runReconcileStep(ctx, cr, "reconcileStatus", func() error { return r.reconcileStatus(cr) }); err != nil {
 		log.Info(err.Error())
 	}
 
 	log.Info("reconciling roles")
-	if err := r.reconcileRoles(cr); err != nil {
+	if err := r.runReconcileStep(ctx, cr, "reconcileRoles", func() error { return r.reconcileRoles(cr) }); err != nil {
 		log.Info(err.Error())
 		return err
 	}
 
 	log.Info("reconciling rolebindings")
-	if err := r.reconcileRoleBindings(cr); err != nil {
+	if err := r.runReconcileStep(ctx, cr, "reconcileRoleBindings", func() error { return r.reconcileRoleBindings(cr) }); err != nil {
 		log.Info(err.Error())
 		return err
 	}
 
 	log.Info("reconciling service accounts")
-	if err := r.reconcileServiceAccounts(cr); err != nil {
+	if err := r.runReconcileStep(ctx, cr, "reconcileServiceAccounts", func() error { return r.reconcileServiceAccounts(cr) }); err != nil {
 		log.Info(err.Error())
 		return err
 	}
 
 	log.Info("reconciling certificate authority")
-	if err := r.reconcileCertificateAuthority(cr); err != nil {
+	if err := r.runReconcileStep(ctx, cr, "reconcileCertificateAuthority", func() error { return r.reconcileCertificateAuthority(cr) }); err != nil {
 		return err
 	}
 
 	log.Info("reconciling secrets")
-	if err := r.reconcileSecrets(cr); err != nil {
+	if err := r.runReconcileStep(ctx, cr, "reconcileSecrets", func() error { return r.reconcileSecrets(cr) }); err != nil {
 		return err
 	}
 
+	r.reportInvalidSpec(cr, "InvalidRedisConfiguration", validateRedisExternal(cr))
+	r.reportInvalidSpec(cr, "InvalidRedisConfiguration", validateRedisHA(cr))
+	r.reportInvalidSpec(cr, "InvalidRepoServerConfiguration", validateRepoServerRemoteTLS(cr))
+	r.reportInvalidRepoServerPlugins(cr)
+	r.reportInvalidKustomizeVersions(cr)
+	r.reportInvalidSpec(cr, "InvalidTracingConfiguration", validateTracing(cr))
+
 	useTLSForRedis := r.redisShouldUseTLS(cr)
 
 	log.Info("reconciling config maps")
-	if err := r.reconcileConfigMaps(cr, useTLSForRedis); err != nil {
+	if err := r.runReconcileStep(ctx, cr, "reconcileConfigMaps", func() error { return r.reconcileConfigMaps(cr, useTLSForRedis) }); err != nil {
 		return err
 	}
 
 	log.Info("reconciling services")
-	if err := r.reconcileServices(cr); err != nil {
+	if err := r.runReconcileStep(ctx, cr, "reconcileServices", func() error { return r.reconcileServices(cr) }); err != nil {
 		return err
 	}
 
 	log.Info("reconciling deployments")
-	if err := r.reconcileDeployments(cr, useTLSForRedis); err != nil {
+	if err := r.runReconcileStep(ctx, cr, "reconcileDeployments", func() error { return r.reconcileDeployments(cr, useTLSForRedis) }); err != nil {
 		return err
 	}
 
 	log.Info("reconciling statefulsets")
-	if err := r.reconcileStatefulSets(cr, useTLSForRedis); err != nil {
+	if err := r.runReconcileStep(ctx, cr, "reconcileStatefulSets", func() error { return r.reconcileStatefulSets(cr, useTLSForRedis) }); err != nil {
 		return err
 	}
 
 	log.Info("reconciling autoscalers")
-	if err := r.reconcileAutoscalers(cr); err != nil {
+	if err := r.runReconcileStep(ctx, cr, "reconcileAutoscalers", func() error { return r.reconcileAutoscalers(cr) }); err != nil {
 		return err
 	}
 
 	log.Info("reconciling ingresses")
-	if err := r.reconcileIngresses(cr); err != nil {
+	if err := r.runReconcileStep(ctx, cr, "reconcileIngresses", func() error { return r.reconcileIngresses(cr) }); err != nil {
 		return err
 	}
 
 	if IsRouteAPIAvailable() {
 		log.Info("reconciling routes")
-		if err := r.reconcileRoutes(cr); err != nil {
+		if err := r.runReconcileStep(ctx, cr, "reconcileRoutes", func() error { return r.reconcileRoutes(cr) }); err != nil {
+			return err
+		}
+	}
+
+	if IsGatewayAPIAvailable() {
+		log.Info("reconciling gateway routes")
+		if err := r.runReconcileStep(ctx, cr, "reconcileGatewayRoutes", func() error { return r.reconcileGatewayRoutes(cr) }); err != nil {
 			return err
 		}
 	}
 
 	if IsPrometheusAPIAvailable() {
 		log.Info("reconciling prometheus")
-		if err := r.reconcilePrometheus(cr); err != nil {
+		if err := r.runReconcileStep(ctx, cr, "reconcilePrometheus", func() error { return r.reconcilePrometheus(cr) }); err != nil {
 			return err
 		}
 
 		// Reconciles prometheusRule created to alert based on argo-cd workload status
-		if err := r.reconcilePrometheusRule(cr); err != nil {
+		if err := r.runReconcileStep(ctx, cr, "reconcilePrometheusRule", func() error { return r.reconcilePrometheusRule(cr) }); err != nil {
 			return err
 		}
 
-		if err := r.reconcileMetricsServiceMonitor(cr); err != nil {
+		if err := r.runReconcileStep(ctx, cr, "reconcileMetricsServiceMonitor", func() error { return r.reconcileMetricsServiceMonitor(cr) }); err != nil {
 			return err
 		}
 
-		if err := r.reconcileRepoServerServiceMonitor(cr); err != nil {
+		if err := r.runReconcileStep(ctx, cr, "reconcileRepoServerServiceMonitor", func() error { return r.reconcileRepoServerServiceMonitor(cr) }); err != nil {
 			return err
 		}
 
-		if err := r.reconcileServerMetricsServiceMonitor(cr); err != nil {
+		if err := r.runReconcileStep(ctx, cr, "reconcileServerMetricsServiceMonitor", func() error { return r.reconcileServerMetricsServiceMonitor(cr) }); err != nil {
+			return err
+		}
+
+		if err := r.runReconcileStep(ctx, cr, "reconcileApplicationSetServiceMonitor", func() error { return r.reconcileApplicationSetServiceMonitor(cr) }); err != nil {
+			return err
+		}
+
+		if err := r.runReconcileStep(ctx, cr, "reconcileDexServiceMonitor", func() error { return r.reconcileDexServiceMonitor(cr) }); err != nil {
+			return err
+		}
+
+		if err := r.runReconcileStep(ctx, cr, "reconcileRedisServiceMonitor", func() error { return r.reconcileRedisServiceMonitor(cr) }); err != nil {
 			return err
 		}
 	}
@@ -842,30 +902,32 @@ func (r *ReconcileArgoCD) reconcileResources(cr *argoproj.ArgoCD) error {
 	// check ManagedApplicationSetSourceNamespaces for proper cleanup
 	if cr.Spec.ApplicationSet != nil || len(r.ManagedApplicationSetSourceNamespaces) > 0 {
 		log.Info("reconciling ApplicationSet controller")
-		if err := r.reconcileApplicationSetController(cr); err != nil {
+		if err := r.runReconcileStep(ctx, cr, "reconcileApplicationSetController", func() error { return r.reconcileApplicationSetController(cr) }); err != nil {
 			return err
 		}
 	}
 
 	if cr.Spec.Notifications.Enabled {
 		log.Info("reconciling Notifications controller")
-		if err := r.reconcileNotificationsController(cr); err != nil {
+		if err := r.runReconcileStep(ctx, cr, "reconcileNotificationsController", func() error { return r.reconcileNotificationsController(cr) }); err != nil {
 			return err
 		}
 	}
 
-	if err := r.reconcileRepoServerTLSSecret(cr); err != nil {
+	if err := r.runReconcileStep(ctx, cr, "reconcileRepoServerTLSSecret", func() error { return r.reconcileRepoServerTLSSecret(cr) }); err != nil {
 		return err
 	}
 
-	if err := r.reconcileRedisTLSSecret(cr, useTLSForRedis); err != nil {
+	if err := r.runReconcileStep(ctx, cr, "reconcileRedisTLSSecret", func() error { return r.reconcileRedisTLSSecret(cr, useTLSForRedis) }); err != nil {
 		return err
 	}
 
-	if err := r.ReconcileNetworkPolicies(cr); err != nil {
+	if err := r.runReconcileStep(ctx, cr, "ReconcileNetworkPolicies", func() error { return r.ReconcileNetworkPolicies(cr) }); err != nil {
 		return err
 	}
 
+	r.updateCertificateExpiryMetrics(cr)
+
 	return nil
 }
 
@@ -968,7 +1030,7 @@ func removeString(slice []string, s string) []string {
 }
 
 // setResourceWatches will register Watches for each of the supported Resources.
-func (r *ReconcileArgoCD) setResourceWatches(bldr *builder.Builder, clusterResourceMapper, tlsSecretMapper, namespaceResourceMapper, clusterSecretResourceMapper, applicationSetGitlabSCMTLSConfigMapMapper handler.MapFunc) *builder.Builder {
+func (r *ReconcileArgoCD) setResourceWatches(bldr *builder.Builder, clusterResourceMapper, tlsSecretMapper, namespaceResourceMapper, clusterSecretResourceMapper, applicationSetGitlabSCMTLSConfigMapMapper, rbacFragmentConfigMapMapper, trustSourceMapper, trustedCABundleConfigMapMapper, referencedObjectMapper handler.MapFunc) *builder.Builder {
 
 	deploymentConfigPred := predicate.Funcs{
 		UpdateFunc: func(e event.UpdateEvent) bool {
@@ -1087,6 +1149,14 @@ func (r *ReconcileArgoCD) setResourceWatches(bldr *builder.Builder, clusterResou
 
 	tlsSecretHandler := handler.EnqueueRequestsFromMapFunc(tlsSecretMapper)
 
+	rbacFragmentConfigMapHandler := handler.EnqueueRequestsFromMapFunc(rbacFragmentConfigMapMapper)
+
+	trustSourceHandler := handler.EnqueueRequestsFromMapFunc(trustSourceMapper)
+
+	trustedCABundleConfigMapHandler := handler.EnqueueRequestsFromMapFunc(trustedCABundleConfigMapMapper)
+
+	referencedObjectHandler := handler.EnqueueRequestsFromMapFunc(referencedObjectMapper)
+
 	bldr.Watches(&v1.ClusterRoleBinding{}, clusterResourceHandler)
 
 	bldr.Watches(&v1.ClusterRole{}, clusterResourceHandler)
@@ -1095,6 +1165,43 @@ func (r *ReconcileArgoCD) setResourceWatches(bldr *builder.Builder, clusterResou
 		Name: common.ArgoCDAppSetGitlabSCMTLSCertsConfigMapName,
 	}}, appSetGitlabSCMTLSConfigMapHandler)
 
+	// Watch for RBAC policy fragments in namespaces managed by the argocd instance. Updates are matched when either
+	// version of the configmap is labelled, so that removing the label drops the fragment from the policy.
+	rbacFragmentPred := predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			return isRBACFragment(e.Object)
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return isRBACFragment(e.ObjectOld) || isRBACFragment(e.ObjectNew)
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return isRBACFragment(e.Object)
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return isRBACFragment(e.Object)
+		},
+	}
+	bldr.Watches(&corev1.ConfigMap{}, rbacFragmentConfigMapHandler, builder.WithPredicates(rbacFragmentPred))
+
+	// Watch for SSH known hosts and TLS certificates sources in the namespaces of the argocd instance
+	trustSourcePred := predicate.NewPredicateFuncs(func(o client.Object) bool {
+		_, sshKnownHosts := o.GetLabels()[common.ArgoCDSSHKnownHostsSourceLabel]
+		_, tlsCerts := o.GetLabels()[common.ArgoCDTLSCertsSourceLabel]
+		return sshKnownHosts || tlsCerts
+	})
+	bldr.Watches(&corev1.ConfigMap{}, trustSourceHandler, builder.WithPredicates(trustSourcePred))
+	bldr.Watches(&corev1.Secret{}, trustSourceHandler, builder.WithPredicates(trustSourcePred))
+
+	// Watch for changes to the trusted CA bundle, which may be provided by the user or injected by OpenShift
+	trustedCABundlePred := predicate.NewPredicateFuncs(r.isTrustedCABundleConfigMap)
+	bldr.Watches(&corev1.ConfigMap{}, trustedCABundleConfigMapHandler, builder.WithPredicates(trustedCABundlePred))
+
+	// Watch for changes to the configmaps and secrets referenced by the spec of an argocd instance, such as the OIDC
+	// client secret and the GPG key sources
+	referencedObjectPred := predicate.NewPredicateFuncs(r.isReferencedObject)
+	bldr.Watches(&corev1.ConfigMap{}, referencedObjectHandler, builder.WithPredicates(referencedObjectPred))
+	bldr.Watches(&corev1.Secret{}, referencedObjectHandler, builder.WithPredicates(referencedObjectPred))
+
 	// Watch for secrets of type TLS that might be created by external processes
 	bldr.Watches(&corev1.Secret{Type: corev1.SecretTypeTLS}, tlsSecretHandler)
 
@@ -1118,6 +1225,16 @@ func (r *ReconcileArgoCD) setResourceWatches(bldr *builder.Builder, clusterResou
 		bldr.Owns(&routev1.Route{})
 	}
 
+	if IsGatewayAPIAvailable() {
+		// Watch Gateway API route sub-resources owned by ArgoCD instances.
+		bldr.Owns(&gatewayv1.HTTPRoute{})
+
+		if IsGatewayExperimentalAPIAvailable() {
+			bldr.Owns(&gatewayv1alpha2.GRPCRoute{})
+			bldr.Owns(&gatewayv1alpha2.TLSRoute{})
+		}
+	}
+
 	if IsPrometheusAPIAvailable() {
 		// Watch Prometheus sub-resources owned by ArgoCD instances.
 		bldr.Owns(&monitoringv1.Prometheus{})
@@ -1142,6 +1259,24 @@ func (r *ReconcileArgoCD) setResourceWatches(bldr *builder.Builder, clusterResou
 	return bldr
 }
 
+// recordEvent emits an Event of the given type on the given ArgoCD, when the reconciler has an event recorder.
+func (r *ReconcileArgoCD) recordEvent(cr *argoproj.ArgoCD, eventType, reason, message string) {
+	if r.recorder != nil {
+		r.recorder.Event(cr, eventType, reason, message)
+	}
+}
+
+// reportInvalidSpec will record the given error, found while validating the spec of the given ArgoCD, as a warning
+// Event on the ArgoCD with the given reason, so that it is visible without the operator logs. The invalid settings
+// are skipped when rendering the resources, so the rest of the ArgoCD is still reconciled.
+func (r *ReconcileArgoCD) reportInvalidSpec(cr *argoproj.ArgoCD, reason string, err error) {
+	if err == nil {
+		return
+	}
+	log.Info(fmt.Sprintf("skipping invalid configuration of ArgoCD %s in namespace %s: %v", cr.Name, cr.Namespace, err))
+	r.recordEvent(cr, corev1.EventTypeWarning, reason, err.Error())
+}
+
 // boolPtr returns a pointer to val
 func boolPtr(val bool) *bool {
 	return &val
@@ -22,6 +22,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	testclient "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
 )
 
 const (
@@ -1094,3 +1095,18 @@ func TestReconcileArgoCD_reconcileDexOAuthClientSecret(t *testing.T) {
 	}
 	assert.True(t, tokenExists, "Dex is enabled but unable to create oauth client secret")
 }
+
+func TestReconcileArgoCD_reportInvalidSpec(t *testing.T) {
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Redis.External = &argoproj.ArgoCDRedisExternalSpec{}
+	})
+	r := &ReconcileArgoCD{}
+	recorder := record.NewFakeRecorder(10)
+	r.recorder = recorder
+
+	r.reportInvalidSpec(a, "InvalidRedisConfiguration", nil)
+	assert.Empty(t, recorder.Events)
+
+	r.reportInvalidSpec(a, "InvalidRedisConfiguration", validateRedisExternal(a))
+	assert.Equal(t, "Warning InvalidRedisConfiguration redis external address must not be empty when sentinel is not set", <-recorder.Events)
+}
@@ -232,6 +232,12 @@ func (r *ReconcileArgoCDExport) reconcileCronJob(cr *argoproj.ArgoCDExport) erro
 
 	cj := newCronJob(cr)
 	if argoutil.IsObjectFound(r.Client, cr.Namespace, cj.Name, cj) {
+		if cj.Status.LastSuccessfulTime != nil {
+			ExportLastSuccessTime.WithLabelValues(cr.Namespace, cr.Name).Set(float64(cj.Status.LastSuccessfulTime.Unix()))
+		}
+		if err := r.recordCronJobOutcomes(cr, cj); err != nil {
+			return err
+		}
 		if *cr.Spec.Schedule != cj.Spec.Schedule {
 			cj.Spec.Schedule = *cr.Spec.Schedule
 			return r.Client.Update(context.TODO(), cj)
@@ -259,6 +265,46 @@ func (r *ReconcileArgoCDExport) reconcileCronJob(cr *argoproj.ArgoCDExport) erro
 	return r.Client.Create(context.TODO(), cj)
 }
 
+// recordCronJobOutcomes will count the outcomes of the finished Jobs started by the given CronJob that were not
+// counted yet, and mark them as counted.
+func (r *ReconcileArgoCDExport) recordCronJobOutcomes(cr *argoproj.ArgoCDExport, cj *batchv1.CronJob) error {
+	jobs := &batchv1.JobList{}
+	if err := r.Client.List(context.TODO(), jobs, &client.ListOptions{Namespace: cr.Namespace}); err != nil {
+		return err
+	}
+
+	for i := range jobs.Items {
+		job := &jobs.Items[i]
+		owner := metav1.GetControllerOf(job)
+		if owner == nil || owner.UID != cj.UID {
+			continue
+		}
+		if _, ok := job.Annotations[common.ArgoCDExportOutcomeAnnotation]; ok {
+			continue
+		}
+
+		var outcome string
+		switch {
+		case job.Status.Succeeded > 0:
+			outcome = "succeeded"
+		case isJobFailed(job):
+			outcome = "failed"
+		default:
+			continue // Job not complete, move along...
+		}
+
+		if job.Annotations == nil {
+			job.Annotations = make(map[string]string)
+		}
+		job.Annotations[common.ArgoCDExportOutcomeAnnotation] = outcome
+		if err := r.Client.Update(context.TODO(), job); err != nil {
+			return err
+		}
+		ExportJobOutcomes.WithLabelValues(cr.Namespace, cr.Name, outcome).Inc()
+	}
+	return nil
+}
+
 // reconcileJob will ensure that the Job for the ArgoCDExport is present.
 func (r *ReconcileArgoCDExport) reconcileJob(cr *argoproj.ArgoCDExport) error {
 	if cr.Spec.Storage == nil {
@@ -270,6 +316,16 @@ func (r *ReconcileArgoCDExport) reconcileJob(cr *argoproj.ArgoCDExport) error {
 		if job.Status.Succeeded > 0 && cr.Status.Phase != common.ArgoCDStatusCompleted {
 			// Mark status Phase as Complete
 			cr.Status.Phase = common.ArgoCDStatusCompleted
+			ExportJobOutcomes.WithLabelValues(cr.Namespace, cr.Name, "succeeded").Inc()
+			if job.Status.CompletionTime != nil {
+				ExportLastSuccessTime.WithLabelValues(cr.Namespace, cr.Name).Set(float64(job.Status.CompletionTime.Unix()))
+			}
+			return r.Client.Status().Update(context.TODO(), cr)
+		}
+		if isJobFailed(job) && cr.Status.Phase != common.ArgoCDStatusFailed {
+			// Mark status Phase as Failed
+			cr.Status.Phase = common.ArgoCDStatusFailed
+			ExportJobOutcomes.WithLabelValues(cr.Namespace, cr.Name, "failed").Inc()
 			return r.Client.Status().Update(context.TODO(), cr)
 		}
 		return nil // Job not complete, move along...
@@ -290,6 +346,16 @@ func (r *ReconcileArgoCDExport) reconcileJob(cr *argoproj.ArgoCDExport) error {
 	return r.Client.Create(context.TODO(), job)
 }
 
+// isJobFailed returns true when the given Job has failed and will not be retried.
+func isJobFailed(job *batchv1.Job) bool {
+	for _, condition := range job.Status.Conditions {
+		if condition.Type == batchv1.JobFailed && condition.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
 func (r *ReconcileArgoCDExport) argocdName(namespace string) (string, error) {
 	argocds := &argoproj.ArgoCDList{}
 	if err := r.Client.List(context.TODO(), argocds, &client.ListOptions{Namespace: namespace}); err != nil {
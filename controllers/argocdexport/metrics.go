@@ -0,0 +1,48 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocdexport
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	ExportJobOutcomes = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "argocd_export_job_outcomes_total",
+			Help: "Number of finished export jobs by outcome",
+		},
+		[]string{"namespace", "name", "outcome"},
+	)
+
+	ExportLastSuccessTime = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "argocd_export_last_success_timestamp_seconds",
+			Help: "Time of the last successful export, in seconds since the epoch",
+		},
+		[]string{"namespace", "name"},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(ExportJobOutcomes, ExportLastSuccessTime)
+}
+
+// deleteExportMetrics removes the metrics of the ArgoCDExport with the given namespace and name.
+func deleteExportMetrics(namespace, name string) {
+	ExportJobOutcomes.DeletePartialMatch(prometheus.Labels{"namespace": namespace, "name": name})
+	ExportLastSuccessTime.DeleteLabelValues(namespace, name)
+}
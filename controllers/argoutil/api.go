@@ -56,6 +56,50 @@ func VerifyAPI(group string, version string) (bool, error) {
 	return true, nil
 }
 
+// VerifyAPIResources will verify that all the given resources are served for the given group/version in the cluster.
+func VerifyAPIResources(group string, version string, resources ...string) (bool, error) {
+	cfg, err := config.GetConfig()
+	if err != nil {
+		log.Error(err, "unable to get k8s config")
+		return false, err
+	}
+
+	k8s, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		log.Error(err, "unable to create k8s client")
+		return false, err
+	}
+
+	return hasAPIResources(k8s.Discovery(), schema.GroupVersion{Group: group, Version: version}, resources...)
+}
+
+// hasAPIResources returns whether all the given resources are served for the given group/version.
+func hasAPIResources(d discovery.ServerResourcesInterface, gv schema.GroupVersion, resources ...string) (bool, error) {
+	list, err := d.ServerResourcesForGroupVersion(gv.String())
+	if err != nil {
+		if errors.IsNotFound(err) {
+			log.Info(fmt.Sprintf("%s API not available", gv))
+			return false, nil
+		}
+		log.Error(err, fmt.Sprintf("%s API resources check failed.", gv))
+		return false, err
+	}
+
+	served := make(map[string]bool, len(list.APIResources))
+	for _, r := range list.APIResources {
+		served[r.Name] = true
+	}
+	for _, r := range resources {
+		if !served[r] {
+			log.Info(fmt.Sprintf("%s API does not serve %s", gv, r))
+			return false, nil
+		}
+	}
+
+	log.Info(fmt.Sprintf("%s API resources %v verified", gv, resources))
+	return true, nil
+}
+
 // IsAPIRegistered returns true if the API is registered irrespective of
 // whether the API status is available or not.
 func IsAPIRegistered(group string, version string) (bool, error) {
@@ -0,0 +1,58 @@
+package argoutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestHasAPIResources(t *testing.T) {
+	d := &fakediscovery.FakeDiscovery{Fake: &k8stesting.Fake{Resources: []*metav1.APIResourceList{
+		{
+			GroupVersion: "gateway.networking.k8s.io/v1alpha2",
+			APIResources: []metav1.APIResource{{Name: "referencegrants"}},
+		},
+		{
+			GroupVersion: "gateway.networking.k8s.io/v1",
+			APIResources: []metav1.APIResource{{Name: "gateways"}, {Name: "httproutes"}},
+		},
+	}}}
+
+	tests := []struct {
+		name      string
+		gv        schema.GroupVersion
+		resources []string
+		want      bool
+	}{
+		{
+			name:      "all resources served",
+			gv:        schema.GroupVersion{Group: "gateway.networking.k8s.io", Version: "v1"},
+			resources: []string{"httproutes"},
+			want:      true,
+		},
+		{
+			name:      "group version served without the resources",
+			gv:        schema.GroupVersion{Group: "gateway.networking.k8s.io", Version: "v1alpha2"},
+			resources: []string{"grpcroutes", "tlsroutes"},
+			want:      false,
+		},
+		{
+			name:      "group version not served",
+			gv:        schema.GroupVersion{Group: "gateway.networking.k8s.io", Version: "v1beta1"},
+			resources: []string{"httproutes"},
+			want:      false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			found, err := hasAPIResources(d, test.gv, test.resources...)
+			assert.NoError(t, err)
+			assert.Equal(t, test.want, found)
+		})
+	}
+}
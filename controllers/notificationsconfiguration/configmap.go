@@ -18,8 +18,9 @@ const (
 	ArgoCDNotificationsConfigMap = "argocd-notifications-cm"
 )
 
-// reconcileNotificationsConfigmap will ensure that the notifications configuration is updated
-func (r *NotificationsConfigurationReconciler) reconcileNotificationsConfigmap(cr *v1alpha1.NotificationsConfiguration) error {
+// reconcileNotificationsConfigmap will ensure that the notifications configuration is updated. The given rejected keys
+// keep the value they have in the configmap, if any.
+func (r *NotificationsConfigurationReconciler) reconcileNotificationsConfigmap(cr *v1alpha1.NotificationsConfiguration, rejected map[string]bool) error {
 
 	NotificationsConfigMap := &corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
@@ -61,10 +62,25 @@ func (r *NotificationsConfigurationReconciler) reconcileNotificationsConfigmap(c
 		expectedConfiguration[k] = v
 	}
 
+	services, err := getNotificationServicesConfig(cr)
+	if err != nil {
+		return err
+	}
+	for k, v := range services {
+		expectedConfiguration[k] = v
+	}
+
 	if cr.Spec.Context != nil {
 		expectedConfiguration["context"] = mapToString(cr.Spec.Context)
 	}
 
+	for k := range rejected {
+		delete(expectedConfiguration, k)
+		if v, ok := NotificationsConfigMap.Data[k]; ok {
+			expectedConfiguration[k] = v
+		}
+	}
+
 	if !reflect.DeepEqual(expectedConfiguration, NotificationsConfigMap.Data) {
 		NotificationsConfigMap.Data = expectedConfiguration
 		err := r.Client.Update(context.TODO(), NotificationsConfigMap)
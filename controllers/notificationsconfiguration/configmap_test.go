@@ -94,7 +94,7 @@ func TestReconcileNotifications_CreateConfigMap(t *testing.T) {
 		},
 	}
 
-	err := r.reconcileNotificationsConfigmap(a)
+	err := r.reconcileNotificationsConfigmap(a, nil)
 	assert.NoError(t, err)
 
 	// Verify if the ConfigMap is created
@@ -145,7 +145,7 @@ func TestReconcileNotifications_UpdateConfigMap(t *testing.T) {
 		},
 	}
 
-	err := r.reconcileNotificationsConfigmap(a)
+	err := r.reconcileNotificationsConfigmap(a, nil)
 	assert.NoError(t, err)
 
 	// Verify if the ConfigMap is created
@@ -161,7 +161,7 @@ func TestReconcileNotifications_UpdateConfigMap(t *testing.T) {
 	// Update the NotificationsConfiguration
 	a.Spec.Triggers["trigger.on-sync-status-test"] = "- when: app.status.sync.status == 'Unknown' \n send: [my-custom-template]"
 
-	err = r.reconcileNotificationsConfigmap(a)
+	err = r.reconcileNotificationsConfigmap(a, nil)
 	assert.NoError(t, err)
 
 	testCM = &corev1.ConfigMap{}
@@ -197,7 +197,7 @@ func TestReconcileNotifications_DeleteConfigMap(t *testing.T) {
 		},
 	}
 
-	err := r.reconcileNotificationsConfigmap(a)
+	err := r.reconcileNotificationsConfigmap(a, nil)
 	assert.NoError(t, err)
 
 	// Delete the Notifications ConfigMap
@@ -211,7 +211,7 @@ func TestReconcileNotifications_DeleteConfigMap(t *testing.T) {
 		context.TODO(), testCM))
 
 	// Reconcile to check if the ConfigMap is recreated
-	err = r.reconcileNotificationsConfigmap(a)
+	err = r.reconcileNotificationsConfigmap(a, nil)
 	assert.NoError(t, err)
 
 	assert.NoError(t, r.Client.Get(
@@ -0,0 +1,100 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notificationsconfiguration
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+// defaultNotificationsConfigurationName is the name of the only NotificationsConfiguration reconciled in
+// notifications source namespaces.
+const defaultNotificationsConfigurationName = "default-notifications-configuration"
+
+// getAcceptedCondition returns whether the given NotificationsConfiguration is reconciled by the operator. It is
+// reconciled either in the namespace of an Argo CD instance, or in a notifications source namespace of a cluster-scoped
+// Argo CD instance (self-service notifications), where it must be named default-notifications-configuration.
+func (r *NotificationsConfigurationReconciler) getAcceptedCondition(cr *v1alpha1.NotificationsConfiguration) (metav1.Condition, error) {
+	condition := metav1.Condition{
+		Type:   ConditionTypeAccepted,
+		Status: metav1.ConditionFalse,
+		Reason: ReasonNotAccepted,
+	}
+
+	argocds := &argoproj.ArgoCDList{}
+	if err := r.Client.List(context.TODO(), argocds, client.InNamespace(cr.Namespace)); err != nil {
+		return condition, fmt.Errorf("failed to list Argo CD instances in namespace %s : %s", cr.Namespace, err)
+	}
+	if len(argocds.Items) > 0 {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = ReasonAccepted
+		condition.Message = fmt.Sprintf("NotificationsConfiguration is applied to the Argo CD instance %s", argocds.Items[0].Name)
+		return condition, nil
+	}
+
+	namespace := &corev1.Namespace{}
+	if err := r.Client.Get(context.TODO(), types.NamespacedName{Name: cr.Namespace}, namespace); err != nil {
+		return condition, fmt.Errorf("failed to get namespace %s : %s", cr.Namespace, err)
+	}
+	argocdNamespace := namespace.Labels[common.ArgoCDNotificationsManagedByClusterArgoCDLabel]
+	if argocdNamespace == "" {
+		condition.Message = fmt.Sprintf("namespace %s is neither the namespace of an Argo CD instance nor a notifications source namespace", cr.Namespace)
+		return condition, nil
+	}
+	if cr.Name != defaultNotificationsConfigurationName {
+		condition.Message = fmt.Sprintf("only the NotificationsConfiguration named %s is applied in notifications source namespaces", defaultNotificationsConfigurationName)
+		return condition, nil
+	}
+
+	condition.Status = metav1.ConditionTrue
+	condition.Reason = ReasonAccepted
+	condition.Message = fmt.Sprintf("NotificationsConfiguration is applied to the Applications of namespace %s by the Argo CD instance in namespace %s",
+		cr.Namespace, argocdNamespace)
+	return condition, nil
+}
+
+// namespaceMapper maps a Namespace to the NotificationsConfigurations it contains, so that they are reconciled
+// when the namespace becomes or stops being a notifications source namespace.
+func (r *NotificationsConfigurationReconciler) namespaceMapper(ctx context.Context, o client.Object) []reconcile.Request {
+	return r.getNotificationsConfigurationRequests(ctx, o.GetName())
+}
+
+// argoCDMapper maps an Argo CD instance to the NotificationsConfigurations of its namespace.
+func (r *NotificationsConfigurationReconciler) argoCDMapper(ctx context.Context, o client.Object) []reconcile.Request {
+	return r.getNotificationsConfigurationRequests(ctx, o.GetNamespace())
+}
+
+func (r *NotificationsConfigurationReconciler) getNotificationsConfigurationRequests(ctx context.Context, namespace string) []reconcile.Request {
+	list := &v1alpha1.NotificationsConfigurationList{}
+	if err := r.Client.List(ctx, list, client.InNamespace(namespace)); err != nil {
+		return nil
+	}
+
+	result := []reconcile.Request{}
+	for _, cr := range list.Items {
+		result = append(result, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&cr)})
+	}
+	return result
+}
@@ -0,0 +1,139 @@
+package notificationsconfiguration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+func makeTestNamespace(name string, labels map[string]string) *corev1.Namespace {
+	return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+}
+
+func TestGetAcceptedCondition(t *testing.T) {
+	tests := []struct {
+		name     string
+		crName   string
+		objs     []client.Object
+		accepted bool
+		message  string
+	}{
+		{
+			name:     "namespace of an Argo CD instance",
+			crName:   "custom-notifications",
+			objs:     []client.Object{makeTestNamespace("default", nil), &argoproj.ArgoCD{ObjectMeta: metav1.ObjectMeta{Name: "argocd", Namespace: "default"}}},
+			accepted: true,
+			message:  "NotificationsConfiguration is applied to the Argo CD instance argocd",
+		},
+		{
+			name:     "notifications source namespace",
+			crName:   "default-notifications-configuration",
+			objs:     []client.Object{makeTestNamespace("default", map[string]string{common.ArgoCDNotificationsManagedByClusterArgoCDLabel: "argocd"})},
+			accepted: true,
+			message:  "NotificationsConfiguration is applied to the Applications of namespace default by the Argo CD instance in namespace argocd",
+		},
+		{
+			name:     "notifications source namespace with another name",
+			crName:   "custom-notifications",
+			objs:     []client.Object{makeTestNamespace("default", map[string]string{common.ArgoCDNotificationsManagedByClusterArgoCDLabel: "argocd"})},
+			accepted: false,
+			message:  "only the NotificationsConfiguration named default-notifications-configuration is applied in notifications source namespaces",
+		},
+		{
+			name:     "unmanaged namespace",
+			crName:   "default-notifications-configuration",
+			objs:     []client.Object{makeTestNamespace("default", nil)},
+			accepted: false,
+			message:  "namespace default is neither the namespace of an Argo CD instance nor a notifications source namespace",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a := makeTestNotificationsConfiguration(func(a *v1alpha1.NotificationsConfiguration) {
+				a.Name = test.crName
+			})
+
+			resObjs := append([]client.Object{a}, test.objs...)
+			subresObjs := []client.Object{a}
+			runtimeObjs := []runtime.Object{}
+			sch := makeTestReconcilerScheme(v1alpha1.AddToScheme, argoproj.AddToScheme)
+			cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+			r := makeTestReconciler(cl, sch)
+
+			condition, err := r.getAcceptedCondition(a)
+			assert.NoError(t, err)
+			assert.Equal(t, test.accepted, condition.Status == metav1.ConditionTrue)
+			assert.Equal(t, test.message, condition.Message)
+		})
+	}
+}
+
+func TestReconcile_SourceNamespace(t *testing.T) {
+	a := makeTestNotificationsConfiguration(func(a *v1alpha1.NotificationsConfiguration) {
+		a.Namespace = "team-a"
+		a.Spec.Triggers = map[string]string{
+			"trigger.on-created": "- send: [app-created]\n  when: \"true\"",
+		}
+		a.Spec.Templates = map[string]string{
+			"template.app-created": "message: Application {{.app.metadata.name}} has been created.",
+		}
+		a.Spec.ServiceDefinitions = []v1alpha1.NotificationService{
+			{Slack: &v1alpha1.NotificationSlackService{TokenRef: secretKeyRef("team-tokens", "slack")}},
+		}
+	})
+	ns := makeTestNamespace("team-a", nil)
+	tokens := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "team-tokens", Namespace: "team-a"},
+		Data:       map[string][]byte{"slack": []byte("xoxb-token")},
+	}
+
+	resObjs := []client.Object{a, ns, tokens}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(v1alpha1.AddToScheme, argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+	request := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(a)}
+
+	// not a notifications source namespace, the configuration is not applied
+	_, err := r.Reconcile(context.TODO(), request)
+	assert.NoError(t, err)
+	assert.NoError(t, r.Client.Get(context.TODO(), request.NamespacedName, a))
+	assert.False(t, meta.IsStatusConditionTrue(a.Status.Conditions, ConditionTypeAccepted))
+	testCM := &corev1.ConfigMap{}
+	assert.Error(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: ArgoCDNotificationsConfigMap, Namespace: a.Namespace}, testCM))
+
+	ns.Labels = map[string]string{common.ArgoCDNotificationsManagedByClusterArgoCDLabel: "argocd"}
+	assert.NoError(t, r.Client.Update(context.TODO(), ns))
+	assert.Equal(t, []ctrl.Request{request}, r.namespaceMapper(context.TODO(), ns))
+
+	// the configmap and the secret are created in the source namespace
+	_, err = r.Reconcile(context.TODO(), request)
+	assert.NoError(t, err)
+	assert.NoError(t, r.Client.Get(context.TODO(), request.NamespacedName, a))
+	for _, conditionType := range []string{ConditionTypeAccepted, ConditionTypeServicesValid, ConditionTypeTriggersValid, ConditionTypeTemplatesValid} {
+		assert.True(t, meta.IsStatusConditionTrue(a.Status.Conditions, conditionType), conditionType)
+	}
+
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: ArgoCDNotificationsConfigMap, Namespace: a.Namespace}, testCM))
+	assert.Equal(t, "token: $slack-token\n", testCM.Data["service.slack"])
+
+	secret := &corev1.Secret{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: ArgoCDNotificationsSecret, Namespace: a.Namespace}, secret))
+	assert.Equal(t, map[string][]byte{"slack-token": []byte("xoxb-token")}, secret.Data)
+	assert.Equal(t, "slack-token", secret.Annotations[notificationsManagedKeysAnnotation])
+	assert.True(t, metav1.IsControlledBy(secret, a))
+}
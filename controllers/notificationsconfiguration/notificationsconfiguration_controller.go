@@ -22,6 +22,7 @@ import (
 	v1alpha1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
 
 	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -30,6 +31,8 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
+var log = logr.Log.WithName("controller_notificationsconfiguration")
+
 // blank assignment to verify that ReconcileNotificationsConfiguration implements reconcile.Reconciler
 var _ reconcile.Reconciler = &NotificationsConfigurationReconciler{}
 
@@ -50,6 +53,10 @@ type NotificationsConfigurationReconciler struct {
 // +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterroles;clusterrolebindings,verbs=*
 // +kubebuilder:rbac:groups="",resources=configmaps,verbs=*
 // +kubebuilder:rbac:groups=argoproj.io,resources=notificationsconfiguration,verbs=*
+// +kubebuilder:rbac:groups=argoproj.io,resources=notificationsconfigurations/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+// +kubebuilder:rbac:groups=argoproj.io,resources=argocds,verbs=get;list;watch
 func (r *NotificationsConfigurationReconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
 
 	reqLogger := logr.FromContext(ctx, "Request.Namespace", request.Namespace, "Request.Name", request.Name)
@@ -68,6 +75,19 @@ func (r *NotificationsConfigurationReconciler) Reconcile(ctx context.Context, re
 		return reconcile.Result{}, err
 	}
 
+	accepted, err := r.getAcceptedCondition(notificationsConfig)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if err := r.reconcileStatusConditions(notificationsConfig, accepted); err != nil {
+		return reconcile.Result{}, err
+	}
+	if accepted.Status != metav1.ConditionTrue {
+		// NotificationsConfiguration is not in a namespace managed by the operator, nothing to apply
+		reqLogger.Info("NotificationsConfiguration not accepted", "reason", accepted.Message)
+		return reconcile.Result{}, nil
+	}
+
 	if err := r.reconcileNotificationsConfigurationResources(notificationsConfig); err != nil {
 		return reconcile.Result{}, err
 	}
@@ -79,6 +99,6 @@ func (r *NotificationsConfigurationReconciler) Reconcile(ctx context.Context, re
 // SetupWithManager sets up the controller with the Manager.
 func (r *NotificationsConfigurationReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	bldr := ctrl.NewControllerManagedBy(mgr)
-	setResourceWatches(bldr)
+	setResourceWatches(bldr, r.notificationsSecretMapper, r.namespaceMapper, r.argoCDMapper)
 	return bldr.Complete(r)
 }
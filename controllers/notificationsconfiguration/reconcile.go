@@ -15,27 +15,93 @@
 package notificationsconfiguration
 
 import (
+	"reflect"
+
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	v1alpha1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
 )
 
+// secretDataChangedPredicate filters out Secret updates that do not change the data of the Secret.
+var secretDataChangedPredicate = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldSecret, ok := e.ObjectOld.(*corev1.Secret)
+		if !ok {
+			return false
+		}
+		newSecret, ok := e.ObjectNew.(*corev1.Secret)
+		if !ok {
+			return false
+		}
+		return !reflect.DeepEqual(oldSecret.Data, newSecret.Data)
+	},
+}
+
 // reconcileNotificationsConfigurationResources will reconcile all the resources for the given CR.
 func (r *NotificationsConfigurationReconciler) reconcileNotificationsConfigurationResources(cr *v1alpha1.NotificationsConfiguration) error {
 
-	if err := r.reconcileNotificationsConfigmap(cr); err != nil {
+	data, servicesErr := r.getNotificationServicesSecretData(cr)
+	rejectedTemplates := validateNotificationTemplates(cr)
+	rejectedTriggers := validateNotificationTriggers(cr, rejectedTemplates)
+
+	conditions := []metav1.Condition{
+		getServicesCondition(servicesErr),
+		getTriggersCondition(getValidationError("triggers", rejectedTriggers)),
+		getTemplatesCondition(getValidationError("templates", rejectedTemplates)),
+	}
+	if servicesErr != nil {
+		// Keep the last applied configuration until the notification services are fixed, since the credentials of
+		// the services cannot be copied into the notifications Secret
+		log.Info("invalid notification services, keeping the last applied configuration",
+			"name", cr.Name, "namespace", cr.Namespace)
+		return r.reconcileStatusConditions(cr, conditions...)
+	}
+
+	if err := r.reconcileNotificationsSecret(cr, data); err != nil {
 		return err
 	}
-	return nil
+
+	// Invalid triggers and templates keep their last applied value, the rest of the configuration is applied
+	rejected := map[string]bool{}
+	for key := range rejectedTemplates {
+		rejected[key] = true
+	}
+	for key := range rejectedTriggers {
+		rejected[key] = true
+	}
+	if len(rejected) > 0 {
+		log.Info("invalid notification triggers or templates, keeping their last applied value",
+			"name", cr.Name, "namespace", cr.Namespace, "keys", sortedKeys(rejected))
+	}
+
+	if err := r.reconcileNotificationsConfigmap(cr, rejected); err != nil {
+		return err
+	}
+
+	return r.reconcileStatusConditions(cr, conditions...)
 }
 
 // setResourceWatches will register Watches for each of the supported Resources.
-func setResourceWatches(bld *builder.Builder) *builder.Builder {
+func setResourceWatches(bld *builder.Builder, secretMapper, namespaceMapper, argoCDMapper handler.MapFunc) *builder.Builder {
 	// Watch for changes to primary resource NotificationsConfiguration
 	bld.For(&v1alpha1.NotificationsConfiguration{})
 	// Watch for changes to Configmap sub-resources owned by NotificationsConfigurationController.
 	bld.Owns(&corev1.ConfigMap{})
+	// Watch for changes to Secrets referenced by the typed notification services.
+	bld.Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(secretMapper),
+		builder.WithPredicates(secretDataChangedPredicate))
+	// Watch for Namespaces becoming or ceasing to be notifications source namespaces.
+	bld.Watches(&corev1.Namespace{}, handler.EnqueueRequestsFromMapFunc(namespaceMapper),
+		builder.WithPredicates(predicate.LabelChangedPredicate{}))
+	// Watch for Argo CD instances created or deleted in the namespace of a NotificationsConfiguration.
+	bld.Watches(&argoproj.ArgoCD{}, handler.EnqueueRequestsFromMapFunc(argoCDMapper),
+		builder.WithPredicates(predicate.Funcs{UpdateFunc: func(event.UpdateEvent) bool { return false }}))
 
 	return bld
 }
@@ -0,0 +1,527 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notificationsconfiguration
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+const (
+	// ArgoCDNotificationsSecret is the name of the Secret holding the credentials of the notification services.
+	ArgoCDNotificationsSecret = "argocd-notifications-secret"
+	// Annotation on the notifications Secret that records the keys copied from the Secrets referenced by the
+	// typed notification services.
+	notificationsManagedKeysAnnotation = "argocd.argoproj.io/managed-notification-keys"
+)
+
+// slackServiceConfig is the configuration of an Argo CD notifications Slack service.
+type slackServiceConfig struct {
+	Token         string `yaml:"token"`
+	SigningSecret string `yaml:"signingSecret,omitempty"`
+	Username      string `yaml:"username,omitempty"`
+	Icon          string `yaml:"icon,omitempty"`
+	APIURL        string `yaml:"apiURL,omitempty"`
+}
+
+// emailServiceConfig is the configuration of an Argo CD notifications email service.
+type emailServiceConfig struct {
+	Host               string `yaml:"host"`
+	Port               int32  `yaml:"port"`
+	From               string `yaml:"from"`
+	Username           string `yaml:"username,omitempty"`
+	Password           string `yaml:"password,omitempty"`
+	HTML               bool   `yaml:"html,omitempty"`
+	InsecureSkipVerify bool   `yaml:"insecure_skip_verify,omitempty"`
+}
+
+// webhookServiceConfig is the configuration of an Argo CD notifications webhook service.
+type webhookServiceConfig struct {
+	URL                string                  `yaml:"url"`
+	Headers            []webhookHeaderConfig   `yaml:"headers,omitempty"`
+	BasicAuth          *webhookBasicAuthConfig `yaml:"basicAuth,omitempty"`
+	InsecureSkipVerify bool                    `yaml:"insecureSkipVerify,omitempty"`
+}
+
+type webhookHeaderConfig struct {
+	Name  string `yaml:"name"`
+	Value string `yaml:"value"`
+}
+
+type webhookBasicAuthConfig struct {
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+}
+
+// teamsServiceConfig is the configuration of an Argo CD notifications Microsoft Teams service.
+type teamsServiceConfig struct {
+	RecipientURLs yaml.MapSlice `yaml:"recipientUrls"`
+}
+
+// pagerDutyServiceConfig is the configuration of an Argo CD notifications PagerDuty v2 service.
+type pagerDutyServiceConfig struct {
+	ServiceKeys yaml.MapSlice `yaml:"serviceKeys"`
+}
+
+// gitHubServiceConfig is the configuration of an Argo CD notifications GitHub service.
+type gitHubServiceConfig struct {
+	AppID             string `yaml:"appID"`
+	InstallationID    string `yaml:"installationID"`
+	PrivateKey        string `yaml:"privateKey"`
+	EnterpriseBaseURL string `yaml:"enterpriseBaseURL,omitempty"`
+}
+
+// notificationServiceType returns the Argo CD notifications type of the given service, or an empty string if not
+// exactly one service type is set.
+func notificationServiceType(svc v1alpha1.NotificationService) string {
+	types := []string{}
+	if svc.Slack != nil {
+		types = append(types, "slack")
+	}
+	if svc.Email != nil {
+		types = append(types, "email")
+	}
+	if svc.Webhook != nil {
+		types = append(types, "webhook")
+	}
+	if svc.Teams != nil {
+		types = append(types, "teams")
+	}
+	if svc.PagerDuty != nil {
+		types = append(types, "pagerdutyv2")
+	}
+	if svc.GitHub != nil {
+		types = append(types, "github")
+	}
+	if len(types) != 1 {
+		return ""
+	}
+	return types[0]
+}
+
+// notificationServiceName returns the name of the given service as used in subscriptions.
+func notificationServiceName(svc v1alpha1.NotificationService) string {
+	if svc.Name != "" {
+		return svc.Name
+	}
+	return notificationServiceType(svc)
+}
+
+// notificationServiceKey returns the key of the given service in the argocd-notifications-cm ConfigMap.
+func notificationServiceKey(svc v1alpha1.NotificationService) string {
+	serviceType := notificationServiceType(svc)
+	if svc.Name == "" || svc.Name == serviceType {
+		return "service." + serviceType
+	}
+	return "service." + serviceType + "." + svc.Name
+}
+
+// notificationSecretKey returns the key of the argocd-notifications-secret Secret that holds the given credential
+// of the given service. The key includes the service type, so that services of different types may share a name.
+func notificationSecretKey(svc v1alpha1.NotificationService, field string) string {
+	service := strings.ReplaceAll(strings.TrimPrefix(notificationServiceKey(svc), "service."), ".", "-")
+	return service + "-" + field
+}
+
+// notificationSecretKeyPattern matches the keys that Argo CD notifications resolves in `$key` references.
+var notificationSecretKeyPattern = regexp.MustCompile(`^[\w-]+$`)
+
+// getNotificationServicesConfig will return the argocd-notifications-cm entries of the typed notification services
+// of the given NotificationsConfiguration. Credentials are rendered as references to the argocd-notifications-secret
+// Secret.
+func getNotificationServicesConfig(cr *v1alpha1.NotificationsConfiguration) (map[string]string, error) {
+	config := make(map[string]string)
+
+	for _, svc := range cr.Spec.ServiceDefinitions {
+		ref := func(field string) string {
+			return "$" + notificationSecretKey(svc, field)
+		}
+
+		var cfg interface{}
+		switch {
+		case svc.Slack != nil:
+			slack := slackServiceConfig{
+				Token:    ref("token"),
+				Username: svc.Slack.Username,
+				Icon:     svc.Slack.Icon,
+				APIURL:   svc.Slack.APIURL,
+			}
+			if svc.Slack.SigningSecretRef != nil {
+				slack.SigningSecret = ref("signingSecret")
+			}
+			cfg = slack
+		case svc.Email != nil:
+			email := emailServiceConfig{
+				Host:               svc.Email.Host,
+				Port:               svc.Email.Port,
+				From:               svc.Email.From,
+				Username:           svc.Email.Username,
+				HTML:               svc.Email.HTML,
+				InsecureSkipVerify: svc.Email.InsecureSkipVerify,
+			}
+			if svc.Email.PasswordRef != nil {
+				email.Password = ref("password")
+			}
+			cfg = email
+		case svc.Webhook != nil:
+			webhook := webhookServiceConfig{
+				URL:                svc.Webhook.URL,
+				InsecureSkipVerify: svc.Webhook.InsecureSkipVerify,
+			}
+			for _, header := range svc.Webhook.Headers {
+				value := header.Value
+				if header.ValueFrom != nil {
+					value = ref(header.Name)
+				}
+				webhook.Headers = append(webhook.Headers, webhookHeaderConfig{Name: header.Name, Value: value})
+			}
+			if svc.Webhook.BasicAuth != nil {
+				webhook.BasicAuth = &webhookBasicAuthConfig{
+					Username: svc.Webhook.BasicAuth.Username,
+					Password: ref("password"),
+				}
+			}
+			cfg = webhook
+		case svc.Teams != nil:
+			teams := teamsServiceConfig{RecipientURLs: yaml.MapSlice{}}
+			for _, recipient := range svc.Teams.Recipients {
+				teams.RecipientURLs = append(teams.RecipientURLs, yaml.MapItem{Key: recipient.Name, Value: ref(recipient.Name)})
+			}
+			cfg = teams
+		case svc.PagerDuty != nil:
+			pagerDuty := pagerDutyServiceConfig{ServiceKeys: yaml.MapSlice{}}
+			for _, key := range svc.PagerDuty.ServiceKeys {
+				pagerDuty.ServiceKeys = append(pagerDuty.ServiceKeys, yaml.MapItem{Key: key.Name, Value: ref(key.Name)})
+			}
+			cfg = pagerDuty
+		case svc.GitHub != nil:
+			cfg = gitHubServiceConfig{
+				AppID:             svc.GitHub.AppID,
+				InstallationID:    svc.GitHub.InstallationID,
+				PrivateKey:        ref("privateKey"),
+				EnterpriseBaseURL: svc.GitHub.EnterpriseBaseURL,
+			}
+		default:
+			continue
+		}
+
+		out, err := yaml.Marshal(cfg)
+		if err != nil {
+			return nil, err
+		}
+		config[notificationServiceKey(svc)] = string(out)
+	}
+
+	return config, nil
+}
+
+// notificationSecretRef is a credential of a typed notification service.
+type notificationSecretRef struct {
+	path string
+	key  string
+	ref  corev1.SecretKeySelector
+}
+
+// getNotificationSecretRefs returns the credentials of the given service that are read from Secrets.
+func getNotificationSecretRefs(svc v1alpha1.NotificationService, path string) []notificationSecretRef {
+	refs := []notificationSecretRef{}
+	add := func(field, refPath string, ref *corev1.SecretKeySelector) {
+		if ref != nil {
+			refs = append(refs, notificationSecretRef{path: refPath, key: notificationSecretKey(svc, field), ref: *ref})
+		}
+	}
+
+	switch {
+	case svc.Slack != nil:
+		add("token", path+".slack.tokenRef", &svc.Slack.TokenRef)
+		add("signingSecret", path+".slack.signingSecretRef", svc.Slack.SigningSecretRef)
+	case svc.Email != nil:
+		add("password", path+".email.passwordRef", svc.Email.PasswordRef)
+	case svc.Webhook != nil:
+		for i, header := range svc.Webhook.Headers {
+			add(header.Name, fmt.Sprintf("%s.webhook.headers[%d].valueFrom", path, i), header.ValueFrom)
+		}
+		if svc.Webhook.BasicAuth != nil {
+			add("password", path+".webhook.basicAuth.passwordRef", &svc.Webhook.BasicAuth.PasswordRef)
+		}
+	case svc.Teams != nil:
+		for i, recipient := range svc.Teams.Recipients {
+			add(recipient.Name, fmt.Sprintf("%s.teams.recipients[%d].urlRef", path, i), &recipient.URLRef)
+		}
+	case svc.PagerDuty != nil:
+		for i, key := range svc.PagerDuty.ServiceKeys {
+			add(key.Name, fmt.Sprintf("%s.pagerDuty.serviceKeys[%d].keyRef", path, i), &key.KeyRef)
+		}
+	case svc.GitHub != nil:
+		add("privateKey", path+".github.privateKeyRef", &svc.GitHub.PrivateKeyRef)
+	}
+	return refs
+}
+
+// validateNotificationService returns the problems of the given service, apart from its Secret references.
+func validateNotificationService(svc v1alpha1.NotificationService, path string) []string {
+	errs := []string{}
+	invalid := func(field, msg string) {
+		errs = append(errs, fmt.Sprintf("%s%s: %s", path, field, msg))
+	}
+	validURL := func(field, value string) {
+		if u, err := url.Parse(value); err != nil || u.Scheme == "" || u.Host == "" {
+			invalid(field, fmt.Sprintf("%q is not a valid URL", value))
+		}
+	}
+
+	switch {
+	case svc.Slack != nil:
+		if svc.Slack.APIURL != "" {
+			validURL(".slack.apiURL", svc.Slack.APIURL)
+		}
+	case svc.Email != nil:
+		if svc.Email.Host == "" {
+			invalid(".email.host", "must not be empty")
+		}
+		if svc.Email.Port <= 0 || svc.Email.Port > 65535 {
+			invalid(".email.port", fmt.Sprintf("%d is not a valid port", svc.Email.Port))
+		}
+		if svc.Email.From == "" {
+			invalid(".email.from", "must not be empty")
+		}
+	case svc.Webhook != nil:
+		validURL(".webhook.url", svc.Webhook.URL)
+		names := make(map[string]bool)
+		for i, header := range svc.Webhook.Headers {
+			headerPath := fmt.Sprintf(".webhook.headers[%d]", i)
+			if header.Name == "" {
+				invalid(headerPath+".name", "must not be empty")
+			} else if names[header.Name] {
+				invalid(headerPath+".name", fmt.Sprintf("duplicate header %q", header.Name))
+			}
+			names[header.Name] = true
+			if (header.Value == "") == (header.ValueFrom == nil) {
+				invalid(headerPath, "exactly one of value or valueFrom must be set")
+			}
+		}
+	case svc.Teams != nil:
+		if len(svc.Teams.Recipients) == 0 {
+			invalid(".teams.recipients", "must not be empty")
+		}
+		names := make(map[string]bool)
+		for i, recipient := range svc.Teams.Recipients {
+			if recipient.Name == "" {
+				invalid(fmt.Sprintf(".teams.recipients[%d].name", i), "must not be empty")
+			} else if names[recipient.Name] {
+				invalid(fmt.Sprintf(".teams.recipients[%d].name", i), fmt.Sprintf("duplicate recipient %q", recipient.Name))
+			}
+			names[recipient.Name] = true
+		}
+	case svc.PagerDuty != nil:
+		if len(svc.PagerDuty.ServiceKeys) == 0 {
+			invalid(".pagerDuty.serviceKeys", "must not be empty")
+		}
+		names := make(map[string]bool)
+		for i, key := range svc.PagerDuty.ServiceKeys {
+			if key.Name == "" {
+				invalid(fmt.Sprintf(".pagerDuty.serviceKeys[%d].name", i), "must not be empty")
+			} else if names[key.Name] {
+				invalid(fmt.Sprintf(".pagerDuty.serviceKeys[%d].name", i), fmt.Sprintf("duplicate service key %q", key.Name))
+			}
+			names[key.Name] = true
+		}
+	case svc.GitHub != nil:
+		if svc.GitHub.AppID == "" {
+			invalid(".github.appID", "must not be empty")
+		}
+		if svc.GitHub.InstallationID == "" {
+			invalid(".github.installationID", "must not be empty")
+		}
+		if svc.GitHub.EnterpriseBaseURL != "" {
+			validURL(".github.enterpriseBaseURL", svc.GitHub.EnterpriseBaseURL)
+		}
+	}
+	return errs
+}
+
+// getNotificationServicesSecretData will validate the typed notification services of the given
+// NotificationsConfiguration and return the credentials to copy into the argocd-notifications-secret Secret.
+func (r *NotificationsConfigurationReconciler) getNotificationServicesSecretData(cr *v1alpha1.NotificationsConfiguration) (map[string][]byte, error) {
+	errs := []string{}
+	data := make(map[string][]byte)
+	keys := make(map[string]bool)
+	secretKeys := make(map[string]string)
+	secrets := make(map[string]*corev1.Secret)
+
+	for i, svc := range cr.Spec.ServiceDefinitions {
+		path := fmt.Sprintf("serviceDefinitions[%d]", i)
+
+		if notificationServiceType(svc) == "" {
+			errs = append(errs, path+": exactly one of slack, email, webhook, teams, pagerDuty or github must be set")
+			continue
+		}
+		key := notificationServiceKey(svc)
+		if keys[key] {
+			errs = append(errs, fmt.Sprintf("%s.name: duplicate service %q", path, notificationServiceName(svc)))
+		} else if _, ok := cr.Spec.Services[key]; ok {
+			errs = append(errs, fmt.Sprintf("%s.name: service %q is already defined in services", path, notificationServiceName(svc)))
+		}
+		keys[key] = true
+		errs = append(errs, validateNotificationService(svc, path)...)
+
+		for _, ref := range getNotificationSecretRefs(svc, path) {
+			if msgs := validation.IsConfigMapKey(ref.key); len(msgs) > 0 {
+				errs = append(errs, fmt.Sprintf("%s: %q is not a valid secret key: %s", ref.path, ref.key, strings.Join(msgs, ", ")))
+				continue
+			}
+			if !notificationSecretKeyPattern.MatchString(ref.key) {
+				errs = append(errs, fmt.Sprintf("%s: %q is not a valid secret key: must consist of alphanumeric characters, '-' or '_'", ref.path, ref.key))
+				continue
+			}
+			if other, ok := secretKeys[ref.key]; ok {
+				errs = append(errs, fmt.Sprintf("%s: secret key %q is already used by %s", ref.path, ref.key, other))
+				continue
+			}
+			secretKeys[ref.key] = ref.path
+
+			secret, ok := secrets[ref.ref.Name]
+			if !ok {
+				secret = &corev1.Secret{}
+				if err := r.Client.Get(context.TODO(), types.NamespacedName{Name: ref.ref.Name, Namespace: cr.Namespace}, secret); err != nil {
+					if !errors.IsNotFound(err) {
+						return nil, err
+					}
+					secret = nil
+				}
+				secrets[ref.ref.Name] = secret
+			}
+			if secret == nil {
+				errs = append(errs, fmt.Sprintf("%s: secret %q not found", ref.path, ref.ref.Name))
+				continue
+			}
+			value, ok := secret.Data[ref.ref.Key]
+			if !ok {
+				errs = append(errs, fmt.Sprintf("%s: key %q not found in secret %q", ref.path, ref.ref.Key, ref.ref.Name))
+				continue
+			}
+			data[ref.key] = value
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("invalid notification services: %s", strings.Join(errs, "; "))
+	}
+	return data, nil
+}
+
+// reconcileNotificationsSecret will copy the given credentials of the typed notification services into the
+// argocd-notifications-secret Secret and remove the credentials of services that no longer exist.
+func (r *NotificationsConfigurationReconciler) reconcileNotificationsSecret(cr *v1alpha1.NotificationsConfiguration, data map[string][]byte) error {
+	secret := &corev1.Secret{}
+	if err := argoutil.FetchObject(r.Client, cr.Namespace, ArgoCDNotificationsSecret, secret); err != nil {
+		if !errors.IsNotFound(err) {
+			return fmt.Errorf("failed to get the secret %s : %s", ArgoCDNotificationsSecret, err)
+		}
+		if len(data) == 0 {
+			return nil
+		}
+
+		// the secret is created by the Argo CD instance in its namespace, but not in notifications source namespaces
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        ArgoCDNotificationsSecret,
+				Namespace:   cr.Namespace,
+				Annotations: map[string]string{notificationsManagedKeysAnnotation: strings.Join(sortedKeys(data), ",")},
+			},
+			Data: data,
+		}
+		if err := controllerutil.SetControllerReference(cr, secret, r.Scheme); err != nil {
+			return err
+		}
+		log.Info(fmt.Sprintf("Creating secret %s in namespace %s", secret.Name, secret.Namespace))
+		return r.Client.Create(context.TODO(), secret)
+	}
+
+	desired := make(map[string][]byte)
+	for k, v := range secret.Data {
+		desired[k] = v
+	}
+	if managed, ok := secret.Annotations[notificationsManagedKeysAnnotation]; ok && managed != "" {
+		for _, k := range strings.Split(managed, ",") {
+			delete(desired, k)
+		}
+	}
+	keys := []string{}
+	for k, v := range data {
+		desired[k] = v
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	managed := strings.Join(keys, ",")
+
+	if reflect.DeepEqual(desired, secret.Data) || (len(desired) == 0 && len(secret.Data) == 0) {
+		if secret.Annotations[notificationsManagedKeysAnnotation] == managed {
+			return nil
+		}
+	}
+
+	secret.Data = desired
+	if secret.Annotations == nil {
+		secret.Annotations = make(map[string]string)
+	}
+	secret.Annotations[notificationsManagedKeysAnnotation] = managed
+	return r.Client.Update(context.TODO(), secret)
+}
+
+// notificationsSecretMapper maps a Secret to the NotificationsConfigurations in its namespace that reference it
+// from their typed notification services.
+func (r *NotificationsConfigurationReconciler) notificationsSecretMapper(ctx context.Context, o client.Object) []reconcile.Request {
+	list := &v1alpha1.NotificationsConfigurationList{}
+	if err := r.Client.List(ctx, list, client.InNamespace(o.GetNamespace())); err != nil {
+		return nil
+	}
+
+	result := []reconcile.Request{}
+	for _, cr := range list.Items {
+		for i, svc := range cr.Spec.ServiceDefinitions {
+			found := false
+			for _, ref := range getNotificationSecretRefs(svc, fmt.Sprintf("serviceDefinitions[%d]", i)) {
+				if ref.ref.Name == o.GetName() {
+					found = true
+					break
+				}
+			}
+			if found {
+				result = append(result, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&cr)})
+				break
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,112 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notificationsconfiguration
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+)
+
+const (
+	// ConditionTypeAccepted indicates whether the NotificationsConfiguration is in a namespace managed by the operator.
+	ConditionTypeAccepted = "Accepted"
+	// ConditionTypeServicesValid indicates whether the typed notification services are valid and applied.
+	ConditionTypeServicesValid = "ServicesValid"
+	// ConditionTypeTriggersValid indicates whether the notification triggers are valid and applied.
+	ConditionTypeTriggersValid = "TriggersValid"
+	// ConditionTypeTemplatesValid indicates whether the notification templates are valid and applied.
+	ConditionTypeTemplatesValid = "TemplatesValid"
+
+	// ReasonAccepted means the NotificationsConfiguration is reconciled by the operator.
+	ReasonAccepted = "Accepted"
+	// ReasonNotAccepted means the NotificationsConfiguration is ignored by the operator.
+	ReasonNotAccepted = "NotAccepted"
+	// ReasonServicesApplied means the typed notification services were applied.
+	ReasonServicesApplied = "ServicesApplied"
+	// ReasonInvalidServices means at least one typed notification service is invalid.
+	ReasonInvalidServices = "InvalidServices"
+	// ReasonTriggersApplied means the notification triggers were applied.
+	ReasonTriggersApplied = "TriggersApplied"
+	// ReasonInvalidTriggers means at least one notification trigger is invalid.
+	ReasonInvalidTriggers = "InvalidTriggers"
+	// ReasonTemplatesApplied means the notification templates were applied.
+	ReasonTemplatesApplied = "TemplatesApplied"
+	// ReasonInvalidTemplates means at least one notification template is invalid or failed to render.
+	ReasonInvalidTemplates = "InvalidTemplates"
+)
+
+// reconcileStatusConditions will set the given conditions on the status of the given NotificationsConfiguration,
+// updating the status only if a condition changed.
+func (r *NotificationsConfigurationReconciler) reconcileStatusConditions(cr *v1alpha1.NotificationsConfiguration, conditions ...metav1.Condition) error {
+	changed := false
+	for _, condition := range conditions {
+		condition.ObservedGeneration = cr.Generation
+		if existing := meta.FindStatusCondition(cr.Status.Conditions, condition.Type); existing != nil &&
+			existing.Status == condition.Status && existing.Reason == condition.Reason &&
+			existing.Message == condition.Message && existing.ObservedGeneration == condition.ObservedGeneration {
+			continue
+		}
+		meta.SetStatusCondition(&cr.Status.Conditions, condition)
+		changed = true
+	}
+
+	if !changed {
+		return nil
+	}
+	return r.Client.Status().Update(context.TODO(), cr)
+}
+
+// getValidationCondition returns a condition of the given type for the given validation error.
+func getValidationCondition(conditionType, appliedReason, invalidReason, appliedMessage string, err error) metav1.Condition {
+	if err != nil {
+		return metav1.Condition{
+			Type:    conditionType,
+			Status:  metav1.ConditionFalse,
+			Reason:  invalidReason,
+			Message: err.Error(),
+		}
+	}
+	return metav1.Condition{
+		Type:    conditionType,
+		Status:  metav1.ConditionTrue,
+		Reason:  appliedReason,
+		Message: appliedMessage,
+	}
+}
+
+// getServicesCondition returns the ServicesValid condition for the given validation error of the typed
+// notification services.
+func getServicesCondition(err error) metav1.Condition {
+	return getValidationCondition(ConditionTypeServicesValid, ReasonServicesApplied, ReasonInvalidServices,
+		"Notification services are applied", err)
+}
+
+// getTriggersCondition returns the TriggersValid condition for the given validation error of the notification
+// triggers.
+func getTriggersCondition(err error) metav1.Condition {
+	return getValidationCondition(ConditionTypeTriggersValid, ReasonTriggersApplied, ReasonInvalidTriggers,
+		"Notification triggers are applied", err)
+}
+
+// getTemplatesCondition returns the TemplatesValid condition for the given validation error of the notification
+// templates.
+func getTemplatesCondition(err error) metav1.Condition {
+	return getValidationCondition(ConditionTypeTemplatesValid, ReasonTemplatesApplied, ReasonInvalidTemplates,
+		"Notification templates are applied", err)
+}
@@ -0,0 +1,340 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notificationsconfiguration
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/Masterminds/sprig/v3"
+	"github.com/antonmedv/expr"
+	stringsexpr "github.com/argoproj/argo-cd/v2/util/notification/expression/strings"
+	timeexpr "github.com/argoproj/argo-cd/v2/util/notification/expression/time"
+	"gopkg.in/yaml.v2"
+
+	"github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+)
+
+const (
+	// Prefix of the keys of notification templates in the argocd-notifications-cm ConfigMap.
+	notificationTemplatePrefix = "template."
+	// Prefix of the keys of notification triggers in the argocd-notifications-cm ConfigMap.
+	notificationTriggerPrefix = "trigger."
+)
+
+// notificationTrigger is a condition of a notification trigger.
+type notificationTrigger struct {
+	When        string   `yaml:"when"`
+	Send        []string `yaml:"send"`
+	OncePer     string   `yaml:"oncePer,omitempty"`
+	Description string   `yaml:"description,omitempty"`
+}
+
+// validateNotificationTriggers will return the problems of the triggers of the given NotificationsConfiguration that
+// cannot be parsed, have a condition that does not compile or send templates that are not defined or were rejected,
+// by key.
+func validateNotificationTriggers(cr *v1alpha1.NotificationsConfiguration, rejectedTemplates map[string][]string) map[string][]string {
+	problems := map[string][]string{}
+
+	for _, key := range sortedKeys(cr.Spec.Triggers) {
+		if !strings.HasPrefix(key, notificationTriggerPrefix) {
+			problems[key] = append(problems[key], fmt.Sprintf("key must start with %q", notificationTriggerPrefix))
+			continue
+		}
+
+		conditions := []notificationTrigger{}
+		if err := yaml.UnmarshalStrict([]byte(cr.Spec.Triggers[key]), &conditions); err != nil {
+			problems[key] = append(problems[key], err.Error())
+			continue
+		}
+		if len(conditions) == 0 {
+			problems[key] = append(problems[key], "must contain at least one condition")
+		}
+
+		for i, condition := range conditions {
+			// Argo CD notifications compiles an empty condition as false
+			when := condition.When
+			if when == "" {
+				when = "false"
+			}
+			if _, err := expr.Compile(when); err != nil {
+				problems[key] = append(problems[key], fmt.Sprintf("[%d].when: %v", i, err))
+			}
+			if condition.OncePer != "" {
+				if _, err := expr.Compile(condition.OncePer); err != nil {
+					problems[key] = append(problems[key], fmt.Sprintf("[%d].oncePer: %v", i, err))
+				}
+			}
+			if len(condition.Send) == 0 {
+				problems[key] = append(problems[key], fmt.Sprintf("[%d].send: must reference at least one template", i))
+			}
+			for _, name := range condition.Send {
+				if _, ok := cr.Spec.Templates[notificationTemplatePrefix+name]; !ok {
+					problems[key] = append(problems[key], fmt.Sprintf("[%d].send: unknown template %q", i, name))
+				} else if _, ok := rejectedTemplates[notificationTemplatePrefix+name]; ok {
+					problems[key] = append(problems[key], fmt.Sprintf("[%d].send: template %q is invalid", i, name))
+				}
+			}
+		}
+	}
+
+	return problems
+}
+
+// validateNotificationTemplates will return the problems of the templates of the given NotificationsConfiguration
+// that cannot be parsed or rendered, by key. Templates are parsed with the functions Argo CD notifications provides to
+// them and rendered against a sample Application. Errors caused by fields the sample Application does not set are not
+// reported, since the fields of an Application depend on the Application.
+func validateNotificationTemplates(cr *v1alpha1.NotificationsConfiguration) map[string][]string {
+	problems := map[string][]string{}
+	vars := getNotificationTemplateVars(cr)
+
+	for _, key := range sortedKeys(cr.Spec.Templates) {
+		if !strings.HasPrefix(key, notificationTemplatePrefix) {
+			problems[key] = append(problems[key], fmt.Sprintf("key must start with %q", notificationTemplatePrefix))
+			continue
+		}
+
+		fields := map[string]interface{}{}
+		if err := yaml.Unmarshal([]byte(cr.Spec.Templates[key]), &fields); err != nil {
+			problems[key] = append(problems[key], err.Error())
+			continue
+		}
+
+		for _, field := range getTemplateFields("", fields) {
+			tmpl, err := template.New(field.path).Funcs(notificationTemplateFuncs).Parse(field.value)
+			if err != nil {
+				problems[key] = append(problems[key], err.Error())
+				continue
+			}
+			if err := tmpl.Execute(io.Discard, vars); err != nil && !strings.Contains(err.Error(), "nil pointer evaluating") {
+				problems[key] = append(problems[key], err.Error())
+			}
+		}
+	}
+
+	return problems
+}
+
+// getValidationError returns an error listing the given problems per key, or nil if there are none.
+func getValidationError(kind string, problems map[string][]string) error {
+	if len(problems) == 0 {
+		return nil
+	}
+
+	errs := []string{}
+	for _, key := range sortedKeys(problems) {
+		for _, problem := range problems[key] {
+			errs = append(errs, fmt.Sprintf("%s: %s", key, problem))
+		}
+	}
+	return fmt.Errorf("invalid notification %s: %s", kind, strings.Join(errs, "; "))
+}
+
+// templateField is a string field of a notification template.
+type templateField struct {
+	path  string
+	value string
+}
+
+// getTemplateFields returns the string fields of the given notification template in a stable order. All string
+// fields of a notification template are Go templates.
+func getTemplateFields(prefix string, value interface{}) []templateField {
+	fields := []templateField{}
+
+	switch v := value.(type) {
+	case string:
+		fields = append(fields, templateField{path: prefix, value: v})
+	case map[interface{}]interface{}:
+		keys := []string{}
+		values := map[string]interface{}{}
+		for k, child := range v {
+			keys = append(keys, fmt.Sprint(k))
+			values[fmt.Sprint(k)] = child
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			fields = append(fields, getTemplateFields(joinTemplatePath(prefix, k), values[k])...)
+		}
+	case map[string]interface{}:
+		for _, k := range sortedKeys(v) {
+			fields = append(fields, getTemplateFields(joinTemplatePath(prefix, k), v[k])...)
+		}
+	case []interface{}:
+		for i, child := range v {
+			fields = append(fields, getTemplateFields(fmt.Sprintf("%s[%d]", prefix, i), child)...)
+		}
+	}
+	return fields
+}
+
+func joinTemplatePath(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// notificationTemplateFuncs are the functions Argo CD notifications makes available to notification templates.
+var notificationTemplateFuncs = func() template.FuncMap {
+	funcs := sprig.TxtFuncMap()
+	delete(funcs, "env")
+	delete(funcs, "expandenv")
+	return funcs
+}()
+
+// getNotificationTemplateVars returns the variables Argo CD notifications passes to notification templates, for a
+// sample Application and the context of the given NotificationsConfiguration.
+func getNotificationTemplateVars(cr *v1alpha1.NotificationsConfiguration) map[string]interface{} {
+	context := map[string]string{
+		"notificationType": "slack",
+	}
+	for k, v := range cr.Spec.Context {
+		context[k] = v
+	}
+
+	return map[string]interface{}{
+		"app":         getSampleApplication(),
+		"context":     context,
+		"secrets":     map[string][]byte{},
+		"serviceType": "slack",
+		"recipient":   "",
+		"time":        timeexpr.NewExprs(),
+		"strings":     stringsexpr.NewExprs(),
+		"repo":        getSampleRepoExprs(),
+	}
+}
+
+// sampleCommitMetadata has the fields of the commit metadata returned by repo.GetCommitMetadata.
+type sampleCommitMetadata struct {
+	Message string
+	Author  string
+	Date    time.Time
+	Tags    []string
+}
+
+// sampleHelmAppSpec has the methods of the Helm details returned by repo.GetAppDetails.
+type sampleHelmAppSpec struct{}
+
+func (sampleHelmAppSpec) GetParameterValueByName(name string) string { return "" }
+
+func (sampleHelmAppSpec) GetFileParameterPathByName(name string) string { return "" }
+
+// sampleAppDetail has the fields of the application details returned by repo.GetAppDetails.
+type sampleAppDetail struct {
+	Type      string
+	Helm      *sampleHelmAppSpec
+	Kustomize interface{}
+	Directory interface{}
+}
+
+// getSampleRepoExprs returns the repo helpers of Argo CD notifications, answering with sample data instead of
+// querying the repo server.
+func getSampleRepoExprs() map[string]interface{} {
+	return map[string]interface{}{
+		"RepoURLToHTTPS":    func(rawURL string) string { return rawURL },
+		"FullNameByRepoURL": func(rawURL string) string { return "argoproj/argocd-example-apps" },
+		"QueryEscape":       url.QueryEscape,
+		"GetCommitMetadata": func(commitSHA string) interface{} {
+			return sampleCommitMetadata{
+				Message: "Update guestbook",
+				Author:  "Argo CD <argocd@example.com>",
+				Date:    time.Now(),
+				Tags:    []string{"v1.0.0"},
+			}
+		},
+		"GetAppDetails": func() interface{} {
+			return sampleAppDetail{Type: "Helm", Helm: &sampleHelmAppSpec{}}
+		},
+	}
+}
+
+// getSampleApplication returns the sample Application notification templates are rendered against.
+func getSampleApplication() map[string]interface{} {
+	return map[string]interface{}{
+		"apiVersion": "argoproj.io/v1alpha1",
+		"kind":       "Application",
+		"metadata": map[string]interface{}{
+			"name":              "guestbook",
+			"namespace":         "argocd",
+			"uid":               "c5ba8d3c-9d2b-4d0c-9d2a-0f5e0d3b4a5f",
+			"creationTimestamp": "2024-01-01T00:00:00Z",
+			"labels":            map[string]interface{}{},
+			"annotations":       map[string]interface{}{},
+		},
+		"spec": map[string]interface{}{
+			"project": "default",
+			"source": map[string]interface{}{
+				"repoURL":        "https://github.com/argoproj/argocd-example-apps.git",
+				"path":           "guestbook",
+				"targetRevision": "HEAD",
+			},
+			"sources": []interface{}{},
+			"destination": map[string]interface{}{
+				"server":    "https://kubernetes.default.svc",
+				"namespace": "guestbook",
+			},
+			"syncPolicy": map[string]interface{}{},
+		},
+		"status": map[string]interface{}{
+			"sync": map[string]interface{}{
+				"status":   "Synced",
+				"revision": "4773b9f1f8ff1cd5e31fb5ea3ef0ad4e4e2a9a61",
+			},
+			"health": map[string]interface{}{
+				"status":  "Healthy",
+				"message": "",
+			},
+			"operationState": map[string]interface{}{
+				"phase":      "Succeeded",
+				"message":    "successfully synced (all tasks run)",
+				"startedAt":  "2024-01-01T00:00:00Z",
+				"finishedAt": "2024-01-01T00:00:10Z",
+				"operation": map[string]interface{}{
+					"initiatedBy": map[string]interface{}{
+						"username": "admin",
+					},
+					"sync": map[string]interface{}{
+						"revision": "4773b9f1f8ff1cd5e31fb5ea3ef0ad4e4e2a9a61",
+					},
+				},
+				"syncResult": map[string]interface{}{
+					"revision":  "4773b9f1f8ff1cd5e31fb5ea3ef0ad4e4e2a9a61",
+					"resources": []interface{}{},
+				},
+			},
+			"conditions": []interface{}{},
+			"history":    []interface{}{},
+			"resources":  []interface{}{},
+			"summary": map[string]interface{}{
+				"images": []interface{}{},
+			},
+		},
+	}
+}
@@ -0,0 +1,138 @@
+package notificationsconfiguration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+)
+
+func TestValidateNotificationTriggers(t *testing.T) {
+	a := makeTestNotificationsConfiguration(func(a *v1alpha1.NotificationsConfiguration) {
+		a.Spec.Templates = map[string]string{
+			"template.app-created": "message: Application {{.app.metadata.name}} has been created.",
+		}
+		a.Spec.Triggers = map[string]string{
+			"trigger.on-created":  "- oncePer: app.metadata.name\n  send: [app-created]\n  when: \"true\"",
+			"trigger.on-degraded": "- send: [app-created]\n  when: app.status.operationState.phase in ['Succeeded'] and app.status.health.status == 'Degraded'",
+			"trigger.on-stuck":    "- send: [app-created]\n  when: time.Now().Sub(time.Parse(app.status.operationState.startedAt)).Minutes() >= 5",
+			"trigger.on-error":    "- send: [app-created]\n  when: any(app.status.conditions, {.type == 'SyncError'})",
+			"trigger.on-never":    "- send: [app-created]",
+		}
+	})
+	assert.Empty(t, validateNotificationTriggers(a, nil))
+
+	a.Spec.Triggers = map[string]string{
+		"trigger.on-created":   "- send: [app-created, app-creatd]\n  when: app.status.sync.status ==",
+		"trigger.on-deleted":   "- send: [app-created]\n  when: true\n  unknown: field",
+		"trigger.on-unknown":   "[]",
+		"trigger.on-rejected":  "- send: [app-created]\n  when: 'true'",
+		"on-sync-status-error": "- send: [app-created]\n  when: 'true'",
+	}
+	problems := validateNotificationTriggers(a, map[string][]string{"template.app-created": {"invalid"}})
+	assert.Equal(t, []string{"on-sync-status-error", "trigger.on-created", "trigger.on-deleted", "trigger.on-rejected", "trigger.on-unknown"}, sortedKeys(problems))
+	assert.Equal(t, []string{`key must start with "trigger."`}, problems["on-sync-status-error"])
+	assert.Len(t, problems["trigger.on-created"], 3)
+	assert.Contains(t, problems["trigger.on-created"][0], "[0].when: unexpected token EOF")
+	assert.Equal(t, `[0].send: template "app-created" is invalid`, problems["trigger.on-created"][1])
+	assert.Equal(t, `[0].send: unknown template "app-creatd"`, problems["trigger.on-created"][2])
+	assert.Equal(t, []string{"yaml: unmarshal errors:\n  line 3: field unknown not found in type notificationsconfiguration.notificationTrigger"}, problems["trigger.on-deleted"])
+	assert.Equal(t, []string{`[0].send: template "app-created" is invalid`}, problems["trigger.on-rejected"])
+	assert.Equal(t, []string{"must contain at least one condition"}, problems["trigger.on-unknown"])
+}
+
+func TestValidateNotificationTemplates(t *testing.T) {
+	a := makeTestNotificationsConfiguration(func(a *v1alpha1.NotificationsConfiguration) {
+		a.Spec.Templates = map[string]string{
+			"template.app-deployed": `message: |
+  {{if eq .serviceType "slack"}}:white_check_mark:{{end}} Application {{.app.metadata.name}} is running in {{.context.environmentName}}.
+  Team: {{.app.metadata.annotations.team}}
+  {{range .app.spec.sources}}Source: {{.repoURL}}{{end}}
+  {{range .app.status.operationState.syncResult.resources}}{{.kind}}/{{.name}}{{end}}
+slack:
+  attachments: |
+    [{"title": "{{.app.metadata.name | upper | trunc 20}}", "title_link": "{{.context.argocdUrl}}/applications/{{.app.metadata.name}}", "ts": "{{now | date "2006-01-02"}}"}]
+webhook:
+  github:
+    method: POST
+    path: /repos/{{call .repo.FullNameByRepoURL .app.spec.source.repoURL}}/statuses/{{.app.status.operationState.syncResult.revision}}
+    body: |
+      {"state": "success", "token": "{{.secrets.token | toString | b64enc}}", "sha": "{{.app.status.sync.revision | sha256sum}}"}`,
+		}
+	})
+	assert.Empty(t, validateNotificationTemplates(a))
+
+	a.Spec.Templates = map[string]string{
+		"template.app-deleted":  "email:\n  subject: Application {{.app.metadata.name",
+		"template.app-degraded": "message: Application {{.app.metadata.name | shout}} has degraded.",
+		"template.app-env":      "message: Application {{env \"HOME\"}} has degraded.",
+		"template.app-pushed":   "message: Application {{.app.metadata.name}} was pushed by {{(call .repo.GetCommitMetadata).Author}}.",
+		"template.app-synced":   "message: \"{{.app.status.sync.status}}\" [",
+		"app-created":           "message: Application {{.app.metadata.name}} has been created.",
+	}
+	assert.EqualError(t, getValidationError("templates", validateNotificationTemplates(a)), `invalid notification templates: `+
+		`app-created: key must start with "template."; `+
+		`template.app-degraded: template: message:1: function "shout" not defined; `+
+		`template.app-deleted: template: email.subject:1: unclosed action; `+
+		`template.app-env: template: message:1: function "env" not defined; `+
+		`template.app-pushed: template: message:1:52: executing "message" at <call .repo.GetCommitMetadata>: `+
+		`error calling call: wrong number of args for .repo.GetCommitMetadata: got 0 want 1; `+
+		`template.app-synced: yaml: did not find expected key`)
+}
+
+func TestReconcileNotifications_InvalidTemplatesAndTriggers(t *testing.T) {
+	a := makeTestNotificationsConfiguration(func(a *v1alpha1.NotificationsConfiguration) {
+		a.Spec.Templates = map[string]string{
+			"template.app-created": "message: Application {{.app.metadata.name}} has been created.",
+		}
+		a.Spec.Triggers = map[string]string{
+			"trigger.on-created": "- send: [app-created]\n  when: \"true\"",
+		}
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(v1alpha1.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileNotificationsConfigurationResources(a))
+	for _, conditionType := range []string{ConditionTypeServicesValid, ConditionTypeTriggersValid, ConditionTypeTemplatesValid} {
+		assert.True(t, meta.IsStatusConditionTrue(a.Status.Conditions, conditionType), conditionType)
+	}
+
+	// an invalid template and the triggers sending it keep their last applied value, the rest is applied
+	a.Spec.Templates["template.app-created"] = "message: Application {{.app.metadata.name | shout}} has been created."
+	a.Spec.Templates["template.app-deleted"] = "message: Application {{.app.metadata.name}} has been deleted."
+	a.Spec.Triggers["trigger.on-created"] = "- send: [app-created]\n  when: app.metadata.name != ''"
+	a.Spec.Triggers["trigger.on-deleted"] = "- send: [app-deleted]\n  when: app.metadata.deletionTimestamp != nil"
+	a.Spec.Triggers["trigger.on-unknown"] = "- send: [app-unknown]\n  when: 'true'"
+	assert.NoError(t, r.reconcileNotificationsConfigurationResources(a))
+
+	condition := meta.FindStatusCondition(a.Status.Conditions, ConditionTypeTemplatesValid)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, ReasonInvalidTemplates, condition.Reason)
+	assert.Equal(t, `invalid notification templates: template.app-created: template: message:1: function "shout" not defined`, condition.Message)
+	condition = meta.FindStatusCondition(a.Status.Conditions, ConditionTypeTriggersValid)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, `invalid notification triggers: trigger.on-created: [0].send: template "app-created" is invalid; `+
+		`trigger.on-unknown: [0].send: unknown template "app-unknown"`, condition.Message)
+	assert.True(t, meta.IsStatusConditionTrue(a.Status.Conditions, ConditionTypeServicesValid))
+
+	testCM := &corev1.ConfigMap{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: ArgoCDNotificationsConfigMap, Namespace: a.Namespace}, testCM))
+	assert.Equal(t, "message: Application {{.app.metadata.name}} has been created.", testCM.Data["template.app-created"])
+	assert.Equal(t, "- send: [app-created]\n  when: \"true\"", testCM.Data["trigger.on-created"])
+	assert.Equal(t, "message: Application {{.app.metadata.name}} has been deleted.", testCM.Data["template.app-deleted"])
+	assert.Equal(t, "- send: [app-deleted]\n  when: app.metadata.deletionTimestamp != nil", testCM.Data["trigger.on-deleted"])
+	assert.NotContains(t, testCM.Data, "trigger.on-unknown")
+}
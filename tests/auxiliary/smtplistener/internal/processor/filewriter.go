@@ -3,13 +3,31 @@ package processor
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 
 	"github.com/flashmob/go-guerrilla/backends"
 	"github.com/flashmob/go-guerrilla/mail"
 )
 
+// DefaultFileWriterDir is the directory received mails are written to when file_writer_dir is not configured.
+const DefaultFileWriterDir = "/tmp/"
+
+type fileWriterConfig struct {
+	// Dir is the directory received mails are written to, one file per mail named after its queued id.
+	Dir string `json:"file_writer_dir,omitempty"`
+}
+
 var FileWriter = func() backends.Decorator {
+	config := &fileWriterConfig{}
 	initializer := backends.InitializeWith(func(backendConfig backends.BackendConfig) error {
+		bcfg, err := backends.Svc.ExtractConfig(backendConfig, &fileWriterConfig{})
+		if err != nil {
+			return err
+		}
+		config = bcfg.(*fileWriterConfig)
+		if config.Dir == "" {
+			config.Dir = DefaultFileWriterDir
+		}
 		return nil
 	})
 	// register our initializer
@@ -25,9 +43,10 @@ var FileWriter = func() backends.Decorator {
 				var stringer fmt.Stringer
 				stringer = e
 				data := []byte(stringer.String())
-				key := fmt.Sprintf("%s%s", "/tmp/", e.QueuedId)
-				err := os.WriteFile(key, data, 0666)
-				fmt.Println(err)
+				key := filepath.Join(config.Dir, e.QueuedId)
+				if err := os.WriteFile(key, data, 0666); err != nil {
+					fmt.Println(err)
+				}
 				return p.Process(e, task)
 			} else {
 				return p.Process(e, task)
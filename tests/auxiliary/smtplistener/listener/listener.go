@@ -0,0 +1,135 @@
+// Package listener runs the SMTP listener in-process, so that Go tests can deliver mails to it and read
+// back what the FileWriter processor received.
+package listener
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"smtplistener/internal/processor"
+
+	"github.com/flashmob/go-guerrilla"
+	"github.com/flashmob/go-guerrilla/backends"
+	"github.com/flashmob/go-guerrilla/log"
+)
+
+// Listener is an SMTP server writing each received mail to a file in Dir.
+type Listener struct {
+	// Addr is the host:port the listener accepts connections on.
+	Addr string
+	// Dir is the directory the received mails are written to.
+	Dir string
+
+	daemon guerrilla.Daemon
+}
+
+// Start starts a listener on a free port of the loopback interface, writing received mails to dir.
+func Start(dir string) (*Listener, error) {
+	addr, err := freeAddr()
+	if err != nil {
+		return nil, err
+	}
+
+	l := &Listener{Addr: addr, Dir: dir}
+	l.daemon = guerrilla.Daemon{Config: &guerrilla.AppConfig{
+		LogFile:      log.OutputOff.String(),
+		LogLevel:     log.InfoLevel.String(),
+		AllowedHosts: []string{"*"},
+		BackendConfig: backends.BackendConfig{
+			"save_process":       "HeadersParser|Header|FileWriter",
+			"save_workers_size":  1,
+			"primary_mail_host":  "test.com",
+			"log_received_mails": false,
+			"file_writer_dir":    dir,
+		},
+		Servers: []guerrilla.ServerConfig{
+			{
+				IsEnabled:       true,
+				Hostname:        "mail.test.com",
+				ListenInterface: addr,
+				MaxSize:         2000000,
+				Timeout:         30,
+				MaxClients:      10,
+				LogFile:         log.OutputOff.String(),
+			},
+		},
+	}}
+	l.daemon.AddProcessor("FileWriter", processor.FileWriter)
+
+	if err := l.daemon.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start SMTP listener on %s: %w", addr, err)
+	}
+	return l, nil
+}
+
+// Shutdown stops the listener.
+func (l *Listener) Shutdown() {
+	l.daemon.Shutdown()
+}
+
+// Messages returns the received mails, oldest first.
+func (l *Listener) Messages() ([]string, error) {
+	entries, err := os.ReadDir(l.Dir)
+	if err != nil {
+		return nil, err
+	}
+
+	type message struct {
+		modTime time.Time
+		data    string
+	}
+	messages := []message{}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return nil, err
+		}
+		data, err := os.ReadFile(filepath.Join(l.Dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, message{modTime: info.ModTime(), data: string(data)})
+	}
+	sort.SliceStable(messages, func(i, j int) bool { return messages[i].modTime.Before(messages[j].modTime) })
+
+	result := make([]string, 0, len(messages))
+	for _, m := range messages {
+		result = append(result, m.data)
+	}
+	return result, nil
+}
+
+// WaitForMessages waits until at least n mails were received or the timeout expires.
+func (l *Listener) WaitForMessages(n int, timeout time.Duration) ([]string, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		messages, err := l.Messages()
+		if err != nil {
+			return nil, err
+		}
+		if len(messages) >= n {
+			return messages, nil
+		}
+		if time.Now().After(deadline) {
+			return messages, fmt.Errorf("received %d mails, expected %d", len(messages), n)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+// freeAddr returns a loopback address with a port that is free at the time of the call.
+func freeAddr() (string, error) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", err
+	}
+	defer ln.Close()
+	return ln.Addr().String(), nil
+}
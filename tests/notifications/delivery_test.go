@@ -0,0 +1,262 @@
+// Package notifications delivers the notifications configured by a NotificationsConfiguration with the
+// notifications-engine used by the Argo CD notifications controller, to the SMTP listener of
+// tests/auxiliary/smtplistener and to an HTTP webhook sink.
+package notifications
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/argoproj/notifications-engine/pkg/api"
+	"github.com/argoproj/notifications-engine/pkg/services"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"smtplistener/listener"
+
+	"github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/controllers/notificationsconfiguration"
+)
+
+const testNamespace = "argocd"
+
+// webhookRequest is a request received by the webhook sink.
+type webhookRequest struct {
+	Method string
+	Path   string
+	Header http.Header
+	Body   string
+}
+
+// webhookSink is an HTTP server recording the requests sent to it by webhook notification services.
+type webhookSink struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	requests []webhookRequest
+}
+
+func newWebhookSink(t *testing.T) *webhookSink {
+	sink := &webhookSink{}
+	sink.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		sink.mu.Lock()
+		defer sink.mu.Unlock()
+		sink.requests = append(sink.requests, webhookRequest{Method: req.Method, Path: req.URL.Path, Header: req.Header, Body: string(body)})
+	}))
+	t.Cleanup(sink.Close)
+	return sink
+}
+
+func (s *webhookSink) Requests() []webhookRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]webhookRequest{}, s.requests...)
+}
+
+func makeTestNotificationsConfiguration(smtpAddr, webhookURL string) *v1alpha1.NotificationsConfiguration {
+	host, port, _ := net.SplitHostPort(smtpAddr)
+	smtpPort, _ := strconv.Atoi(port)
+
+	return &v1alpha1.NotificationsConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: "default-notifications-configuration", Namespace: testNamespace},
+		Spec: v1alpha1.NotificationsConfigurationSpec{
+			Context: map[string]string{"environmentName": "staging"},
+			ServiceDefinitions: []v1alpha1.NotificationService{
+				{Name: "smtp", Email: &v1alpha1.NotificationEmailService{Host: host, Port: int32(smtpPort), From: "argocd@example.com"}},
+				{Name: "sink", Webhook: &v1alpha1.NotificationWebhookService{
+					URL: webhookURL,
+					Headers: []v1alpha1.NotificationWebhookHeader{
+						{Name: "Content-Type", Value: "application/json"},
+						{Name: "Authorization", ValueFrom: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "notification-tokens"}, Key: "webhook"}},
+					},
+				}},
+			},
+			Templates: map[string]string{
+				"template.app-sync-succeeded": `message: Application {{.app.metadata.name}} has been successfully synced in {{.context.environmentName}}.
+email:
+  subject: Application {{.app.metadata.name}} has been successfully synced.
+webhook:
+  sink:
+    method: POST
+    path: /applications/{{.app.metadata.name}}
+    body: |
+      {"app": "{{.app.metadata.name}}", "revision": "{{.app.status.sync.revision}}"}`,
+			},
+			Triggers: map[string]string{
+				"trigger.on-sync-succeeded": "- when: app.status.operationState.phase in ['Succeeded']\n  send: [app-sync-succeeded]",
+			},
+		},
+	}
+}
+
+// makeTestReconciler returns a reconciler of the given NotificationsConfiguration in the namespace of an Argo CD
+// instance, with the secret holding the webhook token.
+func makeTestReconciler(t *testing.T, cr *v1alpha1.NotificationsConfiguration) *notificationsconfiguration.NotificationsConfigurationReconciler {
+	sch := runtime.NewScheme()
+	assert.NoError(t, clientgoscheme.AddToScheme(sch))
+	assert.NoError(t, v1alpha1.AddToScheme(sch))
+	assert.NoError(t, argoproj.AddToScheme(sch))
+
+	cl := fake.NewClientBuilder().WithScheme(sch).
+		WithObjects(cr,
+			&argoproj.ArgoCD{ObjectMeta: metav1.ObjectMeta{Name: "argocd", Namespace: testNamespace}},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "notification-tokens", Namespace: testNamespace},
+				Data:       map[string][]byte{"webhook": []byte("Bearer token")},
+			}).
+		WithStatusSubresource(cr).
+		Build()
+	return &notificationsconfiguration.NotificationsConfigurationReconciler{Client: cl, Scheme: sch}
+}
+
+// reconcile reconciles the NotificationsConfiguration and checks that all its parts were applied.
+func reconcile(t *testing.T, r *notificationsconfiguration.NotificationsConfigurationReconciler, cr *v1alpha1.NotificationsConfiguration) {
+	_, err := r.Reconcile(context.TODO(), ctrl.Request{NamespacedName: types.NamespacedName{Name: cr.Name, Namespace: cr.Namespace}})
+	assert.NoError(t, err)
+
+	assert.NoError(t, r.Client.Get(context.TODO(), client.ObjectKeyFromObject(cr), cr))
+	for _, conditionType := range []string{
+		notificationsconfiguration.ConditionTypeServicesValid,
+		notificationsconfiguration.ConditionTypeTriggersValid,
+		notificationsconfiguration.ConditionTypeTemplatesValid,
+	} {
+		assert.True(t, meta.IsStatusConditionTrue(cr.Status.Conditions, conditionType), conditionType)
+	}
+}
+
+// notify runs the trigger against the sample Application with the notifications-engine configured from the
+// argocd-notifications-cm ConfigMap and argocd-notifications-secret Secret written by the reconciler, and sends
+// the templates of the matching conditions to the destination.
+func notify(t *testing.T, r *notificationsconfiguration.NotificationsConfigurationReconciler, trigger string, dest services.Destination) {
+	cm := &corev1.ConfigMap{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: notificationsconfiguration.ArgoCDNotificationsConfigMap, Namespace: testNamespace}, cm))
+	secret := &corev1.Secret{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: notificationsconfiguration.ArgoCDNotificationsSecret, Namespace: testNamespace}, secret))
+
+	argocdContext := map[string]string{}
+	assert.NoError(t, yaml.Unmarshal([]byte(cm.Data["context"]), &argocdContext))
+
+	cfg, err := api.ParseConfig(cm, secret)
+	assert.NoError(t, err)
+	notificationsAPI, err := api.NewAPI(*cfg, func(obj map[string]interface{}, dest services.Destination) map[string]interface{} {
+		return map[string]interface{}{"app": obj, "context": argocdContext, "notificationType": dest.Service}
+	})
+	assert.NoError(t, err)
+
+	app := getSampleApplication()
+	results, err := notificationsAPI.RunTrigger(trigger, app)
+	assert.NoError(t, err)
+	sent := 0
+	for _, result := range results {
+		if !result.Triggered {
+			continue
+		}
+		assert.NoError(t, notificationsAPI.Send(app, result.Templates, dest))
+		sent++
+	}
+	assert.Equal(t, 1, sent, "trigger %s did not send any notification", trigger)
+}
+
+func TestNotificationsDelivery_Email(t *testing.T) {
+	l, err := listener.Start(t.TempDir())
+	assert.NoError(t, err)
+	defer l.Shutdown()
+
+	cr := makeTestNotificationsConfiguration(l.Addr, "https://hooks.example.com")
+	r := makeTestReconciler(t, cr)
+	reconcile(t, r, cr)
+
+	notify(t, r, "on-sync-succeeded", services.Destination{Service: "smtp", Recipient: "ops@example.com"})
+
+	messages, err := l.WaitForMessages(1, 10*time.Second)
+	assert.NoError(t, err)
+	assert.Len(t, messages, 1)
+	assert.Contains(t, messages[0], "From: argocd@example.com")
+	assert.Contains(t, messages[0], "To: ops@example.com")
+	assert.Contains(t, messages[0], "Subject: Application guestbook has been successfully synced.")
+	assert.Contains(t, messages[0], "Application guestbook has been successfully synced in staging.")
+}
+
+func TestNotificationsDelivery_Webhook(t *testing.T) {
+	sink := newWebhookSink(t)
+
+	cr := makeTestNotificationsConfiguration("127.0.0.1:2525", sink.URL)
+	r := makeTestReconciler(t, cr)
+	reconcile(t, r, cr)
+
+	notify(t, r, "on-sync-succeeded", services.Destination{Service: "sink"})
+
+	requests := sink.Requests()
+	assert.Len(t, requests, 1)
+	assert.Equal(t, http.MethodPost, requests[0].Method)
+	assert.Equal(t, "/applications/guestbook", requests[0].Path)
+	assert.Equal(t, "Bearer token", requests[0].Header.Get("Authorization"))
+	assert.Equal(t, "application/json", requests[0].Header.Get("Content-Type"))
+	assert.JSONEq(t, `{"app": "guestbook", "revision": "4773b9f1f8ff1cd5e31fb5ea3ef0ad4e4e2a9a61"}`, requests[0].Body)
+
+	// a rotated credential is delivered once the referenced secret changes and the configuration is reconciled
+	tokens := &corev1.Secret{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "notification-tokens", Namespace: testNamespace}, tokens))
+	tokens.Data["webhook"] = []byte("Bearer rotated")
+	assert.NoError(t, r.Client.Update(context.TODO(), tokens))
+	reconcile(t, r, cr)
+
+	notify(t, r, "on-sync-succeeded", services.Destination{Service: "sink"})
+	requests = sink.Requests()
+	assert.Len(t, requests, 2)
+	assert.Equal(t, "Bearer rotated", requests[1].Header.Get("Authorization"))
+}
+
+// getSampleApplication returns a synced Argo CD Application as an unstructured object.
+func getSampleApplication() map[string]interface{} {
+	return map[string]interface{}{
+		"apiVersion": "argoproj.io/v1alpha1",
+		"kind":       "Application",
+		"metadata": map[string]interface{}{
+			"name":      "guestbook",
+			"namespace": testNamespace,
+		},
+		"spec": map[string]interface{}{
+			"project": "default",
+			"source": map[string]interface{}{
+				"repoURL":        "https://github.com/argoproj/argocd-example-apps.git",
+				"path":           "guestbook",
+				"targetRevision": "HEAD",
+			},
+			"destination": map[string]interface{}{
+				"server":    "https://kubernetes.default.svc",
+				"namespace": "guestbook",
+			},
+		},
+		"status": map[string]interface{}{
+			"sync": map[string]interface{}{
+				"status":   "Synced",
+				"revision": "4773b9f1f8ff1cd5e31fb5ea3ef0ad4e4e2a9a61",
+			},
+			"health": map[string]interface{}{
+				"status": "Healthy",
+			},
+			"operationState": map[string]interface{}{
+				"phase":   "Succeeded",
+				"message": "successfully synced (all tasks run)",
+			},
+		},
+	}
+}